@@ -36,6 +36,12 @@ const (
 	EncodingASCII
 	// EncodingLATIN1 indicates ISO-8859-1 (Latin-1) encoding.
 	EncodingLATIN1
+	// EncodingWindows1252 indicates Windows code page 1252, which GEDCOM
+	// files from Windows programs declare as CHAR ANSI.
+	EncodingWindows1252
+	// EncodingIBMPC indicates IBM PC code page 437, declared as CHAR IBMPC
+	// by DOS-era programs.
+	EncodingIBMPC
 )
 
 // ErrInvalidUTF8 is returned when invalid UTF-8 sequences are encountered.
@@ -57,6 +63,8 @@ func (e *ErrInvalidUTF8) Error() string {
 //   - UTF-16 BE (BOM: 0xFE 0xFF) - Converted to UTF-8
 //   - UTF-8 (BOM: 0xEF 0xBB 0xBF) - BOM removed, validated
 //   - ANSEL (CHAR tag: ANSEL) - Converted to UTF-8, validated
+//   - Latin-1, Windows-1252, and IBM PC (CHAR tag: LATIN1, ANSI, IBMPC, and
+//     the names LookupEncoding accepts) - Converted to UTF-8
 //   - No BOM or CHAR tag - Assumed UTF-8, validated
 func NewReader(r io.Reader) io.Reader {
 	// First check for BOM (UTF-16, UTF-8 BOM)
@@ -315,40 +323,60 @@ func DetectEncodingFromHeader(r io.Reader) (io.Reader, Encoding, error) {
 	encoding := EncodingUnknown
 	matches := charTagPattern.FindSubmatch(peek)
 	if len(matches) >= 2 {
-		charValue := strings.ToUpper(string(matches[1]))
-		switch charValue {
-		case "ANSEL":
-			encoding = EncodingANSEL
-		case "ASCII":
-			encoding = EncodingASCII
-		case "UTF-8":
-			encoding = EncodingUTF8
-		case "UNICODE":
-			// UNICODE typically means UTF-8 in GEDCOM context
-			encoding = EncodingUTF8
-		// UTF-16 is handled by BOM detection, but recognize the tag
-		case "UTF-16", "UTF-16LE":
-			encoding = EncodingUTF16LE
-		case "UTF-16BE":
-			encoding = EncodingUTF16BE
-		case "LATIN1", "ISO-8859-1", "ANSI":
-			encoding = EncodingLATIN1
-		}
+		encoding = LookupEncoding(string(matches[1]))
 	}
 
 	// Return reader with all content
 	return io.MultiReader(bytes.NewReader(peek), r), encoding, nil
 }
 
+// LookupEncoding returns the encoding named by a GEDCOM CHAR value, such as
+// "ANSEL", "ANSI", or "IBMPC", ignoring case. Common code page names
+// ("WINDOWS-1252", "CP1252", "CP437") are accepted too. It returns
+// EncodingUnknown for names it does not recognize.
+func LookupEncoding(name string) Encoding {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "ANSEL":
+		return EncodingANSEL
+	case "ASCII":
+		return EncodingASCII
+	case "UTF-8", "UTF8":
+		return EncodingUTF8
+	case "UNICODE":
+		// UNICODE typically means UTF-8 in GEDCOM context
+		return EncodingUTF8
+	// UTF-16 is handled by BOM detection, but recognize the tag
+	case "UTF-16", "UTF-16LE":
+		return EncodingUTF16LE
+	case "UTF-16BE":
+		return EncodingUTF16BE
+	case "LATIN1", "ISO-8859-1", "ISO8859-1":
+		return EncodingLATIN1
+	case "ANSI", "WINDOWS-1252", "CP1252":
+		return EncodingWindows1252
+	case "IBMPC", "IBM-PC", "CP437":
+		return EncodingIBMPC
+	}
+	return EncodingUnknown
+}
+
 // NewReaderWithEncoding wraps a reader with the specified encoding converter.
 // It converts the input from the given encoding to UTF-8 and validates the result.
 //
 // Supported encodings:
 //   - EncodingANSEL: ANSEL to UTF-8 conversion, then validation
 //   - EncodingLATIN1: ISO-8859-1 to UTF-8 conversion, then validation
+//   - EncodingWindows1252: Windows-1252 to UTF-8 conversion
+//   - EncodingASCII: UTF-8 validation, with Windows-1252 for invalid bytes
+//   - EncodingIBMPC: code page 437 to UTF-8 conversion
 //   - EncodingUTF16LE: UTF-16 LE to UTF-8 conversion, then validation
 //   - EncodingUTF16BE: UTF-16 BE to UTF-8 conversion, then validation
-//   - EncodingUTF8, EncodingASCII, EncodingUnknown: UTF-8 validation only
+//   - EncodingUTF8, EncodingUnknown: UTF-8 validation only
+//
+// Files declaring ASCII are read as UTF-8, since many programs write UTF-8
+// under that label. Bytes that do not form valid UTF-8 are decoded as
+// Windows-1252, as programs that declare ASCII often write accented letters
+// in a code page anyway.
 func NewReaderWithEncoding(r io.Reader, enc Encoding) io.Reader {
 	var convertedReader io.Reader
 
@@ -360,13 +388,19 @@ func NewReaderWithEncoding(r io.Reader, enc Encoding) io.Reader {
 		// LATIN1 (ISO-8859-1) needs conversion to UTF-8
 		decoder := charmap.ISO8859_1.NewDecoder()
 		convertedReader = transform.NewReader(r, decoder)
+	case EncodingWindows1252:
+		convertedReader = transform.NewReader(r, charmap.Windows1252.NewDecoder())
+	case EncodingASCII:
+		convertedReader = transform.NewReader(r, asciiFallback{})
+	case EncodingIBMPC:
+		convertedReader = transform.NewReader(r, charmap.CodePage437.NewDecoder())
 	case EncodingUTF16LE:
 		// UTF-16 LE needs conversion to UTF-8
 		convertedReader = newUTF16Reader(r, false)
 	case EncodingUTF16BE:
 		// UTF-16 BE needs conversion to UTF-8
 		convertedReader = newUTF16Reader(r, true)
-	case EncodingUTF8, EncodingASCII, EncodingUnknown:
+	case EncodingUTF8, EncodingUnknown:
 		// Already UTF-8 compatible, just validate
 		convertedReader = r
 	default:
@@ -382,3 +416,38 @@ func NewReaderWithEncoding(r io.Reader, enc Encoding) io.Reader {
 		bomSkipped: true, // Assume BOM already handled
 	}
 }
+
+// asciiFallback passes valid UTF-8 through unchanged and decodes any byte
+// that does not start a valid UTF-8 sequence as Windows-1252.
+type asciiFallback struct {
+	transform.NopResetter
+}
+
+// Transform implements transform.Transformer.
+func (asciiFallback) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		b := src[nSrc]
+		if b < utf8.RuneSelf {
+			if nDst >= len(dst) {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+			dst[nDst] = b
+			nDst++
+			nSrc++
+			continue
+		}
+		if !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if r == utf8.RuneError && size <= 1 {
+			r, size = charmap.Windows1252.DecodeByte(b), 1
+		}
+		if nDst+utf8.RuneLen(r) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += utf8.EncodeRune(dst[nDst:], r)
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 // errorReader returns an error on read
@@ -1192,12 +1193,12 @@ func TestDetectEncodingFromHeader_LATIN1(t *testing.T) {
 		{
 			name:         "ANSI encoding",
 			input:        "0 HEAD\n1 CHAR ANSI\n0 TRLR\n",
-			wantEncoding: EncodingLATIN1,
+			wantEncoding: EncodingWindows1252,
 		},
 		{
 			name:         "ANSI encoding (lowercase)",
 			input:        "0 HEAD\n1 char ansi\n0 TRLR\n",
-			wantEncoding: EncodingLATIN1,
+			wantEncoding: EncodingWindows1252,
 		},
 		{
 			name:         "LATIN1 with CR line ending",
@@ -1398,3 +1399,78 @@ func TestNewReader_LATIN1_AutoDetection(t *testing.T) {
 		})
 	}
 }
+
+func TestLookupEncoding(t *testing.T) {
+	tests := []struct {
+		name string
+		want Encoding
+	}{
+		{"ANSI", EncodingWindows1252},
+		{"ansi", EncodingWindows1252},
+		{"WINDOWS-1252", EncodingWindows1252},
+		{"CP1252", EncodingWindows1252},
+		{"IBMPC", EncodingIBMPC},
+		{"IBM-PC", EncodingIBMPC},
+		{"CP437", EncodingIBMPC},
+		{"ASCII", EncodingASCII},
+		{"LATIN1", EncodingLATIN1},
+		{"ISO-8859-1", EncodingLATIN1},
+		{"ANSEL", EncodingANSEL},
+		{"UTF-8", EncodingUTF8},
+		{" utf-8 ", EncodingUTF8},
+		{"UNICODE", EncodingUTF8},
+		{"UTF-16BE", EncodingUTF16BE},
+		{"MACINTOSH", EncodingUnknown},
+		{"", EncodingUnknown},
+	}
+	for _, tt := range tests {
+		if got := LookupEncoding(tt.name); got != tt.want {
+			t.Errorf("LookupEncoding(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNewReader_CodePages(t *testing.T) {
+	tests := []struct {
+		name  string
+		char  string
+		value []byte
+		want  string
+	}{
+		// 0xE9 is e acute in Latin-1 and Windows-1252
+		{"ANSI", "ANSI", []byte{'J', 'o', 's', 0xE9}, "Jos\u00e9"},
+		{"Windows-1252", "WINDOWS-1252", []byte{'J', 'o', 's', 0xE9}, "Jos\u00e9"},
+		// 0x8A is S caron in Windows-1252 but a C1 control in Latin-1
+		{"ANSI S caron", "ANSI", []byte{0x8A, 'a', 'n', 'j', 'a'}, "\u0160anja"},
+		// 0x81 is u umlaut and 0x82 e acute in code page 437
+		{"IBMPC", "IBMPC", []byte{'M', 0x81, 'n', 'c', 'h', 'e', 'n'}, "M\u00fcnchen"},
+		{"ASCII with 8-bit text", "ASCII", []byte{'R', 0xE9, 'n', 'e'}, "R\u00e9ne"},
+		{"ASCII", "ASCII", []byte("Rene"), "Rene"},
+		{"ASCII with UTF-8 text", "ASCII", []byte("Jos\u00e9 /M\u00fcller/"), "Jos\u00e9 /M\u00fcller/"},
+		{"ASCII with mixed text", "ASCII", []byte{'R', 0xE9, 'n', 'e', ' ', 0xC3, 0xA9}, "R\u00e9ne \u00e9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := "0 HEAD\n1 CHAR " + tt.char + "\n0 @I1@ INDI\n1 NAME " + string(tt.value) + "\n0 TRLR\n"
+			got, err := io.ReadAll(NewReader(strings.NewReader(input)))
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if !strings.Contains(string(got), "1 NAME "+tt.want+"\n") {
+				t.Errorf("NewReader() = %q, want NAME %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewReaderWithEncoding_ASCIISplitReads(t *testing.T) {
+	input := []byte("1 NAME José /Müller/ R\xe9ne\n")
+	got, err := io.ReadAll(NewReaderWithEncoding(iotest.OneByteReader(bytes.NewReader(input)), EncodingASCII))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	want := "1 NAME José /Müller/ Réne\n"
+	if string(got) != want {
+		t.Errorf("NewReaderWithEncoding() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,458 @@
+// Package csvimport builds GEDCOM documents from generic spreadsheets.
+//
+// Many family trees start life as a spreadsheet. This package reads a
+// persons table (one row per person, with father/mother columns referencing
+// other rows) and an optional events table, and produces a gedcom.Document
+// with individuals, families, and events that can be written with the
+// encoder package.
+//
+// Example usage:
+//
+//	persons, _ := os.Open("persons.csv")
+//	events, _ := os.Open("events.csv")
+//
+//	doc, err := csvimport.Import(persons, events, nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	out, _ := os.Create("family.ged")
+//	defer out.Close()
+//	encoder.Encode(out, doc)
+//
+// Column names are configurable through ImportOptions; the defaults are
+// documented on DefaultPersonColumns and DefaultEventColumns.
+package csvimport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// eventAliases maps common spreadsheet event names to GEDCOM tags.
+var eventAliases = map[string]gedcom.EventType{
+	"birth":        gedcom.EventBirth,
+	"born":         gedcom.EventBirth,
+	"death":        gedcom.EventDeath,
+	"died":         gedcom.EventDeath,
+	"baptism":      gedcom.EventBaptism,
+	"burial":       gedcom.EventBurial,
+	"buried":       gedcom.EventBurial,
+	"census":       gedcom.EventCensus,
+	"christening":  gedcom.EventChristening,
+	"adoption":     gedcom.EventAdoption,
+	"residence":    gedcom.EventResidence,
+	"immigration":  gedcom.EventImmigration,
+	"emigration":   gedcom.EventEmigration,
+	"graduation":   gedcom.EventGraduation,
+	"retirement":   gedcom.EventRetirement,
+	"cremation":    gedcom.EventCremation,
+	"probate":      gedcom.EventProbate,
+	"will":         gedcom.EventWill,
+	"confirmation": gedcom.EventConfirmation,
+}
+
+// individualEventTags lists the GEDCOM tags accepted verbatim in events.csv.
+var individualEventTags = map[string]bool{
+	"BIRT": true, "DEAT": true, "BAPM": true, "BURI": true, "CENS": true,
+	"CHR": true, "ADOP": true, "RESI": true, "IMMI": true, "EMIG": true,
+	"BARM": true, "BASM": true, "BLES": true, "CHRA": true, "CONF": true,
+	"FCOM": true, "GRAD": true, "RETI": true, "NATU": true, "ORDN": true,
+	"PROB": true, "WILL": true, "CREM": true,
+}
+
+// Import builds a Document from a persons table and an optional events table.
+// Pass a nil events reader to import persons only. If opts is nil,
+// DefaultOptions is used.
+//
+// Each person row becomes an INDI record with a generated XRef (@I1@, @I2@,
+// ...) in row order. Each distinct father/mother pair becomes a FAM record
+// linking the parents and their children. Rows referencing unknown parent
+// IDs, duplicate IDs, and events for unknown people are reported as
+// *ImportError.
+func Import(persons, events io.Reader, opts *ImportOptions) (*gedcom.Document, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	b := newBuilder(opts)
+
+	if err := b.readPersons(persons); err != nil {
+		return nil, err
+	}
+	if err := b.linkFamilies(); err != nil {
+		return nil, err
+	}
+	if events != nil {
+		if err := b.readEvents(events); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.doc, nil
+}
+
+// personRow holds the relationship columns of a person until all rows are read.
+type personRow struct {
+	row    int
+	indi   *gedcom.Individual
+	father string
+	mother string
+}
+
+// builder accumulates the document while reading the spreadsheets.
+type builder struct {
+	opts     *ImportOptions
+	doc      *gedcom.Document
+	byID     map[string]*gedcom.Individual
+	rows     []personRow
+	famCount int
+}
+
+func newBuilder(opts *ImportOptions) *builder {
+	version := opts.Version
+	if version == "" {
+		version = gedcom.Version551
+	}
+	return &builder{
+		opts: opts,
+		doc: &gedcom.Document{
+			Header: &gedcom.Header{
+				Version:      version,
+				Encoding:     gedcom.EncodingUTF8,
+				SourceSystem: opts.SourceSystem,
+			},
+			Trailer: &gedcom.Trailer{},
+			XRefMap: make(map[string]*gedcom.Record),
+		},
+		byID: make(map[string]*gedcom.Individual),
+	}
+}
+
+// table wraps a csv.Reader with header-name column lookup.
+type table struct {
+	file    string
+	reader  *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+func (b *builder) openTable(r io.Reader, file string) (*table, error) {
+	reader := csv.NewReader(r)
+	if b.opts.Comma != 0 {
+		reader.Comma = b.opts.Comma
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, &ImportError{File: file, Row: 1, Message: "missing header row"}
+		}
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	t := &table{file: file, reader: reader, columns: make(map[string]int), row: 1}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, exists := t.columns[name]; !exists {
+			t.columns[name] = i
+		}
+	}
+	return t, nil
+}
+
+// require returns a MissingColumnError if the named column is not present.
+func (t *table) require(column string) error {
+	if column == "" {
+		return &MissingColumnError{File: t.file, Column: column}
+	}
+	if _, ok := t.columns[strings.ToLower(column)]; !ok {
+		return &MissingColumnError{File: t.file, Column: column}
+	}
+	return nil
+}
+
+// next reads the next row. Returns io.EOF when the table is exhausted.
+func (t *table) next() ([]string, error) {
+	record, err := t.reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", t.file, err)
+	}
+	t.row++
+	return record, nil
+}
+
+// get returns the trimmed value of the named column, or "" if the column is
+// unmapped or absent.
+func (t *table) get(record []string, column string) string {
+	if column == "" {
+		return ""
+	}
+	idx, ok := t.columns[strings.ToLower(column)]
+	if !ok || idx >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[idx])
+}
+
+func (b *builder) readPersons(r io.Reader) error {
+	cols := b.opts.Persons
+	if cols == nil {
+		cols = DefaultPersonColumns()
+	}
+
+	t, err := b.openTable(r, "persons")
+	if err != nil {
+		return err
+	}
+	if err := t.require(cols.ID); err != nil {
+		return err
+	}
+
+	for {
+		record, err := t.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		id := t.get(record, cols.ID)
+		if id == "" {
+			return &ImportError{File: t.file, Row: t.row, Column: cols.ID, Message: "empty person ID"}
+		}
+		if _, dup := b.byID[id]; dup {
+			return &ImportError{File: t.file, Row: t.row, Column: cols.ID, Message: fmt.Sprintf("duplicate person ID %q", id)}
+		}
+
+		xref := fmt.Sprintf("@I%d@", len(b.rows)+1)
+		indi := &gedcom.Individual{
+			XRef: xref,
+			Sex:  normalizeSex(t.get(record, cols.Sex)),
+		}
+		if name := buildName(t.get(record, cols.Given), t.get(record, cols.Surname), t.get(record, cols.Name)); name != nil {
+			indi.Names = append(indi.Names, name)
+		}
+		if event := buildEvent(gedcom.EventBirth, t.get(record, cols.BirthDate), t.get(record, cols.BirthPlace)); event != nil {
+			indi.Events = append(indi.Events, event)
+		}
+		if event := buildEvent(gedcom.EventDeath, t.get(record, cols.DeathDate), t.get(record, cols.DeathPlace)); event != nil {
+			indi.Events = append(indi.Events, event)
+		}
+
+		b.byID[id] = indi
+		b.rows = append(b.rows, personRow{
+			row:    t.row,
+			indi:   indi,
+			father: t.get(record, cols.Father),
+			mother: t.get(record, cols.Mother),
+		})
+		b.addRecord(xref, gedcom.RecordTypeIndividual, indi)
+	}
+}
+
+// linkFamilies creates one family per distinct father/mother pair.
+func (b *builder) linkFamilies() error {
+	cols := b.opts.Persons
+	if cols == nil {
+		cols = DefaultPersonColumns()
+	}
+
+	families := make(map[[2]string]*gedcom.Family)
+
+	for _, row := range b.rows {
+		if row.father == "" && row.mother == "" {
+			continue
+		}
+
+		father, err := b.lookupParent(row.row, cols.Father, row.father)
+		if err != nil {
+			return err
+		}
+		mother, err := b.lookupParent(row.row, cols.Mother, row.mother)
+		if err != nil {
+			return err
+		}
+
+		key := [2]string{row.father, row.mother}
+		fam, ok := families[key]
+		if !ok {
+			b.famCount++
+			fam = &gedcom.Family{XRef: fmt.Sprintf("@F%d@", b.famCount)}
+			if father != nil {
+				fam.Husband = father.XRef
+				father.SpouseInFamilies = append(father.SpouseInFamilies, fam.XRef)
+			}
+			if mother != nil {
+				fam.Wife = mother.XRef
+				mother.SpouseInFamilies = append(mother.SpouseInFamilies, fam.XRef)
+			}
+			families[key] = fam
+			b.addRecord(fam.XRef, gedcom.RecordTypeFamily, fam)
+		}
+
+		fam.Children = append(fam.Children, row.indi.XRef)
+		row.indi.ChildInFamilies = append(row.indi.ChildInFamilies, gedcom.FamilyLink{FamilyXRef: fam.XRef})
+	}
+
+	return nil
+}
+
+func (b *builder) lookupParent(row int, column, id string) (*gedcom.Individual, error) {
+	if id == "" {
+		return nil, nil
+	}
+	parent, ok := b.byID[id]
+	if !ok {
+		return nil, &ImportError{File: "persons", Row: row, Column: column, Message: fmt.Sprintf("unknown person ID %q", id)}
+	}
+	return parent, nil
+}
+
+func (b *builder) readEvents(r io.Reader) error {
+	cols := b.opts.Events
+	if cols == nil {
+		cols = DefaultEventColumns()
+	}
+
+	t, err := b.openTable(r, "events")
+	if err != nil {
+		return err
+	}
+	if err := t.require(cols.Person); err != nil {
+		return err
+	}
+	if err := t.require(cols.Type); err != nil {
+		return err
+	}
+
+	for {
+		record, err := t.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		id := t.get(record, cols.Person)
+		indi, ok := b.byID[id]
+		if !ok {
+			return &ImportError{File: t.file, Row: t.row, Column: cols.Person, Message: fmt.Sprintf("unknown person ID %q", id)}
+		}
+
+		rawType := t.get(record, cols.Type)
+		if rawType == "" {
+			return &ImportError{File: t.file, Row: t.row, Column: cols.Type, Message: "empty event type"}
+		}
+
+		eventType, detail := resolveEventType(rawType)
+		event := &gedcom.Event{
+			Type:            eventType,
+			EventTypeDetail: detail,
+			Description:     t.get(record, cols.Description),
+		}
+		setEventDatePlace(event, t.get(record, cols.Date), t.get(record, cols.Place))
+		indi.Events = append(indi.Events, event)
+	}
+}
+
+func (b *builder) addRecord(xref string, recordType gedcom.RecordType, entity interface{}) {
+	record := &gedcom.Record{XRef: xref, Type: recordType, Entity: entity}
+	b.doc.Records = append(b.doc.Records, record)
+	b.doc.XRefMap[xref] = record
+}
+
+// resolveEventType maps a spreadsheet event type to a GEDCOM event type.
+// Unrecognized values become EVEN with the original value as TYPE detail.
+func resolveEventType(value string) (gedcom.EventType, string) {
+	if upper := strings.ToUpper(value); individualEventTags[upper] {
+		return gedcom.EventType(upper), ""
+	}
+	if eventType, ok := eventAliases[strings.ToLower(value)]; ok {
+		return eventType, ""
+	}
+	return gedcom.EventType("EVEN"), value
+}
+
+// buildName creates a PersonalName from separate components or a full name.
+// Returns nil if no name data is present.
+func buildName(given, surname, full string) *gedcom.PersonalName {
+	if given == "" && surname == "" {
+		if full == "" {
+			return nil
+		}
+		name := &gedcom.PersonalName{Full: full}
+		if start := strings.Index(full, "/"); start >= 0 {
+			name.Given = strings.TrimSpace(full[:start])
+			rest := full[start+1:]
+			if end := strings.Index(rest, "/"); end >= 0 {
+				name.Surname = rest[:end]
+			} else {
+				name.Surname = strings.TrimSpace(rest)
+			}
+		} else {
+			name.Given = full
+		}
+		return name
+	}
+
+	name := &gedcom.PersonalName{Given: given, Surname: surname}
+	switch {
+	case surname == "":
+		name.Full = given
+	case given == "":
+		name.Full = "/" + surname + "/"
+	default:
+		name.Full = given + " /" + surname + "/"
+	}
+	return name
+}
+
+// buildEvent creates an event from date and place, or nil if both are empty.
+func buildEvent(eventType gedcom.EventType, date, place string) *gedcom.Event {
+	if date == "" && place == "" {
+		return nil
+	}
+	event := &gedcom.Event{Type: eventType}
+	setEventDatePlace(event, date, place)
+	return event
+}
+
+func setEventDatePlace(event *gedcom.Event, date, place string) {
+	if date != "" {
+		event.Date = date
+		if parsed, err := gedcom.ParseDate(date); err == nil {
+			event.ParsedDate = parsed
+		}
+	}
+	if place != "" {
+		event.Place = place
+	}
+}
+
+// normalizeSex maps common spreadsheet values to GEDCOM SEX values.
+func normalizeSex(value string) string {
+	switch strings.ToLower(value) {
+	case "":
+		return ""
+	case "m", "male", "man":
+		return "M"
+	case "f", "female", "woman":
+		return "F"
+	case "x":
+		return "X"
+	default:
+		return "U"
+	}
+}
@@ -0,0 +1,222 @@
+package csvimport
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/decoder"
+	"github.com/cacack/gedcom-go/encoder"
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+const testPersons = `id,given,surname,sex,father,mother,birth_date,birth_place
+p1,John,Smith,male,,,1 JAN 1850,Boston
+p2,Mary,Jones,F,,,,
+p3,Alice,Smith,female,p1,p2,3 MAR 1875,Salem
+p4,Bob,Smith,m,p1,p2,,
+p5,Carol,Smith,f,,p2,,
+`
+
+const testEvents = `person,type,date,place,description
+p1,death,12 DEC 1920,Boston,
+p3,BAPM,10 MAR 1875,Salem,
+p4,Military service,1898,,Spanish-American War
+`
+
+func TestImport(t *testing.T) {
+	doc, err := Import(strings.NewReader(testPersons), strings.NewReader(testEvents), nil)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if got := len(doc.Individuals()); got != 5 {
+		t.Fatalf("len(Individuals()) = %d, want 5", got)
+	}
+	if got := len(doc.Families()); got != 2 {
+		t.Fatalf("len(Families()) = %d, want 2", got)
+	}
+	if doc.Header.Version != gedcom.Version551 {
+		t.Errorf("Header.Version = %s, want 5.5.1", doc.Header.Version)
+	}
+
+	john := doc.GetIndividual("@I1@")
+	if john.Names[0].Full != "John /Smith/" {
+		t.Errorf("Full = %q, want %q", john.Names[0].Full, "John /Smith/")
+	}
+	if john.Sex != "M" {
+		t.Errorf("Sex = %q, want M", john.Sex)
+	}
+	if birth := john.BirthEvent(); birth == nil || birth.Place != "Boston" || birth.ParsedDate == nil {
+		t.Errorf("BirthEvent() = %+v, want parsed birth in Boston", birth)
+	}
+	if death := john.DeathEvent(); death == nil || death.Date != "12 DEC 1920" {
+		t.Errorf("DeathEvent() = %+v, want death from events.csv", death)
+	}
+
+	// Full siblings share one family
+	fam := doc.GetFamily("@F1@")
+	if fam.Husband != "@I1@" || fam.Wife != "@I2@" {
+		t.Errorf("@F1@ spouses = %s/%s, want @I1@/@I2@", fam.Husband, fam.Wife)
+	}
+	if len(fam.Children) != 2 || fam.Children[0] != "@I3@" || fam.Children[1] != "@I4@" {
+		t.Errorf("@F1@ children = %v, want [@I3@ @I4@]", fam.Children)
+	}
+
+	// Half sibling with mother only gets a separate family
+	fam2 := doc.GetFamily("@F2@")
+	if fam2.Husband != "" || fam2.Wife != "@I2@" || len(fam2.Children) != 1 {
+		t.Errorf("@F2@ = %+v, want mother-only family with one child", fam2)
+	}
+
+	mary := doc.GetIndividual("@I2@")
+	if len(mary.SpouseInFamilies) != 2 {
+		t.Errorf("mary.SpouseInFamilies = %v, want 2 families", mary.SpouseInFamilies)
+	}
+	alice := doc.GetIndividual("@I3@")
+	if parents := alice.Parents(doc); len(parents) != 2 {
+		t.Errorf("len(alice.Parents) = %d, want 2", len(parents))
+	}
+
+	bob := doc.GetIndividual("@I4@")
+	if len(bob.Events) != 1 || bob.Events[0].Type != "EVEN" || bob.Events[0].EventTypeDetail != "Military service" {
+		t.Errorf("bob.Events = %+v, want EVEN with TYPE detail", bob.Events)
+	}
+	if bob.Events[0].Description != "Spanish-American War" {
+		t.Errorf("Description = %q", bob.Events[0].Description)
+	}
+}
+
+func TestImportCustomMapping(t *testing.T) {
+	persons := "Person;Full Name;Gender;Dad;Mum\n" +
+		"1;Hans /Müller/;M;;\n" +
+		"2;Greta;F;1;\n"
+
+	opts := DefaultOptions()
+	opts.Comma = ';'
+	opts.Persons = &PersonColumns{
+		ID:     "person",
+		Name:   "full name",
+		Sex:    "gender",
+		Father: "dad",
+		Mother: "mum",
+	}
+	opts.Version = gedcom.Version70
+	opts.SourceSystem = "spreadsheet"
+
+	doc, err := Import(strings.NewReader(persons), nil, opts)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	hans := doc.GetIndividual("@I1@")
+	if hans.Names[0].Given != "Hans" || hans.Names[0].Surname != "Müller" {
+		t.Errorf("name = %+v, want Hans Müller", hans.Names[0])
+	}
+	greta := doc.GetIndividual("@I2@")
+	if greta.Names[0].Full != "Greta" || greta.Names[0].Surname != "" {
+		t.Errorf("name = %+v, want Greta without surname", greta.Names[0])
+	}
+	if len(greta.ChildInFamilies) != 1 {
+		t.Errorf("greta.ChildInFamilies = %v, want one family", greta.ChildInFamilies)
+	}
+	if doc.Header.Version != gedcom.Version70 || doc.Header.SourceSystem != "spreadsheet" {
+		t.Errorf("Header = %+v", doc.Header)
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		persons string
+		events  string
+		want    string
+	}{
+		{name: "empty persons", persons: "", want: "missing header row"},
+		{name: "missing id column", persons: "name\nJohn\n", want: `missing required column "id"`},
+		{name: "empty id", persons: "id,name\n,John\n", want: "persons row 2"},
+		{name: "duplicate id", persons: "id\np1\np1\n", want: `duplicate person ID "p1"`},
+		{name: "unknown parent", persons: "id,father\np1,p9\n", want: `unknown person ID "p9"`},
+		{name: "missing type column", persons: "id\np1\n", events: "person\np1\n", want: `missing required column "type"`},
+		{name: "unknown event person", persons: "id\np1\n", events: "person,type\np2,birth\n", want: "events row 2"},
+		{name: "empty event type", persons: "id\np1\n", events: "person,type\np1,\n", want: "empty event type"},
+		{name: "malformed csv", persons: "id\n\"p1\n", want: "persons:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events io.Reader
+			if tt.events != "" {
+				events = strings.NewReader(tt.events)
+			}
+			_, err := Import(strings.NewReader(tt.persons), events, nil)
+			if err == nil {
+				t.Fatal("Import() error = nil, want error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Import() error = %q, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportErrorTypes(t *testing.T) {
+	_, err := Import(strings.NewReader("id,father\np1,p9\n"), nil, nil)
+	var importErr *ImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("error type = %T, want *ImportError", err)
+	}
+	if importErr.Row != 2 || importErr.Column != "father" {
+		t.Errorf("ImportError = %+v, want row 2 column father", importErr)
+	}
+
+	_, err = Import(strings.NewReader("name\n"), nil, nil)
+	var colErr *MissingColumnError
+	if !errors.As(err, &colErr) {
+		t.Fatalf("error type = %T, want *MissingColumnError", err)
+	}
+}
+
+func TestImportRoundTrip(t *testing.T) {
+	doc, err := Import(strings.NewReader(testPersons), strings.NewReader(testEvents), nil)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(decoded.Individuals()) != 5 || len(decoded.Families()) != 2 {
+		t.Errorf("decoded %d individuals / %d families, want 5 / 2",
+			len(decoded.Individuals()), len(decoded.Families()))
+	}
+	alice := decoded.GetIndividual("@I3@")
+	if alice == nil || len(alice.Parents(decoded)) != 2 {
+		t.Error("parent links not preserved through encode/decode")
+	}
+}
+
+func TestNormalizeSex(t *testing.T) {
+	tests := map[string]string{
+		"":        "",
+		"M":       "M",
+		"Male":    "M",
+		"f":       "F",
+		"FEMALE":  "F",
+		"x":       "X",
+		"unknown": "U",
+	}
+	for in, want := range tests {
+		if got := normalizeSex(in); got != want {
+			t.Errorf("normalizeSex(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
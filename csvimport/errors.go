@@ -0,0 +1,35 @@
+package csvimport
+
+import "fmt"
+
+// ImportError reports a problem with a specific spreadsheet row.
+type ImportError struct {
+	// File identifies the input ("persons" or "events")
+	File string
+
+	// Row is the 1-based row number, counting the header as row 1
+	Row int
+
+	// Column is the header name of the offending column, if applicable
+	Column string
+
+	// Message describes what went wrong
+	Message string
+}
+
+func (e *ImportError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("%s row %d: column %q: %s", e.File, e.Row, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s row %d: %s", e.File, e.Row, e.Message)
+}
+
+// MissingColumnError reports a required column absent from the header row.
+type MissingColumnError struct {
+	File   string
+	Column string
+}
+
+func (e *MissingColumnError) Error() string {
+	return fmt.Sprintf("%s: missing required column %q", e.File, e.Column)
+}
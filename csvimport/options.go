@@ -0,0 +1,118 @@
+package csvimport
+
+import "github.com/cacack/gedcom-go/gedcom"
+
+// PersonColumns maps persons.csv header names to individual fields.
+// Header matching is case-insensitive and ignores surrounding whitespace.
+// Empty entries disable the corresponding field.
+type PersonColumns struct {
+	// ID is the column holding the row's unique person identifier (required)
+	ID string
+
+	// Name is a full-name column in GEDCOM format ("John /Smith/") or plain text.
+	// Used only when Given and Surname are both absent from the row.
+	Name string
+
+	// Given is the given-name column
+	Given string
+
+	// Surname is the surname column
+	Surname string
+
+	// Sex is the sex column; values such as "male", "f", or "U" are normalized
+	Sex string
+
+	// Father is the column holding the father's person ID
+	Father string
+
+	// Mother is the column holding the mother's person ID
+	Mother string
+
+	// BirthDate and BirthPlace are optional convenience columns for a BIRT event
+	BirthDate  string
+	BirthPlace string
+
+	// DeathDate and DeathPlace are optional convenience columns for a DEAT event
+	DeathDate  string
+	DeathPlace string
+}
+
+// EventColumns maps events.csv header names to event fields.
+// Header matching is case-insensitive and ignores surrounding whitespace.
+type EventColumns struct {
+	// Person is the column holding the person ID the event belongs to (required)
+	Person string
+
+	// Type is the event type column (required). Accepts GEDCOM tags ("BIRT")
+	// or common English names ("birth", "burial"). Unrecognized values are
+	// imported as generic EVEN events with the value as the TYPE detail.
+	Type string
+
+	// Date is the event date column (GEDCOM date format)
+	Date string
+
+	// Place is the event place column
+	Place string
+
+	// Description is the event description column
+	Description string
+}
+
+// ImportOptions provides configuration for importing spreadsheets.
+type ImportOptions struct {
+	// Persons maps persons.csv columns. If nil, DefaultPersonColumns is used.
+	Persons *PersonColumns
+
+	// Events maps events.csv columns. If nil, DefaultEventColumns is used.
+	Events *EventColumns
+
+	// Comma is the field delimiter (default ',')
+	Comma rune
+
+	// Version is the GEDCOM version recorded in the header (default 5.5.1)
+	Version gedcom.Version
+
+	// SourceSystem is written to HEAD.SOUR when set
+	SourceSystem string
+}
+
+// DefaultPersonColumns returns the default persons.csv column mapping:
+// id, name, given, surname, sex, father, mother, birth_date, birth_place,
+// death_date, death_place.
+func DefaultPersonColumns() *PersonColumns {
+	return &PersonColumns{
+		ID:         "id",
+		Name:       "name",
+		Given:      "given",
+		Surname:    "surname",
+		Sex:        "sex",
+		Father:     "father",
+		Mother:     "mother",
+		BirthDate:  "birth_date",
+		BirthPlace: "birth_place",
+		DeathDate:  "death_date",
+		DeathPlace: "death_place",
+	}
+}
+
+// DefaultEventColumns returns the default events.csv column mapping:
+// person, type, date, place, description.
+func DefaultEventColumns() *EventColumns {
+	return &EventColumns{
+		Person:      "person",
+		Type:        "type",
+		Date:        "date",
+		Place:       "place",
+		Description: "description",
+	}
+}
+
+// DefaultOptions returns the default import options.
+func DefaultOptions() *ImportOptions {
+	return &ImportOptions{
+		Persons: DefaultPersonColumns(),
+		Events:  DefaultEventColumns(),
+		Comma:   ',',
+		Version: gedcom.Version551,
+	}
+}
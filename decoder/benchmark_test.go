@@ -102,6 +102,50 @@ func BenchmarkDecodeLarge(b *testing.B) {
 	}
 }
 
+// BenchmarkDecodeLargeLazy benchmarks parsing the US Presidents file with
+// LazyEntities, looking up a single individual.
+func BenchmarkDecodeLargeLazy(b *testing.B) {
+	data, err := os.ReadFile("../testdata/gedcom-5.5/pres2020.ged")
+	if err != nil {
+		b.Skip("Test file not found:", err)
+	}
+	opts := DefaultOptions()
+	opts.LazyEntities = true
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		doc, err := DecodeWithOptions(newBytesReader(data), opts)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if doc.GetIndividual("@I1@") == nil {
+			b.Fatal("@I1@ not found")
+		}
+	}
+}
+
+// BenchmarkDecodeLargeInternStrings benchmarks parsing the US Presidents
+// file with InternStrings.
+func BenchmarkDecodeLargeInternStrings(b *testing.B) {
+	data, err := os.ReadFile("../testdata/gedcom-5.5/pres2020.ged")
+	if err != nil {
+		b.Skip("Test file not found:", err)
+	}
+	opts := DefaultOptions()
+	opts.InternStrings = true
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeWithOptions(newBytesReader(data), opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 // BenchmarkDecode10MB benchmarks parsing a GEDCOM file ~10MB (set GEDCOM_BENCH_10MB to override).
 func BenchmarkDecode10MB(b *testing.B) {
 	data := readBenchmarkGED(b, bench10MBEnv, bench10MBMinSize)
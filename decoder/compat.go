@@ -0,0 +1,111 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+	"github.com/cacack/gedcom-go/tags"
+)
+
+// sourceVendor returns the vendor of the program that produced the file,
+// read from the HEAD.SOUR structure of parsed lines.
+func sourceVendor(lines []*parser.Line) gedcom.Vendor {
+	doc := &gedcom.Document{Header: &gedcom.Header{}}
+	buildHeader(doc, lines, "")
+	return doc.Header.SourceProduct().Product.Vendor()
+}
+
+// applyCompatFixes works around known exporter quirks for the given vendor.
+// Only the fixes targeting that vendor are applied; each change is reported
+// as a warning. Level jump repairs are performed by the parser and reported
+// here only when the vendor is RootsMagic.
+func applyCompatFixes(lines []*parser.Line, vendor gedcom.Vendor, repairs []parser.LevelRepair) ([]*parser.Line, []gedcom.Warning) {
+	var warnings []gedcom.Warning
+
+	switch vendor {
+	case gedcom.VendorAncestry:
+		warnings = fixContinuationLevels(lines)
+	case gedcom.VendorMyHeritage:
+		lines, warnings = dropEmptyDates(lines)
+	case gedcom.VendorRootsMagic:
+		warnings = levelRepairWarnings(WarnCompatLevelJump, repairs)
+	}
+
+	return lines, warnings
+}
+
+// fixContinuationLevels re-levels CONC/CONT lines that are not exactly one
+// level below the line they continue. Ancestry exports sometimes leave CONC
+// chains at the level of the text they continue, which would otherwise be
+// read as sibling tags and lose the text.
+func fixContinuationLevels(lines []*parser.Line) []gedcom.Warning {
+	var warnings []gedcom.Warning
+	var owner *parser.Line
+
+	for _, line := range lines {
+		if line.Tag != "CONC" && line.Tag != "CONT" {
+			owner = line
+			continue
+		}
+		if owner == nil || line.Level == owner.Level+1 {
+			continue
+		}
+
+		warnings = append(warnings, gedcom.Warning{
+			Code: WarnCompatContinuationLevel,
+			Line: line.LineNumber,
+			Message: fmt.Sprintf("%s at level %d attached to %s at level %d",
+				line.Tag, line.Level, owner.Tag, owner.Level),
+		})
+		line.Level = owner.Level + 1
+	}
+
+	return warnings
+}
+
+// dropEmptyDates removes DATE lines that have neither a value nor subordinate
+// lines. MyHeritage emits these for events with unknown dates.
+func dropEmptyDates(lines []*parser.Line) ([]*parser.Line, []gedcom.Warning) {
+	var warnings []gedcom.Warning
+	kept := lines[:0:0]
+
+	for i, line := range lines {
+		if line.Tag == "DATE" && line.Value == "" &&
+			(i+1 >= len(lines) || lines[i+1].Level <= line.Level) {
+			warnings = append(warnings, gedcom.Warning{
+				Code:    WarnCompatEmptyDate,
+				Line:    line.LineNumber,
+				Message: "dropped empty DATE",
+			})
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	return kept, warnings
+}
+
+// compatRepairGate is a parser line hook that turns on level jump repair
+// once the header shows the file comes from RootsMagic, so CompatMode
+// repairs jumps only in the records of RootsMagic files. Jumps in files from
+// other products stay parse errors: they fail the decode, or are skipped
+// under RecoverErrors, exactly as without CompatMode.
+type compatRepairGate struct {
+	p    *parser.Parser
+	head []*parser.Line
+	done bool
+}
+
+func (g *compatRepairGate) lineParsed(line *parser.Line) {
+	if g.done {
+		return
+	}
+	if line.Level == 0 && tags.Tag(line.Tag) != tags.HEAD {
+		g.done = true
+		g.p.SetRepairLevelJumps(sourceVendor(g.head) == gedcom.VendorRootsMagic)
+		g.head = nil
+		return
+	}
+	g.head = append(g.head, line)
+}
@@ -0,0 +1,184 @@
+package decoder
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/parser"
+)
+
+func compatOptions() *DecodeOptions {
+	opts := DefaultOptions()
+	opts.CompatMode = true
+	return opts
+}
+
+func TestCompatModeAncestryContinuation(t *testing.T) {
+	input := `0 HEAD
+1 SOUR Ancestry.com Family Trees
+1 GEDC
+2 VERS 5.5.1
+0 @N1@ NOTE First part
+0 @I1@ INDI
+1 NAME John /Smith/
+1 NOTE Born in a small
+1 CONC  town
+0 TRLR`
+
+	doc, err := DecodeWithOptions(strings.NewReader(input), compatOptions())
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+
+	tags := doc.GetRecord("@I1@").Tags
+	conc := tags[len(tags)-1]
+	if conc.Tag != "CONC" || conc.Level != 2 {
+		t.Errorf("CONC tag = %+v, want level 2", conc)
+	}
+	if len(doc.Warnings) != 1 || doc.Warnings[0].Code != WarnCompatContinuationLevel {
+		t.Errorf("Warnings = %v, want one %s", doc.Warnings, WarnCompatContinuationLevel)
+	}
+	if doc.Warnings[0].Line != 9 {
+		t.Errorf("Warning line = %d, want 9", doc.Warnings[0].Line)
+	}
+}
+
+func TestCompatModeMyHeritageEmptyDate(t *testing.T) {
+	input := `0 HEAD
+1 SOUR MYHERITAGE
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 BIRT
+2 DATE
+2 PLAC Oslo
+0 TRLR`
+
+	doc, err := DecodeWithOptions(strings.NewReader(input), compatOptions())
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+
+	ind := doc.GetIndividual("@I1@")
+	if len(ind.Events) != 1 || ind.Events[0].Place != "Oslo" {
+		t.Fatalf("Events = %+v", ind.Events)
+	}
+	for _, tag := range doc.GetRecord("@I1@").Tags {
+		if tag.Tag == "DATE" {
+			t.Error("empty DATE tag should be dropped")
+		}
+	}
+	if len(doc.Warnings) != 1 || doc.Warnings[0].Code != WarnCompatEmptyDate || doc.Warnings[0].Line != 7 {
+		t.Errorf("Warnings = %v", doc.Warnings)
+	}
+}
+
+func TestCompatModeRootsMagicLevelJump(t *testing.T) {
+	input := `0 HEAD
+1 SOUR RootsMagic
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 BIRT
+3 DATE 1 JAN 1900
+3 PLAC Boston
+0 TRLR`
+
+	if _, err := Decode(strings.NewReader(input)); err == nil {
+		t.Fatal("Decode() without compat mode should fail on level jump")
+	}
+
+	doc, err := DecodeWithOptions(strings.NewReader(input), compatOptions())
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+
+	ind := doc.GetIndividual("@I1@")
+	if len(ind.Events) != 1 || ind.Events[0].Date != "1 JAN 1900" || ind.Events[0].Place != "Boston" {
+		t.Fatalf("Events = %+v", ind.Events)
+	}
+	if len(doc.Warnings) != 1 || doc.Warnings[0].Code != WarnCompatLevelJump || doc.Warnings[0].Line != 7 {
+		t.Errorf("Warnings = %v", doc.Warnings)
+	}
+}
+
+func TestCompatModeGatedByVendor(t *testing.T) {
+	levelJump := `0 HEAD
+1 SOUR Gramps
+0 @I1@ INDI
+1 BIRT
+3 DATE 1 JAN 1900
+0 TRLR`
+
+	_, err := DecodeWithOptions(strings.NewReader(levelJump), compatOptions())
+	var mismatch *parser.LevelMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("error = %v, want LevelMismatchError", err)
+	}
+
+	opts := compatOptions()
+	opts.RecoverErrors = true
+	doc, err := DecodeWithOptions(strings.NewReader(levelJump), opts)
+	var decodeErrs *DecodeErrors
+	if !errors.As(err, &decodeErrs) || doc == nil {
+		t.Fatalf("error = %v, want DecodeErrors with document", err)
+	}
+
+	emptyDate := `0 HEAD
+1 SOUR Gramps
+0 @I1@ INDI
+1 BIRT
+2 DATE
+0 TRLR`
+
+	doc, err = DecodeWithOptions(strings.NewReader(emptyDate), compatOptions())
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	if len(doc.Warnings) != 0 {
+		t.Errorf("Warnings = %v, want none for non-targeted vendor", doc.Warnings)
+	}
+}
+
+func TestCompatModeRecoverySkipsLevelJumps(t *testing.T) {
+	input := `0 HEAD
+1 SOUR Gramps
+0 @I1@ INDI
+1 NAME John /Doe/
+3 DATE 1 JAN 1900
+3 NOTE jumped
+4 NOTE jumped
+1 SEX M
+0 TRLR`
+
+	recover := DefaultOptions()
+	recover.RecoverErrors = true
+	want, wantErr := DecodeWithOptions(strings.NewReader(input), recover)
+
+	opts := compatOptions()
+	opts.RecoverErrors = true
+	doc, err := DecodeWithOptions(strings.NewReader(input), opts)
+	var decodeErrs, wantErrs *DecodeErrors
+	if !errors.As(err, &decodeErrs) || !errors.As(wantErr, &wantErrs) {
+		t.Fatalf("errors = %v, %v, want DecodeErrors", err, wantErr)
+	}
+	if len(decodeErrs.Errors) != len(wantErrs.Errors) {
+		t.Errorf("got %d errors, want %d as without CompatMode: %v", len(decodeErrs.Errors), len(wantErrs.Errors), err)
+	}
+
+	// The jumped lines are skipped, not kept and reported
+	if !reflect.DeepEqual(doc.GetRecord("@I1@").Tags, want.GetRecord("@I1@").Tags) {
+		t.Errorf("Tags differ from a recovering decode without CompatMode")
+	}
+	if len(doc.GetRecord("@I1@").Tags) != 2 {
+		t.Errorf("kept %d tags, want NAME and SEX", len(doc.GetRecord("@I1@").Tags))
+	}
+	if !reflect.DeepEqual(doc.DecodeReport, want.DecodeReport) {
+		t.Errorf("DecodeReport = %+v, want %+v", doc.DecodeReport, want.DecodeReport)
+	}
+	if len(doc.Warnings) != 0 {
+		t.Errorf("Warnings = %v, want none for non-targeted vendor", doc.Warnings)
+	}
+}
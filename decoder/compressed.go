@@ -0,0 +1,73 @@
+package decoder
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zipMagic  = []byte("PK\x03\x04")
+)
+
+// decompress returns a reader of the GEDCOM text in r, decompressing it
+// first if it starts like a gzip stream (.ged.gz) or a zip archive. Other
+// input is returned unchanged apart from buffering.
+//
+// A zip archive must hold exactly one .ged file (matched case-insensitively),
+// or else exactly one file. Zip archives are read into memory, since their
+// directory is at the end.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(len(zipMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("gzip input: %w", err)
+		}
+		return zr, nil
+	case bytes.Equal(magic, zipMagic):
+		return zipMember(br)
+	}
+	return br, nil
+}
+
+// zipMember opens the GEDCOM file of the zip archive read from r.
+func zipMember(r io.Reader) (io.Reader, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("zip input: %w", err)
+	}
+
+	var files, geds []*zip.File
+	for _, f := range archive.File {
+		// Skip directories and the resource forks macOS adds to archives
+		if f.FileInfo().IsDir() || strings.HasPrefix(f.Name, "__MACOSX/") {
+			continue
+		}
+		files = append(files, f)
+		if strings.EqualFold(path.Ext(f.Name), ".ged") {
+			geds = append(geds, f)
+		}
+	}
+	switch {
+	case len(geds) == 1:
+		return geds[0].Open()
+	case len(geds) == 0 && len(files) == 1:
+		return files[0].Open()
+	case len(geds) > 1:
+		return nil, fmt.Errorf("zip input: archive holds %d .ged files, want one", len(geds))
+	}
+	return nil, fmt.Errorf("zip input: archive holds no .ged file")
+}
@@ -0,0 +1,88 @@
+package decoder
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+)
+
+const compressedInput = "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n0 @I1@ INDI\n1 NAME John /Doe/\n0 TRLR\n"
+
+func gzipData(t *testing.T, text string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(text)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zipData(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, text := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(text)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeCompressed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"plain", []byte(compressedInput)},
+		{"gzip", gzipData(t, compressedInput)},
+		{"zip", zipData(t, map[string]string{"tree/family.GED": compressedInput, "README.txt": "notes"})},
+		{"zip single file", zipData(t, map[string]string{"export": compressedInput})},
+		{"zip macOS", zipData(t, map[string]string{"family.ged": compressedInput, "__MACOSX/._family.ged": "fork"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Decode(bytes.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if doc.GetIndividual("@I1@") == nil {
+				t.Error("Decode() did not decode @I1@")
+			}
+		})
+	}
+}
+
+func TestDecodeCompressedErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"corrupt gzip", []byte{0x1f, 0x8b, 0x00}, "gzip input"},
+		{"corrupt zip", []byte("PK\x03\x04garbage"), "zip input"},
+		{"zip without ged", zipData(t, map[string]string{"a.txt": "a", "b.txt": "b"}), "no .ged file"},
+		{"zip with two geds", zipData(t, map[string]string{"a.ged": compressedInput, "b.ged": compressedInput}), "2 .ged files"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(bytes.NewReader(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Decode() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
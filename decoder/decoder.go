@@ -22,25 +22,34 @@
 package decoder
 
 import (
+	"errors"
+	"fmt"
 	"io"
 
 	"github.com/cacack/gedcom-go/charset"
 	"github.com/cacack/gedcom-go/gedcom"
 	"github.com/cacack/gedcom-go/parser"
+	"github.com/cacack/gedcom-go/tags"
 	"github.com/cacack/gedcom-go/version"
 )
 
 // Decode parses a GEDCOM file from an io.Reader and returns a Document.
 // This is a convenience function that uses default options.
+//
+// Compressed input is detected by its leading bytes and decompressed
+// transparently: a gzip stream, such as a .ged.gz file, or a zip archive
+// holding one .ged file.
 func Decode(r io.Reader) (*gedcom.Document, error) {
 	return DecodeWithOptions(r, DefaultOptions())
 }
 
 // DecodeWithOptions parses a GEDCOM file with custom options.
-func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (*gedcom.Document, error) {
+func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (doc *gedcom.Document, err error) {
 	if opts == nil {
 		opts = DefaultOptions()
 	}
+	stats := newStatsRecorder(opts)
+	defer func() { stats.finish(doc) }()
 
 	// Check context cancellation before starting
 	if opts.Context != nil {
@@ -51,15 +60,68 @@ func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (*gedcom.Document, erro
 		}
 	}
 
-	// Wrap reader with UTF-8 validation
-	validatedReader := charset.NewReader(r)
+	// Decompress gzip and zip input
+	r, err = decompress(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count input bytes for progress reports
+	var progress *progressTracker
+	if opts.Progress != nil || stats != nil {
+		input := &countingReader{r: r}
+		progress = newProgressTracker(opts, input)
+		if stats != nil {
+			stats.input = input
+		}
+		r = input
+	}
+
+	// Capture the original text for PreserveRaw
+	var raw *rawCapture
+	if opts.PreserveRaw {
+		raw = &rawCapture{}
+		r = raw.wrapInput(r)
+	}
+
+	// Convert the input to UTF-8, in the encoding its header declares unless
+	// one is forced, and validate it
+	var validatedReader io.Reader
+	if opts.ForceEncoding != charset.EncodingUnknown {
+		validatedReader = charset.NewReaderWithEncoding(r, opts.ForceEncoding)
+	} else {
+		validatedReader = charset.NewReader(r)
+	}
+	if raw != nil {
+		validatedReader = raw.wrapDecoded(validatedReader)
+	}
+
+	// Stop reading once the document would exceed MaxMemory
+	watchdog := newMemoryWatchdog(opts)
+	if watchdog != nil {
+		validatedReader = watchdog.wrap(validatedReader)
+	}
 
 	// Parse all lines
-	p := parser.NewParser()
-	p.SetMaxNestingDepth(opts.MaxNestingDepth)
+	p := newParser(opts)
+	var hooks []func(*parser.Line)
+	if opts.CompatMode && !opts.RepairLevelJumps {
+		hooks = append(hooks, (&compatRepairGate{p: p}).lineParsed)
+	}
+	if opts.InternStrings {
+		hooks = append(hooks, newStringInterner().lineParsed)
+	}
+	if progress != nil {
+		hooks = append(hooks, progress.lineParsed)
+	}
+	if watchdog != nil {
+		hooks = append(hooks, watchdog.lineParsed)
+	}
+	if len(hooks) > 0 {
+		p.SetLineHook(chainLineHooks(hooks))
+	}
 	var (
 		lines     []*parser.Line
-		err       error
 		parseErrs []error
 	)
 	if opts.RecoverErrors {
@@ -67,10 +129,19 @@ func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (*gedcom.Document, erro
 	} else {
 		lines, err = p.Parse(validatedReader)
 		if err != nil {
+			var limitErr *MemoryLimitError
+			if errors.As(err, &limitErr) {
+				return nil, limitErr
+			}
 			// Preserve charset errors in the error message
 			return nil, err
 		}
 	}
+	if watchdog != nil {
+		if err := watchdog.err(); err != nil {
+			return nil, err
+		}
+	}
 
 	// Check context after parsing
 	if opts.Context != nil {
@@ -81,6 +152,17 @@ func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (*gedcom.Document, erro
 		}
 	}
 
+	var (
+		warnings []gedcom.Warning
+		report   *gedcom.DecodeReport
+	)
+	lines, warnings, report, parseErrs, err = processLines(p, lines, parseErrs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.parsed(len(lines))
+
 	// Detect GEDCOM version
 	detectedVersion, err := version.DetectVersion(lines)
 	if err != nil {
@@ -88,13 +170,30 @@ func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (*gedcom.Document, erro
 	}
 
 	// Build document from lines
-	doc := buildDocument(lines, detectedVersion)
+	doc = buildDocument(lines, detectedVersion)
+	doc.Warnings = warnings
+	doc.DecodeReport = report
+	unknownErrs := applyUnknownRecordPolicy(doc, opts.UnknownRecords)
+	if raw != nil {
+		raw.attach(doc)
+	}
+	stats.built()
 
 	// Convert raw tags to proper entity types
-	populateEntities(doc)
+	switch {
+	case opts.LazyEntities:
+		deferEntities(doc)
+	case progress != nil:
+		progress.populateEntities(doc)
+	default:
+		populateEntities(doc)
+	}
+	stats.entitiesBuilt()
 
 	var decodeErrs []error
 	decodeErrs = append(decodeErrs, parseErrs...)
+	decodeErrs = append(decodeErrs, unknownErrs...)
+	decodeErrs = append(decodeErrs, applyExtensions(doc, opts.Extensions)...)
 	if opts.StrictMode {
 		decodeErrs = append(decodeErrs, validateStrictTags(lines)...)
 	}
@@ -104,6 +203,10 @@ func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (*gedcom.Document, erro
 	if opts.ValidateXRefs {
 		decodeErrs = append(decodeErrs, validateXRefs(doc)...)
 	}
+	stats.validated()
+	if progress != nil {
+		progress.done()
+	}
 	if len(decodeErrs) > 0 {
 		return doc, &DecodeErrors{Errors: decodeErrs}
 	}
@@ -111,6 +214,87 @@ func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (*gedcom.Document, erro
 	return doc, nil
 }
 
+// newParser returns a parser configured by opts.
+func newParser(opts *DecodeOptions) *parser.Parser {
+	p := parser.NewParser()
+	p.SetMaxNestingDepth(opts.MaxNestingDepth)
+	p.SetRepairLevelJumps(opts.RepairLevelJumps)
+	p.SetRepairXRefs(opts.RepairXRefs)
+	p.SetSkipBlankLines(opts.Tolerant)
+	p.SetStripBOM(opts.Tolerant)
+	p.SetTagRules(opts.TagRules)
+	return p
+}
+
+// processLines runs the stages between parsing and building the document:
+// warnings for the repairs p made, vendor workarounds, error recovery, and
+// text normalization. It returns the lines to build from, their warnings,
+// the recovery report, and the parse errors still to be reported. Warning
+// line numbers are those of lines, so callers that renumber lines afterwards
+// must renumber the warnings too.
+func processLines(p *parser.Parser, lines []*parser.Line, parseErrs []error, opts *DecodeOptions) (
+	[]*parser.Line, []gedcom.Warning, *gedcom.DecodeReport, []error, error,
+) {
+	warnings := toleratedLineWarnings(p.ToleratedLines())
+	warnings = append(warnings, tagRepairWarnings(p.TagRepairs())...)
+	for _, r := range p.XRefRepairs() {
+		warnings = append(warnings, gedcom.Warning{
+			Code:    WarnXRefRepaired,
+			Line:    r.Line,
+			Message: fmt.Sprintf("xref %q normalized to %q", r.Original, r.Repaired),
+		})
+	}
+
+	// Level jumps repaired for any product; otherwise any repairs are those
+	// CompatMode made in a RootsMagic file
+	levelRepairs := p.LevelRepairs()
+	if opts.RepairLevelJumps {
+		warnings = append(warnings, levelRepairWarnings(WarnLevelJumpRepaired, levelRepairs)...)
+		levelRepairs = nil
+	}
+
+	// Apply vendor-specific workarounds
+	if opts.CompatMode {
+		var compatWarnings []gedcom.Warning
+		lines, compatWarnings = applyCompatFixes(lines, sourceVendor(lines), levelRepairs)
+		warnings = append(warnings, compatWarnings...)
+	}
+
+	warnings = append(warnings, unknownTagWarnings(lines)...)
+
+	// Widen error recovery to the configured scope and record what was lost
+	var report *gedcom.DecodeReport
+	if opts.RecoverErrors {
+		if len(parseErrs) > 0 && opts.RecoveryScope == RecoveryScopeDocument {
+			return nil, nil, nil, nil, &DecodeErrors{Errors: parseErrs}
+		}
+		lines, report = applyRecovery(lines, parseErrs, opts.RecoveryScope)
+	}
+
+	if opts.SanitizeText {
+		warnings = append(warnings, sanitizeText(lines)...)
+	}
+	if opts.NormalizeNFC {
+		normalizeNFC(lines)
+	}
+	var voidWarnings []gedcom.Warning
+	lines, voidWarnings = applyVoidPolicy(lines, opts.VoidPointers)
+	warnings = append(warnings, voidWarnings...)
+	return lines, warnings, report, parseErrs, nil
+}
+
+// chainLineHooks returns a parser line hook that calls each of hooks in turn.
+func chainLineHooks(hooks []func(*parser.Line)) func(*parser.Line) {
+	if len(hooks) == 1 {
+		return hooks[0]
+	}
+	return func(line *parser.Line) {
+		for _, hook := range hooks {
+			hook(line)
+		}
+	}
+}
+
 // buildDocument constructs a Document from parsed lines.
 func buildDocument(lines []*parser.Line, ver gedcom.Version) *gedcom.Document {
 	doc := &gedcom.Document{
@@ -138,9 +322,12 @@ func buildDocument(lines []*parser.Line, ver gedcom.Version) *gedcom.Document {
 func buildHeader(doc *gedcom.Document, lines []*parser.Line, ver gedcom.Version) {
 	inHead := false
 	inSour := false
+	inPlac := false
+	inNote := false
+	inCopr := false
 
 	for _, line := range lines {
-		if line.Level == 0 && line.Tag == "HEAD" {
+		if line.Level == 0 && tags.Tag(line.Tag) == tags.HEAD {
 			inHead = true
 			continue
 		}
@@ -148,6 +335,9 @@ func buildHeader(doc *gedcom.Document, lines []*parser.Line, ver gedcom.Version)
 		if line.Level == 0 {
 			inHead = false
 			inSour = false
+			inPlac = false
+			inNote = false
+			inCopr = false
 		}
 
 		if !inHead {
@@ -155,25 +345,71 @@ func buildHeader(doc *gedcom.Document, lines []*parser.Line, ver gedcom.Version)
 		}
 
 		// Track when we're inside SOUR structure
-		if line.Level == 1 && line.Tag == "SOUR" {
+		if line.Level == 1 && tags.Tag(line.Tag) == tags.SOUR {
 			inSour = true
 			doc.Header.SourceSystem = line.Value
 			continue
 		}
 
-		// Exit SOUR when we see another level 1 tag
-		if line.Level == 1 && inSour {
+		// Exit SOUR and PLAC when we see another level 1 tag
+		if line.Level == 1 {
 			inSour = false
+			inPlac = tags.Tag(line.Tag) == tags.PLAC
+			inNote = tags.Tag(line.Tag) == tags.NOTE
+			inCopr = tags.Tag(line.Tag) == tags.COPR
 		}
 
 		// Extract header fields
-		switch line.Tag {
-		case "CHAR":
+		switch tags.Tag(line.Tag) {
+		case tags.CHAR:
 			doc.Header.Encoding = gedcom.Encoding(line.Value)
-		case "LANG":
-			doc.Header.Language = line.Value
-		case "COPR":
-			doc.Header.Copyright = line.Value
+		case tags.LANG:
+			if line.Level == 1 {
+				doc.Header.Language = line.Value
+			}
+		case tags.COPR:
+			// SOUR.DATA.COPR is the copyright of the source data, not the file
+			if line.Level == 1 {
+				doc.Header.Copyright = line.Value
+			}
+		case tags.NOTE:
+			if line.Level == 1 {
+				doc.Header.Note = line.Value
+			}
+		case tags.CONT:
+			if inNote && line.Level == 2 {
+				doc.Header.Note += "\n" + line.Value
+			}
+			if inCopr && line.Level == 2 {
+				doc.Header.Copyright += "\n" + line.Value
+			}
+		case tags.CONC:
+			if inNote && line.Level == 2 {
+				doc.Header.Note += line.Value
+			}
+			if inCopr && line.Level == 2 {
+				doc.Header.Copyright += line.Value
+			}
+		case tags.SUBM:
+			if line.Level == 1 {
+				doc.Header.Submitter = line.Value
+			}
+		case tags.VERS:
+			if inSour && line.Level == 2 {
+				doc.Header.SourceVersion = line.Value
+			}
+		case tags.NAME:
+			if inSour && line.Level == 2 {
+				doc.Header.SourceName = line.Value
+			}
+		case tags.CORP:
+			if inSour && line.Level == 2 {
+				doc.Header.SourceCorporation = line.Value
+			}
+		case tags.FORM:
+			if inPlac && line.Level == 2 {
+				doc.Header.PlaceForm = line.Value
+			}
 		case "_TREE":
 			// Ancestry.com tree identifier (subordinate of SOUR)
 			if inSour && line.Level == 2 {
@@ -207,7 +443,10 @@ func buildRecords(doc *gedcom.Document, lines []*parser.Line) {
 			}
 
 			// Skip HEAD and TRLR
-			if line.Tag == "HEAD" || line.Tag == "TRLR" {
+			if tags.Tag(line.Tag) == tags.HEAD || tags.Tag(line.Tag) == tags.TRLR {
+				if tags.Tag(line.Tag) == tags.TRLR && doc.Trailer != nil {
+					doc.Trailer.LineNumber = line.LineNumber
+				}
 				currentRecord = nil
 				continue
 			}
@@ -3,10 +3,12 @@ package decoder
 import (
 	"context"
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/cacack/gedcom-go/charset"
 	"github.com/cacack/gedcom-go/gedcom"
 	"github.com/cacack/gedcom-go/parser"
 )
@@ -425,6 +427,141 @@ func TestDecodeHeaderComplete(t *testing.T) {
 	}
 }
 
+func TestDecodeCodePageCharset(t *testing.T) {
+	// "Jos\xe9" is Jos\u00e9 in Windows-1252 and invalid UTF-8
+	ansi := "0 HEAD\n1 CHAR ANSI\n0 @I1@ INDI\n1 NAME Jos\xe9 /Garc\xeda/\n1 BIRT\n2 PLAC M\xe1laga\n0 TRLR\n"
+	doc, err := Decode(strings.NewReader(ansi))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	ind := doc.GetIndividual("@I1@")
+	if got, want := ind.Names[0].Full, "Jos\u00e9 /Garc\u00eda/"; got != want {
+		t.Errorf("Names[0].Full = %q, want %q", got, want)
+	}
+	if got, want := ind.Events[0].Place, "M\u00e1laga"; got != want {
+		t.Errorf("Events[0].Place = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeForceEncoding(t *testing.T) {
+	// Latin-1 text wrongly declared as UTF-8
+	input := "0 HEAD\n1 CHAR UTF-8\n0 @I1@ INDI\n1 NAME Ren\xe9 /M\xfcller/\n0 TRLR\n"
+	if _, err := Decode(strings.NewReader(input)); err == nil {
+		t.Fatal("Decode() of Latin-1 declared as UTF-8 succeeded, want invalid UTF-8 error")
+	}
+
+	opts := DefaultOptions()
+	opts.ForceEncoding = charset.EncodingLATIN1
+	doc, err := DecodeWithOptions(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	if got, want := doc.GetIndividual("@I1@").Names[0].Full, "Ren\u00e9 /M\u00fcller/"; got != want {
+		t.Errorf("Names[0].Full = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeHeaderSubmitter(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 SUBM @U1@
+0 @U1@ SUBM
+1 NAME Jane Researcher
+1 ADDR 1 Main St
+2 CITY Springfield
+1 PHON 555-0100
+1 LANG English
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if doc.Header.Submitter != "@U1@" {
+		t.Errorf("Header.Submitter = %q, want %q", doc.Header.Submitter, "@U1@")
+	}
+	subm := doc.HeaderSubmitter()
+	if subm == nil {
+		t.Fatal("HeaderSubmitter() = nil")
+	}
+	if subm.Name != "Jane Researcher" || subm.Address == nil || subm.Address.City != "Springfield" ||
+		len(subm.Phone) != 1 || len(subm.Language) != 1 || subm.Language[0] != "English" {
+		t.Errorf("HeaderSubmitter() = %+v", subm)
+	}
+}
+
+func TestDecodeHeaderNoteAndCopyright(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 COPR (C) 2024 Jane Researcher
+1 LANG en
+1 NOTE Exported for the family
+2 CONT reunion, includes liv
+2 CONC ing people.
+0 @N1@ NOTE Bonjour
+1 LANG fr
+0 @N2@ NOTE Hello
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if doc.Header.Copyright != "(C) 2024 Jane Researcher" {
+		t.Errorf("Header.Copyright = %q", doc.Header.Copyright)
+	}
+	if want := "Exported for the family\nreunion, includes living people."; doc.Header.Note != want {
+		t.Errorf("Header.Note = %q, want %q", doc.Header.Note, want)
+	}
+	if got := doc.DefaultLanguage(); got != "en" {
+		t.Errorf("DefaultLanguage() = %q, want en", got)
+	}
+	if got := doc.GetNote("@N1@").EffectiveLanguage(doc); got != "fr" {
+		t.Errorf("@N1@ EffectiveLanguage() = %q, want fr", got)
+	}
+	if got := doc.GetNote("@N2@").EffectiveLanguage(doc); got != "en" {
+		t.Errorf("@N2@ EffectiveLanguage() = %q, want en", got)
+	}
+}
+
+func TestDecodeHeaderPlaceForm(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+2 FORM LINEAGE-LINKED
+1 PLAC
+2 FORM City, County, State, Country
+0 @I1@ INDI
+1 BIRT
+2 PLAC Springfield, Sangamon, Illinois, USA
+1 DEAT
+2 PLAC Paris, France
+3 FORM City, Country
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if doc.Header.PlaceForm != "City, County, State, Country" {
+		t.Errorf("Header.PlaceForm = %q", doc.Header.PlaceForm)
+	}
+
+	indi := doc.GetIndividual("@I1@")
+	birth, death := indi.Events[0].PlaceDetail, indi.Events[1].PlaceDetail
+	if birth.Form != "" {
+		t.Errorf("birth Form = %q, want empty", birth.Form)
+	}
+	if got := birth.EffectiveForm(doc); got != "City, County, State, Country" {
+		t.Errorf("birth EffectiveForm() = %q, want header form", got)
+	}
+	if got := death.EffectiveForm(doc); got != "City, Country" {
+		t.Errorf("death EffectiveForm() = %q, want own form", got)
+	}
+}
+
 // Test context cancellation at different stages
 func TestDecodeContextCancellationStages(t *testing.T) {
 	t.Run("context cancelled after parsing", func(t *testing.T) {
@@ -638,3 +775,459 @@ func TestDecodeVendorDetection(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeHeaderSourceProduct(t *testing.T) {
+	input := `0 HEAD
+1 SOUR FTM
+2 VERS 24.0
+2 NAME Family Tree Maker for Windows
+2 CORP The Software MacKiev Company
+1 GEDC
+2 VERS 5.5.1
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	h := doc.Header
+	if h.SourceVersion != "24.0" || h.SourceName != "Family Tree Maker for Windows" ||
+		h.SourceCorporation != "The Software MacKiev Company" {
+		t.Errorf("Header source = %q/%q/%q", h.SourceVersion, h.SourceName, h.SourceCorporation)
+	}
+	if h.Version != gedcom.Version551 {
+		t.Errorf("Version = %q, want %q", h.Version, gedcom.Version551)
+	}
+	if sp := h.SourceProduct(); sp.Product != gedcom.ProductFamilyTreeMaker || sp.Version != "24.0" {
+		t.Errorf("SourceProduct() = %+v", sp)
+	}
+}
+
+func TestDecodeRepairXRefs(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @ I1 @ INDI
+1 NAME John /Smith/
+1 FAMS @F-1@
+0 @F-1@ FAM
+1 HUSB @ I1 @
+0 TRLR`
+
+	if _, err := Decode(strings.NewReader(input)); err == nil {
+		t.Fatal("Decode() without RepairXRefs should fail")
+	}
+
+	opts := DefaultOptions()
+	opts.RepairXRefs = true
+	opts.ValidateXRefs = true
+	doc, err := DecodeWithOptions(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+
+	fam := doc.GetFamily("@F_1@")
+	if fam == nil || fam.Husband != "@I1@" {
+		t.Fatalf("family @F_1@ = %+v", fam)
+	}
+	if ind := doc.GetIndividual("@I1@"); ind == nil || len(ind.SpouseInFamilies) != 1 || ind.SpouseInFamilies[0] != "@F_1@" {
+		t.Errorf("individual @I1@ = %+v", ind)
+	}
+
+	if len(doc.Warnings) != 4 {
+		t.Fatalf("Warnings = %v, want 4", doc.Warnings)
+	}
+	for _, w := range doc.Warnings {
+		if w.Code != WarnXRefRepaired {
+			t.Errorf("warning code = %q, want %q", w.Code, WarnXRefRepaired)
+		}
+	}
+}
+
+func TestDecodeTolerant(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n\n\uFEFF0 @I1@ INDI\r\n  1 NAME John /Smith/\n\n0 TRLR\n"
+
+	if _, err := Decode(strings.NewReader(input)); err == nil {
+		t.Fatal("Decode() without Tolerant should fail")
+	}
+
+	opts := DefaultOptions()
+	opts.Tolerant = true
+	doc, err := DecodeWithOptions(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	if ind := doc.GetIndividual("@I1@"); ind == nil || ind.Names[0].Full != "John /Smith/" {
+		t.Fatalf("individual @I1@ = %+v", ind)
+	}
+
+	want := []gedcom.Warning{
+		{Code: WarnBlankLineSkipped, Line: 4, Message: "blank line skipped"},
+		{Code: WarnBOMStripped, Line: 5, Message: "stray byte order mark removed"},
+		{Code: WarnBlankLineSkipped, Line: 7, Message: "blank line skipped"},
+	}
+	if !reflect.DeepEqual(doc.Warnings, want) {
+		t.Errorf("Warnings = %v, want %v", doc.Warnings, want)
+	}
+}
+
+func TestDecodeTagRulesPermissive(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n0 @I1@ INDI\n1 name John /Smith/\n1 Birt\n2 date 1 JAN 1900\n0 TRLR\n"
+
+	opts := DefaultOptions()
+	opts.TagRules = parser.TagRulesPermissive
+	doc, err := DecodeWithOptions(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	ind := doc.GetIndividual("@I1@")
+	if ind == nil || len(ind.Names) != 1 || len(ind.Events) != 1 || ind.Events[0].Date != "1 JAN 1900" {
+		t.Fatalf("individual @I1@ = %+v", ind)
+	}
+
+	want := []gedcom.Warning{
+		{Code: WarnTagNormalized, Line: 5, Message: "tag name read as NAME"},
+		{Code: WarnTagNormalized, Line: 6, Message: "tag Birt read as BIRT"},
+		{Code: WarnTagNormalized, Line: 7, Message: "tag date read as DATE"},
+	}
+	if !reflect.DeepEqual(doc.Warnings, want) {
+		t.Errorf("Warnings = %v, want %v", doc.Warnings, want)
+	}
+
+	opts.TagRules = parser.TagRulesStrict
+	if _, err := DecodeWithOptions(strings.NewReader(input), opts); err == nil {
+		t.Error("DecodeWithOptions() with TagRulesStrict should reject lowercase tags")
+	}
+}
+
+func TestDecodeRepairLevelJumps(t *testing.T) {
+	input := `0 HEAD
+1 SOUR SomeApp
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+3 DATE 1 JAN 1900
+4 TIME 12:00
+3 PLAC Boston
+0 TRLR`
+
+	if _, err := Decode(strings.NewReader(input)); err == nil {
+		t.Fatal("Decode() without RepairLevelJumps should fail")
+	}
+
+	for _, compat := range []bool{false, true} {
+		opts := DefaultOptions()
+		opts.RepairLevelJumps = true
+		opts.CompatMode = compat
+		doc, err := DecodeWithOptions(strings.NewReader(input), opts)
+		if err != nil {
+			t.Fatalf("CompatMode %v: DecodeWithOptions() error = %v", compat, err)
+		}
+
+		ind := doc.GetIndividual("@I1@")
+		if ind == nil || len(ind.Events) != 1 || ind.Events[0].Date != "1 JAN 1900" || ind.Events[0].Place != "Boston" {
+			t.Fatalf("CompatMode %v: individual @I1@ = %+v", compat, ind)
+		}
+
+		want := []gedcom.Warning{{Code: WarnLevelJumpRepaired, Line: 8, Message: "level jump from 1 to 3 repaired as level 2"}}
+		if !reflect.DeepEqual(doc.Warnings, want) {
+			t.Errorf("CompatMode %v: Warnings = %v, want %v", compat, doc.Warnings, want)
+		}
+	}
+}
+
+func TestDecodeRecoveryScope(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 1 JAN 1900
+BAD LINE
+0 @I2@ INDI
+1 NAME Jane /Doe/
+0 TRLR`
+
+	tests := []struct {
+		scope      RecoveryScope
+		wantI1     bool
+		wantDoc    bool
+		wantErrLen int
+	}{
+		{RecoveryScopeLine, true, true, 1},
+		{RecoveryScopeRecord, false, true, 1},
+		{RecoveryScopeDocument, false, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope.String(), func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.RecoverErrors = true
+			opts.RecoveryScope = tt.scope
+
+			doc, err := DecodeWithOptions(strings.NewReader(input), opts)
+			var decodeErrs *DecodeErrors
+			if !errors.As(err, &decodeErrs) || len(decodeErrs.Errors) != tt.wantErrLen {
+				t.Fatalf("error = %v, want %d decode errors", err, tt.wantErrLen)
+			}
+			if (doc != nil) != tt.wantDoc {
+				t.Fatalf("doc = %v, want document: %v", doc, tt.wantDoc)
+			}
+			if doc == nil {
+				return
+			}
+			if got := doc.GetIndividual("@I1@") != nil; got != tt.wantI1 {
+				t.Errorf("@I1@ present = %v, want %v", got, tt.wantI1)
+			}
+			if doc.GetIndividual("@I2@") == nil {
+				t.Error("@I2@ should be kept")
+			}
+			if doc.Header.Version != gedcom.Version551 {
+				t.Errorf("header version = %q", doc.Header.Version)
+			}
+
+			report := doc.DecodeReport
+			if !report.HasLoss() || len(report.SkippedLines) != 1 {
+				t.Fatalf("DecodeReport = %+v", report)
+			}
+			skipped := report.SkippedLines[0]
+			if skipped.Lines != (gedcom.LineRange{Start: 8, End: 8}) || skipped.RecordXRef != "@I1@" {
+				t.Errorf("SkippedLines[0] = %+v", skipped)
+			}
+			if tt.scope == RecoveryScopeRecord {
+				want := []gedcom.SkippedRecord{{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Lines: gedcom.LineRange{Start: 4, End: 8}}}
+				if len(report.SkippedRecords) != 1 || report.SkippedRecords[0] != want[0] {
+					t.Errorf("SkippedRecords = %+v, want %+v", report.SkippedRecords, want)
+				}
+				if report.LinesSkipped() != 5 {
+					t.Errorf("LinesSkipped() = %d, want 5", report.LinesSkipped())
+				}
+			} else if report.LinesSkipped() != 1 {
+				t.Errorf("LinesSkipped() = %d, want 1", report.LinesSkipped())
+			}
+		})
+	}
+}
+
+func TestDecodeReportMergesRanges(t *testing.T) {
+	input := `BAD
+0 HEAD
+0 @I1@ INDI
+1 NAME John /Smith/
+BAD ONE
+BAD TWO
+1 SEX M
+BAD THREE
+0 TRLR`
+
+	opts := DefaultOptions()
+	opts.RecoverErrors = true
+	doc, _ := DecodeWithOptions(strings.NewReader(input), opts)
+	if doc == nil {
+		t.Fatal("expected document")
+	}
+
+	got := doc.DecodeReport.SkippedLines
+	want := []gedcom.LineRange{{Start: 1, End: 1}, {Start: 5, End: 6}, {Start: 8, End: 8}}
+	if len(got) != len(want) {
+		t.Fatalf("SkippedLines = %+v", got)
+	}
+	for i := range want {
+		if got[i].Lines != want[i] {
+			t.Errorf("SkippedLines[%d] = %v, want %v", i, got[i].Lines, want[i])
+		}
+	}
+	if got[0].RecordXRef != "" || got[1].RecordXRef != "@I1@" {
+		t.Errorf("record xrefs = %q, %q", got[0].RecordXRef, got[1].RecordXRef)
+	}
+	if xrefs := doc.DecodeReport.SkippedXRefs(); len(xrefs) != 1 || xrefs[0] != "@I1@" {
+		t.Errorf("SkippedXRefs() = %v", xrefs)
+	}
+
+	clean, err := DecodeWithOptions(strings.NewReader("0 HEAD\n0 TRLR"), opts)
+	if err != nil || clean.DecodeReport == nil || clean.DecodeReport.HasLoss() {
+		t.Errorf("clean decode report = %+v, err = %v", clean.DecodeReport, err)
+	}
+	if doc, _ := Decode(strings.NewReader("0 HEAD\n0 TRLR")); doc.DecodeReport != nil {
+		t.Error("DecodeReport should be nil without RecoverErrors")
+	}
+}
+
+func TestRecoveryScopeString(t *testing.T) {
+	if got := RecoveryScope(99).String(); got != "RecoveryScope(99)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestDecodeUnknownRecords(t *testing.T) {
+	input := `0 HEAD
+1 SOUR RootsMagic
+0 @I1@ INDI
+1 NAME John /Smith/
+0 @P1@ _PLC Boston
+1 _GOV ABC123
+2 DATE 1900
+0 _EVDEF
+1 NAME Graduation
+0 TRLR
+`
+
+	t.Run("preserve", func(t *testing.T) {
+		doc, err := Decode(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if len(doc.Records) != 3 {
+			t.Fatalf("len(Records) = %d, want 3", len(doc.Records))
+		}
+		plc := doc.GetRecord("@P1@")
+		if plc == nil || plc.Type != "_PLC" || plc.Value != "Boston" || len(plc.Tags) != 2 || plc.Entity != nil {
+			t.Errorf("_PLC record = %+v, want generic record with full subtree", plc)
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		opts := DefaultOptions()
+		opts.UnknownRecords = UnknownRecordSkip
+		doc, err := DecodeWithOptions(strings.NewReader(input), opts)
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if len(doc.Records) != 1 || doc.GetRecord("@P1@") != nil {
+			t.Errorf("Records = %d, want only the individual", len(doc.Records))
+		}
+		want := []gedcom.SkippedRecord{
+			{XRef: "@P1@", Type: "_PLC", Lines: gedcom.LineRange{Start: 5, End: 7}},
+			{Type: "_EVDEF", Lines: gedcom.LineRange{Start: 8, End: 9}},
+		}
+		if !reflect.DeepEqual(doc.DecodeReport.SkippedRecords, want) {
+			t.Errorf("SkippedRecords = %+v, want %+v", doc.DecodeReport.SkippedRecords, want)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		opts := DefaultOptions()
+		opts.UnknownRecords = UnknownRecordError
+		doc, err := DecodeWithOptions(strings.NewReader(input), opts)
+		var decodeErrs *DecodeErrors
+		if !errors.As(err, &decodeErrs) || len(decodeErrs.Errors) != 2 {
+			t.Fatalf("Decode() error = %v, want 2 unknown record errors", err)
+		}
+		var unknown *UnknownRecordTypeError
+		if !errors.As(decodeErrs.Errors[0], &unknown) || unknown.Type != "_PLC" || unknown.Line != 5 || unknown.XRef != "@P1@" {
+			t.Errorf("first error = %v", decodeErrs.Errors[0])
+		}
+		if doc == nil || len(doc.Records) != 3 {
+			t.Error("document should keep unknown records")
+		}
+	})
+}
+
+func TestUnknownRecordPolicyString(t *testing.T) {
+	if got := UnknownRecordSkip.String(); got != "skip" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := UnknownRecordPolicy(99).String(); got != "UnknownRecordPolicy(99)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestDecodePreserveRaw(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n0 @I1@ INDI\n1 NAME John /Smith/\n" +
+		"0 @X1@ _CUSTOM\n1 _DATA x\n0 TRLR\n"
+
+	doc, err := DecodeWithOptions(strings.NewReader(input), &DecodeOptions{
+		PreserveRaw:    true,
+		UnknownRecords: UnknownRecordSkip,
+	})
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	if doc.Raw == nil {
+		t.Fatal("Raw = nil, want original lines")
+	}
+	if want := []string{"0 HEAD\n", "1 GEDC\n", "2 VERS 5.5.1\n"}; !reflect.DeepEqual(doc.Raw.Header, want) {
+		t.Errorf("Raw.Header = %q, want %q", doc.Raw.Header, want)
+	}
+	if want := []string{"0 TRLR\n"}; !reflect.DeepEqual(doc.Raw.Trailer, want) {
+		t.Errorf("Raw.Trailer = %q, want %q", doc.Raw.Trailer, want)
+	}
+	if want := []string{"0 @I1@ INDI\n", "1 NAME John /Smith/\n"}; !reflect.DeepEqual(doc.GetRecord("@I1@").Raw, want) {
+		t.Errorf("Record.Raw = %q, want %q", doc.GetRecord("@I1@").Raw, want)
+	}
+
+	plain, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if plain.Raw != nil || plain.Records[0].Raw != nil {
+		t.Error("Raw should be nil without PreserveRaw")
+	}
+}
+
+func TestDocumentRawRecord(t *testing.T) {
+	input := "0 HEAD\r\n1 GEDC\r\n2 VERS 5.5.1\r\n" +
+		"0 @I1@ INDI\r\n1  NAME John /Smith/\r\n1 FAMS @F1@\r\n" +
+		"0 @F1@ FAM\r\n1 HUSB @I1@\r\n0 TRLR\r\n"
+
+	doc, err := DecodeWithOptions(strings.NewReader(input), &DecodeOptions{PreserveRaw: true})
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	text, ok := doc.RawRecord("@I1@")
+	if want := "0 @I1@ INDI\r\n1  NAME John /Smith/\r\n1 FAMS @F1@\r\n"; !ok || text != want {
+		t.Errorf("RawRecord() = %q, %v, want original bytes %q", text, ok, want)
+	}
+
+	plain, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	text, ok = plain.RawRecord("@I1@")
+	if want := "0 @I1@ INDI\n1 NAME John /Smith/\n1 FAMS @F1@\n"; !ok || text != want {
+		t.Errorf("RawRecord() without PreserveRaw = %q, %v, want %q", text, ok, want)
+	}
+	if _, ok := plain.RawRecord("@X9@"); ok {
+		t.Error("RawRecord() of a missing record should return false")
+	}
+}
+
+func TestDecodeLazyEntities(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n" +
+		"0 @I1@ INDI\n1 NAME John /Smith/\n1 FAMS @F1@\n" +
+		"0 @I2@ INDI\n1 NAME Mary /Jones/\n1 FAMS @F1@\n" +
+		"0 @F1@ FAM\n1 HUSB @I1@\n1 WIFE @I2@\n" +
+		"0 TRLR\n"
+
+	doc, err := DecodeWithOptions(strings.NewReader(input), &DecodeOptions{LazyEntities: true})
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	for _, record := range doc.Records {
+		if record.Entity != nil {
+			t.Fatalf("%s entity built during decode", record.XRef)
+		}
+	}
+
+	john := doc.GetIndividual("@I1@")
+	if john == nil || john.Names[0].Full != "John /Smith/" {
+		t.Fatalf("GetIndividual(@I1@) = %+v", john)
+	}
+	if doc.GetIndividual("@I1@") != john || doc.GetRecord("@I1@").Entity != john {
+		t.Error("entity should be memoized on the record")
+	}
+	if doc.GetRecord("@I2@").Entity != nil {
+		t.Error("@I2@ should not be built by looking up @I1@")
+	}
+
+	if n := doc.LoadEntities(); n != 2 {
+		t.Errorf("LoadEntities() = %d, want 2 pending entities", n)
+	}
+	if fam := doc.GetFamily("@F1@"); fam == nil || fam.Wife != "@I2@" {
+		t.Errorf("GetFamily(@F1@) = %+v", fam)
+	}
+}
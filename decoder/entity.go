@@ -10,23 +10,63 @@ import (
 // populateEntities converts raw tags in each record into proper entities.
 func populateEntities(doc *gedcom.Document) {
 	for _, record := range doc.Records {
-		switch record.Type {
-		case gedcom.RecordTypeIndividual:
-			record.Entity = parseIndividual(record)
-		case gedcom.RecordTypeFamily:
-			record.Entity = parseFamily(record)
-		case gedcom.RecordTypeSource:
-			record.Entity = parseSource(record)
-		case gedcom.RecordTypeSubmitter:
-			record.Entity = parseSubmitter(record)
-		case gedcom.RecordTypeRepository:
-			record.Entity = parseRepository(record)
-		case gedcom.RecordTypeNote:
-			record.Entity = parseNote(record)
-		case gedcom.RecordTypeMedia:
-			record.Entity = parseMediaObject(record)
+		populateEntity(record)
+	}
+}
+
+// deferEntities arranges for each record's entity to be built on first
+// access instead of now (see DecodeOptions.LazyEntities).
+func deferEntities(doc *gedcom.Document) {
+	for _, record := range doc.Records {
+		record.SetEntityBuilder(populateEntity)
+	}
+}
+
+// populateEntity converts a record's raw tags into its entity.
+func populateEntity(record *gedcom.Record) {
+	switch record.Type {
+	case gedcom.RecordTypeIndividual:
+		record.Entity = parseIndividual(record)
+	case gedcom.RecordTypeFamily:
+		record.Entity = parseFamily(record)
+	case gedcom.RecordTypeSource:
+		record.Entity = parseSource(record)
+	case gedcom.RecordTypeSubmitter:
+		record.Entity = parseSubmitter(record)
+	case gedcom.RecordTypeRepository:
+		record.Entity = parseRepository(record)
+	case gedcom.RecordTypeNote, gedcom.RecordTypeSharedNote:
+		record.Entity = parseNote(record)
+	case gedcom.RecordTypeMedia:
+		record.Entity = parseMediaObject(record)
+	}
+}
+
+// RefreshEntity rebuilds a record's Entity from its raw Tags, discarding any
+// direct edits to the entity, and clears the record's modified state. Use it
+// after editing tags with Record.SetTag, AddTag, or RemoveTag.
+func RefreshEntity(record *gedcom.Record) {
+	if record == nil {
+		return
+	}
+	populateEntity(record)
+	record.ClearModified()
+}
+
+// RefreshEntities rebuilds the Entity of every record whose tags were modified
+// and returns the number of records refreshed.
+func RefreshEntities(doc *gedcom.Document) int {
+	if doc == nil {
+		return 0
+	}
+	n := 0
+	for _, record := range doc.Records {
+		if record.TagsModified() {
+			RefreshEntity(record)
+			n++
 		}
 	}
+	return n
 }
 
 // parseIndividual converts record tags to an Individual entity.
@@ -54,7 +94,7 @@ func parseIndividual(record *gedcom.Record) *gedcom.Individual {
 
 		case "BIRT", "DEAT", "BAPM", "BURI", "CENS", "CHR", "ADOP", "RESI", "IMMI", "EMIG",
 			"BARM", "BASM", "BLES", "CHRA", "CONF", "FCOM",
-			"GRAD", "RETI", "NATU", "ORDN", "PROB", "WILL", "CREM":
+			"GRAD", "RETI", "NATU", "ORDN", "PROB", "WILL", "CREM", "EVEN":
 			event := parseEvent(record.Tags, i, tag.Tag)
 			indi.Events = append(indi.Events, event)
 
@@ -62,7 +102,7 @@ func parseIndividual(record *gedcom.Record) *gedcom.Individual {
 			ord := parseLDSOrdinance(record.Tags, i, ldsOrdinanceType(tag.Tag))
 			indi.LDSOrdinances = append(indi.LDSOrdinances, ord)
 
-		case "OCCU", "CAST", "DSCR", "EDUC", "IDNO", "NATI", "SSN", "TITL", "RELI", "NCHI", "NMR", "PROP":
+		case "OCCU", "CAST", "DSCR", "EDUC", "IDNO", "NATI", "SSN", "TITL", "RELI", "NCHI", "NMR", "PROP", "FACT":
 			attr := parseAttribute(record.Tags, i, tag.Tag)
 			indi.Attributes = append(indi.Attributes, attr)
 
@@ -81,7 +121,7 @@ func parseIndividual(record *gedcom.Record) *gedcom.Individual {
 			cite := parseSourceCitation(record.Tags, i, tag.Level)
 			indi.SourceCitations = append(indi.SourceCitations, cite)
 
-		case "NOTE":
+		case "NOTE", "SNOTE":
 			indi.Notes = append(indi.Notes, tag.Value)
 
 		case "OBJE":
@@ -94,14 +134,45 @@ func parseIndividual(record *gedcom.Record) *gedcom.Individual {
 		case "CREA":
 			indi.CreationDate = parseChangeDate(record.Tags, i)
 
-		case "REFN":
-			indi.RefNumber = tag.Value
+		case "REFN", "EXID":
+			id := parseIdentifier(record.Tags, i, tag.Level)
+			if id.Kind == gedcom.IdentifierREFN && indi.RefNumber == "" {
+				indi.RefNumber = id.Value
+			}
+			indi.Identifiers = append(indi.Identifiers, id)
 
 		case "UID":
-			indi.UID = tag.Value
+			if indi.UID == "" {
+				indi.UID = tag.Value
+			}
+			indi.UIDs = append(indi.UIDs, tag.Value)
+
+		case "_UID":
+			indi.UIDs = append(indi.UIDs, tag.Value)
 
 		case "_FSFTID":
 			indi.FamilySearchID = tag.Value
+
+		case "LANG":
+			indi.Language = tag.Value
+
+		case "ALIA":
+			indi.Aliases = append(indi.Aliases, tag.Value)
+
+		case "ANCI":
+			indi.AncestorInterest = append(indi.AncestorInterest, tag.Value)
+
+		case "DESI":
+			indi.DescendantInterest = append(indi.DescendantInterest, tag.Value)
+
+		case "RESN":
+			indi.Restriction = tag.Value
+
+		case "RIN":
+			indi.AutomatedRecordID = tag.Value
+
+		case "AFN":
+			indi.AncestralFileNumber = tag.Value
 		}
 	}
 
@@ -211,8 +282,14 @@ func parseFamilyLink(tags []*gedcom.Tag, famcIdx int) gedcom.FamilyLink {
 		if tag.Level <= 1 {
 			break
 		}
-		if tag.Level == 2 && tag.Tag == "PEDI" {
+		if tag.Level != 2 {
+			continue
+		}
+		switch tag.Tag {
+		case "PEDI":
 			famLink.Pedigree = tag.Value
+		case "STAT":
+			famLink.Status = tag.Value
 		}
 	}
 
@@ -226,6 +303,7 @@ func parseAssociation(tags []*gedcom.Tag, assoIdx int) *gedcom.Association {
 	assoc := &gedcom.Association{
 		IndividualXRef: tags[assoIdx].Value,
 	}
+	dnaType := false
 
 	// Look for subordinate tags at baseLevel+1
 	for i := assoIdx + 1; i < len(tags); i++ {
@@ -239,11 +317,26 @@ func parseAssociation(tags []*gedcom.Tag, assoIdx int) *gedcom.Association {
 				assoc.Role = tag.Value
 			case "PHRASE":
 				assoc.Phrase = tag.Value
-			case "NOTE":
+			case "NOTE", "SNOTE":
 				assoc.Notes = append(assoc.Notes, tag.Value)
+			case "TYPE":
+				dnaType = mentionsDNA(tag.Value)
 			case "SOUR":
 				cite := parseSourceCitation(tags, i, tag.Level)
 				assoc.SourceCitations = append(assoc.SourceCitations, cite)
+			case "_DNA":
+				assoc.DNAMatch = parseDNAMatch(tags, i)
+			}
+		}
+	}
+
+	// A note mentioning shared cM is read as DNA match data only when the
+	// association is marked as a DNA match and has no _DNA structure, so
+	// notes such as "height 180 cm" are left alone
+	if assoc.DNAMatch == nil && (dnaType || mentionsDNA(assoc.Role) || mentionsDNA(assoc.Phrase)) {
+		for _, note := range assoc.Notes {
+			if assoc.DNAMatch = gedcom.ParseDNAMatch(note); assoc.DNAMatch != nil {
+				break
 			}
 		}
 	}
@@ -251,6 +344,53 @@ func parseAssociation(tags []*gedcom.Tag, assoIdx int) *gedcom.Association {
 	return assoc
 }
 
+// mentionsDNA reports whether an association role or type names DNA, such
+// as "DNA Match".
+func mentionsDNA(s string) bool {
+	return strings.Contains(strings.ToUpper(s), "DNA")
+}
+
+// parseDNAMatch extracts DNA match data from a _DNA tag starting at dnaIdx.
+// Explicit subordinates override values parsed from the free-text value.
+func parseDNAMatch(tags []*gedcom.Tag, dnaIdx int) *gedcom.DNAMatch {
+	baseLevel := tags[dnaIdx].Level
+
+	match := gedcom.ParseDNAMatch(tags[dnaIdx].Value)
+	if match == nil {
+		match = &gedcom.DNAMatch{Text: tags[dnaIdx].Value}
+	}
+
+	// Look for subordinate tags at baseLevel+1
+	for i := dnaIdx + 1; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Level <= baseLevel {
+			break
+		}
+		if tag.Level == baseLevel+1 {
+			switch tag.Tag {
+			case "_CM":
+				if v, err := strconv.ParseFloat(strings.TrimSpace(tag.Value), 64); err == nil {
+					match.SharedCM = v
+				}
+			case "_SEG":
+				if v, err := strconv.Atoi(strings.TrimSpace(tag.Value)); err == nil {
+					match.SharedSegments = v
+				}
+			case "_LSEG":
+				if v, err := strconv.ParseFloat(strings.TrimSpace(tag.Value), 64); err == nil {
+					match.LongestSegmentCM = v
+				}
+			case "_REL":
+				match.Relationship = tag.Value
+			case "TYPE":
+				match.Provider = tag.Value
+			}
+		}
+	}
+
+	return match
+}
+
 // parseSourceCitation extracts a source citation from tags starting at sourIdx.
 func parseSourceCitation(tags []*gedcom.Tag, sourIdx, baseLevel int) *gedcom.SourceCitation {
 	cite := &gedcom.SourceCitation{
@@ -300,7 +440,11 @@ func parseSourceCitationData(tags []*gedcom.Tag, dataIdx, baseLevel int) *gedcom
 			case "DATE":
 				data.Date = tag.Value
 			case "TEXT":
-				data.Text = tag.Value
+				// TEXT can repeat in GEDCOM 5.5.1; keep every excerpt
+				if data.Text != "" {
+					data.Text += "\n"
+				}
+				data.Text += continuedText(tags, i)
 			}
 		}
 	}
@@ -308,12 +452,37 @@ func parseSourceCitationData(tags []*gedcom.Tag, dataIdx, baseLevel int) *gedcom
 	return data
 }
 
+// continuedText returns the value of the tag at idx joined with its CONT
+// (newline) and CONC (no separator) continuation lines.
+func continuedText(tags []*gedcom.Tag, idx int) string {
+	var b strings.Builder
+	b.WriteString(tags[idx].Value)
+	for i := idx + 1; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Level <= tags[idx].Level {
+			break
+		}
+		if tag.Level != tags[idx].Level+1 {
+			continue
+		}
+		switch tag.Tag {
+		case "CONT":
+			b.WriteByte('\n')
+			b.WriteString(tag.Value)
+		case "CONC":
+			b.WriteString(tag.Value)
+		}
+	}
+	return b.String()
+}
+
 // parseEvent extracts an event from tags starting at eventIdx.
 //
 //nolint:gocyclo // GEDCOM parsing inherently requires handling many tag types
 func parseEvent(tags []*gedcom.Tag, eventIdx int, eventTag string) *gedcom.Event {
 	event := &gedcom.Event{
-		Type: gedcom.EventType(eventTag),
+		Type:        gedcom.EventType(eventTag),
+		Description: tags[eventIdx].Value,
 	}
 
 	baseLevel := tags[eventIdx].Level
@@ -353,10 +522,24 @@ func parseEvent(tags []*gedcom.Tag, eventIdx int, eventTag string) *gedcom.Event
 				event.Cause = tag.Value
 			case "AGE":
 				event.Age = tag.Value
+				event.ParsedAge = parseEventAge(tags, i, tag.Level)
+			case "HUSB":
+				event.HusbandAge = parseSpouseAge(tags, i, tag.Level)
+			case "WIFE":
+				event.WifeAge = parseSpouseAge(tags, i, tag.Level)
+			case "FAMC":
+				event.FamilyXRef = tag.Value
+				event.AdoptedBy = parseAdoptedBy(tags, i, tag.Level)
 			case "AGNC":
 				event.Agency = tag.Value
+			case "RELI":
+				event.Religion = tag.Value
+			case "CEME", "_CEME", "_CEMETERY":
+				event.Cemetery = tag.Value
+			case "PLOT", "_PLOT":
+				event.Plot = tag.Value
 			case "ADDR":
-				event.Address = parseEventAddress(tags, i, tag.Level)
+				event.Address = parseAddress(tags, i, tag.Level)
 			case "PHON":
 				event.Phone = append(event.Phone, tag.Value)
 			case "EMAIL":
@@ -371,11 +554,17 @@ func parseEvent(tags []*gedcom.Tag, eventIdx int, eventTag string) *gedcom.Event
 				event.UID = tag.Value
 			case "SDATE":
 				event.SortDate = tag.Value
-			case "NOTE":
+			case "NOTE", "SNOTE":
 				event.Notes = append(event.Notes, tag.Value)
 			case "SOUR":
 				cite := parseSourceCitation(tags, i, tag.Level)
 				event.SourceCitations = append(event.SourceCitations, cite)
+			case "ASSO":
+				event.Associations = append(event.Associations, parseAssociation(tags, i))
+			default:
+				if role, ok := participantTagRoles[tag.Tag]; ok {
+					parseEventParticipant(event, tags, i, role)
+				}
 			case "OBJE":
 				link := parseMediaLink(tags, i, tag.Level)
 				event.Media = append(event.Media, link)
@@ -386,9 +575,94 @@ func parseEvent(tags []*gedcom.Tag, eventIdx int, eventTag string) *gedcom.Event
 	return event
 }
 
-// parseEventAddress extracts an address structure from tags starting at addrIdx.
-func parseEventAddress(tags []*gedcom.Tag, addrIdx, baseLevel int) *gedcom.Address {
+// participantTagRoles maps vendor event participant tags to GEDCOM 7.0 roles.
+var participantTagRoles = map[string]string{
+	"_WITN":       gedcom.RoleWitness,
+	"_WITNESS":    gedcom.RoleWitness,
+	"_OFFICIATOR": gedcom.RoleOfficiator,
+	"_OFFICIANT":  gedcom.RoleOfficiator,
+	"_OFFI":       gedcom.RoleOfficiator,
+	"_CLERGY":     gedcom.RoleClergy,
+	"_GODP":       gedcom.RoleGodparent,
+	"_GODPARENT":  gedcom.RoleGodparent,
+}
+
+// parseEventParticipant converts a vendor participant tag at idx into an
+// association when its value is a pointer, or a witness name otherwise.
+func parseEventParticipant(event *gedcom.Event, tags []*gedcom.Tag, idx int, role string) {
+	value := strings.TrimSpace(tags[idx].Value)
+	if value == "" {
+		return
+	}
+	if len(value) > 2 && strings.HasPrefix(value, "@") && strings.HasSuffix(value, "@") {
+		assoc := parseAssociation(tags, idx)
+		if assoc.Role == "" {
+			assoc.Role = role
+		}
+		event.Associations = append(event.Associations, assoc)
+		return
+	}
+	event.Witnesses = append(event.Witnesses, value)
+}
+
+// parseEventAge parses the AGE value at ageIdx with its PHRASE subordinate.
+// It returns nil for an unparseable age without a phrase.
+func parseEventAge(tags []*gedcom.Tag, ageIdx, baseLevel int) *gedcom.Age {
+	age, err := gedcom.ParseAge(tags[ageIdx].Value)
+	for i := ageIdx + 1; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Level <= baseLevel {
+			break
+		}
+		if tag.Level == baseLevel+1 && tag.Tag == "PHRASE" {
+			if err != nil {
+				age = &gedcom.Age{Original: tags[ageIdx].Value}
+			}
+			age.Phrase = tag.Value
+			return age
+		}
+	}
+	if err != nil {
+		return nil
+	}
+	return age
+}
+
+// parseSpouseAge returns the AGE value under a family event's HUSB or WIFE
+// substructure starting at spouseIdx.
+func parseSpouseAge(tags []*gedcom.Tag, spouseIdx, baseLevel int) string {
+	for i := spouseIdx + 1; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Level <= baseLevel {
+			break
+		}
+		if tag.Level == baseLevel+1 && tag.Tag == "AGE" {
+			return tag.Value
+		}
+	}
+	return ""
+}
+
+// parseAdoptedBy returns the ADOP value under an event's FAMC pointer
+// starting at famcIdx.
+func parseAdoptedBy(tags []*gedcom.Tag, famcIdx, baseLevel int) string {
+	for i := famcIdx + 1; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Level <= baseLevel {
+			break
+		}
+		if tag.Level == baseLevel+1 && tag.Tag == "ADOP" {
+			return tag.Value
+		}
+	}
+	return ""
+}
+
+// parseAddress extracts an address structure from tags starting at addrIdx.
+// Used for event, submitter, and repository addresses.
+func parseAddress(tags []*gedcom.Tag, addrIdx, baseLevel int) *gedcom.Address {
 	addr := &gedcom.Address{
+		Text:  tags[addrIdx].Value,
 		Line1: tags[addrIdx].Value,
 	}
 
@@ -416,6 +690,7 @@ func parseEventAddress(tags []*gedcom.Tag, addrIdx, baseLevel int) *gedcom.Addre
 				addr.Country = tag.Value
 			case "CONT":
 				// Continue address on next line
+				addr.Text += "\n" + tag.Value
 				if addr.Line1 != "" {
 					addr.Line1 += "\n" + tag.Value
 				} else {
@@ -423,6 +698,7 @@ func parseEventAddress(tags []*gedcom.Tag, addrIdx, baseLevel int) *gedcom.Addre
 				}
 			case "CONC":
 				// Concatenate to address
+				addr.Text += tag.Value
 				addr.Line1 += tag.Value
 			}
 		}
@@ -501,6 +777,13 @@ func parseAttribute(tags []*gedcom.Tag, attrIdx int, attrTag string) *gedcom.Att
 				}
 			case "PLAC":
 				attr.Place = tag.Value
+			case "TYPE":
+				attr.TypeDetail = tag.Value
+			case "CONT":
+				// Multi-line values such as DSCR physical descriptions
+				attr.Value += "\n" + tag.Value
+			case "CONC":
+				attr.Value += tag.Value
 			case "SOUR":
 				cite := parseSourceCitation(tags, i, tag.Level)
 				attr.SourceCitations = append(attr.SourceCitations, cite)
@@ -593,7 +876,7 @@ func parseFamily(record *gedcom.Record) *gedcom.Family {
 		case "NCHI":
 			fam.NumberOfChildren = tag.Value
 
-		case "MARR", "DIV", "ENGA", "ANUL", "MARB", "MARC", "MARL", "MARS", "DIVF":
+		case "MARR", "DIV", "ENGA", "ANUL", "MARB", "MARC", "MARL", "MARS", "DIVF", "EVEN":
 			event := parseEvent(record.Tags, i, tag.Tag)
 			fam.Events = append(fam.Events, event)
 
@@ -605,7 +888,7 @@ func parseFamily(record *gedcom.Record) *gedcom.Family {
 			cite := parseSourceCitation(record.Tags, i, tag.Level)
 			fam.SourceCitations = append(fam.SourceCitations, cite)
 
-		case "NOTE":
+		case "NOTE", "SNOTE":
 			fam.Notes = append(fam.Notes, tag.Value)
 
 		case "OBJE":
@@ -618,11 +901,21 @@ func parseFamily(record *gedcom.Record) *gedcom.Family {
 		case "CREA":
 			fam.CreationDate = parseChangeDate(record.Tags, i)
 
-		case "REFN":
-			fam.RefNumber = tag.Value
+		case "REFN", "EXID":
+			id := parseIdentifier(record.Tags, i, tag.Level)
+			if id.Kind == gedcom.IdentifierREFN && fam.RefNumber == "" {
+				fam.RefNumber = id.Value
+			}
+			fam.Identifiers = append(fam.Identifiers, id)
 
 		case "UID":
-			fam.UID = tag.Value
+			if fam.UID == "" {
+				fam.UID = tag.Value
+			}
+			fam.UIDs = append(fam.UIDs, tag.Value)
+
+		case "_UID":
+			fam.UIDs = append(fam.UIDs, tag.Value)
 		}
 	}
 
@@ -650,15 +943,21 @@ func parseSource(record *gedcom.Record) *gedcom.Source {
 		case "PUBL":
 			src.Publication = tag.Value
 		case "TEXT":
-			src.Text = tag.Value
+			src.Text = continuedText(record.Tags, i)
 		case "REPO":
-			if tag.Value != "" {
-				src.RepositoryRef = tag.Value
-			} else {
-				// Look for inline repository with NAME subordinate
-				src.Repository = parseInlineRepository(record.Tags, i)
+			citation := parseRepositoryCitation(record.Tags, i)
+			if len(src.Repositories) == 0 {
+				if tag.Value != "" {
+					src.RepositoryRef = tag.Value
+				} else {
+					// Look for inline repository with NAME subordinate
+					src.Repository = parseInlineRepository(record.Tags, i)
+				}
 			}
-		case "NOTE":
+			src.Repositories = append(src.Repositories, citation)
+		case "DATA":
+			src.Data = parseSourceData(record.Tags, i)
+		case "NOTE", "SNOTE":
 			src.Notes = append(src.Notes, tag.Value)
 		case "OBJE":
 			link := parseMediaLink(record.Tags, i, tag.Level)
@@ -667,8 +966,12 @@ func parseSource(record *gedcom.Record) *gedcom.Source {
 			src.ChangeDate = parseChangeDate(record.Tags, i)
 		case "CREA":
 			src.CreationDate = parseChangeDate(record.Tags, i)
-		case "REFN":
-			src.RefNumber = tag.Value
+		case "REFN", "EXID":
+			id := parseIdentifier(record.Tags, i, tag.Level)
+			if id.Kind == gedcom.IdentifierREFN && src.RefNumber == "" {
+				src.RefNumber = id.Value
+			}
+			src.Identifiers = append(src.Identifiers, id)
 		case "UID":
 			src.UID = tag.Value
 		}
@@ -677,6 +980,49 @@ func parseSource(record *gedcom.Record) *gedcom.Source {
 	return src
 }
 
+// parseSourceData extracts a source's DATA structure from tags starting at
+// dataIdx: the recorded events with their periods and places, the agency,
+// and notes.
+func parseSourceData(tags []*gedcom.Tag, dataIdx int) *gedcom.SourceData {
+	data := &gedcom.SourceData{}
+
+	baseLevel := tags[dataIdx].Level
+	var event *gedcom.SourceDataEvent
+	for i := dataIdx + 1; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Level <= baseLevel {
+			break
+		}
+		if tag.Level == baseLevel+2 && event != nil {
+			switch tag.Tag {
+			case "DATE":
+				event.Date = tag.Value
+				if parsed, err := gedcom.ParseDate(tag.Value); err == nil {
+					event.ParsedDate = parsed
+				}
+			case "PLAC":
+				event.Place = tag.Value
+			}
+			continue
+		}
+		if tag.Level != baseLevel+1 {
+			continue
+		}
+		event = nil
+		switch tag.Tag {
+		case "EVEN":
+			event = &gedcom.SourceDataEvent{Types: tag.Value}
+			data.Events = append(data.Events, event)
+		case "AGNC":
+			data.Agency = tag.Value
+		case "NOTE", "SNOTE":
+			data.Notes = append(data.Notes, tag.Value)
+		}
+	}
+
+	return data
+}
+
 // parseInlineRepository extracts an inline repository from tags starting at repoIdx.
 // An inline repository has no XRef value and contains subordinate tags like NAME.
 func parseInlineRepository(tags []*gedcom.Tag, repoIdx int) *gedcom.InlineRepository {
@@ -699,6 +1045,40 @@ func parseInlineRepository(tags []*gedcom.Tag, repoIdx int) *gedcom.InlineReposi
 	return repo
 }
 
+// parseRepositoryCitation extracts a repository citation (SOUR.REPO) from tags
+// starting at repoIdx, including its call numbers and their media.
+func parseRepositoryCitation(tags []*gedcom.Tag, repoIdx int) *gedcom.RepositoryCitation {
+	citation := &gedcom.RepositoryCitation{RepositoryXRef: tags[repoIdx].Value}
+
+	baseLevel := tags[repoIdx].Level
+	var caln *gedcom.CallNumber
+	for i := repoIdx + 1; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Level <= baseLevel {
+			break
+		}
+		if tag.Level == baseLevel+2 && tag.Tag == "MEDI" && caln != nil {
+			caln.Medium = tag.Value
+			continue
+		}
+		if tag.Level != baseLevel+1 {
+			continue
+		}
+		caln = nil
+		switch tag.Tag {
+		case "NAME":
+			citation.Name = tag.Value
+		case "CALN":
+			caln = &gedcom.CallNumber{Number: tag.Value}
+			citation.CallNumbers = append(citation.CallNumbers, caln)
+		case "NOTE", "SNOTE":
+			citation.Notes = append(citation.Notes, tag.Value)
+		}
+	}
+
+	return citation
+}
+
 // parseChangeDate extracts a change date structure from tags starting at chanIdx.
 // Used for both CHAN (change date) and CREA (creation date) tags.
 func parseChangeDate(tags []*gedcom.Tag, chanIdx int) *gedcom.ChangeDate {
@@ -731,6 +1111,27 @@ func parseChangeDate(tags []*gedcom.Tag, chanIdx int) *gedcom.ChangeDate {
 	return cd
 }
 
+// parseIdentifier extracts an Identifier from a REFN or EXID tag and its TYPE.
+func parseIdentifier(tags []*gedcom.Tag, idIdx, baseLevel int) *gedcom.Identifier {
+	id := &gedcom.Identifier{
+		Kind:  tags[idIdx].Tag,
+		Value: tags[idIdx].Value,
+	}
+
+	for i := idIdx + 1; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Level <= baseLevel {
+			break
+		}
+		if tag.Level == baseLevel+1 && tag.Tag == "TYPE" {
+			id.Type = tag.Value
+			break
+		}
+	}
+
+	return id
+}
+
 // parseSubmitter converts record tags to a Submitter entity.
 func parseSubmitter(record *gedcom.Record) *gedcom.Submitter {
 	subm := &gedcom.Submitter{
@@ -749,7 +1150,7 @@ func parseSubmitter(record *gedcom.Record) *gedcom.Submitter {
 			subm.Name = tag.Value
 
 		case "ADDR":
-			subm.Address = parseEventAddress(record.Tags, i, tag.Level)
+			subm.Address = parseAddress(record.Tags, i, tag.Level)
 
 		case "PHON":
 			subm.Phone = append(subm.Phone, tag.Value)
@@ -760,8 +1161,11 @@ func parseSubmitter(record *gedcom.Record) *gedcom.Submitter {
 		case "LANG":
 			subm.Language = append(subm.Language, tag.Value)
 
-		case "NOTE":
+		case "NOTE", "SNOTE":
 			subm.Notes = append(subm.Notes, tag.Value)
+
+		case "REFN", "EXID":
+			subm.Identifiers = append(subm.Identifiers, parseIdentifier(record.Tags, i, tag.Level))
 		}
 	}
 
@@ -786,31 +1190,48 @@ func parseRepository(record *gedcom.Record) *gedcom.Repository {
 			repo.Name = tag.Value
 
 		case "ADDR":
-			repo.Address = parseEventAddress(record.Tags, i, tag.Level)
+			addr := parseAddress(record.Tags, i, tag.Level)
+			if repo.Address != nil {
+				// Keep contact details that appeared before ADDR
+				addr.Phone, addr.Email, addr.Website = repo.Address.Phone, repo.Address.Email, repo.Address.Website
+			}
+			repo.Address = addr
 
 		case "PHON":
 			if repo.Address == nil {
 				repo.Address = &gedcom.Address{}
 			}
-			repo.Address.Phone = tag.Value
+			if len(repo.Phone) == 0 {
+				repo.Address.Phone = tag.Value
+			}
+			repo.Phone = append(repo.Phone, tag.Value)
 
 		case "EMAIL":
 			if repo.Address == nil {
 				repo.Address = &gedcom.Address{}
 			}
-			repo.Address.Email = tag.Value
+			if len(repo.Email) == 0 {
+				repo.Address.Email = tag.Value
+			}
+			repo.Email = append(repo.Email, tag.Value)
 
 		case "FAX":
-			// FAX is not in Address struct, skip for now
+			repo.Fax = append(repo.Fax, tag.Value)
 
 		case "WWW":
 			if repo.Address == nil {
 				repo.Address = &gedcom.Address{}
 			}
-			repo.Address.Website = tag.Value
+			if len(repo.Website) == 0 {
+				repo.Address.Website = tag.Value
+			}
+			repo.Website = append(repo.Website, tag.Value)
 
-		case "NOTE":
+		case "NOTE", "SNOTE":
 			repo.Notes = append(repo.Notes, tag.Value)
+
+		case "REFN", "EXID":
+			repo.Identifiers = append(repo.Identifiers, parseIdentifier(record.Tags, i, tag.Level))
 		}
 	}
 
@@ -846,12 +1267,49 @@ func parseNote(record *gedcom.Record) *gedcom.Note {
 				// Append to main text
 				note.Text += tag.Value
 			}
+
+		case "LANG":
+			note.Language = tag.Value
+
+		case "MIME":
+			note.MediaType = tag.Value
+
+		case "TRAN":
+			note.Translations = append(note.Translations, parseNoteTranslation(record.Tags, i, tag.Level))
 		}
 	}
 
 	return note
 }
 
+// parseNoteTranslation extracts a NoteTranslation from TRAN tag and its subordinates.
+func parseNoteTranslation(tags []*gedcom.Tag, tranIdx, baseLevel int) *gedcom.NoteTranslation {
+	tran := &gedcom.NoteTranslation{Text: tags[tranIdx].Value}
+
+	for i := tranIdx + 1; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Level <= baseLevel {
+			break
+		}
+		if tag.Level != baseLevel+1 {
+			continue
+		}
+
+		switch tag.Tag {
+		case "CONT":
+			tran.Text += "\n" + tag.Value
+		case "CONC":
+			tran.Text += tag.Value
+		case "LANG":
+			tran.Language = tag.Value
+		case "MIME":
+			tran.MediaType = tag.Value
+		}
+	}
+
+	return tran
+}
+
 // parseMediaObject converts record tags to a MediaObject entity.
 //
 //nolint:gocyclo // GEDCOM parsing inherently requires handling many tag types
@@ -871,7 +1329,7 @@ func parseMediaObject(record *gedcom.Record) *gedcom.MediaObject {
 		case "FILE":
 			file := parseMediaFile(record.Tags, i, tag.Level)
 			media.Files = append(media.Files, file)
-		case "NOTE":
+		case "NOTE", "SNOTE":
 			media.Notes = append(media.Notes, tag.Value)
 		case "SOUR":
 			cite := parseSourceCitation(record.Tags, i, tag.Level)
@@ -880,8 +1338,12 @@ func parseMediaObject(record *gedcom.Record) *gedcom.MediaObject {
 			media.ChangeDate = parseChangeDate(record.Tags, i)
 		case "CREA":
 			media.CreationDate = parseChangeDate(record.Tags, i)
-		case "REFN":
-			media.RefNumbers = append(media.RefNumbers, tag.Value)
+		case "REFN", "EXID":
+			id := parseIdentifier(record.Tags, i, tag.Level)
+			if id.Kind == gedcom.IdentifierREFN {
+				media.RefNumbers = append(media.RefNumbers, id.Value)
+			}
+			media.Identifiers = append(media.Identifiers, id)
 		case "UID":
 			media.UIDs = append(media.UIDs, tag.Value)
 		case "RESN":
@@ -907,7 +1369,7 @@ func parseMediaFile(tags []*gedcom.Tag, fileIdx, baseLevel int) *gedcom.MediaFil
 			switch tag.Tag {
 			case "FORM":
 				file.Form = tag.Value
-				// Look for MEDI at baseLevel+2
+				// Look for MEDI at baseLevel+2, with its PHRASE at baseLevel+3
 				for j := i + 1; j < len(tags); j++ {
 					mediTag := tags[j]
 					if mediTag.Level <= baseLevel+1 {
@@ -915,7 +1377,9 @@ func parseMediaFile(tags []*gedcom.Tag, fileIdx, baseLevel int) *gedcom.MediaFil
 					}
 					if mediTag.Level == baseLevel+2 && mediTag.Tag == "MEDI" {
 						file.MediaType = mediTag.Value
-						break
+					}
+					if mediTag.Level == baseLevel+3 && mediTag.Tag == "PHRASE" {
+						file.MediaPhrase = mediTag.Value
 					}
 				}
 			case "TITL":
@@ -2,8 +2,11 @@ package decoder
 
 import (
 	"os"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
 )
 
 const entityTestGedcom = `0 HEAD
@@ -251,6 +254,7 @@ func TestParsePedigreeLinks(t *testing.T) {
 2 PEDI adopted
 1 FAMC @F3@
 2 PEDI foster
+2 STAT challenged
 1 FAMC @F4@
 2 PEDI sealing
 1 FAMC @F5@
@@ -298,6 +302,9 @@ func TestParsePedigreeLinks(t *testing.T) {
 			t.Errorf("ChildInFamilies[%d].Pedigree = %s, want %s", tt.idx, link.Pedigree, tt.pedigree)
 		}
 	}
+	if status := child1.ChildInFamilies[2].Status; status != "challenged" {
+		t.Errorf("ChildInFamilies[2].Status = %q, want challenged", status)
+	}
 
 	// Test GEDCOM 7.0 uppercase values (preserving original casing)
 	child2 := doc.GetIndividual("@I2@")
@@ -435,6 +442,41 @@ func TestSourceCitationStructure(t *testing.T) {
 	}
 }
 
+func TestSourceTextContinuation(t *testing.T) {
+	input := `0 HEAD
+0 @S1@ SOUR
+1 TITL Parish register
+1 TEXT Baptized the fourth day of May, John, son of Tho
+2 CONC mas Smith
+2 CONT and Mary his wife.
+2 CONT
+2 CONT Witnesses: R. Jones
+0 @I1@ INDI
+1 BIRT
+2 SOUR @S1@
+3 DATA
+4 TEXT Born at the mi
+5 CONC ll
+5 CONT on a Sunday
+4 TEXT Second excerpt
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Baptized the fourth day of May, John, son of Thomas Smith\nand Mary his wife.\n\nWitnesses: R. Jones"
+	if src := doc.GetSource("@S1@"); src == nil || src.Text != want {
+		t.Errorf("Source.Text = %q, want %q", src.Text, want)
+	}
+
+	cite := doc.GetIndividual("@I1@").Events[0].SourceCitations[0]
+	if want := "Born at the mill\non a Sunday\nSecond excerpt"; cite.Data == nil || cite.Data.Text != want {
+		t.Errorf("Data.Text = %q, want %q", cite.Data.Text, want)
+	}
+}
+
 // TestIndividualAttributes tests parsing of individual attributes.
 // Tests parsing of CAST, DSCR, EDUC, IDNO, NATI, SSN, TITL, RELI attributes.
 // Priority: P2 (Important)
@@ -639,6 +681,53 @@ func TestReligiousEvents(t *testing.T) {
 	}
 }
 
+func TestReligiousEventDenomination(t *testing.T) {
+	input := `0 HEAD
+0 @I1@ INDI
+1 BAPM
+2 RELI Roman Catholic
+1 CHR
+2 RELI Anglican
+1 CONF
+2 RELI Lutheran
+1 FCOM
+2 RELI Roman Catholic
+1 ORDN
+2 RELI Methodist
+1 BARM
+2 RELI Orthodox Judaism
+1 BASM
+2 RELI Reform Judaism
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		typ      string
+		religion string
+	}{
+		{"BAPM", "Roman Catholic"},
+		{"CHR", "Anglican"},
+		{"CONF", "Lutheran"},
+		{"FCOM", "Roman Catholic"},
+		{"ORDN", "Methodist"},
+		{"BARM", "Orthodox Judaism"},
+		{"BASM", "Reform Judaism"},
+	}
+	events := doc.GetIndividual("@I1@").Events
+	if len(events) != len(want) {
+		t.Fatalf("len(Events) = %d, want %d", len(events), len(want))
+	}
+	for i, w := range want {
+		if string(events[i].Type) != w.typ || events[i].Religion != w.religion {
+			t.Errorf("Events[%d] = %s/%q, want %s/%q", i, events[i].Type, events[i].Religion, w.typ, w.religion)
+		}
+	}
+}
+
 // TestLifeEvents tests parsing of life status events.
 // Validates support for GRAD, RETI, NATU, ORDN, PROB, WILL, CREM event types.
 // Priority: P2 (Important)
@@ -1283,6 +1372,272 @@ func TestMaximal70Source(t *testing.T) {
 // These tests validate handling of empty, nil, and missing values.
 
 // TestEmptyEventSubordinates tests events without subordinate tags.
+func TestFamilyEventSpouseAges(t *testing.T) {
+	input := `0 HEAD
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 MARR
+2 DATE 15 JUN 1920
+2 HUSB
+3 AGE 25y
+2 WIFE
+3 AGE 22y 6m
+1 DIV
+2 HUSB
+3 NOTE no age given
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fam := doc.GetFamily("@F1@")
+	if fam == nil || len(fam.Events) != 2 {
+		t.Fatalf("family events = %v, want 2", fam)
+	}
+
+	marr := fam.Events[0]
+	if marr.HusbandAge != "25y" {
+		t.Errorf("HusbandAge = %q, want 25y", marr.HusbandAge)
+	}
+	if marr.WifeAge != "22y 6m" {
+		t.Errorf("WifeAge = %q, want 22y 6m", marr.WifeAge)
+	}
+	if marr.Age != "" {
+		t.Errorf("Age = %q, want empty (spouse ages are not the event age)", marr.Age)
+	}
+	if fam.Husband != "@I1@" || fam.Wife != "@I2@" {
+		t.Errorf("family spouses = %s/%s, want @I1@/@I2@", fam.Husband, fam.Wife)
+	}
+
+	div := fam.Events[1]
+	if div.HusbandAge != "" || div.WifeAge != "" {
+		t.Errorf("DIV spouse ages = %q/%q, want empty", div.HusbandAge, div.WifeAge)
+	}
+}
+
+func TestEventParsedAge(t *testing.T) {
+	input := `0 HEAD
+0 @I1@ INDI
+1 DEAT
+2 AGE > 72y 3m
+2 CAUS Influenza
+2 AGNC County Coroner
+1 BURI
+2 AGE
+3 PHRASE about seventy
+1 CHR
+2 AGE sometime
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := doc.GetIndividual("@I1@").Events
+	if len(events) != 3 {
+		t.Fatalf("events = %d, want 3", len(events))
+	}
+
+	deat := events[0]
+	want := gedcom.Age{Original: "> 72y 3m", Qualifier: ">", Years: 72, Months: 3}
+	if deat.ParsedAge == nil || *deat.ParsedAge != want {
+		t.Errorf("DEAT ParsedAge = %+v, want %+v", deat.ParsedAge, want)
+	}
+	if deat.Cause != "Influenza" || deat.Agency != "County Coroner" {
+		t.Errorf("DEAT Cause/Agency = %q/%q, want Influenza/County Coroner", deat.Cause, deat.Agency)
+	}
+
+	if buri := events[1]; buri.ParsedAge == nil || buri.ParsedAge.Phrase != "about seventy" {
+		t.Errorf("BURI ParsedAge = %+v, want phrase %q", buri.ParsedAge, "about seventy")
+	}
+	if chr := events[2]; chr.Age != "sometime" || chr.ParsedAge != nil {
+		t.Errorf("CHR Age/ParsedAge = %q/%+v, want raw age and nil", chr.Age, chr.ParsedAge)
+	}
+}
+
+func TestAdoptionEventFamily(t *testing.T) {
+	input := `0 HEAD
+0 @I1@ INDI
+1 NAME Child /Doe/
+1 BIRT
+2 FAMC @F1@
+1 ADOP
+2 DATE 1 MAR 1990
+2 FAMC @F2@
+3 ADOP HUSB
+1 FAMC @F1@
+1 FAMC @F2@
+2 PEDI adopted
+0 @F1@ FAM
+0 @F2@ FAM
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indi := doc.GetIndividual("@I1@")
+	if len(indi.Events) != 2 {
+		t.Fatalf("len(Events) = %d, want 2", len(indi.Events))
+	}
+	if birt := indi.Events[0]; birt.FamilyXRef != "@F1@" || birt.AdoptedBy != "" {
+		t.Errorf("BIRT family = %q/%q, want @F1@ with no ADOP", birt.FamilyXRef, birt.AdoptedBy)
+	}
+	adop := indi.Events[1]
+	if adop.FamilyXRef != "@F2@" {
+		t.Errorf("ADOP FamilyXRef = %q, want @F2@", adop.FamilyXRef)
+	}
+	if adop.AdoptedBy != "HUSB" {
+		t.Errorf("ADOP AdoptedBy = %q, want HUSB", adop.AdoptedBy)
+	}
+	if len(indi.ChildInFamilies) != 2 {
+		t.Errorf("len(ChildInFamilies) = %d, want 2", len(indi.ChildInFamilies))
+	}
+}
+
+func TestEventAssociations(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 BAPM
+2 DATE 3 MAR 1900
+2 ASSO @I2@
+3 ROLE GODP
+2 ASSO @VOID@
+3 PHRASE Rev. Smith
+3 ROLE OFFICIATOR
+0 @I2@ INDI
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bapm := doc.GetIndividual("@I1@").Events[0]
+	if len(bapm.Associations) != 2 {
+		t.Fatalf("len(Associations) = %d, want 2", len(bapm.Associations))
+	}
+	if a := bapm.Associations[0]; a.IndividualXRef != "@I2@" || a.Role != "GODP" {
+		t.Errorf("Associations[0] = %+v, want @I2@ GODP", a)
+	}
+	if a := bapm.Associations[1]; a.Role != "OFFICIATOR" || a.Phrase != "Rev. Smith" {
+		t.Errorf("Associations[1] = %+v, want OFFICIATOR with phrase", a)
+	}
+	if len(doc.GetIndividual("@I1@").Associations) != 0 {
+		t.Error("event ASSO should not be added to the individual's associations")
+	}
+}
+
+func TestGenericEventsAndFacts(t *testing.T) {
+	input := `0 HEAD
+0 @I1@ INDI
+1 EVEN Ran away from home
+2 TYPE Escapade
+2 DATE 1910
+1 FACT Blue
+2 TYPE Eye color
+1 OCCU Farmer
+2 TYPE Primary
+0 @F1@ FAM
+1 EVEN
+2 TYPE Handfasting
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indi := doc.GetIndividual("@I1@")
+	if len(indi.Events) != 1 {
+		t.Fatalf("len(Events) = %d, want 1", len(indi.Events))
+	}
+	even := indi.Events[0]
+	if even.Type != "EVEN" || even.Description != "Ran away from home" || even.EventTypeDetail != "Escapade" {
+		t.Errorf("EVEN = %+v", even)
+	}
+
+	if len(indi.Attributes) != 2 {
+		t.Fatalf("len(Attributes) = %d, want 2", len(indi.Attributes))
+	}
+	if fact := indi.Attributes[0]; fact.Type != "FACT" || fact.Value != "Blue" || fact.TypeDetail != "Eye color" {
+		t.Errorf("FACT = %+v", fact)
+	}
+	if occu := indi.Attributes[1]; occu.TypeDetail != "Primary" {
+		t.Errorf("OCCU TypeDetail = %q, want Primary", occu.TypeDetail)
+	}
+
+	fam := doc.GetFamily("@F1@")
+	if len(fam.Events) != 1 || fam.Events[0].EventTypeDetail != "Handfasting" {
+		t.Errorf("family EVEN = %+v", fam.Events)
+	}
+}
+
+func TestEventParticipantVendorTags(t *testing.T) {
+	input := `0 HEAD
+0 @F1@ FAM
+1 MARR
+2 _WITN @I3@
+2 _WITNESS John Smith
+2 _OFFICIATOR @I4@
+3 NOTE Parish priest
+2 _GODP
+2 _CUSTOM @I5@
+0 @I3@ INDI
+0 @I4@ INDI
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	marr := doc.GetFamily("@F1@").Events[0]
+	if len(marr.Associations) != 2 {
+		t.Fatalf("len(Associations) = %d, want 2", len(marr.Associations))
+	}
+	if a := marr.Associations[0]; a.IndividualXRef != "@I3@" || a.Role != "WITN" {
+		t.Errorf("Associations[0] = %+v, want @I3@ WITN", a)
+	}
+	if a := marr.Associations[1]; a.IndividualXRef != "@I4@" || a.Role != "OFFICIATOR" || len(a.Notes) != 1 {
+		t.Errorf("Associations[1] = %+v, want @I4@ OFFICIATOR with note", a)
+	}
+	if len(marr.Witnesses) != 1 || marr.Witnesses[0] != "John Smith" {
+		t.Errorf("Witnesses = %v, want [John Smith]", marr.Witnesses)
+	}
+}
+
+func TestCauseOfDeathAndPhysicalDescription(t *testing.T) {
+	input := `0 HEAD
+0 @I1@ INDI
+1 DSCR Tall, red ha
+2 CONC ir, blue eyes
+2 CONT Scar on left hand
+1 DEAT
+2 CAUS Tuberculosis
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indi := doc.GetIndividual("@I1@")
+	if got := indi.CauseOfDeath(); got != "Tuberculosis" {
+		t.Errorf("CauseOfDeath() = %q, want Tuberculosis", got)
+	}
+	want := "Tall, red hair, blue eyes\nScar on left hand"
+	if got := indi.PhysicalDescription(); got != want {
+		t.Errorf("PhysicalDescription() = %q, want %q", got, want)
+	}
+}
+
 func TestEmptyEventSubordinates(t *testing.T) {
 	gedcom := `0 HEAD
 1 GEDC
@@ -1842,11 +2197,49 @@ func TestEventAddressWithContinuation(t *testing.T) {
 	if resi.Address.Line1 != expected {
 		t.Errorf("Address.Line1 = %q, want %q", resi.Address.Line1, expected)
 	}
+	if resi.Address.Text != expected {
+		t.Errorf("Address.Text = %q, want %q", resi.Address.Text, expected)
+	}
 	if resi.Address.City != "Springfield" {
 		t.Errorf("Address.City = %s, want Springfield", resi.Address.City)
 	}
 }
 
+func TestAddressTextWithComponents(t *testing.T) {
+	input := `0 HEAD
+0 @U1@ SUBM
+1 NAME Jane Researcher
+1 ADDR 10 Elm St
+2 CONT Springfield, IL
+2 ADR1 10 Elm St
+2 CITY Springfield
+2 STAE IL
+0 @R1@ REPO
+1 NAME State Archive
+1 PHON 555-1234
+1 ADDR 1 Archive Way
+2 CITY Boston
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subm := doc.GetSubmitter("@U1@")
+	if subm.Address.Text != "10 Elm St\nSpringfield, IL" || subm.Address.Line1 != "10 Elm St" {
+		t.Errorf("submitter address = %+v", subm.Address)
+	}
+	if got := subm.Address.Locality(); got != "Springfield, IL" {
+		t.Errorf("Locality() = %q", got)
+	}
+
+	repo := doc.GetRepository("@R1@")
+	if repo.Address.City != "Boston" || repo.Address.Phone != "555-1234" {
+		t.Errorf("repository address = %+v, want phone kept when ADDR follows PHON", repo.Address)
+	}
+}
+
 // TestEventWithoutAddress tests events without address fields.
 func TestEventWithoutAddress(t *testing.T) {
 	gedcom := `0 HEAD
@@ -2746,6 +3139,63 @@ func TestNoteParsing(t *testing.T) {
 	}
 }
 
+func TestSharedNoteParsing(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 SNOTE @N1@
+1 BIRT
+2 SNOTE @N2@
+0 @N1@ SNOTE <p>Born at home</p>
+1 CONT <p>per the family bible</p>
+1 MIME text/html
+1 LANG en
+1 TRAN <p>Né à la maison</p>
+2 MIME text/html
+2 LANG fr
+0 @N2@ NOTE A 5.5.1-style note
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if notes := doc.Notes(); len(notes) != 2 {
+		t.Fatalf("len(Notes()) = %d, want 2", len(notes))
+	}
+
+	note := doc.GetNote("@N1@")
+	if note == nil {
+		t.Fatal("GetNote(@N1@) returned nil")
+	}
+	if want := "<p>Born at home</p>\n<p>per the family bible</p>"; note.FullText() != want {
+		t.Errorf("FullText() = %q, want %q", note.FullText(), want)
+	}
+	if note.MediaType != "text/html" || note.Language != "en" {
+		t.Errorf("MediaType, Language = %q, %q, want text/html, en", note.MediaType, note.Language)
+	}
+	if len(note.Translations) != 1 {
+		t.Fatalf("len(Translations) = %d, want 1", len(note.Translations))
+	}
+	if tran := note.Translations[0]; tran.Text != "<p>Né à la maison</p>" || tran.Language != "fr" || tran.MediaType != "text/html" {
+		t.Errorf("Translations[0] = %+v", tran)
+	}
+	if unhandled := doc.GetRecord("@N1@").UnhandledTags(); len(unhandled) != 0 {
+		t.Errorf("UnhandledTags() = %+v, want none", unhandled)
+	}
+
+	// SNOTE pointers resolve like NOTE pointers
+	indi := doc.GetIndividual("@I1@")
+	if got := indi.Notes; len(got) != 1 || doc.GetNote(got[0]) != note {
+		t.Errorf("individual Notes = %v, want a pointer to @N1@", got)
+	}
+	if got := indi.Events[0].Notes; len(got) != 1 || doc.GetNote(got[0]) == nil {
+		t.Errorf("event Notes = %v, want a resolvable pointer", got)
+	}
+}
+
 // TestParseMediaObject_SingleFile tests basic OBJE record with one FILE
 func TestParseMediaObject_SingleFile(t *testing.T) {
 	input := `0 HEAD
@@ -2791,6 +3241,53 @@ func TestParseMediaObject_SingleFile(t *testing.T) {
 	}
 }
 
+func TestParseMediaObject_PhotoTags(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @O1@ OBJE
+1 FILE reunion.jpg
+2 FORM image/jpeg
+3 MEDI OTHER
+4 PHRASE Glass plate negative
+2 TRAN reunion-thumb.jpg
+3 FORM image/jpeg
+0 @I1@ INDI
+1 OBJE @O1@
+2 CROP
+3 TOP 40
+3 LEFT 120
+3 HEIGHT 200
+3 WIDTH 150
+2 TITL John at the reunion
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	file := doc.GetMediaObject("@O1@").Files[0]
+	if file.MediaType != "OTHER" || file.MediaPhrase != "Glass plate negative" {
+		t.Errorf("MediaType, MediaPhrase = %q, %q; want OTHER, Glass plate negative", file.MediaType, file.MediaPhrase)
+	}
+	if len(file.Translations) != 1 || file.Translations[0].Form != "image/jpeg" {
+		t.Errorf("Translations = %+v, want one image/jpeg translation", file.Translations)
+	}
+
+	regions := doc.MediaRegions("@O1@")
+	if len(regions) != 1 {
+		t.Fatalf("len(MediaRegions) = %d, want 1", len(regions))
+	}
+	want := gedcom.CropRegion{Top: 40, Left: 120, Height: 200, Width: 150}
+	if r := regions[0]; r.RecordXRef != "@I1@" || r.Crop == nil || *r.Crop != want || r.Title != "John at the reunion" {
+		t.Errorf("MediaRegions()[0] = %+v, crop %+v", r, r.Crop)
+	}
+	if unhandled := doc.UnhandledTags(); len(unhandled) != 0 {
+		t.Errorf("UnhandledTags() = %+v, want none", unhandled)
+	}
+}
+
 // TestParseMediaObject_MultipleFiles tests OBJE with multiple FILE entries
 func TestParseMediaObject_MultipleFiles(t *testing.T) {
 	input := `0 HEAD
@@ -2967,10 +3464,12 @@ func TestParseMediaObject_FullMetadata(t *testing.T) {
 		t.Errorf("media.UIDs[0] = %s, want '69ebdd0e-c78c-4b81-873f-dc8ac30a48b9'", media.UIDs[0])
 	}
 
-	// Only inline NOTE is captured, SNOTE references are not currently parsed
-	// TODO: Add SNOTE handling to parseMediaObject
-	if len(media.Notes) != 1 {
-		t.Errorf("len(media.Notes) = %d, want 1", len(media.Notes))
+	// Inline NOTE and SNOTE references are both captured
+	if want := []string{"Test note", "@N1@"}; !reflect.DeepEqual(media.Notes, want) {
+		t.Errorf("media.Notes = %v, want %v", media.Notes, want)
+	}
+	if note := doc.GetNote(media.Notes[1]); note == nil || note.Text != "Shared note" {
+		t.Errorf("GetNote(%s) = %+v, want the shared note", media.Notes[1], note)
 	}
 
 	if len(media.SourceCitations) != 1 {
@@ -3292,6 +3791,84 @@ func TestSourceInlineRepositoryDecoding(t *testing.T) {
 	}
 }
 
+func TestSourceRepositoryCitations(t *testing.T) {
+	gedcom := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @R1@ REPO
+1 NAME County Archives
+1 PHON +1 555 0100
+1 PHON +1 555 0101
+1 FAX +1 555 0199
+1 WWW https://archives.example.org
+0 @R2@ REPO
+1 NAME State Library
+0 @S1@ SOUR
+1 TITL Parish Registers
+1 REPO @R1@
+2 NOTE Originals in the vault
+2 CALN MS 123
+3 MEDI MICROFILM
+2 CALN MS 124
+1 REPO @R2@
+2 CALN F 987
+3 MEDI BOOK
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(gedcom))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := doc.GetSource("@S1@")
+	if src == nil {
+		t.Fatal("Source @S1@ not found")
+	}
+	if src.RepositoryRef != "@R1@" {
+		t.Errorf("RepositoryRef = %q, want @R1@", src.RepositoryRef)
+	}
+	if len(src.Repositories) != 2 {
+		t.Fatalf("len(Repositories) = %d, want 2", len(src.Repositories))
+	}
+	first, second := src.Repositories[0], src.Repositories[1]
+	if first.RepositoryXRef != "@R1@" || second.RepositoryXRef != "@R2@" {
+		t.Errorf("RepositoryXRefs = %q, %q, want @R1@, @R2@", first.RepositoryXRef, second.RepositoryXRef)
+	}
+	if !reflect.DeepEqual(first.Notes, []string{"Originals in the vault"}) {
+		t.Errorf("Notes = %v", first.Notes)
+	}
+	if len(first.CallNumbers) != 2 {
+		t.Fatalf("len(CallNumbers) = %d, want 2", len(first.CallNumbers))
+	}
+	if c := first.CallNumbers[0]; c.Number != "MS 123" || c.Medium != "MICROFILM" {
+		t.Errorf("CallNumbers[0] = %+v, want MS 123 on MICROFILM", c)
+	}
+	if c := first.CallNumbers[1]; c.Number != "MS 124" || c.Medium != "" {
+		t.Errorf("CallNumbers[1] = %+v, want MS 124 without medium", c)
+	}
+	if len(second.CallNumbers) != 1 || second.CallNumbers[0].Medium != "BOOK" {
+		t.Errorf("second CallNumbers = %+v, want F 987 in BOOK", second.CallNumbers)
+	}
+
+	repo := doc.GetRepository("@R1@")
+	if repo == nil {
+		t.Fatal("Repository @R1@ not found")
+	}
+	if !reflect.DeepEqual(repo.Phone, []string{"+1 555 0100", "+1 555 0101"}) {
+		t.Errorf("Phone = %v", repo.Phone)
+	}
+	if !reflect.DeepEqual(repo.Fax, []string{"+1 555 0199"}) {
+		t.Errorf("Fax = %v", repo.Fax)
+	}
+	if repo.Address == nil || repo.Address.Phone != "+1 555 0100" || repo.Address.Website != "https://archives.example.org" {
+		t.Errorf("Address contact = %+v, want first phone and website", repo.Address)
+	}
+
+	if got := doc.RepositorySources("@R2@"); len(got) != 1 || got[0] != src {
+		t.Errorf("RepositorySources(@R2@) = %v, want [@S1@]", got)
+	}
+}
+
 // TestSourceInlineRepositoryRoundtrip tests decoding and re-encoding preserves inline repository
 func TestSourceInlineRepositoryRoundtrip(t *testing.T) {
 	gedcom := `0 HEAD
@@ -3320,6 +3897,187 @@ func TestSourceInlineRepositoryRoundtrip(t *testing.T) {
 	}
 }
 
+func TestBurialCemeteryAndPlot(t *testing.T) {
+	input := `0 HEAD
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BURI
+2 DATE 3 MAR 1920
+2 PLAC Cambridge, MA
+2 _CEME Mount Auburn Cemetery
+2 _PLOT Section B, Lot 12
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 BURI
+2 CEME Harmony Grove
+2 PLOT Row 4
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		xref, cemetery, plot string
+	}{
+		{"@I1@", "Mount Auburn Cemetery", "Section B, Lot 12"},
+		{"@I2@", "Harmony Grove", "Row 4"},
+	}
+	for _, tt := range tests {
+		burial := doc.GetIndividual(tt.xref).Events[0]
+		if burial.Cemetery != tt.cemetery || burial.Plot != tt.plot {
+			t.Errorf("%s burial = %q, %q; want %q, %q", tt.xref, burial.Cemetery, burial.Plot, tt.cemetery, tt.plot)
+		}
+	}
+	if unhandled := doc.UnhandledTags(); len(unhandled) != 0 {
+		t.Errorf("UnhandledTags() = %+v, want none", unhandled)
+	}
+}
+
+func TestSourceData(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @S1@ SOUR
+1 TITL Parish Registers
+1 DATA
+2 EVEN BIRT, DEAT
+3 DATE FROM 1820 TO 1860
+3 PLAC St. Mary, Boston, MA
+2 EVEN MARR
+3 DATE 1830
+2 AGNC St. Mary Parish
+2 NOTE Some pages missing
+1 REPO @R1@
+0 @R1@ REPO
+1 NAME County Archives
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := doc.GetSource("@S1@")
+	if src == nil || src.Data == nil {
+		t.Fatal("Source @S1@ has no Data")
+	}
+	data := src.Data
+	if data.Agency != "St. Mary Parish" {
+		t.Errorf("Agency = %q, want St. Mary Parish", data.Agency)
+	}
+	if !reflect.DeepEqual(data.Notes, []string{"Some pages missing"}) {
+		t.Errorf("Notes = %v", data.Notes)
+	}
+	if len(data.Events) != 2 {
+		t.Fatalf("len(Events) = %d, want 2", len(data.Events))
+	}
+	first := data.Events[0]
+	if first.Types != "BIRT, DEAT" || first.Date != "FROM 1820 TO 1860" || first.Place != "St. Mary, Boston, MA" {
+		t.Errorf("Events[0] = %+v", first)
+	}
+	if first.ParsedDate == nil || first.ParsedDate.Modifier != gedcom.ModifierFromTo {
+		t.Errorf("Events[0].ParsedDate = %+v, want FROM...TO period", first.ParsedDate)
+	}
+	if got := first.EventTypes(); !reflect.DeepEqual(got, []gedcom.EventType{gedcom.EventBirth, gedcom.EventDeath}) {
+		t.Errorf("EventTypes() = %v", got)
+	}
+	if second := data.Events[1]; second.Types != "MARR" || second.Date != "1830" || second.Place != "" {
+		t.Errorf("Events[1] = %+v", second)
+	}
+	if len(src.Repositories) != 1 || src.Repositories[0].RepositoryXRef != "@R1@" {
+		t.Errorf("Repositories = %+v, want @R1@", src.Repositories)
+	}
+	if unhandled := doc.UnhandledTags(); len(unhandled) != 0 {
+		t.Errorf("UnhandledTags() = %+v, want none", unhandled)
+	}
+}
+
+func TestIndividualRecordFields(t *testing.T) {
+	input := `0 HEAD
+0 @I1@ INDI
+1 NAME John /Doe/
+1 ALIA @I7@
+1 ALIA @I9@
+1 ANCI @U1@
+1 DESI @U2@
+1 RESN confidential
+1 RIN 1042
+1 AFN 1BCD-23
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indi := doc.GetIndividual("@I1@")
+	if !reflect.DeepEqual(indi.Aliases, []string{"@I7@", "@I9@"}) {
+		t.Errorf("Aliases = %v, want [@I7@ @I9@]", indi.Aliases)
+	}
+	if !reflect.DeepEqual(indi.AncestorInterest, []string{"@U1@"}) || !reflect.DeepEqual(indi.DescendantInterest, []string{"@U2@"}) {
+		t.Errorf("AncestorInterest/DescendantInterest = %v/%v, want [@U1@]/[@U2@]", indi.AncestorInterest, indi.DescendantInterest)
+	}
+	if indi.Restriction != "confidential" {
+		t.Errorf("Restriction = %q, want confidential", indi.Restriction)
+	}
+	if indi.AutomatedRecordID != "1042" || indi.AncestralFileNumber != "1BCD-23" {
+		t.Errorf("AutomatedRecordID/AncestralFileNumber = %q/%q, want 1042/1BCD-23", indi.AutomatedRecordID, indi.AncestralFileNumber)
+	}
+	if unhandled := doc.UnhandledTags(); len(unhandled) != 0 {
+		t.Errorf("UnhandledTags() = %+v, want none", unhandled)
+	}
+}
+
+func TestRecordUIDs(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 NAME John /Doe/
+1 UID 7a6d2f1e-8c3b-4b5a-9f0e-1d2c3b4a5f60
+1 _UID 4A2B0C9D8E7F6A5B4C3D2E1F0A9B8C7D1234
+1 UID 0f3e2d1c-aaaa-4bbb-8ccc-dddddddddddd
+0 @F1@ FAM
+1 HUSB @I1@
+1 _UID 99887766554433221100AABBCCDDEEFF0000
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indi := doc.GetIndividual("@I1@")
+	if indi.UID != "7a6d2f1e-8c3b-4b5a-9f0e-1d2c3b4a5f60" {
+		t.Errorf("UID = %q, want the first UID", indi.UID)
+	}
+	want := []string{
+		"7a6d2f1e-8c3b-4b5a-9f0e-1d2c3b4a5f60",
+		"4A2B0C9D8E7F6A5B4C3D2E1F0A9B8C7D1234",
+		"0f3e2d1c-aaaa-4bbb-8ccc-dddddddddddd",
+	}
+	if !reflect.DeepEqual(indi.UIDs, want) {
+		t.Errorf("UIDs = %v, want %v", indi.UIDs, want)
+	}
+
+	fam := doc.GetFamily("@F1@")
+	if fam.UID != "" || !reflect.DeepEqual(fam.UIDs, []string{"99887766554433221100AABBCCDDEEFF0000"}) {
+		t.Errorf("family UID, UIDs = %q, %v; want vendor _UID only", fam.UID, fam.UIDs)
+	}
+
+	if got := doc.FindByUID("{7A6D2F1E-8C3B-4B5A-9F0E-1D2C3B4A5F60}"); got == nil || got.XRef != "@I1@" {
+		t.Errorf("FindByUID(UID) = %v, want @I1@", got)
+	}
+	if got := doc.FindByUID("99887766554433221100aabbccddeeff0000"); got == nil || got.XRef != "@F1@" {
+		t.Errorf("FindByUID(_UID) = %v, want @F1@", got)
+	}
+	if unhandled := doc.UnhandledTags(); len(unhandled) != 0 {
+		t.Errorf("UnhandledTags() = %+v, want none", unhandled)
+	}
+}
+
 // TestFamilySearchIDParsing tests parsing of the _FSFTID tag (FamilySearch Family Tree ID).
 // This is a vendor extension from FamilySearch.org.
 // Ref: Issue #80
@@ -3837,3 +4595,148 @@ func TestMaximal70AssociationsFromFile(t *testing.T) {
 	// Note: Family associations are not currently implemented in the Family type.
 	// This test only checks Individual associations.
 }
+
+// TestParseAssociationDNAMatch tests parsing _DNA extension data and cM notes on ASSO.
+func TestParseAssociationDNAMatch(t *testing.T) {
+	gedcom := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Doe/
+1 ASSO @I2@
+2 RELA DNA Match
+2 _DNA Shared 212 cM across 9 segments
+3 _LSEG 41.5
+3 _REL 2nd cousin
+3 TYPE AncestryDNA
+1 ASSO @I3@
+2 RELA DNA Match
+2 NOTE Shared 98.5 cM, 4 segments
+1 ASSO @I4@
+2 RELA GODP
+2 NOTE Godfather at baptism
+1 ASSO @I5@
+2 _DNA
+3 _CM 1700
+3 _SEG 40
+1 ASSO @I6@
+2 RELA Friend
+2 NOTE height 180 cm
+1 ASSO @I7@
+2 NOTE grave 50cm from wall
+1 ASSO @I8@
+2 TYPE dna match
+2 NOTE 45 cM, 3 segments
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(gedcom))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indi := doc.GetIndividual("@I1@")
+	if indi == nil || len(indi.Associations) != 7 {
+		t.Fatalf("expected individual with 7 associations")
+	}
+
+	m := indi.Associations[0].DNAMatch
+	if m == nil {
+		t.Fatal("Associations[0].DNAMatch is nil")
+	}
+	if m.SharedCM != 212 || m.SharedSegments != 9 || m.LongestSegmentCM != 41.5 {
+		t.Errorf("DNAMatch = %+v, want 212 cM / 9 segments / 41.5 longest", m)
+	}
+	if m.Relationship != "2nd cousin" || m.Provider != "AncestryDNA" {
+		t.Errorf("Relationship/Provider = %q/%q", m.Relationship, m.Provider)
+	}
+
+	m = indi.Associations[1].DNAMatch
+	if m == nil || m.SharedCM != 98.5 || m.SharedSegments != 4 {
+		t.Errorf("note-derived DNAMatch = %+v, want 98.5 cM / 4 segments", m)
+	}
+
+	if indi.Associations[2].DNAMatch != nil {
+		t.Errorf("non-DNA association has DNAMatch = %+v", indi.Associations[2].DNAMatch)
+	}
+
+	m = indi.Associations[3].DNAMatch
+	if m == nil || m.SharedCM != 1700 || m.SharedSegments != 40 {
+		t.Errorf("subordinate-only DNAMatch = %+v, want 1700 cM / 40 segments", m)
+	}
+
+	// Notes mentioning cm are not DNA data unless the association says so
+	for _, i := range []int{4, 5} {
+		if m := indi.Associations[i].DNAMatch; m != nil {
+			t.Errorf("Associations[%d] note %q read as DNAMatch = %+v", i, indi.Associations[i].Notes[0], m)
+		}
+	}
+	m = indi.Associations[6].DNAMatch
+	if m == nil || m.SharedCM != 45 || m.SharedSegments != 3 {
+		t.Errorf("TYPE-marked DNAMatch = %+v, want 45 cM / 3 segments", m)
+	}
+}
+
+func TestRefreshEntity(t *testing.T) {
+	input := `0 HEAD
+0 @I1@ INDI
+1 NAME John /Smith/
+1 SEX M
+0 @I2@ INDI
+1 NAME Jane /Doe/
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	rec := doc.GetRecord("@I1@")
+	if err := rec.SetTag("SEX", "F"); err != nil {
+		t.Fatalf("SetTag() error = %v", err)
+	}
+	if _, err := rec.AddTag("BIRT", ""); err != nil {
+		t.Fatalf("AddTag() error = %v", err)
+	}
+	if doc.GetIndividual("@I1@").Sex != "M" {
+		t.Fatal("entity should be stale before refresh")
+	}
+
+	if n := RefreshEntities(doc); n != 1 {
+		t.Errorf("RefreshEntities() = %d, want 1", n)
+	}
+	ind := doc.GetIndividual("@I1@")
+	if ind.Sex != "F" || len(ind.Events) != 1 {
+		t.Errorf("refreshed individual = %+v", ind)
+	}
+	if rec.TagsModified() {
+		t.Error("RefreshEntity should clear modified state")
+	}
+
+	RefreshEntity(nil)
+	if RefreshEntities(nil) != 0 {
+		t.Error("RefreshEntities(nil) should return 0")
+	}
+}
+
+// TestIndividualLanguageParsing tests parsing of the individual LANG tag used
+// for name ordering.
+func TestIndividualLanguageParsing(t *testing.T) {
+	input := `0 HEAD
+1 LANG English
+0 @I1@ INDI
+1 NAME Béla /Bartók/
+1 LANG Hungarian
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	indi := doc.GetIndividual("@I1@")
+	if indi.Language != "Hungarian" {
+		t.Errorf("Language = %q, want 'Hungarian'", indi.Language)
+	}
+	if got := indi.FormattedName(doc); got != "Bartók Béla" {
+		t.Errorf("FormattedName() = %q, want 'Bartók Béla'", got)
+	}
+}
@@ -1,9 +1,13 @@
 package decoder
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/cacack/gedcom-go/parser"
 )
 
 // T064: Test missing cross-reference targets
@@ -169,3 +173,69 @@ func TestTruncatedFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeErrorsSeverity(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5
+0 @I1@ INDI
+INVALID LINE HERE
+1 NAME John /Smith/
+1 FAMS @F999@
+0 TRLR`
+
+	opts := DefaultOptions()
+	opts.RecoverErrors = true
+	opts.ValidateXRefs = true
+
+	_, err := DecodeWithOptions(strings.NewReader(input), opts)
+	var decodeErrs *DecodeErrors
+	if !errors.As(err, &decodeErrs) {
+		t.Fatalf("DecodeWithOptions() error = %v, want DecodeErrors", err)
+	}
+
+	errs := decodeErrs.ErrorsOnly()
+	if len(errs) != 1 || ErrorCode(errs[0]) != CodeParseError {
+		t.Errorf("ErrorsOnly() = %v, want one %s", errs, CodeParseError)
+	}
+	warnings := decodeErrs.Warnings()
+	if len(warnings) != 1 || ErrorCode(warnings[0]) != CodeBrokenXRef {
+		t.Errorf("Warnings() = %v, want one %s", warnings, CodeBrokenXRef)
+	}
+}
+
+func TestErrorCodeAndSeverity(t *testing.T) {
+	tests := []struct {
+		err      error
+		code     string
+		severity Severity
+	}{
+		{&BrokenXRefError{XRef: "@F1@"}, CodeBrokenXRef, SeverityWarning},
+		{&MissingHeaderError{}, CodeMissingHeader, SeverityError},
+		{&MissingTrailerError{}, CodeMissingTrailer, SeverityError},
+		{&NonStandardTagError{Tag: "_X"}, CodeNonStandardTag, SeverityWarning},
+		{&UnknownRecordTypeError{Type: "_PLAC"}, CodeUnknownRecordType, SeverityWarning},
+		{&ExtensionError{Tag: "_X", Err: errors.New("bad")}, CodeExtension, SeverityWarning},
+		{&IncrementalDecodeError{}, CodeIncrementalDecode, SeverityError},
+		{&MemoryLimitError{}, CodeMemoryLimit, SeverityError},
+		{&parser.ParseError{Line: 3, Message: "bad"}, CodeParseError, SeverityError},
+		{fmt.Errorf("wrapped: %w", &BrokenXRefError{}), CodeBrokenXRef, SeverityWarning},
+		{errors.New("other"), CodeDecodeError, SeverityError},
+	}
+	for _, tt := range tests {
+		if got := ErrorCode(tt.err); got != tt.code {
+			t.Errorf("ErrorCode(%T) = %q, want %q", tt.err, got, tt.code)
+		}
+		if got := ErrorSeverity(tt.err); got != tt.severity {
+			t.Errorf("ErrorSeverity(%T) = %v, want %v", tt.err, got, tt.severity)
+		}
+	}
+
+	if SeverityError.String() != "ERROR" || SeverityWarning.String() != "WARNING" {
+		t.Error("Severity.String() mismatch")
+	}
+	var nilErrs *DecodeErrors
+	if nilErrs.ErrorsOnly() != nil || nilErrs.Warnings() != nil {
+		t.Error("nil DecodeErrors should have no errors or warnings")
+	}
+}
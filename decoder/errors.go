@@ -1,8 +1,109 @@
 package decoder
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
 
-// DecodeErrors collects multiple decode-related errors.
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+)
+
+// Severity ranks an error collected in DecodeErrors: SeverityError for an
+// error after which the document may be incomplete or misread, such as a
+// skipped malformed line, and SeverityWarning for a problem in a document
+// that was otherwise decoded in full, such as a broken cross-reference. It
+// is the severity the validator reports too; see gedcom.Severity.
+type Severity = gedcom.Severity
+
+// Severity levels of decode errors.
+const (
+	SeverityError   = gedcom.SeverityError
+	SeverityWarning = gedcom.SeverityWarning
+)
+
+// Error codes reported by ErrorCode.
+const (
+	// CodeParseError reports a malformed line, from the parser package.
+	CodeParseError = "PARSE_ERROR"
+
+	// CodeBrokenXRef reports a BrokenXRefError.
+	CodeBrokenXRef = "BROKEN_XREF"
+
+	// CodeMissingHeader reports a MissingHeaderError.
+	CodeMissingHeader = "MISSING_HEADER"
+
+	// CodeMissingTrailer reports a MissingTrailerError.
+	CodeMissingTrailer = "MISSING_TRAILER"
+
+	// CodeNonStandardTag reports a NonStandardTagError.
+	CodeNonStandardTag = "NON_STANDARD_TAG"
+
+	// CodeUnknownRecordType reports an UnknownRecordTypeError.
+	CodeUnknownRecordType = "UNKNOWN_RECORD_TYPE"
+
+	// CodeExtension reports an ExtensionError.
+	CodeExtension = "EXTENSION_ERROR"
+
+	// CodeIncrementalDecode reports an IncrementalDecodeError.
+	CodeIncrementalDecode = "INCREMENTAL_DECODE"
+
+	// CodeMemoryLimit reports a MemoryLimitError.
+	CodeMemoryLimit = "MEMORY_LIMIT"
+
+	// CodeDecodeError reports any other error.
+	CodeDecodeError = "DECODE_ERROR"
+)
+
+// classifiedError is implemented by the error types of this package.
+type classifiedError interface {
+	error
+	Code() string
+	Severity() Severity
+}
+
+// ErrorCode returns the code of err, such as CodeBrokenXRef. Errors from
+// the parser package report CodeParseError and unrecognized errors
+// CodeDecodeError.
+func ErrorCode(err error) string {
+	var c classifiedError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &c):
+		return c.Code()
+	case isParseError(err):
+		return CodeParseError
+	default:
+		return CodeDecodeError
+	}
+}
+
+// ErrorSeverity returns the severity of err. Errors from the parser package
+// and unrecognized errors are SeverityError.
+func ErrorSeverity(err error) Severity {
+	var c classifiedError
+	if errors.As(err, &c) {
+		return c.Severity()
+	}
+	return SeverityError
+}
+
+// isParseError reports whether err comes from the parser package.
+func isParseError(err error) bool {
+	var (
+		parseErr *parser.ParseError
+		tagErr   *parser.InvalidTagError
+		levelErr *parser.InvalidLevelError
+		jumpErr  *parser.LevelMismatchError
+		xrefErr  *parser.InvalidXRefError
+	)
+	return errors.As(err, &parseErr) || errors.As(err, &tagErr) || errors.As(err, &levelErr) ||
+		errors.As(err, &jumpErr) || errors.As(err, &xrefErr)
+}
+
+// DecodeErrors collects multiple decode-related errors. Each error has a
+// code and severity, reported by ErrorCode and ErrorSeverity; with
+// RecoverErrors, a document whose ErrorsOnly is empty was decoded in full.
 type DecodeErrors struct {
 	Errors []error
 }
@@ -21,6 +122,29 @@ func (e *DecodeErrors) Unwrap() []error {
 	return e.Errors
 }
 
+// ErrorsOnly returns the errors of SeverityError in order.
+func (e *DecodeErrors) ErrorsOnly() []error {
+	return e.bySeverity(SeverityError)
+}
+
+// Warnings returns the errors of SeverityWarning in order.
+func (e *DecodeErrors) Warnings() []error {
+	return e.bySeverity(SeverityWarning)
+}
+
+func (e *DecodeErrors) bySeverity(severity Severity) []error {
+	if e == nil {
+		return nil
+	}
+	var errs []error
+	for _, err := range e.Errors {
+		if ErrorSeverity(err) == severity {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
 // BrokenXRefError reports a missing cross-reference target.
 type BrokenXRefError struct {
 	XRef       string
@@ -43,6 +167,12 @@ func (e *BrokenXRefError) Error() string {
 	return fmt.Sprintf("line %d: broken reference %s in %s", e.Line, e.XRef, e.Tag)
 }
 
+// Code returns CodeBrokenXRef.
+func (e *BrokenXRefError) Code() string { return CodeBrokenXRef }
+
+// Severity returns SeverityWarning.
+func (e *BrokenXRefError) Severity() Severity { return SeverityWarning }
+
 // MissingHeaderError reports a missing HEAD record.
 type MissingHeaderError struct {
 	Line    int
@@ -56,6 +186,12 @@ func (e *MissingHeaderError) Error() string {
 	return fmt.Sprintf("line %d: missing HEAD record", e.Line)
 }
 
+// Code returns CodeMissingHeader.
+func (e *MissingHeaderError) Code() string { return CodeMissingHeader }
+
+// Severity returns SeverityError.
+func (e *MissingHeaderError) Severity() Severity { return SeverityError }
+
 // MissingTrailerError reports a missing TRLR record.
 type MissingTrailerError struct {
 	Line    int
@@ -69,6 +205,12 @@ func (e *MissingTrailerError) Error() string {
 	return fmt.Sprintf("line %d: missing TRLR record", e.Line)
 }
 
+// Code returns CodeMissingTrailer.
+func (e *MissingTrailerError) Code() string { return CodeMissingTrailer }
+
+// Severity returns SeverityError.
+func (e *MissingTrailerError) Severity() Severity { return SeverityError }
+
 // NonStandardTagError reports a custom tag when strict mode is enabled.
 type NonStandardTagError struct {
 	Line    int
@@ -82,3 +224,69 @@ func (e *NonStandardTagError) Error() string {
 	}
 	return fmt.Sprintf("line %d: non-standard tag %s", e.Line, e.Tag)
 }
+
+// Code returns CodeNonStandardTag.
+func (e *NonStandardTagError) Code() string { return CodeNonStandardTag }
+
+// Severity returns SeverityWarning.
+func (e *NonStandardTagError) Severity() Severity { return SeverityWarning }
+
+// IncrementalDecodeError reports an edit that Redecode cannot apply
+// incrementally; the full text must be decoded instead.
+type IncrementalDecodeError struct {
+	Reason string
+}
+
+func (e *IncrementalDecodeError) Error() string {
+	return fmt.Sprintf("incremental decode not possible: %s", e.Reason)
+}
+
+// Code returns CodeIncrementalDecode.
+func (e *IncrementalDecodeError) Code() string { return CodeIncrementalDecode }
+
+// Severity returns SeverityError.
+func (e *IncrementalDecodeError) Severity() Severity { return SeverityError }
+
+// UnknownRecordTypeError reports a level-0 record with a non-standard type when
+// DecodeOptions.UnknownRecords is UnknownRecordError.
+type UnknownRecordTypeError struct {
+	Line int
+	Type string
+	XRef string
+}
+
+func (e *UnknownRecordTypeError) Error() string {
+	if e.XRef != "" {
+		return fmt.Sprintf("line %d: unknown record type %s (record %s)", e.Line, e.Type, e.XRef)
+	}
+	return fmt.Sprintf("line %d: unknown record type %s", e.Line, e.Type)
+}
+
+// Code returns CodeUnknownRecordType.
+func (e *UnknownRecordTypeError) Code() string { return CodeUnknownRecordType }
+
+// Severity returns SeverityWarning.
+func (e *UnknownRecordTypeError) Severity() Severity { return SeverityWarning }
+
+// MemoryLimitError reports a decode aborted because the estimated memory of
+// the document exceeded DecodeOptions.MaxMemory.
+type MemoryLimitError struct {
+	// Line is the last line parsed before the decode was aborted
+	Line int
+
+	// Limit is the configured DecodeOptions.MaxMemory
+	Limit int64
+
+	// Estimated is the estimated memory of the lines parsed so far
+	Estimated int64
+}
+
+func (e *MemoryLimitError) Error() string {
+	return fmt.Sprintf("line %d: estimated document memory %d bytes exceeds limit of %d bytes", e.Line, e.Estimated, e.Limit)
+}
+
+// Code returns CodeMemoryLimit.
+func (e *MemoryLimitError) Code() string { return CodeMemoryLimit }
+
+// Severity returns SeverityError.
+func (e *MemoryLimitError) Severity() Severity { return SeverityError }
@@ -0,0 +1,113 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// ExtensionHandler builds a typed value from an extension tag. subtags are
+// the tag's subordinates, in document order. An error is reported as an
+// *ExtensionError and the value is dropped.
+type ExtensionHandler func(tag *gedcom.Tag, subtags []*gedcom.Tag) (interface{}, error)
+
+// ExtensionRegistry maps extension tags to the handlers that decode them
+// (see DecodeOptions.Extensions). The zero value is empty and ready to use.
+// A registry must not be modified while a decode is using it.
+type ExtensionRegistry struct {
+	handlers map[string]ExtensionHandler
+}
+
+// NewExtensionRegistry returns a registry with the handlers for the vendor
+// extensions this package knows how to type: _APID (*gedcom.AncestryAPID).
+// Callers can add or replace handlers with Register.
+func NewExtensionRegistry() *ExtensionRegistry {
+	r := &ExtensionRegistry{}
+	r.Register("_APID", func(tag *gedcom.Tag, _ []*gedcom.Tag) (interface{}, error) {
+		apid := gedcom.ParseAPID(tag.Value)
+		if apid == nil {
+			return nil, fmt.Errorf("invalid Ancestry APID %q", tag.Value)
+		}
+		return apid, nil
+	})
+	return r
+}
+
+// Register sets the handler for tag, replacing any previous handler. A nil
+// handler removes the tag.
+func (r *ExtensionRegistry) Register(tag string, handler ExtensionHandler) {
+	if handler == nil {
+		delete(r.handlers, tag)
+		return
+	}
+	if r.handlers == nil {
+		r.handlers = make(map[string]ExtensionHandler)
+	}
+	r.handlers[tag] = handler
+}
+
+// Handler returns the handler registered for tag.
+func (r *ExtensionRegistry) Handler(tag string) (ExtensionHandler, bool) {
+	if r == nil {
+		return nil, false
+	}
+	handler, ok := r.handlers[tag]
+	return handler, ok
+}
+
+// ExtensionError reports an extension tag its handler could not decode.
+type ExtensionError struct {
+	Line       int
+	Tag        string
+	RecordXRef string
+	Err        error
+}
+
+func (e *ExtensionError) Error() string {
+	if e.RecordXRef != "" {
+		return fmt.Sprintf("line %d: extension %s (record %s): %v", e.Line, e.Tag, e.RecordXRef, e.Err)
+	}
+	return fmt.Sprintf("line %d: extension %s: %v", e.Line, e.Tag, e.Err)
+}
+
+// Code returns CodeExtension.
+func (e *ExtensionError) Code() string { return CodeExtension }
+
+// Severity returns SeverityWarning: the extension's tags are kept in
+// Record.Tags.
+func (e *ExtensionError) Severity() Severity { return SeverityWarning }
+
+func (e *ExtensionError) Unwrap() error {
+	return e.Err
+}
+
+// applyExtensions runs the registered handlers over every record's tags and
+// stores the results in Record.Extensions.
+func applyExtensions(doc *gedcom.Document, registry *ExtensionRegistry) []error {
+	if registry == nil || len(registry.handlers) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, record := range doc.Records {
+		record.Extensions = nil
+		for i, tag := range record.Tags {
+			handler, ok := registry.handlers[tag.Tag]
+			if !ok {
+				continue
+			}
+			end := i + 1
+			for end < len(record.Tags) && record.Tags[end].Level > tag.Level {
+				end++
+			}
+			value, err := handler(tag, record.Tags[i+1:end])
+			if err != nil {
+				errs = append(errs, &ExtensionError{
+					Line: tag.LineNumber, Tag: tag.Tag, RecordXRef: record.XRef, Err: err,
+				})
+				continue
+			}
+			record.Extensions = append(record.Extensions, gedcom.Extension{Tag: tag.Tag, Value: value, Source: tag})
+		}
+	}
+	return errs
+}
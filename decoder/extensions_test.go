@@ -0,0 +1,161 @@
+package decoder
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+const extensionsTestGedcom = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 _MILT Army
+2 DATE 1918
+2 PLAC France
+1 BIRT
+2 SOUR @S1@
+3 _APID 1,7602::2771226
+1 _MILT Navy
+0 @S1@ SOUR
+1 TITL Census
+0 TRLR
+`
+
+type militaryService struct {
+	Branch string
+	Date   string
+	Place  string
+}
+
+func militaryHandler(tag *gedcom.Tag, subtags []*gedcom.Tag) (interface{}, error) {
+	service := &militaryService{Branch: tag.Value}
+	for _, sub := range subtags {
+		if sub.Level != tag.Level+1 {
+			continue
+		}
+		switch sub.Tag {
+		case "DATE":
+			service.Date = sub.Value
+		case "PLAC":
+			service.Place = sub.Value
+		}
+	}
+	return service, nil
+}
+
+func TestDecodeExtensions(t *testing.T) {
+	registry := NewExtensionRegistry()
+	registry.Register("_MILT", militaryHandler)
+
+	doc, err := DecodeWithOptions(strings.NewReader(extensionsTestGedcom), &DecodeOptions{Extensions: registry})
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+
+	record := doc.GetRecord("@I1@")
+	values := record.ExtensionValues("_MILT")
+	if len(values) != 2 {
+		t.Fatalf("len(ExtensionValues(_MILT)) = %d, want 2", len(values))
+	}
+	first, ok := values[0].(*militaryService)
+	if !ok {
+		t.Fatalf("_MILT value is %T, want *militaryService", values[0])
+	}
+	if *first != (militaryService{Branch: "Army", Date: "1918", Place: "France"}) {
+		t.Errorf("first _MILT = %+v", *first)
+	}
+	if second := values[1].(*militaryService); second.Branch != "Navy" || second.Date != "" {
+		t.Errorf("second _MILT = %+v", *second)
+	}
+
+	// The built-in _APID handler runs at any depth
+	value, ok := record.Extension("_APID")
+	if !ok {
+		t.Fatal("Extension(_APID) not found")
+	}
+	if apid, ok := value.(*gedcom.AncestryAPID); !ok || apid.Database != "7602" || apid.Record != "2771226" {
+		t.Errorf("_APID = %#v", value)
+	}
+	if len(record.Extensions) != 3 || record.Extensions[1].Source.LineNumber != 11 {
+		t.Errorf("Extensions = %+v, want 3 in document order", record.Extensions)
+	}
+
+	// Raw tags are kept
+	if len(record.FindAll("_MILT")) != 2 {
+		t.Error("raw _MILT tags were not kept")
+	}
+}
+
+func TestDecodeExtensionsWithoutRegistry(t *testing.T) {
+	doc, err := Decode(strings.NewReader(extensionsTestGedcom))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if ext := doc.GetRecord("@I1@").Extensions; ext != nil {
+		t.Errorf("Extensions = %+v, want nil", ext)
+	}
+}
+
+func TestDecodeExtensionsHandlerError(t *testing.T) {
+	errBadBranch := errors.New("bad branch")
+	registry := &ExtensionRegistry{}
+	registry.Register("_MILT", func(tag *gedcom.Tag, _ []*gedcom.Tag) (interface{}, error) {
+		if tag.Value == "Navy" {
+			return nil, errBadBranch
+		}
+		return tag.Value, nil
+	})
+
+	doc, err := DecodeWithOptions(strings.NewReader(extensionsTestGedcom), &DecodeOptions{Extensions: registry})
+	var extErr *ExtensionError
+	if !errors.As(err, &extErr) {
+		t.Fatalf("error = %v, want *ExtensionError", err)
+	}
+	if extErr.Line != 12 || extErr.Tag != "_MILT" || extErr.RecordXRef != "@I1@" || !errors.Is(err, errBadBranch) {
+		t.Errorf("ExtensionError = %+v", extErr)
+	}
+	if doc == nil {
+		t.Fatal("document not returned with extension errors")
+	}
+	if values := doc.GetRecord("@I1@").ExtensionValues("_MILT"); len(values) != 1 || values[0] != "Army" {
+		t.Errorf("ExtensionValues(_MILT) = %v, want [Army]", values)
+	}
+}
+
+func TestExtensionRegistry(t *testing.T) {
+	var nilRegistry *ExtensionRegistry
+	if _, ok := nilRegistry.Handler("_MILT"); ok {
+		t.Error("nil registry has a handler")
+	}
+
+	registry := NewExtensionRegistry()
+	if _, ok := registry.Handler("_APID"); !ok {
+		t.Error("NewExtensionRegistry() has no _APID handler")
+	}
+	registry.Register("_APID", nil)
+	if _, ok := registry.Handler("_APID"); ok {
+		t.Error("Register(nil) did not remove the handler")
+	}
+}
+
+func TestRedecodeExtensions(t *testing.T) {
+	registry := &ExtensionRegistry{}
+	registry.Register("_MILT", militaryHandler)
+	opts := &DecodeOptions{Extensions: registry}
+
+	doc, err := DecodeWithOptions(strings.NewReader(extensionsTestGedcom), opts)
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	if err := Redecode(doc, LineEdit{StartLine: 6, OldLines: 1, NewText: "1 _MILT Marines"}, opts); err != nil {
+		t.Fatalf("Redecode() error = %v", err)
+	}
+	value, _ := doc.GetRecord("@I1@").Extension("_MILT")
+	if service, ok := value.(*militaryService); !ok || service.Branch != "Marines" || service.Date != "1918" {
+		t.Errorf("_MILT after Redecode = %#v", value)
+	}
+}
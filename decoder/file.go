@@ -0,0 +1,60 @@
+package decoder
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+const (
+	minFileBuffer = 4 * 1024
+	maxFileBuffer = 1024 * 1024
+)
+
+// DecodeFile opens, decodes, and closes the GEDCOM file at path. opts may be
+// nil for defaults. Compressed files such as family.ged.gz or family.zip are
+// decompressed as by Decode, whatever their extension.
+func DecodeFile(path string, opts *DecodeOptions) (*gedcom.Document, error) {
+	f, err := os.Open(path) // #nosec G304 -- decoding a caller-chosen path is the purpose
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return decodeOpenFile(f, opts)
+}
+
+// DecodeFS is DecodeFile for a file in fsys, such as an embed.FS or
+// os.DirFS.
+func DecodeFS(fsys fs.FS, name string, opts *DecodeOptions) (*gedcom.Document, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return decodeOpenFile(f, opts)
+}
+
+// decodeOpenFile decodes f through a read buffer sized to the file.
+func decodeOpenFile(f fs.File, opts *DecodeOptions) (*gedcom.Document, error) {
+	size := int64(maxFileBuffer)
+	if info, err := f.Stat(); err == nil && info.Mode().IsRegular() {
+		size = info.Size()
+	}
+	return DecodeWithOptions(bufio.NewReaderSize(f, fileBufferSize(size)), opts)
+}
+
+// fileBufferSize returns the read buffer size for a file of size bytes: the
+// whole file when it is small, up to maxFileBuffer for large files, so large
+// files are read in few system calls without small files paying for a large
+// buffer.
+func fileBufferSize(size int64) int {
+	switch {
+	case size < minFileBuffer:
+		return minFileBuffer
+	case size > maxFileBuffer:
+		return maxFileBuffer
+	}
+	return int(size)
+}
@@ -0,0 +1,65 @@
+package decoder
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDecodeFile(t *testing.T) {
+	doc, err := DecodeFile("../testdata/gedcom-5.5/royal92.ged", nil)
+	if err != nil {
+		t.Fatalf("DecodeFile() error = %v", err)
+	}
+	if len(doc.Individuals()) == 0 {
+		t.Error("DecodeFile() decoded no individuals")
+	}
+
+	if _, err := DecodeFile(filepath.Join(t.TempDir(), "missing.ged"), nil); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("DecodeFile() of a missing file error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestDecodeFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"tree/family.ged":    {Data: []byte(compressedInput)},
+		"tree/family.ged.gz": {Data: gzipData(t, compressedInput)},
+		"tree/family.zip":    {Data: zipData(t, map[string]string{"family.ged": compressedInput})},
+	}
+	for _, name := range []string{"tree/family.ged", "tree/family.ged.gz", "tree/family.zip"} {
+		t.Run(name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.ValidateXRefs = true
+			doc, err := DecodeFS(fsys, name, opts)
+			if err != nil {
+				t.Fatalf("DecodeFS() error = %v", err)
+			}
+			if doc.GetIndividual("@I1@") == nil {
+				t.Error("DecodeFS() did not decode @I1@")
+			}
+		})
+	}
+
+	if _, err := DecodeFS(fsys, "tree/other.ged", nil); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("DecodeFS() of a missing file error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestFileBufferSize(t *testing.T) {
+	tests := []struct {
+		size int64
+		want int
+	}{
+		{0, minFileBuffer},
+		{100, minFileBuffer},
+		{64 * 1024, 64 * 1024},
+		{50 * 1024 * 1024, maxFileBuffer},
+	}
+	for _, tt := range tests {
+		if got := fileBufferSize(tt.size); got != tt.want {
+			t.Errorf("fileBufferSize(%d) = %d, want %d", tt.size, got, tt.want)
+		}
+	}
+}
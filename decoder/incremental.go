@@ -0,0 +1,276 @@
+package decoder
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cacack/gedcom-go/charset"
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+	"github.com/cacack/gedcom-go/tags"
+)
+
+// LineEdit describes a change to the source text of a decoded document:
+// OldLines lines starting at StartLine (1-based) were replaced by NewText,
+// which may span any number of lines, including none.
+type LineEdit struct {
+	// StartLine is the first replaced line, or the line before which NewText
+	// was inserted when OldLines is 0
+	StartLine int
+
+	// OldLines is the number of lines replaced
+	OldLines int
+
+	// NewText is the replacement text
+	NewText string
+}
+
+// Redecode applies edit to doc by re-parsing only the records whose lines it
+// touches and splicing the results into doc. The region goes through the same
+// stages as in DecodeWithOptions, so the edited lines get the warnings a full
+// decode would give them; warnings for other lines are kept, and line numbers
+// of later records, their tags, and Document.Warnings are shifted to match
+// the edited text.
+// The record and tag line numbers recorded by the previous decode serve as
+// the source map, so doc must be the result of decoding the text before the
+// edit, with any earlier edits applied through Redecode.
+//
+// Edits to the header or trailer, edits that leave lines outside any record,
+// documents with lines skipped by error recovery, and decoding with
+// CompatMode, RecoverErrors, or UnknownRecordSkip cannot be applied
+// incrementally; Redecode then returns an
+// *IncrementalDecodeError and leaves doc unchanged, and the full text must be
+// decoded again. A parse error in
+// the edited region also leaves doc unchanged. Validation errors requested
+// by opts are returned as *DecodeErrors after the edit is applied, as with
+// DecodeWithOptions.
+func Redecode(doc *gedcom.Document, edit LineEdit, opts *DecodeOptions) error {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if doc == nil {
+		return &IncrementalDecodeError{Reason: "no document"}
+	}
+	if opts.CompatMode || opts.RecoverErrors {
+		return &IncrementalDecodeError{Reason: "compatibility mode and error recovery require a full decode"}
+	}
+	if opts.UnknownRecords == UnknownRecordSkip {
+		return &IncrementalDecodeError{Reason: "skipped unknown records leave lines outside the records"}
+	}
+	if len(doc.Records) == 0 {
+		return &IncrementalDecodeError{Reason: "document has no records"}
+	}
+	if doc.DecodeReport.HasLoss() {
+		return &IncrementalDecodeError{Reason: "skipped lines leave lines outside the records"}
+	}
+	if edit.StartLine < 1 || edit.OldLines < 0 {
+		return &IncrementalDecodeError{Reason: fmt.Sprintf("invalid edit range: line %d, %d lines", edit.StartLine, edit.OldLines)}
+	}
+
+	newLines := splitLines(edit.NewText)
+	delta := len(newLines) - edit.OldLines
+	editEnd := edit.StartLine + edit.OldLines - 1 // last replaced line
+
+	// Replaced lines must lie within the records; the header and trailer
+	// are decoded separately
+	firstStart := doc.Records[0].LineNumber
+	lastEnd := recordEndLine(doc.Records[len(doc.Records)-1])
+	if edit.OldLines > 0 && (edit.StartLine < firstStart || editEnd > lastEnd) {
+		return &IncrementalDecodeError{Reason: "edit touches the header or trailer"}
+	}
+	if edit.StartLine < firstStart || edit.StartLine > lastEnd+1 {
+		return &IncrementalDecodeError{Reason: "edit inserts lines outside the records"}
+	}
+
+	// Affected records span the replaced lines plus the line before, so
+	// deleting a record's header line re-parses the record it merges into
+	first, last := -1, -1
+	for i, record := range doc.Records {
+		if record.LineNumber <= editEnd && recordEndLine(record) >= edit.StartLine-1 {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		// Insertion before the first record
+		first, last = 0, -1
+	}
+
+	// Rebuild the region's text from the affected records' untouched lines
+	// and the replacement text
+	regionStart, regionEnd := edit.StartLine, editEnd
+	old := make(map[int]string)
+	for _, record := range doc.Records[first : last+1] {
+		if record.LineNumber < regionStart {
+			regionStart = record.LineNumber
+		}
+		if end := recordEndLine(record); end > regionEnd {
+			regionEnd = end
+		}
+		line := parser.Line{XRef: record.XRef, Tag: string(record.Type), Value: record.Value}
+		old[record.LineNumber] = line.String()
+		for _, tag := range record.Tags {
+			line := parser.Line{Level: tag.Level, XRef: tag.XRef, Tag: tag.Tag, Value: tag.Value}
+			old[tag.LineNumber] = line.String()
+		}
+	}
+	var text strings.Builder
+	for n := regionStart; n <= regionEnd; n++ {
+		if n == edit.StartLine {
+			for _, line := range newLines {
+				text.WriteString(line)
+				text.WriteByte('\n')
+			}
+		}
+		if n >= edit.StartLine && n <= editEnd {
+			continue
+		}
+		line, ok := old[n]
+		if !ok {
+			return &IncrementalDecodeError{Reason: fmt.Sprintf("no source for line %d", n)}
+		}
+		text.WriteString(line)
+		text.WriteByte('\n')
+	}
+	if edit.StartLine > regionEnd {
+		// Insertion after the last affected line
+		for _, line := range newLines {
+			text.WriteString(line)
+			text.WriteByte('\n')
+		}
+	}
+
+	// Parse the region and number its lines and warnings as in the edited
+	// text
+	p := newParser(opts)
+	lines, err := p.Parse(charset.NewReader(strings.NewReader(text.String())))
+	if err != nil {
+		var parseErr *parser.ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Line += regionStart - 1
+		}
+		return err
+	}
+	lines, regionWarnings, report, _, err := processLines(p, lines, nil, opts)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		line.LineNumber += regionStart - 1
+	}
+	for i := range regionWarnings {
+		regionWarnings[i].Line += regionStart - 1
+	}
+	for _, line := range lines {
+		if line.Level == 0 && (tags.Tag(line.Tag) == tags.HEAD || tags.Tag(line.Tag) == tags.TRLR) {
+			return &IncrementalDecodeError{Reason: fmt.Sprintf("edit adds %s at line %d", line.Tag, line.LineNumber)}
+		}
+	}
+	if len(lines) > 0 && lines[0].Level != 0 {
+		return &IncrementalDecodeError{Reason: fmt.Sprintf("line %d does not belong to a record", lines[0].LineNumber)}
+	}
+
+	region := &gedcom.Document{XRefMap: make(map[string]*gedcom.Record)}
+	buildRecords(region, lines)
+	unknownErrs := applyUnknownRecordPolicy(region, opts.UnknownRecords)
+	if opts.LazyEntities {
+		deferEntities(region)
+	} else {
+		populateEntities(region)
+	}
+	extensionErrs := applyExtensions(region, opts.Extensions)
+
+	// Splice the new records in place of the affected ones
+	for _, record := range doc.Records[first : last+1] {
+		if record.XRef != "" && doc.XRefMap[record.XRef] == record {
+			delete(doc.XRefMap, record.XRef)
+		}
+	}
+	for _, record := range doc.Records[last+1:] {
+		shiftRecordLines(record, delta)
+	}
+	if doc.XRefMap == nil {
+		doc.XRefMap = make(map[string]*gedcom.Record)
+	}
+	for xref, record := range region.XRefMap {
+		doc.XRefMap[xref] = record
+	}
+	records := make([]*gedcom.Record, 0, len(doc.Records)-(last+1-first)+len(region.Records))
+	records = append(records, doc.Records[:first]...)
+	records = append(records, region.Records...)
+	records = append(records, doc.Records[last+1:]...)
+	doc.Records = records
+	if doc.Trailer != nil && doc.Trailer.LineNumber > 0 {
+		doc.Trailer.LineNumber += delta
+	}
+
+	// Replace the warnings for the replaced lines with those for the new
+	// ones and shift later warnings. Untouched lines of the region were
+	// rebuilt from their decoded values, so their repairs would not be found
+	// again; their earlier warnings stand.
+	var warnings []gedcom.Warning
+	for _, w := range doc.Warnings {
+		switch {
+		case w.Line >= edit.StartLine && w.Line <= editEnd:
+			continue
+		case w.Line > editEnd:
+			w.Line += delta
+		}
+		warnings = append(warnings, w)
+	}
+	for _, w := range regionWarnings {
+		if w.Line >= edit.StartLine && w.Line < edit.StartLine+len(newLines) {
+			warnings = append(warnings, w)
+		}
+	}
+	doc.Warnings = warnings
+	doc.DecodeReport = report
+
+	decodeErrs := append(unknownErrs, extensionErrs...)
+	if opts.StrictMode {
+		decodeErrs = append(decodeErrs, validateStrictTags(lines)...)
+	}
+	if opts.ValidateXRefs {
+		decodeErrs = append(decodeErrs, validateXRefs(doc)...)
+	}
+	if len(decodeErrs) > 0 {
+		return &DecodeErrors{Errors: decodeErrs}
+	}
+	return nil
+}
+
+// splitLines splits text into lines using GEDCOM line ending rules.
+func splitLines(text string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 64*1024), len(text)+1)
+	scanner.Split(parser.ScanGEDCOMLines)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+// recordEndLine returns the last source line of a record.
+func recordEndLine(record *gedcom.Record) int {
+	end := record.LineNumber
+	if n := len(record.Tags); n > 0 && record.Tags[n-1].LineNumber > end {
+		end = record.Tags[n-1].LineNumber
+	}
+	return end
+}
+
+// shiftRecordLines moves a record and its tags by delta lines.
+func shiftRecordLines(record *gedcom.Record, delta int) {
+	if delta == 0 {
+		return
+	}
+	record.LineNumber += delta
+	for _, tag := range record.Tags {
+		tag.LineNumber += delta
+	}
+}
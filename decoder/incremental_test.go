@@ -0,0 +1,253 @@
+package decoder
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+)
+
+const incrementalSource = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 FAMS @F1@
+0 @I2@ INDI
+1 NAME Jane /Doe/
+1 BIRT
+2 DATE 1 JAN 1900
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+0 TRLR
+`
+
+// applyLineEdit applies edit to src the way an editor would.
+func applyLineEdit(src string, edit LineEdit) string {
+	lines := strings.Split(strings.TrimSuffix(src, "\n"), "\n")
+	start := edit.StartLine - 1
+	var out []string
+	out = append(out, lines[:start]...)
+	if edit.NewText != "" {
+		out = append(out, strings.Split(strings.TrimSuffix(edit.NewText, "\n"), "\n")...)
+	}
+	out = append(out, lines[start+edit.OldLines:]...)
+	return strings.Join(out, "\n") + "\n"
+}
+
+func TestRedecode(t *testing.T) {
+	tests := []struct {
+		name string
+		edit LineEdit
+	}{
+		{"change a value", LineEdit{StartLine: 8, OldLines: 1, NewText: "1 NAME Janet /Doe/"}},
+		{"add subordinate lines", LineEdit{StartLine: 6, OldLines: 0, NewText: "1 SEX M\n1 OCCU Farmer\n"}},
+		{"insert a record", LineEdit{StartLine: 7, OldLines: 0, NewText: "0 @I3@ INDI\n1 NAME Bob /Smith/\n"}},
+		{"delete a record", LineEdit{StartLine: 7, OldLines: 4}},
+		{"delete a record header line", LineEdit{StartLine: 7, OldLines: 1}},
+		{"append before trailer", LineEdit{StartLine: 14, OldLines: 0, NewText: "0 @N1@ NOTE Hello\n"}},
+		{"insert before first record", LineEdit{StartLine: 4, OldLines: 0, NewText: "0 @S1@ SOUR\n1 TITL Census\n"}},
+		{"replace across records", LineEdit{StartLine: 5, OldLines: 4, NewText: "1 NAME Jack /Smith/\n0 @I2@ INDI\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Decode(strings.NewReader(incrementalSource))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if err := Redecode(doc, tt.edit, nil); err != nil {
+				t.Fatalf("Redecode() error = %v", err)
+			}
+
+			want, err := Decode(strings.NewReader(applyLineEdit(incrementalSource, tt.edit)))
+			if err != nil {
+				t.Fatalf("Decode() of edited text error = %v", err)
+			}
+			if !reflect.DeepEqual(doc.Records, want.Records) {
+				t.Errorf("Records differ from a full decode")
+				for i, r := range doc.Records {
+					t.Logf("got[%d] = %s %s line %d", i, r.XRef, r.Type, r.LineNumber)
+				}
+				for i, r := range want.Records {
+					t.Logf("want[%d] = %s %s line %d", i, r.XRef, r.Type, r.LineNumber)
+				}
+			}
+			if !reflect.DeepEqual(doc.XRefMap, want.XRefMap) {
+				t.Errorf("XRefMap differs from a full decode")
+			}
+		})
+	}
+}
+
+func TestRedecodeRequiresFullDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		edit LineEdit
+		opts *DecodeOptions
+	}{
+		{"header edit", LineEdit{StartLine: 3, OldLines: 1, NewText: "2 VERS 7.0"}, nil},
+		{"trailer edit", LineEdit{StartLine: 14, OldLines: 1}, nil},
+		{"adds trailer", LineEdit{StartLine: 12, OldLines: 0, NewText: "0 TRLR"}, nil},
+		{"orphan lines", LineEdit{StartLine: 4, OldLines: 0, NewText: "1 NOTE stray"}, nil},
+		{"invalid range", LineEdit{StartLine: 0}, nil},
+		{"compat mode", LineEdit{StartLine: 8, OldLines: 1, NewText: "1 NAME Janet /Doe/"}, &DecodeOptions{CompatMode: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Decode(strings.NewReader(incrementalSource))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			before := len(doc.Records)
+
+			var incErr *IncrementalDecodeError
+			if err := Redecode(doc, tt.edit, tt.opts); !errors.As(err, &incErr) {
+				t.Fatalf("Redecode() error = %v, want IncrementalDecodeError", err)
+			}
+			if len(doc.Records) != before {
+				t.Errorf("document modified: %d records, want %d", len(doc.Records), before)
+			}
+		})
+	}
+
+	var incErr *IncrementalDecodeError
+	if err := Redecode(nil, LineEdit{StartLine: 1}, nil); !errors.As(err, &incErr) {
+		t.Errorf("Redecode(nil) error = %v, want IncrementalDecodeError", err)
+	}
+}
+
+func TestRedecodeParseError(t *testing.T) {
+	doc, err := Decode(strings.NewReader(incrementalSource))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	err = Redecode(doc, LineEdit{StartLine: 8, OldLines: 1, NewText: "X NAME"}, nil)
+	var parseErr *parser.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 8 {
+		t.Fatalf("Redecode() error = %v, want parse error on line 8", err)
+	}
+	if name := doc.GetIndividual("@I2@").Names[0].Full; name != "Jane /Doe/" {
+		t.Errorf("name = %q, want document unchanged", name)
+	}
+}
+
+func TestRedecodeValidatesXRefs(t *testing.T) {
+	doc, err := Decode(strings.NewReader(incrementalSource))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	opts := DefaultOptions()
+	opts.ValidateXRefs = true
+	err = Redecode(doc, LineEdit{StartLine: 13, OldLines: 1, NewText: "1 WIFE @I9@"}, opts)
+
+	var broken *BrokenXRefError
+	if !errors.As(err, &broken) || broken.XRef != "@I9@" || broken.Line != 13 {
+		t.Errorf("Redecode() error = %v, want broken reference @I9@ on line 13", err)
+	}
+	if fam := doc.GetFamily("@F1@"); fam == nil || fam.Wife != "@I9@" {
+		t.Errorf("family = %+v, want edit applied", fam)
+	}
+}
+
+// warningSource has lines that draw warnings from each stage of the decode:
+// a lowercase tag, a sanitized value, and unknown tags.
+const warningSource = "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n" +
+	"0 @I1@ INDI\n1 name John /Smith/\n1 FOOB x\n" +
+	"0 @I2@ INDI\n1 NAME Jane\u200b /Doe/\n1 BIRT\n2 DATE 1 JAN 1900\n" +
+	"0 TRLR\n"
+
+func TestRedecodeMatchesFullDecodeWarnings(t *testing.T) {
+	opts := DefaultOptions()
+	opts.SanitizeText = true
+	opts.TagRules = parser.TagRulesPermissive
+
+	tests := []struct {
+		name string
+		edit LineEdit
+	}{
+		{"fix a sanitized value", LineEdit{StartLine: 8, OldLines: 1, NewText: "1 NAME Jane /Doe/"}},
+		{"add warned lines", LineEdit{StartLine: 9, OldLines: 0, NewText: "1 occu Farmer\n1 BARZ y\n1 NOTE a\u200bb\n"}},
+		{"edit next to warned lines", LineEdit{StartLine: 5, OldLines: 0, NewText: "1 SEX M\n"}},
+		{"delete warned lines", LineEdit{StartLine: 5, OldLines: 2}},
+		{"delete a record", LineEdit{StartLine: 4, OldLines: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := DecodeWithOptions(strings.NewReader(warningSource), opts)
+			if err != nil {
+				t.Fatalf("DecodeWithOptions() error = %v", err)
+			}
+			if err := Redecode(doc, tt.edit, opts); err != nil {
+				t.Fatalf("Redecode() error = %v", err)
+			}
+
+			want, err := DecodeWithOptions(strings.NewReader(applyLineEdit(warningSource, tt.edit)), opts)
+			if err != nil {
+				t.Fatalf("DecodeWithOptions() of edited text error = %v", err)
+			}
+			if !reflect.DeepEqual(doc.Records, want.Records) {
+				t.Errorf("Records differ from a full decode")
+			}
+			got, wantWarnings := sortedWarnings(doc.Warnings), sortedWarnings(want.Warnings)
+			if !reflect.DeepEqual(got, wantWarnings) {
+				t.Errorf("Warnings = %v, want %v", got, wantWarnings)
+			}
+			if !reflect.DeepEqual(doc.DecodeReport, want.DecodeReport) {
+				t.Errorf("DecodeReport = %+v, want %+v", doc.DecodeReport, want.DecodeReport)
+			}
+		})
+	}
+}
+
+func TestRedecodeReplacesDecodeReport(t *testing.T) {
+	opts := DefaultOptions()
+	opts.RecoverErrors = true
+	doc, err := DecodeWithOptions(strings.NewReader(incrementalSource), opts)
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	if doc.DecodeReport == nil {
+		t.Fatal("DecodeReport = nil, want report from recovery")
+	}
+	if err := Redecode(doc, LineEdit{StartLine: 8, OldLines: 1, NewText: "1 NAME Janet /Doe/"}, nil); err != nil {
+		t.Fatalf("Redecode() error = %v", err)
+	}
+	if doc.DecodeReport != nil {
+		t.Errorf("DecodeReport = %+v, want nil as from a full decode without recovery", doc.DecodeReport)
+	}
+
+	lossy, err := DecodeWithOptions(strings.NewReader(strings.Replace(incrementalSource, "1 BIRT\n", "X BIRT\n", 1)), opts)
+	if err == nil || !lossy.DecodeReport.HasLoss() {
+		t.Fatalf("DecodeWithOptions() = %v, want recovered lines", err)
+	}
+	var incErr *IncrementalDecodeError
+	if err := Redecode(lossy, LineEdit{StartLine: 5, OldLines: 1, NewText: "1 NAME Jack /Smith/"}, nil); !errors.As(err, &incErr) {
+		t.Errorf("Redecode() error = %v, want IncrementalDecodeError", err)
+	}
+}
+
+// sortedWarnings returns warnings ordered by line, code, and message.
+func sortedWarnings(warnings []gedcom.Warning) []gedcom.Warning {
+	sorted := append([]gedcom.Warning(nil), warnings...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Code != b.Code {
+			return a.Code < b.Code
+		}
+		return a.Message < b.Message
+	})
+	return sorted
+}
@@ -0,0 +1,242 @@
+package decoder
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cacack/gedcom-go/charset"
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+	"github.com/cacack/gedcom-go/tags"
+)
+
+// DefaultIndexCacheSize is the number of materialized records an Index keeps
+// when IndexOptions.CacheSize is zero.
+const DefaultIndexCacheSize = 1024
+
+// IndexOptions configures an Index.
+type IndexOptions struct {
+	// CacheSize is the maximum number of materialized records kept in the
+	// LRU cache (default: DefaultIndexCacheSize). A negative value disables
+	// caching, so every lookup parses the record again.
+	CacheSize int
+
+	// MaxNestingDepth sets the maximum allowed nesting depth (default: 100)
+	MaxNestingDepth int
+}
+
+// indexEntry locates a record's bytes in the source.
+type indexEntry struct {
+	offset int64
+	length int64
+	line   int
+}
+
+// Index decodes records on demand from a GEDCOM source that stays on disk.
+// NewIndex scans the source once, recording the byte offset of every record
+// with an XRef; Record and the typed lookups parse a record's bytes only when
+// it is requested. Materialized records are kept in a bounded LRU cache so
+// repeated traversals, such as ancestor walks, do not re-parse the same bytes.
+//
+// Records returned by an Index are shared between callers and must be treated
+// as read-only. Pointers are not resolved across records; use the XRefs in the
+// returned entities to look up related records. An Index is safe for
+// concurrent use.
+type Index struct {
+	r       io.ReaderAt
+	entries map[string]indexEntry
+	xrefs   []string
+	depth   int
+	enc     charset.Encoding
+	cache   *recordCache
+}
+
+// NewIndex scans size bytes of r and returns an Index of its records.
+// The source must be UTF-8 or ASCII; files declaring another character set
+// must be decoded with Decode instead. ASCII sources are read the way Decode
+// reads them, as UTF-8 with Windows-1252 for bytes that are not valid UTF-8. Every line is checked for syntax
+// during the scan, so a malformed file fails here rather than on lookup.
+func NewIndex(r io.ReaderAt, size int64, opts *IndexOptions) (*Index, error) {
+	if opts == nil {
+		opts = &IndexOptions{}
+	}
+	cacheSize := opts.CacheSize
+	if cacheSize == 0 {
+		cacheSize = DefaultIndexCacheSize
+	}
+	depth := opts.MaxNestingDepth
+	if depth == 0 {
+		depth = 100
+	}
+
+	// Skip a UTF-8 BOM; other encodings cannot be read at byte offsets
+	var start int64
+	bom := make([]byte, 3)
+	n, err := r.ReadAt(bom, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	switch {
+	case n >= 3 && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}):
+		start = 3
+	case n >= 2 && (bytes.Equal(bom[:2], []byte{0xFF, 0xFE}) || bytes.Equal(bom[:2], []byte{0xFE, 0xFF})):
+		return nil, fmt.Errorf("index: UTF-16 sources are not supported")
+	}
+
+	idx := &Index{
+		r:       r,
+		entries: make(map[string]indexEntry),
+		depth:   depth,
+		enc:     charset.EncodingUTF8,
+		cache:   newRecordCache(cacheSize),
+	}
+
+	// Track the byte offset of each line as the scanner consumes it
+	offset := start
+	var lineStart int64
+	scanner := bufio.NewScanner(io.NewSectionReader(r, start, size-start))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := parser.ScanGEDCOMLines(data, atEOF)
+		if token != nil {
+			lineStart = offset
+		}
+		offset += int64(advance)
+		return advance, token, err
+	})
+
+	p := parser.NewParser()
+	p.SetMaxNestingDepth(depth)
+	var (
+		current string
+		entry   indexEntry
+		inHead  bool
+	)
+	closeRecord := func(end int64) {
+		if current != "" {
+			entry.length = end - entry.offset
+			idx.entries[current] = entry
+			idx.xrefs = append(idx.xrefs, current)
+		}
+		current = ""
+	}
+	for scanner.Scan() {
+		line, err := p.ParseLine(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		if line.Level != 0 {
+			if inHead && line.Level == 1 && line.Tag == "CHAR" {
+				enc, err := checkIndexCharset(line.Value)
+				if err != nil {
+					return nil, err
+				}
+				idx.enc = enc
+			}
+			continue
+		}
+		closeRecord(lineStart)
+		inHead = tags.Tag(line.Tag) == tags.HEAD
+		if line.XRef != "" && tags.Tag(line.Tag) != tags.HEAD && tags.Tag(line.Tag) != tags.TRLR {
+			if _, dup := idx.entries[line.XRef]; !dup {
+				current = line.XRef
+				entry = indexEntry{offset: lineStart, line: line.LineNumber}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	closeRecord(offset)
+
+	return idx, nil
+}
+
+// checkIndexCharset returns the encoding of a CHAR value, rejecting character
+// sets whose bytes cannot be read at record offsets.
+func checkIndexCharset(value string) (charset.Encoding, error) {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "UTF-8", "UTF8", "":
+		return charset.EncodingUTF8, nil
+	case "ASCII":
+		return charset.EncodingASCII, nil
+	default:
+		return charset.EncodingUnknown, fmt.Errorf("index: character set %q is not supported", value)
+	}
+}
+
+// Len returns the number of indexed records.
+func (idx *Index) Len() int {
+	return len(idx.xrefs)
+}
+
+// XRefs returns the XRefs of the indexed records in file order.
+func (idx *Index) XRefs() []string {
+	return append([]string(nil), idx.xrefs...)
+}
+
+// Record returns the record with the given XRef, parsing it from the source
+// unless it is cached. Returns nil and no error if the XRef is not indexed.
+// When the same XRef is defined more than once, the first definition wins.
+func (idx *Index) Record(xref string) (*gedcom.Record, error) {
+	entry, ok := idx.entries[xref]
+	if !ok {
+		return nil, nil
+	}
+	if record, ok := idx.cache.get(xref); ok {
+		return record, nil
+	}
+
+	buf := make([]byte, entry.length)
+	if _, err := idx.r.ReadAt(buf, entry.offset); err != nil && err != io.EOF {
+		return nil, err
+	}
+	p := parser.NewParser()
+	p.SetMaxNestingDepth(idx.depth)
+	lines, err := p.Parse(charset.NewReaderWithEncoding(bytes.NewReader(buf), idx.enc))
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		line.LineNumber += entry.line - 1
+	}
+
+	doc := &gedcom.Document{XRefMap: make(map[string]*gedcom.Record)}
+	buildRecords(doc, lines)
+	if len(doc.Records) == 0 {
+		return nil, fmt.Errorf("index: no record at offset %d for %s", entry.offset, xref)
+	}
+	record := doc.Records[0]
+	populateEntity(record)
+	return idx.cache.put(xref, record), nil
+}
+
+// Individual returns the individual with the given XRef, or nil if the XRef
+// is not indexed or is not an individual.
+func (idx *Index) Individual(xref string) (*gedcom.Individual, error) {
+	record, err := idx.Record(xref)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	indi, _ := record.GetIndividual()
+	return indi, nil
+}
+
+// Family returns the family with the given XRef, or nil if the XRef is not
+// indexed or is not a family.
+func (idx *Index) Family(xref string) (*gedcom.Family, error) {
+	record, err := idx.Record(xref)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	fam, _ := record.GetFamily()
+	return fam, nil
+}
+
+// CacheStats returns the hit, miss, and occupancy counters of the record cache.
+func (idx *Index) CacheStats() CacheStats {
+	return idx.cache.stats()
+}
@@ -0,0 +1,184 @@
+package decoder
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const indexSource = "0 HEAD\r\n1 GEDC\r\n2 VERS 5.5.1\r\n1 CHAR UTF-8\r\n" +
+	"0 @I1@ INDI\r\n1 NAME John /Smith/\r\n1 FAMC @F1@\r\n" +
+	"0 @I2@ INDI\r\n1 NAME Robert /Smith/\r\n1 FAMS @F1@\r\n1 BIRT\r\n2 DATE 1 JAN 1870\r\n" +
+	"0 @F1@ FAM\r\n1 HUSB @I2@\r\n1 CHIL @I1@\r\n" +
+	"0 TRLR\r\n"
+
+func TestIndexMatchesDecode(t *testing.T) {
+	idx, err := NewIndex(strings.NewReader(indexSource), int64(len(indexSource)), nil)
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+	doc, err := Decode(strings.NewReader(indexSource))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if got, want := idx.XRefs(), []string{"@I1@", "@I2@", "@F1@"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("XRefs() = %v, want %v", got, want)
+	}
+	for _, xref := range idx.XRefs() {
+		record, err := idx.Record(xref)
+		if err != nil {
+			t.Fatalf("Record(%s) error = %v", xref, err)
+		}
+		if !reflect.DeepEqual(record, doc.GetRecord(xref)) {
+			t.Errorf("Record(%s) differs from a full decode", xref)
+		}
+	}
+
+	if record, err := idx.Record("@X9@"); record != nil || err != nil {
+		t.Errorf("Record(@X9@) = %v, %v, want nil, nil", record, err)
+	}
+}
+
+func TestIndexAncestorWalkUsesCache(t *testing.T) {
+	idx, err := NewIndex(strings.NewReader(indexSource), int64(len(indexSource)), nil)
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		child, err := idx.Individual("@I1@")
+		if err != nil || child == nil {
+			t.Fatalf("Individual(@I1@) = %v, %v", child, err)
+		}
+		fam, err := idx.Family(child.ChildInFamilies[0].FamilyXRef)
+		if err != nil || fam == nil {
+			t.Fatalf("Family() = %v, %v", fam, err)
+		}
+		father, err := idx.Individual(fam.Husband)
+		if err != nil || father == nil || father.Names[0].Full != "Robert /Smith/" {
+			t.Fatalf("Individual(%s) = %v, %v", fam.Husband, father, err)
+		}
+	}
+
+	stats := idx.CacheStats()
+	if stats.Misses != 3 || stats.Hits != 6 || stats.Len != 3 {
+		t.Errorf("CacheStats() = %+v, want 3 misses, 6 hits, 3 cached", stats)
+	}
+}
+
+func TestIndexCacheEviction(t *testing.T) {
+	idx, err := NewIndex(strings.NewReader(indexSource), int64(len(indexSource)), &IndexOptions{CacheSize: 2})
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+
+	first, _ := idx.Record("@I1@")
+	_, _ = idx.Record("@I2@")
+	if again, _ := idx.Record("@I1@"); again != first {
+		t.Error("cached record not reused")
+	}
+	_, _ = idx.Record("@F1@") // evicts @I2@, the least recently used
+	if stats := idx.CacheStats(); stats.Len != 2 || stats.Size != 2 {
+		t.Errorf("CacheStats() = %+v, want 2 of 2 cached", stats)
+	}
+	_, _ = idx.Record("@I1@")
+	_, _ = idx.Record("@I2@")
+	if stats := idx.CacheStats(); stats.Hits != 2 || stats.Misses != 4 {
+		t.Errorf("CacheStats() = %+v, want 2 hits, 4 misses", stats)
+	}
+
+	uncached, err := NewIndex(strings.NewReader(indexSource), int64(len(indexSource)), &IndexOptions{CacheSize: -1})
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+	a, _ := uncached.Record("@I1@")
+	b, _ := uncached.Record("@I1@")
+	if a == b || !reflect.DeepEqual(a, b) {
+		t.Error("uncached index should parse a fresh, equal record on each lookup")
+	}
+}
+
+func TestIndexConcurrentLookups(t *testing.T) {
+	idx, err := NewIndex(strings.NewReader(indexSource), int64(len(indexSource)), &IndexOptions{CacheSize: 1})
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				for _, xref := range idx.XRefs() {
+					if record, err := idx.Record(xref); err != nil || record == nil || record.XRef != xref {
+						t.Errorf("Record(%s) = %v, %v", xref, record, err)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestIndexRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"transcoded charset", "0 HEAD\n1 CHAR ANSEL\n0 @I1@ INDI\n0 TRLR\n"},
+		{"UTF-16", "\xFF\xFE0\x00"},
+		{"malformed line", "0 HEAD\n0 @I1@ INDI\n3 NAME Bad\n0 TRLR\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewIndex(strings.NewReader(tt.input), int64(len(tt.input)), nil); err == nil {
+				t.Error("NewIndex() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestIndexSkipsBOM(t *testing.T) {
+	input := "\xEF\xBB\xBF" + indexSource
+	idx, err := NewIndex(strings.NewReader(input), int64(len(input)), nil)
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+	record, err := idx.Record("@I1@")
+	if err != nil || record == nil || record.LineNumber != 5 {
+		t.Errorf("Record(@I1@) = %+v, %v, want record on line 5", record, err)
+	}
+}
+
+func TestIndexASCIIMatchesDecode(t *testing.T) {
+	input := "0 HEAD\n1 CHAR ASCII\n" +
+		"0 @I1@ INDI\n1 NAME José /Müller/\n" +
+		"0 @I2@ INDI\n1 NAME R\xe9ne /Dubois/\n" +
+		"0 TRLR\n"
+	idx, err := NewIndex(strings.NewReader(input), int64(len(input)), nil)
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	for xref, want := range map[string]string{"@I1@": "José /Müller/", "@I2@": "Réne /Dubois/"} {
+		record, err := idx.Record(xref)
+		if err != nil {
+			t.Fatalf("Record(%s) error = %v", xref, err)
+		}
+		if !reflect.DeepEqual(record, doc.GetRecord(xref)) {
+			t.Errorf("Record(%s) differs from a full decode", xref)
+		}
+		if got := record.Tags[0].Value; got != want {
+			t.Errorf("Record(%s) NAME = %q, want %q", xref, got, want)
+		}
+	}
+}
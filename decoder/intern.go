@@ -0,0 +1,49 @@
+package decoder
+
+import (
+	"strings"
+
+	"github.com/cacack/gedcom-go/parser"
+	"github.com/cacack/gedcom-go/tags"
+)
+
+// internedValues are the tags whose values InternStrings shares: places and
+// their address parts, and surnames, which repeat across thousands of lines
+// in large files.
+var internedValues = map[tags.Tag]bool{
+	tags.PLAC: true,
+	tags.CITY: true,
+	tags.STAE: true,
+	tags.CTRY: true,
+	tags.SURN: true,
+}
+
+// stringInterner replaces repeated strings with one shared copy.
+type stringInterner struct {
+	strings map[string]string
+}
+
+func newStringInterner() *stringInterner {
+	return &stringInterner{strings: make(map[string]string)}
+}
+
+// intern returns the shared copy of s. The first occurrence is cloned, so
+// the shared copy does not keep the line it was read from in memory.
+func (in *stringInterner) intern(s string) string {
+	if shared, ok := in.strings[s]; ok {
+		return shared
+	}
+	s = strings.Clone(s)
+	in.strings[s] = s
+	return s
+}
+
+// lineParsed interns the line's tag and, for the tags in internedValues, its
+// value; it is installed as the parser's line hook. A line whose strings are
+// all interned no longer keeps its input text alive.
+func (in *stringInterner) lineParsed(line *parser.Line) {
+	line.Tag = in.intern(line.Tag)
+	if internedValues[tags.Tag(line.Tag)] {
+		line.Value = in.intern(line.Value)
+	}
+}
@@ -0,0 +1,45 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestDecodeInternStrings(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n" +
+		"0 @I1@ INDI\n1 NAME John /Smith/\n2 SURN Smith\n1 BIRT\n2 PLAC Boston, Suffolk, Massachusetts, USA\n" +
+		"0 @I2@ INDI\n1 NAME Mary /Smith/\n2 SURN Smith\n1 BIRT\n2 PLAC Boston, Suffolk, Massachusetts, USA\n" +
+		"0 TRLR\n"
+
+	for _, intern := range []bool{false, true} {
+		opts := DefaultOptions()
+		opts.InternStrings = intern
+		doc, err := DecodeWithOptions(strings.NewReader(input), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		john, mary := doc.GetIndividual("@I1@"), doc.GetIndividual("@I2@")
+		if john.Events[0].Place != "Boston, Suffolk, Massachusetts, USA" || mary.Names[0].Surname != "Smith" {
+			t.Fatalf("InternStrings=%v changed values: %q, %q", intern, john.Events[0].Place, mary.Names[0].Surname)
+		}
+
+		samePlace := unsafe.StringData(john.Events[0].Place) == unsafe.StringData(mary.Events[0].Place)
+		sameTag := unsafe.StringData(doc.GetRecord("@I1@").Tags[2].Tag) == unsafe.StringData(doc.GetRecord("@I2@").Tags[2].Tag)
+		if samePlace != intern || sameTag != intern {
+			t.Errorf("InternStrings=%v: shared place = %v, shared tag = %v", intern, samePlace, sameTag)
+		}
+	}
+}
+
+func TestStringInternerClones(t *testing.T) {
+	line := "2 PLAC Boston"
+	in := newStringInterner()
+	got := in.intern(line[7:])
+	if got != "Boston" || unsafe.StringData(got) == unsafe.StringData(line[7:]) {
+		t.Errorf("intern() = %q sharing the input line, want a copy", got)
+	}
+	if again := in.intern(strings.Clone("Boston")); unsafe.StringData(again) != unsafe.StringData(got) {
+		t.Error("intern() of an equal string returned a different copy")
+	}
+}
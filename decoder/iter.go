@@ -0,0 +1,33 @@
+//go:build go1.23
+
+package decoder
+
+import (
+	"io"
+	"iter"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// Records returns an iterator that decodes r one record at a time, so a file
+// can be processed without holding the whole document in memory:
+//
+//	for record, err := range decoder.Records(f) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if indi, ok := record.GetIndividual(); ok {
+//	        fmt.Println(indi.XRef)
+//	    }
+//	}
+//
+// Gzip and zip input is decompressed as by Decode. Each record has its
+// Entity populated. The header and trailer are not
+// returned, and document-wide steps such as cross-reference validation and
+// vendor compatibility fixes are not applied. A parse error is yielded with
+// a nil record and ends the iteration.
+func Records(r io.Reader) iter.Seq2[*gedcom.Record, error] {
+	return func(yield func(*gedcom.Record, error) bool) {
+		streamRecords(r, yield)
+	}
+}
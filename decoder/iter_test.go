@@ -0,0 +1,72 @@
+//go:build go1.23
+
+package decoder
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecords(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n" +
+		"0 @I1@ INDI\n1 NAME John /Smith/\n1 BIRT\n2 DATE 1900\n" +
+		"0 @F1@ FAM\n1 HUSB @I1@\n" +
+		"0 @N1@ NOTE Shared note\n" +
+		"0 TRLR\n"
+
+	var xrefs []string
+	for record, err := range Records(strings.NewReader(input)) {
+		if err != nil {
+			t.Fatalf("Records() error = %v", err)
+		}
+		xrefs = append(xrefs, record.XRef)
+		if record.Entity == nil {
+			t.Errorf("%s has no entity", record.XRef)
+		}
+	}
+	if want := []string{"@I1@", "@F1@", "@N1@"}; !reflect.DeepEqual(xrefs, want) {
+		t.Errorf("Records() = %v, want %v", xrefs, want)
+	}
+
+	// Stopping early does not read further records
+	n := 0
+	for range Records(strings.NewReader(input)) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("iterations after break = %d, want 1", n)
+	}
+}
+
+func TestRecordsError(t *testing.T) {
+	input := "0 HEAD\n0 @I1@ INDI\n1 NAME John /Smith/\nnot a line\n0 TRLR\n"
+
+	var errs int
+	for record, err := range Records(strings.NewReader(input)) {
+		if err != nil {
+			errs++
+			if record != nil {
+				t.Errorf("record = %v with error, want nil", record)
+			}
+		}
+	}
+	if errs != 1 {
+		t.Errorf("errors = %d, want 1", errs)
+	}
+}
+
+func TestRecordsCompressed(t *testing.T) {
+	var xrefs []string
+	for record, err := range Records(bytes.NewReader(gzipData(t, compressedInput))) {
+		if err != nil {
+			t.Fatalf("Records() error = %v", err)
+		}
+		xrefs = append(xrefs, record.XRef)
+	}
+	if want := []string{"@I1@"}; !reflect.DeepEqual(xrefs, want) {
+		t.Errorf("Records() = %v, want %v", xrefs, want)
+	}
+}
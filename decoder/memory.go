@@ -0,0 +1,64 @@
+package decoder
+
+import (
+	"io"
+
+	"github.com/cacack/gedcom-go/parser"
+)
+
+// lineMemoryOverhead is the estimated memory a decoded line costs apart from
+// its text: the parsed line, the record tag built from it, and its share of
+// the entity. With twice the text length for the copies of its strings, it
+// approximates the retained heap of decoding the royal92 and pres2020
+// corpora in testdata (about 190 to 280 bytes per line).
+const lineMemoryOverhead = 200
+
+// memoryWatchdog estimates the memory of the document being decoded and
+// stops the decode once it exceeds the limit.
+type memoryWatchdog struct {
+	limit     int64
+	estimated int64
+	line      int
+}
+
+// newMemoryWatchdog returns a watchdog for opts, or nil if opts sets no
+// MaxMemory.
+func newMemoryWatchdog(opts *DecodeOptions) *memoryWatchdog {
+	if opts.MaxMemory <= 0 {
+		return nil
+	}
+	return &memoryWatchdog{limit: opts.MaxMemory}
+}
+
+// lineParsed adds a parsed line to the estimate; it is installed as the
+// parser's line hook.
+func (m *memoryWatchdog) lineParsed(line *parser.Line) {
+	m.estimated += lineMemoryOverhead + 2*int64(len(line.Tag)+len(line.Value)+len(line.XRef))
+	m.line = line.LineNumber
+}
+
+// err returns a *MemoryLimitError if the estimate exceeds the limit.
+func (m *memoryWatchdog) err() error {
+	if m.estimated <= m.limit {
+		return nil
+	}
+	return &MemoryLimitError{Line: m.line, Limit: m.limit, Estimated: m.estimated}
+}
+
+// wrap returns a reader that fails with the watchdog's error once the limit
+// is exceeded, so the parser stops reading within a buffer of the limit.
+func (m *memoryWatchdog) wrap(r io.Reader) io.Reader {
+	return &watchedReader{r: r, m: m}
+}
+
+type watchedReader struct {
+	r io.Reader
+	m *memoryWatchdog
+}
+
+func (w *watchedReader) Read(p []byte) (int, error) {
+	if err := w.m.err(); err != nil {
+		return 0, err
+	}
+	return w.r.Read(p)
+}
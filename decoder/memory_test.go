@@ -0,0 +1,48 @@
+package decoder
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestDecodeMaxMemory(t *testing.T) {
+	data, err := os.ReadFile("../testdata/gedcom-5.5/royal92.ged")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, recoverErrs := range []bool{false, true} {
+		opts := DefaultOptions()
+		opts.MaxMemory = 1 << 20
+		opts.RecoverErrors = recoverErrs
+		doc, err := DecodeWithOptions(bytes.NewReader(data), opts)
+		var limitErr *MemoryLimitError
+		if !errors.As(err, &limitErr) || doc != nil {
+			t.Fatalf("RecoverErrors=%v: DecodeWithOptions() = %v, %v, want *MemoryLimitError", recoverErrs, doc, err)
+		}
+		if limitErr.Limit != 1<<20 || limitErr.Estimated <= limitErr.Limit || limitErr.Line == 0 {
+			t.Errorf("MemoryLimitError = %+v", limitErr)
+		}
+		// The decode stops soon after the limit, not at the end of the file
+		if limitErr.Estimated > 2<<20 {
+			t.Errorf("Estimated = %d, want the decode stopped near the limit", limitErr.Estimated)
+		}
+	}
+
+	opts := DefaultOptions()
+	opts.MaxMemory = 64 << 20
+	opts.Progress = func(Progress) {}
+	if _, err := DecodeWithOptions(bytes.NewReader(data), opts); err != nil {
+		t.Errorf("DecodeWithOptions() under the limit error = %v", err)
+	}
+}
+
+func TestMemoryLimitErrorMessage(t *testing.T) {
+	err := &MemoryLimitError{Line: 120, Limit: 1000, Estimated: 1200}
+	want := "line 120: estimated document memory 1200 bytes exceeds limit of 1000 bytes"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,41 @@
+package decoder
+
+import (
+	"fmt"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+)
+
+// normalizeNFC rewrites the values of lines in Unicode normalization form C,
+// so precomposed and decomposed spellings of the same text (such as "é" as
+// one code point or as "e" and a combining acute accent) compare equal.
+func normalizeNFC(lines []*parser.Line) {
+	for _, line := range lines {
+		if !norm.NFC.IsNormalString(line.Value) {
+			line.Value = norm.NFC.String(line.Value)
+		}
+	}
+}
+
+// sanitizeText removes control and invisible characters from the values of
+// lines (see gedcom.SanitizeText), returning a WarnTextSanitized warning for
+// each line it changed.
+func sanitizeText(lines []*parser.Line) []gedcom.Warning {
+	var warnings []gedcom.Warning
+	for _, line := range lines {
+		clean, removed := gedcom.SanitizeText(line.Value)
+		if removed == 0 {
+			continue
+		}
+		line.Value = clean
+		warnings = append(warnings, gedcom.Warning{
+			Code:    WarnTextSanitized,
+			Line:    line.LineNumber,
+			Message: fmt.Sprintf("removed %d control or invisible characters from %s", removed, line.Tag),
+		})
+	}
+	return warnings
+}
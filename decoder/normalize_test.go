@@ -0,0 +1,99 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+)
+
+// Decomposed spellings: "e" + U+0301 COMBINING ACUTE ACCENT, "o" + U+0308
+// COMBINING DIAERESIS
+const nfdInput = "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n" +
+	"0 @I1@ INDI\n1 NAME Rene\u0301 /Mo\u0308ller/\n2 GIVN Rene\u0301\n1 BIRT\n2 PLAC Ko\u0308ln\n" +
+	"0 @N1@ NOTE Cafe\u0301\n" +
+	"0 TRLR\n"
+
+func TestDecodeNormalizeNFC(t *testing.T) {
+	opts := DefaultOptions()
+	opts.NormalizeNFC = true
+	doc, err := DecodeWithOptions(strings.NewReader(nfdInput), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indi := doc.GetIndividual("@I1@")
+	if got, want := indi.Names[0].Full, "Ren\u00e9 /M\u00f6ller/"; got != want {
+		t.Errorf("Name = %q, want %q", got, want)
+	}
+	if got, want := indi.Names[0].Given, "Ren\u00e9"; got != want {
+		t.Errorf("Given = %q, want %q", got, want)
+	}
+	if got, want := indi.Events[0].Place, "K\u00f6ln"; got != want {
+		t.Errorf("Place = %q, want %q", got, want)
+	}
+	if got, want := doc.GetRecord("@I1@").Tags[0].Value, "Ren\u00e9 /M\u00f6ller/"; got != want {
+		t.Errorf("NAME tag = %q, want %q", got, want)
+	}
+	if got, want := doc.GetRecord("@N1@").Value, "Caf\u00e9"; got != want {
+		t.Errorf("note = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeWithoutNormalizeNFC(t *testing.T) {
+	doc, err := Decode(strings.NewReader(nfdInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := doc.GetIndividual("@I1@").Names[0].Full, "Rene\u0301 /Mo\u0308ller/"; got != want {
+		t.Errorf("Name = %q, want %q unchanged", got, want)
+	}
+}
+
+func TestRedecodeNormalizeNFC(t *testing.T) {
+	opts := DefaultOptions()
+	opts.NormalizeNFC = true
+	doc, err := DecodeWithOptions(strings.NewReader(nfdInput), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit := LineEdit{StartLine: 9, OldLines: 1, NewText: "2 PLAC Zu\u0308rich"}
+	if err := Redecode(doc, edit, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := doc.GetIndividual("@I1@").Events[0].Place, "Z\u00fcrich"; got != want {
+		t.Errorf("Place = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeSanitizeText(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n" +
+		"0 @I1@ INDI\n1 NAME Jo\u200bhn /Doe/\n1 BIRT\n2 PLAC Boston\ufeff\x07\n" +
+		"0 TRLR\n"
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := doc.GetIndividual("@I1@").Names[0].Full; got != "Jo\u200bhn /Doe/" {
+		t.Errorf("Name without SanitizeText = %q, want unchanged", got)
+	}
+
+	opts := DefaultOptions()
+	opts.SanitizeText = true
+	doc, err = DecodeWithOptions(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indi := doc.GetIndividual("@I1@")
+	if got, want := indi.Names[0].Full, "John /Doe/"; got != want {
+		t.Errorf("Name = %q, want %q", got, want)
+	}
+	if got, want := indi.Events[0].Place, "Boston"; got != want {
+		t.Errorf("Place = %q, want %q", got, want)
+	}
+	if len(doc.Warnings) != 2 {
+		t.Fatalf("Warnings = %v, want 2", doc.Warnings)
+	}
+	if w := doc.Warnings[1]; w.Code != WarnTextSanitized || w.Line != 8 || !strings.Contains(w.Message, "removed 2") {
+		t.Errorf("Warning = %v", w)
+	}
+}
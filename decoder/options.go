@@ -1,6 +1,12 @@
 package decoder
 
-import "context"
+import (
+	"context"
+	"fmt"
+
+	"github.com/cacack/gedcom-go/charset"
+	"github.com/cacack/gedcom-go/parser"
+)
 
 // DecodeOptions provides configuration options for decoding GEDCOM files.
 type DecodeOptions struct {
@@ -11,17 +17,248 @@ type DecodeOptions struct {
 	// This prevents stack overflow with malformed files
 	MaxNestingDepth int
 
+	// MaxMemory, if positive, is a ceiling in bytes on the estimated memory
+	// of the decoded document. The estimate is kept while parsing, and the
+	// decode is aborted with a *MemoryLimitError soon after it exceeds the
+	// limit, before the rest of the input is read, so one pathological
+	// upload cannot exhaust a shared service. The estimate is approximate,
+	// about 200 bytes per line plus twice the line's text, so leave headroom
+	// below the memory actually available.
+	MaxMemory int64
+
 	// StrictMode enables strict parsing (reject non-standard extensions)
 	StrictMode bool
 
 	// RecoverErrors continues parsing after errors and returns aggregated errors.
 	RecoverErrors bool
 
+	// RecoveryScope controls how much data is discarded around a malformed
+	// line when RecoverErrors is set (default: RecoveryScopeLine).
+	RecoveryScope RecoveryScope
+
 	// ValidateXRefs checks for missing cross-reference targets after decoding.
 	ValidateXRefs bool
 
 	// ValidateStructure checks for missing HEAD/TRLR records after decoding.
 	ValidateStructure bool
+
+	// CompatMode works around known exporter quirks, gated by the product
+	// detected from HEAD.SOUR: Ancestry's misleveled CONC/CONT chains,
+	// MyHeritage's empty DATE lines, and RootsMagic's illegal level jumps.
+	// Each fix is recorded in Document.Warnings. Level jumps in files from
+	// other products are still reported as errors; with RecoverErrors the
+	// jumped lines are skipped, as they are without CompatMode.
+	CompatMode bool
+
+	// RepairLevelJumps repairs illegal level jumps (e.g., 1 -> 3) in files
+	// from any product instead of failing the decode: the line is clamped
+	// to one level below its predecessor and its subordinates are shifted
+	// with it. Each repair is recorded in Document.Warnings. CompatMode
+	// alone repairs jumps only in RootsMagic files.
+	RepairLevelJumps bool
+
+	// RepairXRefs normalizes malformed cross-reference identifiers such as
+	// "@ I1 @" or "@I-1@" instead of failing the decode. Whitespace is removed
+	// and other illegal characters become underscores, consistently across
+	// record definitions and references. Each repair is recorded in
+	// Document.Warnings.
+	RepairXRefs bool
+
+	// TagRules selects the tag syntax accepted (default:
+	// parser.TagRulesLegacy). parser.TagRulesPermissive reads lowercase
+	// tags from careless exporters as uppercase, recording each in
+	// Document.Warnings; parser.TagRulesStrict and parser.TagRulesGEDCOM7
+	// reject tags their grammar does not allow. See parser.TagRules.
+	TagRules parser.TagRules
+
+	// Tolerant accepts lines that are trivially recoverable instead of
+	// failing the decode: blank and whitespace-only lines are skipped, and
+	// stray byte order marks at the start of a line (left by concatenating
+	// files or by some phone apps) are removed. Each is recorded in
+	// Document.Warnings. Leading whitespace before the level is accepted
+	// with or without this option.
+	Tolerant bool
+
+	// UnknownRecords controls level-0 records whose type is not defined by
+	// any GEDCOM version, such as RootsMagic's _PLC and _EVDEF (default:
+	// UnknownRecordPreserve).
+	UnknownRecords UnknownRecordPolicy
+
+	// VoidPointers controls pointers to @VOID@, which GEDCOM 7 uses for a
+	// record that is intentionally unknown, such as "1 HUSB @VOID@" for an
+	// unknown father (default: VoidKeep).
+	VoidPointers VoidPolicy
+
+	// Progress, if set, is called with the decode's progress every
+	// ProgressInterval lines while parsing and every ProgressInterval records
+	// while building entities, and once more with Done set when the document
+	// is complete. It runs on the decoding goroutine and should return quickly.
+	Progress func(Progress)
+
+	// LazyEntities leaves Record.Entity unset after decoding and builds each
+	// entity from the record's Tags the first time it is requested through
+	// Record.LoadEntity, a typed accessor such as GetIndividual, or a
+	// Document lookup such as Individuals; the result is kept for later
+	// calls. Workloads that touch only a few records of a large file skip
+	// most of the entity construction. Code that reads Record.Entity
+	// directly must call Document.LoadEntities first.
+	LazyEntities bool
+
+	// PreserveRaw retains every original line, with its line terminator, in
+	// Record.Raw and Document.Raw. The encoder writes these lines verbatim for
+	// records whose Tags and Entity are unmodified, so re-encoding an
+	// unchanged UTF-8 or ASCII file reproduces it byte for byte, including
+	// custom tag order, CONC/CONT wrapping, and line endings. It roughly
+	// doubles the memory needed for the document's text.
+	PreserveRaw bool
+
+	// NormalizeNFC rewrites every decoded value (names, places, notes, and
+	// all other tag values) in Unicode normalization form C. Files exported
+	// on macOS often mix precomposed and decomposed accents, which otherwise
+	// makes equal-looking strings compare unequal in lookups, duplicate
+	// detection, and phonetic keys. XRefs and tags are left as they are, and
+	// so is the original text kept by PreserveRaw.
+	NormalizeNFC bool
+
+	// SanitizeText removes control characters (other than tab), zero-width
+	// spaces, and byte order marks from every decoded value, as
+	// gedcom.SanitizeText does. These artifacts of copy and paste corrupt CSV
+	// and JSON output downstream. Each changed line is recorded as a
+	// TEXT_SANITIZED entry in Document.Warnings.
+	SanitizeText bool
+
+	// InternStrings shares one copy of each distinct tag name, place (PLAC,
+	// CITY, STAE, CTRY), and surname (SURN) among all the lines that repeat
+	// it, instead of keeping a copy per line. It costs a map lookup per line
+	// while parsing. Memory saved grows with how often places and surnames
+	// repeat; the per-line structures of the document dominate otherwise
+	// (about 1% for the royal92 and pres2020 corpora in testdata).
+	InternStrings bool
+
+	// ForceEncoding, when set, decodes the input in this encoding instead of
+	// the one named by its byte order mark or HEAD.CHAR line. Use it for
+	// files whose CHAR value is missing or wrong, such as Latin-1 text
+	// declared as UTF-8. The zero value, charset.EncodingUnknown, detects
+	// the encoding.
+	ForceEncoding charset.Encoding
+
+	// ProgressInterval is the number of lines or records between Progress
+	// calls (default: DefaultProgressInterval).
+	ProgressInterval int
+
+	// Stats, when non-nil, is filled with statistics of the decode: bytes
+	// read, lines parsed, records by type, and the time spent in each phase.
+	// Its previous contents are replaced. It is filled in as far as the
+	// decode got when decoding fails.
+	Stats *DecodeStats
+
+	// Extensions, if set, decodes extension tags into typed values: each tag
+	// with a registered handler, at any level of any record, is passed to
+	// its handler and the result is appended to Record.Extensions. Handler
+	// failures are reported as *ExtensionError in the returned
+	// *DecodeErrors. The raw tags are kept either way.
+	Extensions *ExtensionRegistry
+}
+
+// UnknownRecordPolicy determines how the decoder handles level-0 records
+// with non-standard types (see gedcom.RecordType.IsStandard).
+type UnknownRecordPolicy int
+
+const (
+	// UnknownRecordPreserve keeps unknown records as generic records with
+	// their full tag subtree and no Entity. They are indexed in XRefMap and
+	// re-encoded unchanged.
+	UnknownRecordPreserve UnknownRecordPolicy = iota
+
+	// UnknownRecordSkip drops unknown records. Each dropped record is listed
+	// in Document.DecodeReport.SkippedRecords.
+	UnknownRecordSkip
+
+	// UnknownRecordError keeps unknown records but reports each one as an
+	// *UnknownRecordTypeError in the returned *DecodeErrors.
+	UnknownRecordError
+)
+
+// String returns the name of the unknown record policy.
+func (p UnknownRecordPolicy) String() string {
+	switch p {
+	case UnknownRecordPreserve:
+		return "preserve"
+	case UnknownRecordSkip:
+		return "skip"
+	case UnknownRecordError:
+		return "error"
+	default:
+		return fmt.Sprintf("UnknownRecordPolicy(%d)", int(p))
+	}
+}
+
+// VoidPolicy determines how the decoder handles @VOID@ pointers.
+type VoidPolicy int
+
+const (
+	// VoidKeep keeps @VOID@ pointers as a sentinel: typed fields such as
+	// Family.Husband and Family.Children hold the literal "@VOID@", and
+	// cross-reference checks ignore it.
+	VoidKeep VoidPolicy = iota
+
+	// VoidDrop removes each @VOID@ pointer line, with its subordinate lines
+	// such as PHRASE, so typed fields are left empty as if the pointer were
+	// absent.
+	VoidDrop
+
+	// VoidWarn keeps @VOID@ pointers as VoidKeep does and records each one
+	// as a VOID_POINTER entry in Document.Warnings.
+	VoidWarn
+)
+
+// String returns the name of the void pointer policy.
+func (p VoidPolicy) String() string {
+	switch p {
+	case VoidKeep:
+		return "keep"
+	case VoidDrop:
+		return "drop"
+	case VoidWarn:
+		return "warn"
+	default:
+		return fmt.Sprintf("VoidPolicy(%d)", int(p))
+	}
+}
+
+// RecoveryScope determines what is discarded when a line fails to parse
+// during error recovery.
+type RecoveryScope int
+
+const (
+	// RecoveryScopeLine drops only the malformed lines and keeps everything
+	// else, which may leave partially populated records.
+	RecoveryScopeLine RecoveryScope = iota
+
+	// RecoveryScopeRecord drops every level-0 record that contains a
+	// malformed line and keeps the rest. HEAD and TRLR are never dropped.
+	// When the malformed line is itself a record header, its subordinate
+	// lines cannot be told apart from the preceding record, so that record
+	// is dropped as well.
+	RecoveryScopeRecord
+
+	// RecoveryScopeDocument fails the whole decode if any line is malformed,
+	// returning all parse errors and no document.
+	RecoveryScopeDocument
+)
+
+// String returns the name of the recovery scope.
+func (s RecoveryScope) String() string {
+	switch s {
+	case RecoveryScopeLine:
+		return "line"
+	case RecoveryScopeRecord:
+		return "record"
+	case RecoveryScopeDocument:
+		return "document"
+	default:
+		return fmt.Sprintf("RecoveryScope(%d)", int(s))
+	}
 }
 
 // DefaultOptions returns the default decoding options.
@@ -31,7 +268,13 @@ func DefaultOptions() *DecodeOptions {
 		MaxNestingDepth:   100,
 		StrictMode:        false,
 		RecoverErrors:     false,
+		RecoveryScope:     RecoveryScopeLine,
 		ValidateXRefs:     false,
 		ValidateStructure: false,
+		CompatMode:        false,
+		RepairXRefs:       false,
+		TagRules:          parser.TagRulesLegacy,
+		UnknownRecords:    UnknownRecordPreserve,
+		VoidPointers:      VoidKeep,
 	}
 }
@@ -0,0 +1,97 @@
+package decoder
+
+import (
+	"io"
+
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+)
+
+// DefaultProgressInterval is the number of lines or records between progress
+// reports when DecodeOptions.ProgressInterval is zero.
+const DefaultProgressInterval = 10000
+
+// Progress reports how far a decode has advanced (see DecodeOptions.Progress).
+type Progress struct {
+	// BytesRead is the number of input bytes consumed so far. Compare it to
+	// the file size to drive a progress bar; the reader buffers ahead, so it
+	// can lead the lines parsed by up to 64KB.
+	BytesRead int64
+
+	// LinesParsed is the number of lines parsed so far
+	LinesParsed int
+
+	// RecordsBuilt is the number of records converted to typed entities so
+	// far. Records are built after all lines are parsed, so it stays zero
+	// while LinesParsed grows, and for the whole decode with LazyEntities.
+	RecordsBuilt int
+
+	// Done is true for the final report, sent when the decode returns a
+	// document
+	Done bool
+}
+
+// countingReader counts the bytes read from an io.Reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// progressTracker invokes the Progress callback every interval lines while
+// parsing and every interval records while building entities.
+type progressTracker struct {
+	fn       func(Progress)
+	interval int
+	input    *countingReader
+	progress Progress
+}
+
+// newProgressTracker returns a tracker for opts, or nil if opts has no
+// Progress callback.
+func newProgressTracker(opts *DecodeOptions, input *countingReader) *progressTracker {
+	if opts.Progress == nil {
+		return nil
+	}
+	interval := opts.ProgressInterval
+	if interval <= 0 {
+		interval = DefaultProgressInterval
+	}
+	return &progressTracker{fn: opts.Progress, interval: interval, input: input}
+}
+
+// lineParsed counts a parsed line; it is installed as the parser's line hook.
+func (t *progressTracker) lineParsed(*parser.Line) {
+	t.progress.LinesParsed++
+	if t.progress.LinesParsed%t.interval == 0 {
+		t.report()
+	}
+}
+
+// populateEntities builds the entities of doc's records like the package
+// function, counting records as they are built.
+func (t *progressTracker) populateEntities(doc *gedcom.Document) {
+	for _, record := range doc.Records {
+		populateEntity(record)
+		t.progress.RecordsBuilt++
+		if t.progress.RecordsBuilt%t.interval == 0 {
+			t.report()
+		}
+	}
+}
+
+// done sends the final report.
+func (t *progressTracker) done() {
+	t.progress.Done = true
+	t.report()
+}
+
+func (t *progressTracker) report() {
+	t.progress.BytesRead = t.input.n
+	t.fn(t.progress)
+}
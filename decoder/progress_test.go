@@ -0,0 +1,59 @@
+package decoder
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDecodeProgress(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("0 HEAD\n1 GEDC\n2 VERS 5.5.1\n")
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(&b, "0 @I%d@ INDI\n1 NAME Person /%d/\n", i, i)
+	}
+	b.WriteString("0 TRLR\n")
+	input := b.String()
+
+	var reports []Progress
+	opts := DefaultOptions()
+	opts.ProgressInterval = 4
+	opts.Progress = func(p Progress) { reports = append(reports, p) }
+
+	if _, err := DecodeWithOptions(strings.NewReader(input), opts); err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+
+	// 14 lines: reports at 4, 8, and 12 lines; 5 records: report at 4 records
+	if len(reports) != 5 {
+		t.Fatalf("got %d reports, want 5: %+v", len(reports), reports)
+	}
+	if reports[0].LinesParsed != 4 || reports[0].RecordsBuilt != 0 || reports[0].BytesRead == 0 {
+		t.Errorf("first report = %+v", reports[0])
+	}
+	if reports[3].LinesParsed != 14 || reports[3].RecordsBuilt != 4 {
+		t.Errorf("build report = %+v", reports[3])
+	}
+	last := reports[len(reports)-1]
+	if !last.Done || last.LinesParsed != 14 || last.RecordsBuilt != 5 || last.BytesRead != int64(len(input)) {
+		t.Errorf("final report = %+v", last)
+	}
+	for i := 0; i < len(reports)-1; i++ {
+		if reports[i].Done {
+			t.Errorf("report %d marked done", i)
+		}
+	}
+}
+
+func TestDecodeProgressDefaultInterval(t *testing.T) {
+	var calls int
+	opts := DefaultOptions()
+	opts.Progress = func(p Progress) { calls++ }
+
+	if _, err := DecodeWithOptions(strings.NewReader("0 HEAD\n0 @I1@ INDI\n0 TRLR\n"), opts); err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Progress called %d times, want only the final report", calls)
+	}
+}
@@ -0,0 +1,97 @@
+package decoder
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+)
+
+// rawCapture records the text the parser consumes for PreserveRaw.
+type rawCapture struct {
+	prefix []byte       // first bytes of the undecoded input, for BOM detection
+	text   bytes.Buffer // decoded text as read by the parser
+}
+
+// prefixReader records the first bytes read from r.
+type prefixReader struct {
+	r       io.Reader
+	capture *rawCapture
+}
+
+func (p *prefixReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if need := 3 - len(p.capture.prefix); need > 0 {
+		p.capture.prefix = append(p.capture.prefix, b[:min(n, need)]...)
+	}
+	return n, err
+}
+
+// wrapInput returns a reader that records the start of the undecoded input.
+func (c *rawCapture) wrapInput(r io.Reader) io.Reader {
+	return &prefixReader{r: r, capture: c}
+}
+
+// wrapDecoded returns a reader that records the decoded text.
+func (c *rawCapture) wrapDecoded(r io.Reader) io.Reader {
+	return io.TeeReader(r, &c.text)
+}
+
+// rawLines splits the captured text into lines that keep their terminators,
+// numbered like parser lines (rawLines()[n-1] is line n).
+func (c *rawCapture) rawLines() []string {
+	data := c.text.Bytes()
+	var lines []string
+	for len(data) > 0 {
+		advance, _, _ := parser.ScanGEDCOMLines(data, true)
+		lines = append(lines, string(data[:advance]))
+		data = data[advance:]
+	}
+	return lines
+}
+
+// attach stores the original lines on doc and its records. Every level 0
+// line starts a block that runs to the next level 0 line. The lines up to
+// the end of HEAD's block go to Raw.Header, the lines from TRLR to the end of
+// the file to Raw.Trailer, and each record's block to Record.Raw. Blocks of
+// records that were dropped are not kept.
+func (c *rawCapture) attach(doc *gedcom.Document) {
+	lines := c.rawLines()
+	raw := &gedcom.RawDocument{BOM: bytes.Equal(c.prefix, []byte{0xEF, 0xBB, 0xBF})}
+
+	records := make(map[int]*gedcom.Record, len(doc.Records))
+	for _, record := range doc.Records {
+		records[record.LineNumber] = record
+	}
+
+	var starts []int // 0-based indexes of level 0 lines
+	for i, line := range lines {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == "0" {
+			starts = append(starts, i)
+		}
+	}
+	for k, start := range starts {
+		end := len(lines)
+		if k+1 < len(starts) {
+			end = starts[k+1]
+		}
+		block := lines[start:end]
+		fields := strings.Fields(lines[start])
+		switch {
+		case len(fields) > 1 && fields[1] == "HEAD":
+			raw.Header = lines[:end]
+		case len(fields) > 1 && fields[1] == "TRLR":
+			raw.Trailer = lines[start:]
+		default:
+			if record := records[start+1]; record != nil {
+				record.Raw = block
+			}
+		}
+		if raw.Trailer != nil {
+			break
+		}
+	}
+	doc.Raw = raw
+}
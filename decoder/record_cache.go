@@ -0,0 +1,95 @@
+package decoder
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// recordCache is a bounded, thread-safe LRU cache of materialized records
+// keyed by XRef.
+type recordCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+	hits    int
+	misses  int
+}
+
+// cacheEntry is the value stored in recordCache.order.
+type cacheEntry struct {
+	xref   string
+	record *gedcom.Record
+}
+
+// newRecordCache returns a cache holding at most size records. A size of 0
+// or less disables caching.
+func newRecordCache(size int) *recordCache {
+	return &recordCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached record for xref and marks it most recently used.
+func (c *recordCache) get(xref string) (*gedcom.Record, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[xref]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).record, true
+}
+
+// put adds a record, evicting the least recently used one when full. If
+// another goroutine cached xref first, its record is kept and returned so
+// that all callers share one instance.
+func (c *recordCache) put(xref string, record *gedcom.Record) *gedcom.Record {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size <= 0 {
+		return record
+	}
+	if elem, ok := c.entries[xref]; ok {
+		c.order.MoveToFront(elem)
+		return elem.Value.(*cacheEntry).record
+	}
+	c.entries[xref] = c.order.PushFront(&cacheEntry{xref: xref, record: record})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).xref)
+	}
+	return record
+}
+
+// stats returns the cache counters.
+func (c *recordCache) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Len: c.order.Len(), Size: c.size}
+}
+
+// CacheStats reports the state of an Index's record cache.
+type CacheStats struct {
+	// Hits is the number of lookups served from the cache
+	Hits int
+
+	// Misses is the number of lookups that parsed the record from the source
+	Misses int
+
+	// Len is the number of records currently cached
+	Len int
+
+	// Size is the maximum number of records cached
+	Size int
+}
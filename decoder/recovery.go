@@ -0,0 +1,144 @@
+package decoder
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+	"github.com/cacack/gedcom-go/tags"
+)
+
+// recordSpan groups a level-0 line with its subordinate lines. The span
+// covers source lines [start, end), including malformed lines that were
+// dropped by the parser.
+type recordSpan struct {
+	lines []*parser.Line
+	start int
+	end   int
+}
+
+// recordSpans splits parsed lines into level-0 record spans.
+func recordSpans(lines []*parser.Line) []recordSpan {
+	var spans []recordSpan
+	for start := 0; start < len(lines); {
+		end := start + 1
+		for end < len(lines) && lines[end].Level > 0 {
+			end++
+		}
+
+		span := recordSpan{lines: lines[start:end], start: lines[start].LineNumber, end: math.MaxInt}
+		if end < len(lines) {
+			span.end = lines[end].LineNumber
+		}
+		spans = append(spans, span)
+		start = end
+	}
+	return spans
+}
+
+// failedLine is a source line reported by a parse error.
+type failedLine struct {
+	line   int
+	reason string
+}
+
+// failedLines extracts the lines reported by parse errors, sorted by line.
+func failedLines(errs []error) []failedLine {
+	var failed []failedLine
+	for _, err := range errs {
+		var parseErr *parser.ParseError
+		if errors.As(err, &parseErr) {
+			failed = append(failed, failedLine{line: parseErr.Line, reason: parseErr.Message})
+		}
+	}
+	sort.SliceStable(failed, func(i, j int) bool { return failed[i].line < failed[j].line })
+	return failed
+}
+
+// applyRecovery widens error recovery to the given scope and reports what was
+// discarded. Under RecoveryScopeRecord, every level-0 record whose span
+// contains a malformed line is dropped; HEAD and TRLR are always kept.
+func applyRecovery(lines []*parser.Line, errs []error, scope RecoveryScope) ([]*parser.Line, *gedcom.DecodeReport) {
+	report := &gedcom.DecodeReport{}
+	failed := failedLines(errs)
+	if len(failed) == 0 {
+		return lines, report
+	}
+
+	spans := recordSpans(lines)
+	kept := lines
+	if scope == RecoveryScopeRecord {
+		kept = make([]*parser.Line, 0, len(lines))
+	}
+
+	fi := 0
+	for si, span := range spans {
+		first := span.lines[0]
+		spanFailed := fi
+		for fi < len(failed) && failed[fi].line < span.end {
+			fi++
+		}
+		spanFailures := failed[spanFailed:fi]
+
+		// Failures before the first record belong to no record
+		if si == 0 {
+			reportSkippedLines(report, "", takeBefore(&spanFailures, span.start))
+		}
+		xref := first.XRef
+		reportSkippedLines(report, xref, spanFailures)
+
+		if scope != RecoveryScopeRecord {
+			continue
+		}
+		structural := first.Level == 0 && (tags.Tag(first.Tag) == tags.HEAD || tags.Tag(first.Tag) == tags.TRLR)
+		if structural || len(spanFailures) == 0 {
+			kept = append(kept, span.lines...)
+			continue
+		}
+
+		last := span.lines[len(span.lines)-1].LineNumber
+		if f := spanFailures[len(spanFailures)-1].line; f > last {
+			last = f
+		}
+		report.SkippedRecords = append(report.SkippedRecords, gedcom.SkippedRecord{
+			XRef:  xref,
+			Type:  gedcom.RecordType(first.Tag),
+			Lines: gedcom.LineRange{Start: span.start, End: last},
+		})
+	}
+
+	// Failures after the last line (e.g., read errors) belong to no record
+	reportSkippedLines(report, "", failed[fi:])
+
+	return kept, report
+}
+
+// takeBefore removes and returns the leading failures before the given line.
+func takeBefore(failures *[]failedLine, line int) []failedLine {
+	n := 0
+	for n < len(*failures) && (*failures)[n].line < line {
+		n++
+	}
+	taken := (*failures)[:n]
+	*failures = (*failures)[n:]
+	return taken
+}
+
+// reportSkippedLines appends failures to the report, merging consecutive
+// lines into ranges.
+func reportSkippedLines(report *gedcom.DecodeReport, xref string, failures []failedLine) {
+	for i := 0; i < len(failures); {
+		j := i + 1
+		for j < len(failures) && failures[j].line <= failures[j-1].line+1 {
+			j++
+		}
+		report.SkippedLines = append(report.SkippedLines, gedcom.SkippedLines{
+			Lines:      gedcom.LineRange{Start: failures[i].line, End: failures[j-1].line},
+			RecordXRef: xref,
+			Reason:     failures[i].reason,
+		})
+		i = j
+	}
+}
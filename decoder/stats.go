@@ -0,0 +1,124 @@
+package decoder
+
+import (
+	"time"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// DecodeStats describes the work of one decode, for capacity planning and
+// reporting. Set DecodeOptions.Stats to have it filled in.
+type DecodeStats struct {
+	// BytesRead is the number of input bytes read, after decompression and
+	// before conversion to UTF-8.
+	BytesRead int64
+
+	// Lines is the number of lines parsed.
+	Lines int
+
+	// Records counts the decoded records by type, as in Document.Records;
+	// the header and trailer are not records.
+	Records map[gedcom.RecordType]int
+
+	// Parse is the time spent reading, converting, and parsing lines into
+	// tags, including compatibility fixes and error recovery.
+	Parse time.Duration
+
+	// Build is the time spent grouping lines into records and building the
+	// header.
+	Build time.Duration
+
+	// Entities is the time spent converting records into entities. It is
+	// near zero with LazyEntities, where entities are built on first use.
+	Entities time.Duration
+
+	// Validate is the time spent on extension handlers and the checks
+	// enabled by StrictMode, ValidateStructure, and ValidateXRefs.
+	Validate time.Duration
+
+	// Total is the duration of the whole decode.
+	Total time.Duration
+}
+
+// TotalRecords returns the number of decoded records of all types.
+func (s *DecodeStats) TotalRecords() int {
+	n := 0
+	for _, count := range s.Records {
+		n += count
+	}
+	return n
+}
+
+// statsRecorder times the phases of a decode into a DecodeStats. A nil
+// recorder records nothing.
+type statsRecorder struct {
+	stats *DecodeStats
+	input *countingReader
+	start time.Time
+	mark  time.Time
+}
+
+// newStatsRecorder returns a recorder filling in opts.Stats, or nil if opts
+// has no Stats. The previous contents of opts.Stats are replaced.
+func newStatsRecorder(opts *DecodeOptions) *statsRecorder {
+	if opts.Stats == nil {
+		return nil
+	}
+	now := time.Now()
+	*opts.Stats = DecodeStats{Records: make(map[gedcom.RecordType]int)}
+	return &statsRecorder{stats: opts.Stats, start: now, mark: now}
+}
+
+// parsed records the parse phase and the number of lines parsed.
+func (s *statsRecorder) parsed(lines int) {
+	if s == nil {
+		return
+	}
+	s.stats.Lines = lines
+	s.phase(&s.stats.Parse)
+}
+
+// built records the phase that builds records from lines.
+func (s *statsRecorder) built() {
+	if s != nil {
+		s.phase(&s.stats.Build)
+	}
+}
+
+// entitiesBuilt records the entity conversion phase.
+func (s *statsRecorder) entitiesBuilt() {
+	if s != nil {
+		s.phase(&s.stats.Entities)
+	}
+}
+
+// validated records the validation phase.
+func (s *statsRecorder) validated() {
+	if s != nil {
+		s.phase(&s.stats.Validate)
+	}
+}
+
+// phase adds the time since the previous phase to *d.
+func (s *statsRecorder) phase(d *time.Duration) {
+	now := time.Now()
+	*d += now.Sub(s.mark)
+	s.mark = now
+}
+
+// finish records the totals of the decode. doc may be nil when the decode
+// failed.
+func (s *statsRecorder) finish(doc *gedcom.Document) {
+	if s == nil {
+		return
+	}
+	if s.input != nil {
+		s.stats.BytesRead = s.input.n
+	}
+	if doc != nil {
+		for _, record := range doc.Records {
+			s.stats.Records[record.Type]++
+		}
+	}
+	s.stats.Total = time.Since(s.start)
+}
@@ -0,0 +1,63 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func TestDecodeStats(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n" +
+		"0 @I1@ INDI\n1 NAME John /Smith/\n" +
+		"0 @I2@ INDI\n1 NAME Mary /Jones/\n" +
+		"0 @F1@ FAM\n1 HUSB @I1@\n1 WIFE @I2@\n" +
+		"0 @S1@ SOUR\n1 TITL Parish Register\n" +
+		"0 TRLR\n"
+
+	var stats DecodeStats
+	opts := DefaultOptions()
+	opts.Stats = &stats
+	if _, err := DecodeWithOptions(strings.NewReader(input), opts); err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+
+	if stats.BytesRead != int64(len(input)) {
+		t.Errorf("BytesRead = %d, want %d", stats.BytesRead, len(input))
+	}
+	if stats.Lines != 13 {
+		t.Errorf("Lines = %d, want 13", stats.Lines)
+	}
+	want := map[gedcom.RecordType]int{
+		gedcom.RecordTypeIndividual: 2,
+		gedcom.RecordTypeFamily:     1,
+		gedcom.RecordTypeSource:     1,
+	}
+	for typ, n := range want {
+		if stats.Records[typ] != n {
+			t.Errorf("Records[%s] = %d, want %d", typ, stats.Records[typ], n)
+		}
+	}
+	if stats.TotalRecords() != 4 {
+		t.Errorf("TotalRecords() = %d, want 4", stats.TotalRecords())
+	}
+	if stats.Total <= 0 || stats.Total < stats.Parse+stats.Build+stats.Entities+stats.Validate {
+		t.Errorf("Total = %v, want at least the sum of the phases %+v", stats.Total, stats)
+	}
+}
+
+func TestDecodeStatsOnFailure(t *testing.T) {
+	stats := DecodeStats{Lines: 99}
+	opts := DefaultOptions()
+	opts.Stats = &stats
+	input := "0 HEAD\nbad line\n0 TRLR\n"
+	if _, err := DecodeWithOptions(strings.NewReader(input), opts); err == nil {
+		t.Fatal("DecodeWithOptions() error = nil, want parse error")
+	}
+	if stats.Lines != 0 || stats.TotalRecords() != 0 {
+		t.Errorf("stats after failure = %+v, want counters reset", stats)
+	}
+	if stats.Total <= 0 || stats.BytesRead == 0 {
+		t.Errorf("stats after failure = %+v, want total time and bytes read", stats)
+	}
+}
@@ -0,0 +1,62 @@
+package decoder
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/cacack/gedcom-go/charset"
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+)
+
+// streamRecords parses r one record at a time, passing each record with its
+// entity to yield until yield returns false. A parse or read error is passed
+// to yield with a nil record and ends the stream.
+func streamRecords(r io.Reader, yield func(*gedcom.Record, error) bool) {
+	r, err := decompress(r)
+	if err != nil {
+		yield(nil, err)
+		return
+	}
+	scanner := bufio.NewScanner(charset.NewReader(r))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	scanner.Split(parser.ScanGEDCOMLines)
+
+	p := parser.NewParser()
+	p.SetMaxNestingDepth(DefaultOptions().MaxNestingDepth)
+
+	// Lines of the current record; HEAD and TRLR are dropped by buildRecords
+	var lines []*parser.Line
+	flush := func() bool {
+		if len(lines) == 0 {
+			return true
+		}
+		doc := &gedcom.Document{XRefMap: make(map[string]*gedcom.Record)}
+		buildRecords(doc, lines)
+		lines = lines[:0]
+		for _, record := range doc.Records {
+			populateEntity(record)
+			if !yield(record, nil) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for scanner.Scan() {
+		line, err := p.ParseLine(scanner.Text())
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		if line.Level == 0 && !flush() {
+			return
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		yield(nil, err)
+		return
+	}
+	flush()
+}
@@ -1,9 +1,8 @@
 package decoder
 
 import (
-	"strings"
-
 	"github.com/cacack/gedcom-go/parser"
+	"github.com/cacack/gedcom-go/tags"
 )
 
 func validateStrictTags(lines []*parser.Line) []error {
@@ -12,7 +11,7 @@ func validateStrictTags(lines []*parser.Line) []error {
 		if line == nil {
 			continue
 		}
-		if strings.HasPrefix(line.Tag, "_") {
+		if tags.Tag(line.Tag).IsExtension() {
 			errs = append(errs, &NonStandardTagError{
 				Line:    line.LineNumber,
 				Tag:     line.Tag,
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/cacack/gedcom-go/parser"
+	"github.com/cacack/gedcom-go/tags"
 )
 
 func validateStructure(lines []*parser.Line) []error {
@@ -21,10 +22,10 @@ func validateStructure(lines []*parser.Line) []error {
 		if line.Level != 0 {
 			continue
 		}
-		if line.Tag == "HEAD" {
+		if tags.Tag(line.Tag) == tags.HEAD {
 			hasHead = true
 		}
-		if line.Tag == "TRLR" {
+		if tags.Tag(line.Tag) == tags.TRLR {
 			hasTrlr = true
 		}
 	}
@@ -0,0 +1,56 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// applyUnknownRecordPolicy handles records with non-standard types according
+// to policy, returning the errors to report under UnknownRecordError.
+func applyUnknownRecordPolicy(doc *gedcom.Document, policy UnknownRecordPolicy) []error {
+	if policy == UnknownRecordPreserve {
+		return nil
+	}
+
+	var errs []error
+	kept := doc.Records[:0]
+	for _, record := range doc.Records {
+		if record.Type.IsStandard() {
+			kept = append(kept, record)
+			continue
+		}
+		switch policy {
+		case UnknownRecordSkip:
+			if record.XRef != "" && doc.XRefMap[record.XRef] == record {
+				delete(doc.XRefMap, record.XRef)
+			}
+			if doc.DecodeReport == nil {
+				doc.DecodeReport = &gedcom.DecodeReport{}
+			}
+			doc.DecodeReport.SkippedRecords = append(doc.DecodeReport.SkippedRecords, gedcom.SkippedRecord{
+				XRef:  record.XRef,
+				Type:  record.Type,
+				Lines: gedcom.LineRange{Start: record.LineNumber, End: recordEndLine(record)},
+			})
+			doc.Warnings = append(doc.Warnings, gedcom.Warning{
+				Code:    WarnUnknownRecordSkipped,
+				Line:    record.LineNumber,
+				Message: fmt.Sprintf("skipped record of unknown type %s", record.Type),
+			})
+			continue
+		case UnknownRecordError:
+			errs = append(errs, &UnknownRecordTypeError{
+				Line: record.LineNumber,
+				Type: string(record.Type),
+				XRef: record.XRef,
+			})
+		}
+		kept = append(kept, record)
+	}
+	for i := len(kept); i < len(doc.Records); i++ {
+		doc.Records[i] = nil
+	}
+	doc.Records = kept
+	return errs
+}
@@ -0,0 +1,46 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+)
+
+// voidPointer is the GEDCOM 7 pointer for an intentionally unknown record.
+const voidPointer = "@VOID@"
+
+// applyVoidPolicy handles lines whose value is the @VOID@ pointer according
+// to policy, returning the kept lines and the warnings to record under
+// VoidWarn.
+func applyVoidPolicy(lines []*parser.Line, policy VoidPolicy) ([]*parser.Line, []gedcom.Warning) {
+	switch policy {
+	case VoidDrop:
+		kept := lines[:0:0]
+		for i := 0; i < len(lines); i++ {
+			line := lines[i]
+			if line.Value != voidPointer {
+				kept = append(kept, line)
+				continue
+			}
+			for i+1 < len(lines) && lines[i+1].Level > line.Level {
+				i++
+			}
+		}
+		return kept, nil
+	case VoidWarn:
+		var warnings []gedcom.Warning
+		for _, line := range lines {
+			if line.Value == voidPointer {
+				warnings = append(warnings, gedcom.Warning{
+					Code:    WarnVoidPointer,
+					Line:    line.LineNumber,
+					Message: fmt.Sprintf("%s points to %s", line.Tag, voidPointer),
+				})
+			}
+		}
+		return lines, warnings
+	default:
+		return lines, nil
+	}
+}
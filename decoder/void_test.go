@@ -0,0 +1,62 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+)
+
+const voidInput = "0 HEAD\n1 GEDC\n2 VERS 7.0\n" +
+	"0 @I1@ INDI\n1 NAME John /Doe/\n1 FAMC @F1@\n" +
+	"0 @F1@ FAM\n1 HUSB @VOID@\n2 PHRASE Unknown father\n1 WIFE @I2@\n1 CHIL @VOID@\n1 CHIL @I1@\n" +
+	"0 @I2@ INDI\n1 NAME Mary /Doe/\n" +
+	"0 TRLR\n"
+
+func TestVoidPolicy(t *testing.T) {
+	tests := []struct {
+		policy       VoidPolicy
+		wantHusband  string
+		wantChildren []string
+		wantWarnings []int
+	}{
+		{VoidKeep, "@VOID@", []string{"@VOID@", "@I1@"}, nil},
+		{VoidDrop, "", []string{"@I1@"}, nil},
+		{VoidWarn, "@VOID@", []string{"@VOID@", "@I1@"}, []int{8, 11}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.VoidPointers = tt.policy
+			opts.ValidateXRefs = true
+			doc, err := DecodeWithOptions(strings.NewReader(voidInput), opts)
+			if err != nil {
+				t.Fatalf("DecodeWithOptions() error = %v", err)
+			}
+
+			fam := doc.GetFamily("@F1@")
+			if fam.Husband != tt.wantHusband || fam.Wife != "@I2@" {
+				t.Errorf("Husband, Wife = %q, %q, want %q, @I2@", fam.Husband, fam.Wife, tt.wantHusband)
+			}
+			if strings.Join(fam.Children, ",") != strings.Join(tt.wantChildren, ",") {
+				t.Errorf("Children = %v, want %v", fam.Children, tt.wantChildren)
+			}
+			if tt.policy == VoidDrop && doc.GetRecord("@F1@").Find("HUSB.PHRASE") != nil {
+				t.Error("PHRASE of dropped pointer was kept")
+			}
+
+			if len(doc.Warnings) != len(tt.wantWarnings) {
+				t.Fatalf("Warnings = %v, want lines %v", doc.Warnings, tt.wantWarnings)
+			}
+			for i, w := range doc.Warnings {
+				if w.Code != WarnVoidPointer || w.Line != tt.wantWarnings[i] {
+					t.Errorf("Warnings[%d] = %v, want %s at line %d", i, w, WarnVoidPointer, tt.wantWarnings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestVoidPolicyString(t *testing.T) {
+	if VoidPolicy(9).String() != "VoidPolicy(9)" {
+		t.Errorf("String() = %q", VoidPolicy(9).String())
+	}
+}
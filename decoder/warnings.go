@@ -0,0 +1,117 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/parser"
+	"github.com/cacack/gedcom-go/tags"
+)
+
+// Warning codes reported in Document.Warnings.
+const (
+	// WarnXRefRepaired reports a malformed xref normalized by RepairXRefs.
+	WarnXRefRepaired = "XREF_REPAIRED"
+
+	// WarnLevelJumpRepaired reports a line whose level was clamped after an
+	// illegal level jump by RepairLevelJumps.
+	WarnLevelJumpRepaired = "LEVEL_JUMP_REPAIRED"
+
+	// WarnTagNormalized reports a lowercase tag uppercased under
+	// parser.TagRulesPermissive.
+	WarnTagNormalized = "TAG_NORMALIZED"
+
+	// WarnBlankLineSkipped reports a blank line skipped by Tolerant.
+	WarnBlankLineSkipped = "BLANK_LINE_SKIPPED"
+
+	// WarnBOMStripped reports a stray byte order mark removed by Tolerant.
+	WarnBOMStripped = "BOM_STRIPPED"
+
+	// WarnCompatContinuationLevel reports a CONC/CONT line re-attached to the
+	// text it continues (Ancestry).
+	WarnCompatContinuationLevel = "COMPAT_CONTINUATION_LEVEL"
+
+	// WarnCompatEmptyDate reports a dropped DATE line with no value (MyHeritage).
+	WarnCompatEmptyDate = "COMPAT_EMPTY_DATE"
+
+	// WarnCompatLevelJump reports a line whose level was clamped after an
+	// illegal level jump (RootsMagic).
+	WarnCompatLevelJump = "COMPAT_LEVEL_JUMP"
+
+	// WarnUnknownTag reports a tag that is neither defined by any GEDCOM
+	// version nor an underscore extension, such as a misspelled "BIRTH". The
+	// line is kept in the record's Tags but not interpreted.
+	WarnUnknownTag = "UNKNOWN_TAG"
+
+	// WarnUnknownRecordSkipped reports a record of non-standard type dropped
+	// by UnknownRecordSkip.
+	WarnUnknownRecordSkipped = "UNKNOWN_RECORD_SKIPPED"
+
+	// WarnTextSanitized reports a value from which SanitizeText removed
+	// control or invisible characters.
+	WarnTextSanitized = "TEXT_SANITIZED"
+
+	// WarnVoidPointer reports a pointer to @VOID@ under VoidWarn.
+	WarnVoidPointer = "VOID_POINTER"
+)
+
+// unknownTagWarnings returns a WarnUnknownTag warning for each line whose
+// tag is neither standard nor an extension.
+func unknownTagWarnings(lines []*parser.Line) []gedcom.Warning {
+	var warnings []gedcom.Warning
+	for _, line := range lines {
+		tag := tags.Tag(line.Tag)
+		if tag.IsStandard() || tag.IsExtension() {
+			continue
+		}
+		warnings = append(warnings, gedcom.Warning{
+			Code:    WarnUnknownTag,
+			Line:    line.LineNumber,
+			Message: fmt.Sprintf("unknown tag %s kept uninterpreted", line.Tag),
+		})
+	}
+	return warnings
+}
+
+// toleratedLineWarnings returns a warning for each line accepted by a
+// tolerant parser.
+func toleratedLineWarnings(tolerated []parser.ToleratedLine) []gedcom.Warning {
+	var warnings []gedcom.Warning
+	for _, t := range tolerated {
+		w := gedcom.Warning{Code: WarnBOMStripped, Line: t.Line, Message: "stray byte order mark removed"}
+		if t.Blank {
+			w = gedcom.Warning{Code: WarnBlankLineSkipped, Line: t.Line, Message: "blank line skipped"}
+		}
+		warnings = append(warnings, w)
+	}
+	return warnings
+}
+
+// tagRepairWarnings returns a WarnTagNormalized warning for each tag the
+// parser uppercased.
+func tagRepairWarnings(repairs []parser.TagRepair) []gedcom.Warning {
+	var warnings []gedcom.Warning
+	for _, r := range repairs {
+		warnings = append(warnings, gedcom.Warning{
+			Code:    WarnTagNormalized,
+			Line:    r.Line,
+			Message: fmt.Sprintf("tag %s read as %s", r.Original, r.Repaired),
+		})
+	}
+	return warnings
+}
+
+// levelRepairWarnings returns a warning with the given code for each level
+// jump the parser repaired.
+func levelRepairWarnings(code string, repairs []parser.LevelRepair) []gedcom.Warning {
+	var warnings []gedcom.Warning
+	for _, r := range repairs {
+		warnings = append(warnings, gedcom.Warning{
+			Code: code,
+			Line: r.Line,
+			Message: fmt.Sprintf("level jump from %d to %d repaired as level %d",
+				r.Previous, r.Original, r.Repaired),
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,42 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnknownTagWarnings(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n" +
+		"0 @I1@ INDI\n1 NAME John /Doe/\n1 BIRTH\n2 DATE 1900\n1 _MILT Army\n" +
+		"0 TRLR\n"
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Warnings) != 1 {
+		t.Fatalf("Warnings = %v, want one", doc.Warnings)
+	}
+	w := doc.Warnings[0]
+	if w.Code != WarnUnknownTag || w.Line != 6 || !strings.Contains(w.Message, "BIRTH") {
+		t.Errorf("Warning = %v, want %s for BIRTH at line 6", w, WarnUnknownTag)
+	}
+	if doc.GetRecord("@I1@").Find("BIRTH.DATE") == nil {
+		t.Error("unknown tag was not kept")
+	}
+}
+
+func TestUnknownRecordSkippedWarning(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n" +
+		"0 @P1@ _PLC\n1 NAME Boston\n" +
+		"0 @I1@ INDI\n1 NAME John /Doe/\n" +
+		"0 TRLR\n"
+	opts := DefaultOptions()
+	opts.UnknownRecords = UnknownRecordSkip
+	doc, err := DecodeWithOptions(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Warnings) != 1 || doc.Warnings[0].Code != WarnUnknownRecordSkipped || doc.Warnings[0].Line != 4 {
+		t.Errorf("Warnings = %v, want one %s at line 4", doc.Warnings, WarnUnknownRecordSkipped)
+	}
+}
@@ -25,6 +25,7 @@ package encoder
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/cacack/gedcom-go/gedcom"
 )
@@ -41,28 +42,74 @@ func Encode(w io.Writer, doc *gedcom.Document) error {
 
 // EncodeWithOptions writes a GEDCOM document with custom options.
 func EncodeWithOptions(w io.Writer, doc *gedcom.Document, opts *EncodeOptions) error {
+	_, err := EncodeWithReport(w, doc, opts)
+	return err
+}
+
+// EncodeWithReport writes a GEDCOM document with custom options and returns
+// a LossReport describing the data that could not be written. The report is
+// returned even when encoding fails, covering the records written so far.
+func EncodeWithReport(w io.Writer, doc *gedcom.Document, opts *EncodeOptions) (*LossReport, error) {
 	if opts == nil {
 		opts = DefaultOptions()
 	}
+	report := &LossReport{}
 
-	// Write header
-	if err := writeHeader(w, doc.Header, opts); err != nil {
-		return err
+	// Rename XRefs on a copy so the caller's options are left untouched
+	if renames := xrefRenames(doc, opts); renames != nil {
+		renamed := *opts
+		renamed.xrefRenames = renames
+		opts = &renamed
+	}
+
+	// Record where each output line came from
+	if opts.LineMap != nil {
+		*opts.LineMap = (*opts.LineMap)[:0]
+		w = &lineMapWriter{w: w, m: opts.LineMap}
+	}
+
+	// Write header, using the original lines when the document kept them
+	if doc.Raw != nil && doc.Raw.BOM {
+		if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+			return report, err
+		}
+	}
+	if doc.Raw != nil && doc.Raw.Header != nil && opts.xrefRenames == nil && opts.HeaderSummary == nil {
+		if err := writeRaw(w, doc.Raw.Header, 1); err != nil {
+			return report, err
+		}
+	} else {
+		header := doc.Header
+		if opts.HeaderSummary != nil {
+			header = summarizedHeader(doc, opts.HeaderSummary)
+		}
+		if err := writeHeader(w, header, opts); err != nil {
+			return report, err
+		}
+		report.addHeaderLosses(doc.Header)
 	}
 
 	// Write records
 	for _, record := range doc.Records {
-		if err := writeRecord(w, record, opts); err != nil {
-			return err
+		if err := writeRecord(w, record, opts, report); err != nil {
+			return report, err
 		}
 	}
 
 	// Write trailer
-	if err := writeTrailer(w, opts); err != nil {
-		return err
+	trailerLine := 0
+	if doc.Trailer != nil {
+		trailerLine = doc.Trailer.LineNumber
+	}
+	if doc.Raw != nil && doc.Raw.Trailer != nil {
+		if err := writeRaw(w, doc.Raw.Trailer, trailerLine); err != nil {
+			return report, err
+		}
+	} else if err := writeTrailer(w, trailerLine, opts); err != nil {
+		return report, err
 	}
 
-	return nil
+	return report, nil
 }
 
 func writeHeader(w io.Writer, header *gedcom.Header, opts *EncodeOptions) error {
@@ -99,6 +146,40 @@ func writeHeader(w io.Writer, header *gedcom.Header, opts *EncodeOptions) error
 		if _, err := fmt.Fprintf(w, "1 SOUR %s%s", header.SourceSystem, opts.LineEnding); err != nil {
 			return err
 		}
+		for _, sub := range []struct{ tag, value string }{
+			{"VERS", header.SourceVersion},
+			{"NAME", header.SourceName},
+			{"CORP", header.SourceCorporation},
+		} {
+			if sub.value == "" {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "2 %s %s%s", sub.tag, sub.value, opts.LineEnding); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The export time is written only with the header summary
+	if opts.HeaderSummary != nil && header != nil && !header.Date.IsZero() {
+		date := strings.ToUpper(header.Date.Format("2 Jan 2006"))
+		if _, err := fmt.Fprintf(w, "1 DATE %s%s2 TIME %s%s", date, opts.LineEnding, header.Date.Format("15:04:05"), opts.LineEnding); err != nil {
+			return err
+		}
+	}
+
+	if header != nil && header.Submitter != "" {
+		if _, err := fmt.Fprintf(w, "1 SUBM %s%s", renamedXRef(header.Submitter, opts), opts.LineEnding); err != nil {
+			return err
+		}
+	}
+
+	if header != nil && header.Copyright != "" {
+		for _, tag := range textToTags(header.Copyright, 1, "COPR", opts) {
+			if err := writeTag(w, tag, opts); err != nil {
+				return err
+			}
+		}
 	}
 
 	if header != nil && header.Language != "" {
@@ -107,26 +188,55 @@ func writeHeader(w io.Writer, header *gedcom.Header, opts *EncodeOptions) error
 		}
 	}
 
+	if header != nil && header.PlaceForm != "" {
+		if _, err := fmt.Fprintf(w, "1 PLAC%s2 FORM %s%s", opts.LineEnding, header.PlaceForm, opts.LineEnding); err != nil {
+			return err
+		}
+	}
+
+	if header != nil && header.Note != "" {
+		for _, tag := range textToTags(header.Note, 1, "NOTE", opts) {
+			if err := writeTag(w, tag, opts); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
-func writeRecord(w io.Writer, record *gedcom.Record, opts *EncodeOptions) error {
-	// Write record line
+func writeRecord(w io.Writer, record *gedcom.Record, opts *EncodeOptions, report *LossReport) error {
+	// Unedited records decoded with PreserveRaw are written as they were read
+	if record.Raw != nil && !record.TagsModified() && !record.EntityModified() && opts.xrefRenames == nil {
+		return writeRaw(w, record.Raw, record.LineNumber)
+	}
+
+	// Write record line, including the level 0 value (NOTE text, or the
+	// payload of vendor records such as _PLC)
+	line := "0 "
 	if record.XRef != "" {
-		if _, err := fmt.Fprintf(w, "0 %s %s%s", record.XRef, record.Type, opts.LineEnding); err != nil {
-			return err
-		}
-	} else {
-		if _, err := fmt.Fprintf(w, "0 %s%s", record.Type, opts.LineEnding); err != nil {
-			return err
-		}
+		line += renamedXRef(record.XRef, opts) + " "
+	}
+	line += string(record.Type)
+	if value := sanitizedValue(record.Value, opts); value != "" {
+		line += " " + value
+	}
+	setSourceLine(w, record.LineNumber)
+	if _, err := fmt.Fprintf(w, "%s%s", line, opts.LineEnding); err != nil {
+		return err
 	}
 
 	// Determine which tags to write:
+	// - If both Tags and Entity were edited, they cannot be reconciled
+	// - If Entity was marked modified, regenerate tags from the entity
 	// - If record.Tags has content, use those (preserves lossless behavior)
 	// - If record.Tags is empty/nil but Entity is set, convert entity to tags
+	if record.EntityModified() && record.TagsModified() {
+		return &SyncConflictError{XRef: record.XRef}
+	}
 	tags := record.Tags
-	if len(tags) == 0 && record.Entity != nil {
+	if (len(tags) == 0 || record.EntityModified()) && record.LoadEntity() != nil {
+		report.addRecordLosses(record)
 		tags = entityToTags(record, opts)
 	}
 
@@ -141,8 +251,9 @@ func writeRecord(w io.Writer, record *gedcom.Record, opts *EncodeOptions) error
 }
 
 func writeTag(w io.Writer, tag *gedcom.Tag, opts *EncodeOptions) error {
-	if tag.Value != "" {
-		if _, err := fmt.Fprintf(w, "%d %s %s%s", tag.Level, tag.Tag, tag.Value, opts.LineEnding); err != nil {
+	setSourceLine(w, tag.LineNumber)
+	if value := sanitizedValue(renamedXRef(tag.Value, opts), opts); value != "" {
+		if _, err := fmt.Fprintf(w, "%d %s %s%s", tag.Level, tag.Tag, value, opts.LineEnding); err != nil {
 			return err
 		}
 	} else {
@@ -153,7 +264,32 @@ func writeTag(w io.Writer, tag *gedcom.Tag, opts *EncodeOptions) error {
 	return nil
 }
 
-func writeTrailer(w io.Writer, opts *EncodeOptions) error {
+// sanitizedValue returns value, sanitized if opts.SanitizeText is set.
+func sanitizedValue(value string, opts *EncodeOptions) string {
+	if opts.SanitizeText {
+		value, _ = gedcom.SanitizeText(value)
+	}
+	return value
+}
+
+// writeRaw writes original lines, which already carry their line endings.
+// firstLine is the input line number of lines[0], or 0 if unknown.
+func writeRaw(w io.Writer, lines []string, firstLine int) error {
+	for i, line := range lines {
+		if firstLine > 0 {
+			setSourceLine(w, firstLine+i)
+		} else {
+			setSourceLine(w, 0)
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTrailer(w io.Writer, line int, opts *EncodeOptions) error {
+	setSourceLine(w, line)
 	_, err := fmt.Fprintf(w, "0 TRLR%s", opts.LineEnding)
 	return err
 }
@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -64,6 +65,83 @@ func TestEncodeRoundtrip(t *testing.T) {
 	}
 }
 
+func TestEncodeLazyEntities(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n" +
+		"0 @I1@ INDI\n1 NAME John /Smith/\n1 FAMS @F1@\n" +
+		"0 @F1@ FAM\n1 HUSB @I1@\n" +
+		"0 TRLR\n"
+
+	doc, err := decoder.DecodeWithOptions(strings.NewReader(input), &decoder.DecodeOptions{LazyEntities: true})
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if buf.String() != input {
+		t.Errorf("Encode() = %q, want %q", buf.String(), input)
+	}
+
+	// Records with tags are written from them without building entities
+	for _, record := range doc.Records {
+		if record.Entity != nil {
+			t.Errorf("%s entity built during encode", record.XRef)
+		}
+	}
+	if n := doc.LoadEntities(); n != len(doc.Records) {
+		t.Errorf("LoadEntities() = %d, want %d pending entities", n, len(doc.Records))
+	}
+}
+
+func TestEncodeRoundtripAfterTagEdits(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 1900
+1 _OLD obsolete
+0 TRLR
+`
+
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	rec := doc.GetRecord("@I1@")
+	if err := rec.SetTag("BIRT.PLAC.MAP.LATI", "N42.36"); err != nil {
+		t.Fatalf("SetTag() error = %v", err)
+	}
+	if _, err := rec.AddTag("BIRT.NOTE", "Patched"); err != nil {
+		t.Fatalf("AddTag() error = %v", err)
+	}
+	rec.RemoveTag("_OLD")
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	doc2, err := decoder.Decode(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("Failed to decode edited output: %v\n%s", err, buf.String())
+	}
+	rec2 := doc2.GetRecord("@I1@")
+	if tag := rec2.Find("BIRT.PLAC.MAP.LATI"); tag == nil || tag.Value != "N42.36" {
+		t.Errorf("LATI = %+v", tag)
+	}
+	if tag := rec2.Find("BIRT.NOTE"); tag == nil || tag.Value != "Patched" {
+		t.Errorf("NOTE = %+v", tag)
+	}
+	if rec2.Find("_OLD") != nil {
+		t.Error("_OLD should be removed")
+	}
+}
+
 func TestEncodeCRLF(t *testing.T) {
 	input := `0 HEAD
 1 GEDC
@@ -141,6 +219,39 @@ func TestEncodeWithOptionsEncodingOverride(t *testing.T) {
 	}
 }
 
+func TestEncodeSanitizeText(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: "5.5.1", Encoding: "UTF-8"},
+		Records: []*gedcom.Record{
+			{XRef: "@N1@", Type: gedcom.RecordTypeNote, Value: "Note\u200B text"},
+			{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NAME", Value: "John\x07 /Doe/\uFEFF"},
+			}},
+		},
+	}
+
+	opts := DefaultOptions()
+	opts.SanitizeText = true
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+	for _, want := range []string{"0 @N1@ NOTE Note text\n", "1 NAME John /Doe/\n"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	buf.Reset()
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "John\x07 /Doe/\uFEFF") {
+		t.Error("Encode() without SanitizeText changed the value")
+	}
+}
+
 func TestEncodeHeaderFields(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -153,7 +264,11 @@ func TestEncodeHeaderFields(t *testing.T) {
 				Version:      "5.5.1",
 				Encoding:     "UTF-8",
 				SourceSystem: "MyGedcomApp",
+				Submitter:    "@U1@",
+				Copyright:    "(C) 2024",
 				Language:     "English",
+				PlaceForm:    "City, County, State, Country",
+				Note:         "Line one\nLine two",
 			},
 			want: []string{
 				"0 HEAD",
@@ -161,7 +276,29 @@ func TestEncodeHeaderFields(t *testing.T) {
 				"2 VERS 5.5.1",
 				"1 CHAR UTF-8",
 				"1 SOUR MyGedcomApp",
+				"1 SUBM @U1@",
+				"1 COPR (C) 2024",
 				"1 LANG English",
+				"1 PLAC",
+				"2 FORM City, County, State, Country",
+				"1 NOTE Line one",
+				"2 CONT Line two",
+			},
+		},
+		{
+			name: "header with source product",
+			header: &gedcom.Header{
+				SourceSystem:      "FTM",
+				SourceVersion:     "24.0",
+				SourceName:        "Family Tree Maker for Windows",
+				SourceCorporation: "The Software MacKiev Company",
+			},
+			want: []string{
+				"0 HEAD",
+				"1 SOUR FTM",
+				"2 VERS 24.0",
+				"2 NAME Family Tree Maker for Windows",
+				"2 CORP The Software MacKiev Company",
 			},
 		},
 		{
@@ -239,6 +376,48 @@ func TestEncodeHeaderFields(t *testing.T) {
 	}
 }
 
+func TestEncodeHeaderCopyrightRoundtrip(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+1 COPR (C) 2024 Jane Researcher
+2 CONT All rights reserved.
+1 SOUR MyGedcomApp
+2 DATA Census Index
+3 COPR Data copyright
+4 CONT second line
+0 TRLR`
+
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if want := "(C) 2024 Jane Researcher\nAll rights reserved."; doc.Header.Copyright != want {
+		t.Errorf("Header.Copyright = %q, want %q", doc.Header.Copyright, want)
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "1 COPR (C) 2024 Jane Researcher\n2 CONT All rights reserved.\n") {
+		t.Errorf("Output missing the file copyright:\n%s", output)
+	}
+	if strings.Contains(output, "1 COPR Data copyright") || strings.Contains(output, "second line") {
+		t.Errorf("Output promotes the source data copyright to the file:\n%s", output)
+	}
+
+	doc2, err := decoder.Decode(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("Decode() of encoded output error = %v", err)
+	}
+	if doc2.Header.Copyright != doc.Header.Copyright {
+		t.Errorf("round-tripped Copyright = %q, want %q", doc2.Header.Copyright, doc.Header.Copyright)
+	}
+}
+
 func TestEncodeRecords(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1386,6 +1565,27 @@ func TestRoundtripTagsPreserved(t *testing.T) {
 	}
 }
 
+// TestRoundtripUnknownRecords tests that vendor records keep their level 0
+// value and full subtree
+func TestRoundtripUnknownRecords(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n" +
+		"0 @P1@ _PLC Boston, Suffolk, Massachusetts\n1 _GOV ABC123\n2 DATE 1900\n" +
+		"0 @N1@ NOTE Shared note text\n1 CONT second line\n" +
+		"0 TRLR\n"
+
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if buf.String() != input {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), input)
+	}
+}
+
 // TestRoundtripComplexIndividual tests round-trip of an individual with all fields
 func TestRoundtripComplexIndividual(t *testing.T) {
 	doc := &gedcom.Document{
@@ -1656,6 +1856,65 @@ func TestRoundtripFamilySearchID(t *testing.T) {
 	}
 }
 
+func TestRoundtripIdentifiers(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 NAME John /Doe/
+1 REFN 42
+2 TYPE Ancestry member tree
+1 EXID KWCJ-QN7
+2 TYPE https://www.familysearch.org/tree/person/
+1 REFN 43
+0 @R1@ REPO
+1 NAME State Archive
+1 EXID 1234
+2 TYPE https://archive.example.org/
+0 TRLR
+`
+	doc1, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	indi1 := doc1.GetIndividual("@I1@")
+	want := []*gedcom.Identifier{
+		{Kind: gedcom.IdentifierREFN, Value: "42", Type: "Ancestry member tree"},
+		{Kind: gedcom.IdentifierEXID, Value: "KWCJ-QN7", Type: "https://www.familysearch.org/tree/person/"},
+		{Kind: gedcom.IdentifierREFN, Value: "43"},
+	}
+	if !reflect.DeepEqual(indi1.Identifiers, want) {
+		t.Fatalf("Identifiers = %+v, want %+v", indi1.Identifiers, want)
+	}
+	if indi1.RefNumber != "42" {
+		t.Errorf("RefNumber = %q, want first REFN", indi1.RefNumber)
+	}
+
+	// Regenerate both records from their entities
+	doc1.GetRecord("@I1@").MarkEntityModified()
+	doc1.GetRecord("@R1@").MarkEntityModified()
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc1); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	doc2, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() after round trip error = %v", err)
+	}
+	if got := doc2.GetIndividual("@I1@").Identifiers; !reflect.DeepEqual(got, want) {
+		t.Errorf("Identifiers after round trip = %+v, want %+v", got, want)
+	}
+	if got := doc2.GetRepository("@R1@").Identifiers; len(got) != 1 || got[0].Type != "https://archive.example.org/" {
+		t.Errorf("repository Identifiers after round trip = %+v", got)
+	}
+
+	// Entities built without Identifiers still write their RefNumber
+	tags := individualToTags(&gedcom.Individual{RefNumber: "7"}, DefaultOptions())
+	if len(tags) != 1 || tags[0].Tag != "REFN" || tags[0].Value != "7" {
+		t.Errorf("individualToTags() = %v, want REFN 7", tags)
+	}
+}
+
 func collectGEDFiles(t *testing.T, root string) []string {
 	t.Helper()
 
@@ -1677,3 +1936,182 @@ func collectGEDFiles(t *testing.T, root string) []string {
 	}
 	return files
 }
+
+func TestEncodeEntityModified(t *testing.T) {
+	input := `0 HEAD
+0 @I1@ INDI
+1 NAME John /Smith/
+1 SEX M
+0 TRLR
+`
+
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	rec := doc.GetRecord("@I1@")
+	doc.GetIndividual("@I1@").Sex = "F"
+
+	// Without marking, the raw tags are authoritative
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "1 SEX M") {
+		t.Errorf("unmarked entity edit should not be written:\n%s", buf.String())
+	}
+
+	rec.MarkEntityModified()
+	buf.Reset()
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "1 SEX F") {
+		t.Errorf("marked entity edit should be written:\n%s", buf.String())
+	}
+
+	// Editing both sides is a conflict
+	if err := rec.SetTag("SEX", "U"); err != nil {
+		t.Fatalf("SetTag() error = %v", err)
+	}
+	var conflict *SyncConflictError
+	if err := Encode(&bytes.Buffer{}, doc); !errors.As(err, &conflict) || conflict.XRef != "@I1@" {
+		t.Errorf("Encode() error = %v, want SyncConflictError", err)
+	}
+}
+
+func TestSyncTags(t *testing.T) {
+	ind := &gedcom.Individual{XRef: "@I1@", Sex: "F"}
+	rec := &gedcom.Record{
+		XRef:   "@I1@",
+		Type:   gedcom.RecordTypeIndividual,
+		Tags:   []*gedcom.Tag{{Level: 1, Tag: "SEX", Value: "M"}},
+		Entity: ind,
+	}
+	rec.MarkEntityModified()
+
+	SyncTags(rec, nil)
+	if tag := rec.Find("SEX"); tag == nil || tag.Value != "F" {
+		t.Errorf("SEX tag = %+v, want F", tag)
+	}
+	if rec.EntityModified() {
+		t.Error("SyncTags should clear modified state")
+	}
+
+	SyncTags(nil, nil)
+	SyncTags(&gedcom.Record{}, nil)
+}
+
+func TestRoundtripPreserveRaw(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"mixed line endings", "0 HEAD\r\n1 GEDC\n2 VERS 5.5.1\r\n1 CHAR UTF-8\n0 @I1@ INDI\r\n1 NAME John /Smith/\n0 TRLR\r\n"},
+		{"byte order mark", "\xEF\xBB\xBF0 HEAD\n1 GEDC\n2 VERS 5.5.1\n0 @I1@ INDI\n1 NAME John /Smith/\n0 TRLR\n"},
+		{"conc split and custom order", "0 HEAD\n1 GEDC\n2 VERS 5.5\n1 _CUSTOM header ext\n" +
+			"0 @I1@ INDI\n1 _UID 1234\n1 SEX M\n1 NAME John /Smith/\n" +
+			"1 NOTE A long note that was wra\n2 CONC pped mid-word\n2 CONT and continued\n" +
+			"0 @N1@ NOTE Shared\n1 CONC  note\n0 TRLR\n"},
+		{"no trailing newline", "0 HEAD\n0 @I1@ INDI\n1 NAME John /Smith/\n0 TRLR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := decoder.DecodeWithOptions(strings.NewReader(tt.input), &decoder.DecodeOptions{PreserveRaw: true})
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := Encode(&buf, doc); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if buf.String() != tt.input {
+				t.Errorf("output =\n%q\nwant\n%q", buf.String(), tt.input)
+			}
+		})
+	}
+
+	t.Run("sample file", func(t *testing.T) {
+		input, err := os.ReadFile("../testdata/edge-cases/cont-conc.ged")
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		doc, err := decoder.DecodeWithOptions(bytes.NewReader(input), &decoder.DecodeOptions{PreserveRaw: true})
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		var buf bytes.Buffer
+		if err := Encode(&buf, doc); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		if !bytes.Equal(buf.Bytes(), input) {
+			t.Error("output differs from the original file")
+		}
+	})
+}
+
+func TestPreserveRawModifiedRecord(t *testing.T) {
+	input := "0 HEAD\r\n0 @I1@ INDI\r\n1 _UID 1234\r\n1 NAME John /Smith/\r\n" +
+		"0 @I2@ INDI\r\n1 NAME Jane /Doe/\r\n1 SEX F\r\n0 TRLR\r\n"
+
+	doc, err := decoder.DecodeWithOptions(strings.NewReader(input), &decoder.DecodeOptions{PreserveRaw: true})
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if err := doc.GetRecord("@I2@").SetTag("SEX", "U"); err != nil {
+		t.Fatalf("SetTag() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := "0 HEAD\r\n0 @I1@ INDI\r\n1 _UID 1234\r\n1 NAME John /Smith/\r\n" +
+		"0 @I2@ INDI\n1 NAME Jane /Doe/\n1 SEX U\n0 TRLR\r\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestEncodeSplitParts(t *testing.T) {
+	f, err := os.Open("../testdata/gedcom-5.5/royal92.ged")
+	if err != nil {
+		t.Skipf("test file not available: %v", err)
+	}
+	defer f.Close()
+	doc, err := decoder.Decode(f)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	parts, err := gedcom.SplitBySize(doc, 1000)
+	if err != nil {
+		t.Fatalf("SplitBySize() error = %v", err)
+	}
+	if len(parts) < 2 {
+		t.Fatalf("len(parts) = %d, want several", len(parts))
+	}
+
+	individuals := 0
+	for i, part := range parts {
+		var buf bytes.Buffer
+		if err := Encode(&buf, part); err != nil {
+			t.Fatalf("part %d: Encode() error = %v", i, err)
+		}
+		opts := decoder.DefaultOptions()
+		opts.ValidateXRefs = true
+		opts.ValidateStructure = true
+		decoded, err := decoder.DecodeWithOptions(&buf, opts)
+		if err != nil {
+			t.Fatalf("part %d: decode error = %v", i, err)
+		}
+		if len(decoded.Records) > 1000 {
+			t.Errorf("part %d has %d records, limit is 1000", i, len(decoded.Records))
+		}
+		individuals += len(decoded.Individuals())
+	}
+	if individuals != len(doc.Individuals()) {
+		t.Errorf("parts hold %d individuals, want %d", individuals, len(doc.Individuals()))
+	}
+}
@@ -142,9 +142,9 @@ func entityToTags(record *gedcom.Record, opts *EncodeOptions) []*gedcom.Tag {
 		if repo, ok := record.Entity.(*gedcom.Repository); ok {
 			return repositoryToTags(repo, opts)
 		}
-	case gedcom.RecordTypeNote:
+	case gedcom.RecordTypeNote, gedcom.RecordTypeSharedNote:
 		if note, ok := record.Entity.(*gedcom.Note); ok {
-			return noteToTags(note)
+			return noteToTags(note, opts)
 		}
 	case gedcom.RecordTypeMedia:
 		if media, ok := record.Entity.(*gedcom.MediaObject); ok {
@@ -226,21 +226,46 @@ func individualToTags(indi *gedcom.Individual, opts *EncodeOptions) []*gedcom.Ta
 		tags = append(tags, changeDateToTags(indi.CreationDate, 1, "CREA")...)
 	}
 
-	// Reference number (level 1) - REFN
-	if indi.RefNumber != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REFN", Value: indi.RefNumber})
-	}
+	// Identifiers (level 1) - REFN, EXID
+	tags = append(tags, identifiersToTags(indi.Identifiers, indi.RefNumber)...)
 
-	// UID (level 1)
-	if indi.UID != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "UID", Value: indi.UID})
-	}
+	// UID and vendor _UID (level 1)
+	tags = append(tags, uidsToTags(indi.UID, indi.UIDs)...)
 
 	// FamilySearch Family Tree ID (level 1) - _FSFTID
 	if indi.FamilySearchID != "" {
 		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "_FSFTID", Value: indi.FamilySearchID})
 	}
 
+	// Language (level 1) - LANG
+	if indi.Language != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "LANG", Value: indi.Language})
+	}
+
+	// Aliases and submitter interests (level 1) - ALIA, ANCI, DESI
+	for _, alias := range indi.Aliases {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "ALIA", Value: alias})
+	}
+	for _, subm := range indi.AncestorInterest {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "ANCI", Value: subm})
+	}
+	for _, subm := range indi.DescendantInterest {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "DESI", Value: subm})
+	}
+
+	// Restriction (level 1) - RESN
+	if indi.Restriction != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RESN", Value: indi.Restriction})
+	}
+
+	// Record numbers (level 1) - RIN, AFN
+	if indi.AutomatedRecordID != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RIN", Value: indi.AutomatedRecordID})
+	}
+	if indi.AncestralFileNumber != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "AFN", Value: indi.AncestralFileNumber})
+	}
+
 	return tags
 }
 
@@ -305,15 +330,11 @@ func familyToTags(fam *gedcom.Family, opts *EncodeOptions) []*gedcom.Tag {
 		tags = append(tags, changeDateToTags(fam.CreationDate, 1, "CREA")...)
 	}
 
-	// Reference number (level 1) - REFN
-	if fam.RefNumber != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REFN", Value: fam.RefNumber})
-	}
+	// Identifiers (level 1) - REFN, EXID
+	tags = append(tags, identifiersToTags(fam.Identifiers, fam.RefNumber)...)
 
-	// UID (level 1)
-	if fam.UID != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "UID", Value: fam.UID})
-	}
+	// UID and vendor _UID (level 1)
+	tags = append(tags, uidsToTags(fam.UID, fam.UIDs)...)
 
 	return tags
 }
@@ -342,8 +363,12 @@ func sourceToTags(src *gedcom.Source, opts *EncodeOptions) []*gedcom.Tag {
 		tags = append(tags, textToTags(src.Text, 1, "TEXT", opts)...)
 	}
 
-	// Repository reference or inline (level 1) - REPO
-	if src.RepositoryRef != "" {
+	// Repository citations (level 1) - REPO with CALN/MEDI
+	if len(src.Repositories) > 0 {
+		for _, citation := range src.Repositories {
+			tags = append(tags, repositoryCitationToTags(citation, opts)...)
+		}
+	} else if src.RepositoryRef != "" {
 		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REPO", Value: src.RepositoryRef})
 	} else if src.Repository != nil && src.Repository.Name != "" {
 		tags = append(tags,
@@ -352,6 +377,11 @@ func sourceToTags(src *gedcom.Source, opts *EncodeOptions) []*gedcom.Tag {
 		)
 	}
 
+	// Recorded data (level 1) - DATA with EVEN/AGNC/NOTE
+	if src.Data != nil {
+		tags = append(tags, sourceDataToTags(src.Data, opts)...)
+	}
+
 	// Media links (level 1) - OBJE
 	for _, media := range src.Media {
 		tags = append(tags, mediaLinkToTags(media, 1)...)
@@ -372,10 +402,8 @@ func sourceToTags(src *gedcom.Source, opts *EncodeOptions) []*gedcom.Tag {
 		tags = append(tags, changeDateToTags(src.CreationDate, 1, "CREA")...)
 	}
 
-	// Reference number (level 1) - REFN
-	if src.RefNumber != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REFN", Value: src.RefNumber})
-	}
+	// Identifiers (level 1) - REFN, EXID
+	tags = append(tags, identifiersToTags(src.Identifiers, src.RefNumber)...)
 
 	// UID (level 1)
 	if src.UID != "" {
@@ -385,6 +413,47 @@ func sourceToTags(src *gedcom.Source, opts *EncodeOptions) []*gedcom.Tag {
 	return tags
 }
 
+// sourceDataToTags converts a source's DATA structure to GEDCOM tags at
+// level 1.
+func sourceDataToTags(data *gedcom.SourceData, opts *EncodeOptions) []*gedcom.Tag {
+	tags := []*gedcom.Tag{{Level: 1, Tag: "DATA"}}
+	for _, event := range data.Events {
+		tags = append(tags, &gedcom.Tag{Level: 2, Tag: "EVEN", Value: event.Types})
+		if event.Date != "" {
+			tags = append(tags, &gedcom.Tag{Level: 3, Tag: "DATE", Value: event.Date})
+		}
+		if event.Place != "" {
+			tags = append(tags, &gedcom.Tag{Level: 3, Tag: "PLAC", Value: event.Place})
+		}
+	}
+	if data.Agency != "" {
+		tags = append(tags, &gedcom.Tag{Level: 2, Tag: "AGNC", Value: data.Agency})
+	}
+	for _, note := range data.Notes {
+		tags = append(tags, textToTags(note, 2, "NOTE", opts)...)
+	}
+	return tags
+}
+
+// repositoryCitationToTags converts a source's repository citation to GEDCOM
+// tags at level 1.
+func repositoryCitationToTags(citation *gedcom.RepositoryCitation, opts *EncodeOptions) []*gedcom.Tag {
+	tags := []*gedcom.Tag{{Level: 1, Tag: "REPO", Value: citation.RepositoryXRef}}
+	if citation.RepositoryXRef == "" && citation.Name != "" {
+		tags = append(tags, &gedcom.Tag{Level: 2, Tag: "NAME", Value: citation.Name})
+	}
+	for _, note := range citation.Notes {
+		tags = append(tags, textToTags(note, 2, "NOTE", opts)...)
+	}
+	for _, caln := range citation.CallNumbers {
+		tags = append(tags, &gedcom.Tag{Level: 2, Tag: "CALN", Value: caln.Number})
+		if caln.Medium != "" {
+			tags = append(tags, &gedcom.Tag{Level: 3, Tag: "MEDI", Value: caln.Medium})
+		}
+	}
+	return tags
+}
+
 // submitterToTags converts a Submitter entity to GEDCOM tags.
 func submitterToTags(subm *gedcom.Submitter, opts *EncodeOptions) []*gedcom.Tag {
 	var tags []*gedcom.Tag
@@ -396,7 +465,7 @@ func submitterToTags(subm *gedcom.Submitter, opts *EncodeOptions) []*gedcom.Tag
 
 	// Address (level 1) - ADDR
 	if subm.Address != nil {
-		tags = append(tags, addressToTags(subm.Address, 1)...)
+		tags = append(tags, addressToTags(subm.Address, 1, opts)...)
 	}
 
 	// Phone numbers (level 1) - PHON
@@ -419,6 +488,9 @@ func submitterToTags(subm *gedcom.Submitter, opts *EncodeOptions) []*gedcom.Tag
 		tags = append(tags, textToTags(note, 1, "NOTE", opts)...)
 	}
 
+	// Identifiers (level 1) - REFN, EXID
+	tags = append(tags, identifiersToTags(subm.Identifiers)...)
+
 	return tags
 }
 
@@ -432,8 +504,35 @@ func repositoryToTags(repo *gedcom.Repository, opts *EncodeOptions) []*gedcom.Ta
 	}
 
 	// Address (level 1) - ADDR
+	if repo.Address.Formatted() != "" {
+		tags = append(tags, addressToTags(repo.Address, 1, opts)...)
+	}
+
+	// Contact info (level 1) - PHON, EMAIL, FAX, WWW. Repositories built
+	// without the contact lists fall back to the Address fields.
+	phones, emails, websites := repo.Phone, repo.Email, repo.Website
 	if repo.Address != nil {
-		tags = append(tags, addressToTags(repo.Address, 1)...)
+		if len(phones) == 0 && repo.Address.Phone != "" {
+			phones = []string{repo.Address.Phone}
+		}
+		if len(emails) == 0 && repo.Address.Email != "" {
+			emails = []string{repo.Address.Email}
+		}
+		if len(websites) == 0 && repo.Address.Website != "" {
+			websites = []string{repo.Address.Website}
+		}
+	}
+	for _, phone := range phones {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "PHON", Value: phone})
+	}
+	for _, email := range emails {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "EMAIL", Value: email})
+	}
+	for _, fax := range repo.Fax {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "FAX", Value: fax})
+	}
+	for _, www := range websites {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "WWW", Value: www})
 	}
 
 	// Notes (level 1) - NOTE (with CONT/CONC for multiline/long)
@@ -441,11 +540,14 @@ func repositoryToTags(repo *gedcom.Repository, opts *EncodeOptions) []*gedcom.Ta
 		tags = append(tags, textToTags(note, 1, "NOTE", opts)...)
 	}
 
+	// Identifiers (level 1) - REFN, EXID
+	tags = append(tags, identifiersToTags(repo.Identifiers)...)
+
 	return tags
 }
 
 // noteToTags converts a Note entity to GEDCOM tags.
-func noteToTags(note *gedcom.Note) []*gedcom.Tag {
+func noteToTags(note *gedcom.Note, opts *EncodeOptions) []*gedcom.Tag {
 	var tags []*gedcom.Tag
 
 	// Note continuation lines (level 1) - CONT
@@ -453,6 +555,25 @@ func noteToTags(note *gedcom.Note) []*gedcom.Tag {
 		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "CONT", Value: cont})
 	}
 
+	// Media type and language (level 1) - MIME, LANG (GEDCOM 7.0)
+	if note.MediaType != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "MIME", Value: note.MediaType})
+	}
+	if note.Language != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "LANG", Value: note.Language})
+	}
+
+	// Translations (level 1) - TRAN (GEDCOM 7.0)
+	for _, tran := range note.Translations {
+		tags = append(tags, textToTags(tran.Text, 1, "TRAN", opts)...)
+		if tran.MediaType != "" {
+			tags = append(tags, &gedcom.Tag{Level: 2, Tag: "MIME", Value: tran.MediaType})
+		}
+		if tran.Language != "" {
+			tags = append(tags, &gedcom.Tag{Level: 2, Tag: "LANG", Value: tran.Language})
+		}
+	}
+
 	return tags
 }
 
@@ -485,10 +606,8 @@ func mediaObjectToTags(media *gedcom.MediaObject, opts *EncodeOptions) []*gedcom
 		tags = append(tags, changeDateToTags(media.CreationDate, 1, "CREA")...)
 	}
 
-	// Reference numbers (level 1) - REFN
-	for _, refn := range media.RefNumbers {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REFN", Value: refn})
-	}
+	// Identifiers (level 1) - REFN, EXID
+	tags = append(tags, identifiersToTags(media.Identifiers, media.RefNumbers...)...)
 
 	// UIDs (level 1)
 	for _, uid := range media.UIDs {
@@ -503,6 +622,52 @@ func mediaObjectToTags(media *gedcom.MediaObject, opts *EncodeOptions) []*gedcom
 	return tags
 }
 
+// uidsToTags converts a record's UID to a level 1 UID tag and its other
+// UIDs to vendor _UID tags, as PAF and other 5.5.1 writers use them.
+func uidsToTags(uid string, uids []string) []*gedcom.Tag {
+	var tags []*gedcom.Tag
+	if uid != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "UID", Value: uid})
+	}
+	mirrored := false
+	for _, value := range uids {
+		if value == uid && !mirrored {
+			mirrored = true
+			continue
+		}
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "_UID", Value: value})
+	}
+	return tags
+}
+
+// identifiersToTags converts identifiers to level 1 REFN and EXID tags with
+// their TYPE. Entities built without Identifiers fall back to their
+// reference numbers.
+func identifiersToTags(ids []*gedcom.Identifier, refNumbers ...string) []*gedcom.Tag {
+	var tags []*gedcom.Tag
+
+	if len(ids) == 0 {
+		for _, refn := range refNumbers {
+			if refn != "" {
+				tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REFN", Value: refn})
+			}
+		}
+		return tags
+	}
+
+	for _, id := range ids {
+		kind := id.Kind
+		if kind == "" {
+			kind = gedcom.IdentifierREFN
+		}
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: kind, Value: id.Value})
+		if id.Type != "" {
+			tags = append(tags, &gedcom.Tag{Level: 2, Tag: "TYPE", Value: id.Type})
+		}
+	}
+	return tags
+}
+
 // nameToTags converts a PersonalName to GEDCOM tags at the specified level.
 func nameToTags(name *gedcom.PersonalName, level int) []*gedcom.Tag {
 	var tags []*gedcom.Tag
@@ -581,7 +746,7 @@ func eventToTags(event *gedcom.Event, level int, opts *EncodeOptions) []*gedcom.
 	var tags []*gedcom.Tag
 
 	// Event tag (BIRT, DEAT, MARR, etc.)
-	tags = append(tags, &gedcom.Tag{Level: level, Tag: string(event.Type)})
+	tags = append(tags, &gedcom.Tag{Level: level, Tag: string(event.Type), Value: event.Description})
 
 	// Subordinate tags at level+1
 	if event.Date != "" {
@@ -601,17 +766,51 @@ func eventToTags(event *gedcom.Event, level int, opts *EncodeOptions) []*gedcom.
 		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "CAUS", Value: event.Cause})
 	}
 
-	if event.Age != "" {
+	if event.Age != "" || (event.ParsedAge != nil && event.ParsedAge.Phrase != "") {
 		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "AGE", Value: event.Age})
+		if event.ParsedAge != nil && event.ParsedAge.Phrase != "" {
+			tags = append(tags, &gedcom.Tag{Level: level + 2, Tag: "PHRASE", Value: event.ParsedAge.Phrase})
+		}
+	}
+
+	if event.HusbandAge != "" {
+		tags = append(tags,
+			&gedcom.Tag{Level: level + 1, Tag: "HUSB"},
+			&gedcom.Tag{Level: level + 2, Tag: "AGE", Value: event.HusbandAge})
+	}
+
+	if event.WifeAge != "" {
+		tags = append(tags,
+			&gedcom.Tag{Level: level + 1, Tag: "WIFE"},
+			&gedcom.Tag{Level: level + 2, Tag: "AGE", Value: event.WifeAge})
+	}
+
+	if event.FamilyXRef != "" {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "FAMC", Value: event.FamilyXRef})
+		if event.AdoptedBy != "" {
+			tags = append(tags, &gedcom.Tag{Level: level + 2, Tag: "ADOP", Value: event.AdoptedBy})
+		}
 	}
 
 	if event.Agency != "" {
 		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "AGNC", Value: event.Agency})
 	}
 
+	if event.Religion != "" {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "RELI", Value: event.Religion})
+	}
+
+	// Cemetery and grave plot (vendor extensions)
+	if event.Cemetery != "" {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "_CEME", Value: event.Cemetery})
+	}
+	if event.Plot != "" {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "_PLOT", Value: event.Plot})
+	}
+
 	// Address
 	if event.Address != nil {
-		tags = append(tags, addressToTags(event.Address, level+1)...)
+		tags = append(tags, addressToTags(event.Address, level+1, opts)...)
 	}
 
 	// Contact info
@@ -650,6 +849,16 @@ func eventToTags(event *gedcom.Event, level int, opts *EncodeOptions) []*gedcom.
 		tags = append(tags, sourceCitationToTags(cite, level+1, opts)...)
 	}
 
+	// Associations (witnesses, godparents, officiators)
+	for _, assoc := range event.Associations {
+		tags = append(tags, associationToTags(assoc, level+1, opts)...)
+	}
+
+	// Participants recorded only by name
+	for _, witness := range event.Witnesses {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "_WITN", Value: witness})
+	}
+
 	// Media links
 	for _, media := range event.Media {
 		tags = append(tags, mediaLinkToTags(media, level+1)...)
@@ -662,8 +871,8 @@ func eventToTags(event *gedcom.Event, level int, opts *EncodeOptions) []*gedcom.
 func attributeToTags(attr *gedcom.Attribute, level int, opts *EncodeOptions) []*gedcom.Tag {
 	var tags []*gedcom.Tag
 
-	// Attribute tag (OCCU, EDUC, etc.) with value
-	tags = append(tags, &gedcom.Tag{Level: level, Tag: attr.Type, Value: attr.Value})
+	// Attribute tag (OCCU, EDUC, etc.) with value (with CONT/CONC for multiline/long)
+	tags = append(tags, textToTags(attr.Value, level, attr.Type, opts)...)
 
 	// Subordinate tags at level+1
 	if attr.Date != "" {
@@ -674,6 +883,10 @@ func attributeToTags(attr *gedcom.Attribute, level int, opts *EncodeOptions) []*
 		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "PLAC", Value: attr.Place})
 	}
 
+	if attr.TypeDetail != "" {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "TYPE", Value: attr.TypeDetail})
+	}
+
 	// Source citations
 	for _, cite := range attr.SourceCitations {
 		tags = append(tags, sourceCitationToTags(cite, level+1, opts)...)
@@ -732,14 +945,20 @@ func sourceCitationDataToTags(data *gedcom.SourceCitationData, level int, opts *
 }
 
 // addressToTags converts an Address to GEDCOM tags at the specified level.
-func addressToTags(addr *gedcom.Address, level int) []*gedcom.Tag {
+// The free-form Text becomes the ADDR value with CONT/CONC lines; without
+// Text, the ADDR value falls back to Line1.
+func addressToTags(addr *gedcom.Address, level int, opts *EncodeOptions) []*gedcom.Tag {
 	var tags []*gedcom.Tag
 
-	// ADDR tag with optional first line value
-	tags = append(tags, &gedcom.Tag{Level: level, Tag: "ADDR", Value: addr.Line1})
+	if addr.Text != "" {
+		tags = append(tags, textToTags(addr.Text, level, "ADDR", opts)...)
+	} else {
+		tags = append(tags, &gedcom.Tag{Level: level, Tag: "ADDR", Value: addr.Line1})
+	}
 
-	// Subordinate tags at level+1
-	if addr.Line1 != "" {
+	// Subordinate tags at level+1. When Line1 only mirrors the free-form
+	// text (no ADR1 in the source), it is not repeated.
+	if addr.Line1 != "" && addr.Line1 != addr.Text {
 		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "ADR1", Value: addr.Line1})
 	}
 	if addr.Line2 != "" {
@@ -847,6 +1066,9 @@ func familyLinkToTags(link *gedcom.FamilyLink, level int) []*gedcom.Tag {
 	if link.Pedigree != "" {
 		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "PEDI", Value: link.Pedigree})
 	}
+	if link.Status != "" {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "STAT", Value: link.Status})
+	}
 
 	return tags
 }
@@ -879,6 +1101,50 @@ func associationToTags(assoc *gedcom.Association, level int, opts *EncodeOptions
 		tags = append(tags, textToTags(note, level+1, "NOTE", opts)...)
 	}
 
+	// DNA match (vendor extension). Matches read from a NOTE are already
+	// represented by that note and are not duplicated as _DNA.
+	if assoc.DNAMatch != nil && !containsString(assoc.Notes, assoc.DNAMatch.Text) {
+		tags = append(tags, dnaMatchToTags(assoc.DNAMatch, level+1)...)
+	}
+
+	return tags
+}
+
+// containsString reports whether value is a non-empty member of values.
+func containsString(values []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// dnaMatchToTags converts a DNAMatch to a _DNA extension structure at the specified level.
+func dnaMatchToTags(match *gedcom.DNAMatch, level int) []*gedcom.Tag {
+	var tags []*gedcom.Tag
+
+	tags = append(tags, &gedcom.Tag{Level: level, Tag: "_DNA", Value: match.Text})
+
+	if match.SharedCM != 0 {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "_CM", Value: strconv.FormatFloat(match.SharedCM, 'f', -1, 64)})
+	}
+	if match.SharedSegments != 0 {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "_SEG", Value: strconv.Itoa(match.SharedSegments)})
+	}
+	if match.LongestSegmentCM != 0 {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "_LSEG", Value: strconv.FormatFloat(match.LongestSegmentCM, 'f', -1, 64)})
+	}
+	if match.Relationship != "" {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "_REL", Value: match.Relationship})
+	}
+	if match.Provider != "" {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "TYPE", Value: match.Provider})
+	}
+
 	return tags
 }
 
@@ -959,6 +1225,9 @@ func mediaFileToTags(file *gedcom.MediaFile, level int) []*gedcom.Tag {
 		// MEDI subordinate at level+2
 		if file.MediaType != "" {
 			tags = append(tags, &gedcom.Tag{Level: level + 2, Tag: "MEDI", Value: file.MediaType})
+			if file.MediaPhrase != "" {
+				tags = append(tags, &gedcom.Tag{Level: level + 3, Tag: "PHRASE", Value: file.MediaPhrase})
+			}
 		}
 	}
 
@@ -989,3 +1258,18 @@ func mediaTranslationToTags(tran *gedcom.MediaTranslation, level int) []*gedcom.
 
 	return tags
 }
+
+// SyncTags rebuilds a record's raw Tags from its Entity, discarding tags not
+// modeled by the entity, and clears the record's modified state. Use it after
+// editing the entity directly when later code reads the raw tags.
+// If opts is nil, default options are used.
+func SyncTags(record *gedcom.Record, opts *EncodeOptions) {
+	if record == nil || record.Entity == nil {
+		return
+	}
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	record.Tags = entityToTags(record, opts)
+	record.ClearModified()
+}
@@ -2,6 +2,8 @@ package encoder
 
 import (
 	"bytes"
+	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -166,6 +168,18 @@ func TestIndividualToTags(t *testing.T) {
 			},
 			contains: []string{"CHAN", "CREA", "REFN", "UID", "TIME"},
 		},
+		{
+			name: "individual with aliases, interests, and record numbers",
+			indi: &gedcom.Individual{
+				Aliases:             []string{"@I9@"},
+				AncestorInterest:    []string{"@U1@"},
+				DescendantInterest:  []string{"@U2@"},
+				Restriction:         "confidential",
+				AutomatedRecordID:   "1042",
+				AncestralFileNumber: "1BCD-23",
+			},
+			contains: []string{"ALIA", "ANCI", "DESI", "RESN", "RIN", "AFN"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -319,6 +333,18 @@ func TestSourceToTags(t *testing.T) {
 			},
 			contains: []string{"TITL", "REPO", "NAME"},
 		},
+		{
+			name: "source with recorded data",
+			src: &gedcom.Source{
+				Title: "Parish Registers",
+				Data: &gedcom.SourceData{
+					Events: []*gedcom.SourceDataEvent{{Types: "BIRT, DEAT", Date: "FROM 1820 TO 1860", Place: "Boston"}},
+					Agency: "St. Mary Parish",
+					Notes:  []string{"Some pages missing"},
+				},
+			},
+			contains: []string{"TITL", "DATA", "EVEN", "DATE", "PLAC", "AGNC", "NOTE"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -523,6 +549,25 @@ func TestRepositoryToTags(t *testing.T) {
 			},
 			contains: []string{"NAME", "NOTE"},
 		},
+		{
+			name: "repository with contact info",
+			repo: &gedcom.Repository{
+				Name:    "County Archives",
+				Phone:   []string{"+1 555 0100"},
+				Email:   []string{"info@archives.example.org"},
+				Fax:     []string{"+1 555 0199"},
+				Website: []string{"https://archives.example.org"},
+			},
+			contains: []string{"NAME", "PHON", "EMAIL", "FAX", "WWW"},
+		},
+		{
+			name: "contact info from address",
+			repo: &gedcom.Repository{
+				Name:    "County Archives",
+				Address: &gedcom.Address{Phone: "+1 555 0100"},
+			},
+			contains: []string{"NAME", "PHON"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -539,6 +584,81 @@ func TestRepositoryToTags(t *testing.T) {
 	}
 }
 
+func TestRepositoryCitationToTags(t *testing.T) {
+	src := &gedcom.Source{
+		Title:         "Parish Registers",
+		RepositoryRef: "@R1@",
+		Repositories: []*gedcom.RepositoryCitation{
+			{
+				RepositoryXRef: "@R1@",
+				Notes:          []string{"Originals in the vault"},
+				CallNumbers: []*gedcom.CallNumber{
+					{Number: "MS 123", Medium: "MICROFILM"},
+					{Number: "MS 124"},
+				},
+			},
+			{Name: "County Archives"},
+		},
+	}
+
+	var got []string
+	for _, tag := range sourceToTags(src, nil) {
+		got = append(got, fmt.Sprintf("%d %s %s", tag.Level, tag.Tag, tag.Value))
+	}
+	want := []string{
+		"1 TITL Parish Registers",
+		"1 REPO @R1@",
+		"2 NOTE Originals in the vault",
+		"2 CALN MS 123",
+		"3 MEDI MICROFILM",
+		"2 CALN MS 124",
+		"1 REPO ",
+		"2 NAME County Archives",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sourceToTags() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRepositoryCitationRoundtrip(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @R1@ REPO
+1 NAME County Archives
+1 FAX +1 555 0199
+0 @S1@ SOUR
+1 TITL Parish Registers
+1 REPO @R1@
+2 CALN MS 123
+3 MEDI MICROFILM
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range doc.Records {
+		record.MarkEntityModified()
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	doc2, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := doc2.GetSource("@S1@").Repositories; !reflect.DeepEqual(got, doc.GetSource("@S1@").Repositories) {
+		t.Errorf("Repositories after round trip = %+v", got)
+	}
+	if got := doc2.GetRepository("@R1@").Fax; !reflect.DeepEqual(got, []string{"+1 555 0199"}) {
+		t.Errorf("Fax after round trip = %v", got)
+	}
+}
+
 func TestNoteToTags(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -558,11 +678,21 @@ func TestNoteToTags(t *testing.T) {
 			},
 			contains: []string{"CONT"},
 		},
+		{
+			name: "note with media type, language, and translation",
+			note: &gedcom.Note{
+				Text:         "<p>Hello</p>",
+				MediaType:    "text/html",
+				Language:     "en",
+				Translations: []*gedcom.NoteTranslation{{Text: "Bonjour", Language: "fr"}},
+			},
+			contains: []string{"MIME", "LANG", "TRAN"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tags := noteToTags(tt.note)
+			tags := noteToTags(tt.note, DefaultOptions())
 			tagMap := tagNamesToMap(tags)
 
 			for _, expected := range tt.contains {
@@ -771,6 +901,69 @@ func TestEventToTags(t *testing.T) {
 			level:    1,
 			contains: []string{"MARR", "DATE", "TYPE", "CAUS", "AGE", "AGNC"},
 		},
+		{
+			name: "event with age phrase",
+			event: &gedcom.Event{
+				Type:      gedcom.EventBurial,
+				ParsedAge: &gedcom.Age{Phrase: "about seventy"},
+			},
+			level:    1,
+			contains: []string{"BURI", "AGE", "PHRASE"},
+		},
+		{
+			name:     "religious event with denomination",
+			event:    &gedcom.Event{Type: gedcom.EventConfirmation, Religion: "Lutheran"},
+			level:    1,
+			contains: []string{"CONF", "RELI"},
+		},
+		{
+			name: "family event with spouse ages",
+			event: &gedcom.Event{
+				Type:       gedcom.EventMarriage,
+				HusbandAge: "25y",
+				WifeAge:    "22y",
+			},
+			level:    1,
+			contains: []string{"MARR", "HUSB", "WIFE", "AGE"},
+		},
+		{
+			name: "adoption event with family",
+			event: &gedcom.Event{
+				Type:       gedcom.EventAdoption,
+				FamilyXRef: "@F2@",
+				AdoptedBy:  gedcom.AdoptedByBoth,
+			},
+			level:    1,
+			contains: []string{"ADOP", "FAMC"},
+		},
+		{
+			name: "event with associations",
+			event: &gedcom.Event{
+				Type:         gedcom.EventBaptism,
+				Associations: []*gedcom.Association{{IndividualXRef: "@I2@", Role: "GODP"}},
+			},
+			level:    1,
+			contains: []string{"BAPM", "ASSO", "ROLE"},
+		},
+		{
+			name: "event with text witnesses",
+			event: &gedcom.Event{
+				Type:      gedcom.EventMarriage,
+				Witnesses: []string{"John Smith"},
+			},
+			level:    1,
+			contains: []string{"MARR", "_WITN"},
+		},
+		{
+			name: "burial with cemetery and plot",
+			event: &gedcom.Event{
+				Type:     gedcom.EventBurial,
+				Cemetery: "Mount Auburn Cemetery",
+				Plot:     "Section B, Lot 12",
+			},
+			level:    1,
+			contains: []string{"BURI", "_CEME", "_PLOT"},
+		},
 		{
 			name: "event with address",
 			event: &gedcom.Event{
@@ -869,6 +1062,12 @@ func TestAttributeToTags(t *testing.T) {
 			level:    1,
 			contains: []string{"EDUC", "DATE", "PLAC"},
 		},
+		{
+			name:     "fact with type",
+			attr:     &gedcom.Attribute{Type: "FACT", Value: "Blue", TypeDetail: "Eye color"},
+			level:    1,
+			contains: []string{"FACT", "TYPE"},
+		},
 		{
 			name: "attribute with source citation",
 			attr: &gedcom.Attribute{
@@ -907,6 +1106,21 @@ func TestAttributeToTags(t *testing.T) {
 	}
 }
 
+func TestAttributeToTagsMultiline(t *testing.T) {
+	attr := &gedcom.Attribute{Type: "DSCR", Value: "Tall\nScar on left hand"}
+	tags := attributeToTags(attr, 1, nil)
+
+	if len(tags) != 2 {
+		t.Fatalf("attributeToTags() = %d tags, want 2", len(tags))
+	}
+	if tags[0].Tag != "DSCR" || tags[0].Value != "Tall" {
+		t.Errorf("tags[0] = %s %q, want DSCR Tall", tags[0].Tag, tags[0].Value)
+	}
+	if tags[1].Tag != "CONT" || tags[1].Level != 2 || tags[1].Value != "Scar on left hand" {
+		t.Errorf("tags[1] = %d %s %q, want 2 CONT", tags[1].Level, tags[1].Tag, tags[1].Value)
+	}
+}
+
 func TestSourceCitationToTags(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1071,7 +1285,7 @@ func TestAddressToTags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tags := addressToTags(tt.addr, tt.level)
+			tags := addressToTags(tt.addr, tt.level, DefaultOptions())
 			tagMap := tagNamesToMap(tags)
 
 			for _, expected := range tt.contains {
@@ -1083,6 +1297,32 @@ func TestAddressToTags(t *testing.T) {
 	}
 }
 
+func TestAddressToTagsFreeFormText(t *testing.T) {
+	addr := &gedcom.Address{
+		Text:  "10 Elm St\nSpringfield, IL",
+		Line1: "10 Elm St",
+		City:  "Springfield",
+	}
+	tags := addressToTags(addr, 1, DefaultOptions())
+
+	want := []string{"1 ADDR 10 Elm St", "2 CONT Springfield, IL", "2 ADR1 10 Elm St", "2 CITY Springfield"}
+	if len(tags) != len(want) {
+		t.Fatalf("addressToTags() = %d tags, want %d", len(tags), len(want))
+	}
+	for i, tag := range tags {
+		got := fmt.Sprintf("%d %s %s", tag.Level, tag.Tag, tag.Value)
+		if got != want[i] {
+			t.Errorf("tag %d = %q, want %q", i, got, want[i])
+		}
+	}
+
+	// Line1 mirroring the free-form text is not repeated as ADR1
+	tags = addressToTags(&gedcom.Address{Text: "1 Main St", Line1: "1 Main St"}, 1, DefaultOptions())
+	if len(tags) != 1 || tags[0].Tag != "ADDR" {
+		t.Errorf("addressToTags() = %+v, want ADDR only", tags)
+	}
+}
+
 func TestLDSOrdinanceToTags(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1390,6 +1630,17 @@ func TestMediaFileToTags(t *testing.T) {
 			level:    1,
 			contains: []string{"FILE", "FORM", "MEDI"},
 		},
+		{
+			name: "file with media type phrase",
+			file: &gedcom.MediaFile{
+				FileRef:     "scan.png",
+				Form:        "image/png",
+				MediaType:   "OTHER",
+				MediaPhrase: "Glass plate negative",
+			},
+			level:    1,
+			contains: []string{"FILE", "FORM", "MEDI", "PHRASE"},
+		},
 		{
 			name: "file with title",
 			file: &gedcom.MediaFile{
@@ -2415,6 +2666,36 @@ func TestEffectiveMaxLineLength(t *testing.T) {
 	}
 }
 
+func TestUIDsToTags(t *testing.T) {
+	tests := []struct {
+		name string
+		uid  string
+		uids []string
+		want []string
+	}{
+		{"none", "", nil, nil},
+		{"uid only", "U1", nil, []string{"UID U1"}},
+		{"uid mirrored in uids", "U1", []string{"U1"}, []string{"UID U1"}},
+		{"vendor uid", "", []string{"V1"}, []string{"_UID V1"}},
+		{"mixed", "U1", []string{"V1", "U1", "U2"}, []string{"UID U1", "_UID V1", "_UID U2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tag := range uidsToTags(tt.uid, tt.uids) {
+				if tag.Level != 1 {
+					t.Errorf("%s level = %d, want 1", tag.Tag, tag.Level)
+				}
+				got = append(got, tag.Tag+" "+tag.Value)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("uidsToTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestFamilySearchIDEncoding tests encoding of the _FSFTID tag (FamilySearch Family Tree ID).
 // This is a vendor extension from FamilySearch.org.
 // Ref: Issue #80
@@ -2950,3 +3231,55 @@ func TestRoundTripNameWithTransliteration(t *testing.T) {
 		t.Errorf("Transliteration[1].Language = %s, want 'en-CA'", tran2.Language)
 	}
 }
+
+func TestAssociationToTagsWithDNAMatch(t *testing.T) {
+	assoc := &gedcom.Association{
+		IndividualXRef: "@I2@",
+		DNAMatch: &gedcom.DNAMatch{
+			SharedCM:         212.5,
+			SharedSegments:   9,
+			LongestSegmentCM: 41,
+			Relationship:     "2nd cousin",
+			Provider:         "AncestryDNA",
+		},
+	}
+
+	tags := associationToTags(assoc, 1, nil)
+
+	want := []struct {
+		level int
+		tag   string
+		value string
+	}{
+		{1, "ASSO", "@I2@"},
+		{2, "_DNA", ""},
+		{3, "_CM", "212.5"},
+		{3, "_SEG", "9"},
+		{3, "_LSEG", "41"},
+		{3, "_REL", "2nd cousin"},
+		{3, "TYPE", "AncestryDNA"},
+	}
+	if len(tags) != len(want) {
+		t.Fatalf("len(tags) = %d, want %d", len(tags), len(want))
+	}
+	for i, w := range want {
+		if tags[i].Level != w.level || tags[i].Tag != w.tag || tags[i].Value != w.value {
+			t.Errorf("tags[%d] = %d %s %q, want %d %s %q", i, tags[i].Level, tags[i].Tag, tags[i].Value, w.level, w.tag, w.value)
+		}
+	}
+}
+
+func TestAssociationToTagsWithNoteDerivedDNAMatch(t *testing.T) {
+	note := "Shared 98 cM"
+	assoc := &gedcom.Association{
+		IndividualXRef: "@I2@",
+		Notes:          []string{note},
+		DNAMatch:       gedcom.ParseDNAMatch(note),
+	}
+
+	for _, tag := range associationToTags(assoc, 1, nil) {
+		if tag.Tag == "_DNA" {
+			t.Error("note-derived DNA match should not be duplicated as _DNA")
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package encoder
+
+import "fmt"
+
+// SyncConflictError reports a record whose raw tags and typed entity were
+// both edited, so neither can be written without losing the other's changes.
+type SyncConflictError struct {
+	XRef string
+}
+
+func (e *SyncConflictError) Error() string {
+	return fmt.Sprintf("record %s: both tags and entity were modified; refresh one from the other before encoding", e.XRef)
+}
@@ -0,0 +1,115 @@
+package encoder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// HeaderSummary describes the program writing a file, for the provenance
+// summary written into the header when EncodeOptions.HeaderSummary is set.
+type HeaderSummary struct {
+	// System is the identifier of the generating program, written as
+	// HEAD.SOUR (e.g., "MyApp"). The document's SOUR structure is replaced
+	// when System is set and kept otherwise.
+	System string
+
+	// Name is the program's display name, written as HEAD.SOUR.NAME.
+	Name string
+
+	// Version is the program's version, written as HEAD.SOUR.VERS.
+	Version string
+
+	// Time is the export time, written as HEAD.DATE and HEAD.DATE.TIME in
+	// UTC. The zero value means time.Now.
+	Time time.Time
+}
+
+// summaryRecordTypes lists the record types counted in the summary note,
+// in order, with their singular and plural names.
+var summaryRecordTypes = []struct {
+	recordType       gedcom.RecordType
+	singular, plural string
+}{
+	{gedcom.RecordTypeIndividual, "individual", "individuals"},
+	{gedcom.RecordTypeFamily, "family", "families"},
+	{gedcom.RecordTypeSource, "source", "sources"},
+	{gedcom.RecordTypeRepository, "repository", "repositories"},
+	{gedcom.RecordTypeNote, "note", "notes"},
+	{gedcom.RecordTypeSharedNote, "shared note", "shared notes"},
+	{gedcom.RecordTypeMedia, "media object", "media objects"},
+	{gedcom.RecordTypeSubmitter, "submitter", "submitters"},
+}
+
+// summarizedHeader returns a copy of doc's header carrying summary: the
+// generating program, the export time, and a note with record counts
+// appended to the header note.
+func summarizedHeader(doc *gedcom.Document, summary *HeaderSummary) *gedcom.Header {
+	header := &gedcom.Header{}
+	if doc.Header != nil {
+		copied := *doc.Header
+		header = &copied
+	}
+
+	if summary.System != "" {
+		header.SourceSystem = summary.System
+		header.SourceName = summary.Name
+		header.SourceVersion = summary.Version
+		header.SourceCorporation = ""
+	}
+	header.Date = summary.Time
+	if header.Date.IsZero() {
+		header.Date = time.Now()
+	}
+	header.Date = header.Date.UTC()
+
+	generator := summary.Name
+	if generator == "" {
+		generator = summary.System
+	}
+	generator = strings.TrimSpace(generator + " " + summary.Version)
+	note := "Generated"
+	if generator != "" {
+		note += " by " + generator
+	}
+	note += " on " + header.Date.Format("2 Jan 2006 15:04:05") + " UTC\nRecords: " + recordCounts(doc)
+	if header.Note != "" {
+		note = header.Note + "\n" + note
+	}
+	header.Note = note
+	return header
+}
+
+// recordCounts returns the number of records of each type in doc, such as
+// "12 individuals, 4 families, 1 source", or "none".
+func recordCounts(doc *gedcom.Document) string {
+	counts := make(map[gedcom.RecordType]int)
+	for _, record := range doc.Records {
+		counts[record.Type]++
+	}
+
+	var parts []string
+	for _, t := range summaryRecordTypes {
+		if n := counts[t.recordType]; n > 0 {
+			name := t.plural
+			if n == 1 {
+				name = t.singular
+			}
+			parts = append(parts, fmt.Sprintf("%d %s", n, name))
+			delete(counts, t.recordType)
+		}
+	}
+	other := 0
+	for _, n := range counts {
+		other += n
+	}
+	if other > 0 {
+		parts = append(parts, fmt.Sprintf("%d other", other))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
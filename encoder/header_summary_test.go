@@ -0,0 +1,88 @@
+package encoder
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func TestEncodeHeaderSummary(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{
+			Version:           "5.5.1",
+			Encoding:          "UTF-8",
+			SourceSystem:      "OldApp",
+			SourceCorporation: "Old Corp",
+			Note:              "Family tree of the Doe family",
+		},
+		Records: []*gedcom.Record{
+			{XRef: "@I1@", Type: gedcom.RecordTypeIndividual},
+			{XRef: "@I2@", Type: gedcom.RecordTypeIndividual},
+			{XRef: "@F1@", Type: gedcom.RecordTypeFamily},
+			{XRef: "@S1@", Type: gedcom.RecordTypeSource},
+			{XRef: "@P1@", Type: "_PLC"},
+		},
+	}
+
+	opts := DefaultOptions()
+	opts.HeaderSummary = &HeaderSummary{
+		System:  "MyApp",
+		Name:    "My App",
+		Version: "2.1",
+		Time:    time.Date(2026, time.March, 5, 9, 30, 0, 0, time.FixedZone("EST", -5*3600)),
+	}
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "0 HEAD\n" +
+		"1 GEDC\n" +
+		"2 VERS 5.5.1\n" +
+		"1 CHAR UTF-8\n" +
+		"1 SOUR MyApp\n" +
+		"2 VERS 2.1\n" +
+		"2 NAME My App\n" +
+		"1 DATE 5 MAR 2026\n" +
+		"2 TIME 14:30:00\n" +
+		"1 NOTE Family tree of the Doe family\n" +
+		"2 CONT Generated by My App 2.1 on 5 Mar 2026 14:30:00 UTC\n" +
+		"2 CONT Records: 2 individuals, 1 family, 1 source, 1 other\n"
+	if got := buf.String(); !strings.HasPrefix(got, want) {
+		t.Errorf("header =\n%s\nwant\n%s", got, want)
+	}
+	if doc.Header.SourceSystem != "OldApp" || doc.Header.Note != "Family tree of the Doe family" {
+		t.Errorf("EncodeWithOptions() modified the document header: %+v", doc.Header)
+	}
+}
+
+func TestEncodeHeaderSummaryDefaults(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: "5.5.1", SourceSystem: "OldApp"},
+	}
+
+	opts := DefaultOptions()
+	opts.HeaderSummary = &HeaderSummary{}
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"1 SOUR OldApp\n", "1 DATE ", "2 TIME ", "1 NOTE Generated on ", "2 CONT Records: none\n"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	buf.Reset()
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "DATE") || strings.Contains(buf.String(), "NOTE") {
+		t.Errorf("Encode() without HeaderSummary wrote a summary:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,71 @@
+package encoder
+
+import (
+	"fmt"
+	"io"
+)
+
+// LineMap maps the lines of an encoded file back to the lines of the decoded
+// input, using the line numbers the decoder recorded on records and tags (see
+// EncodeOptions.LineMap). Entry i holds the input line number of output line
+// i+1, or 0 for lines with no input counterpart, such as a regenerated header
+// or tags built from a modified entity.
+type LineMap []int
+
+// Input returns the input line number for output line n (1-based), or 0 if
+// the line has no input counterpart or n is out of range.
+func (m LineMap) Input(n int) int {
+	if n < 1 || n > len(m) {
+		return 0
+	}
+	return m[n-1]
+}
+
+// WriteTo writes the map as a sidecar file with one "output<TAB>input" line
+// per output line that has an input counterpart.
+func (m LineMap) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for i, input := range m {
+		if input == 0 {
+			continue
+		}
+		n, err := fmt.Fprintf(w, "%d\t%d\n", i+1, input)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// lineMapWriter fills a LineMap while passing output through. Each line
+// terminator it sees (CR, LF, or CRLF) completes an output line, which maps to
+// the current source line.
+type lineMapWriter struct {
+	w      io.Writer
+	m      *LineMap
+	source int
+	prevCR bool
+}
+
+func (lw *lineMapWriter) Write(p []byte) (int, error) {
+	n, err := lw.w.Write(p)
+	for _, b := range p[:n] {
+		switch {
+		case b == '\n' && lw.prevCR:
+			// Second half of CRLF; the line was counted at CR
+		case b == '\n' || b == '\r':
+			*lw.m = append(*lw.m, lw.source)
+		}
+		lw.prevCR = b == '\r'
+	}
+	return n, err
+}
+
+// setSourceLine sets the input line that the next lines written to w map to.
+// It does nothing unless w is filling a LineMap.
+func setSourceLine(w io.Writer, line int) {
+	if lw, ok := w.(*lineMapWriter); ok {
+		lw.source = line
+	}
+}
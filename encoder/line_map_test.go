@@ -0,0 +1,121 @@
+package encoder
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/decoder"
+)
+
+const lineMapInput = "0 HEAD\n" + // 1
+	"1 GEDC\n" + // 2
+	"2 VERS 5.5.1\n" + // 3
+	"1 CHAR UTF-8\n" + // 4
+	"0 @I1@ INDI\n" + // 5
+	"1 NAME John /Doe/\n" + // 6
+	"1 _MILT Army\n" + // 7
+	"0 @I2@ INDI\n" + // 8
+	"1 NAME Jane /Doe/\n" + // 9
+	"1 SEX F\n" + // 10
+	"0 TRLR\n" // 11
+
+func TestEncodeLineMap(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(lineMapInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m LineMap
+	opts := DefaultOptions()
+	opts.LineMap = &m
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// The header is regenerated, so its lines have no input line
+	want := LineMap{0, 0, 0, 0, 5, 6, 7, 8, 9, 10, 11}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("LineMap = %v, want %v", m, want)
+	}
+	if got := strings.Count(buf.String(), "\n"); got != len(m) {
+		t.Errorf("output has %d lines, LineMap has %d", got, len(m))
+	}
+
+	// A regenerated record maps its level 0 line only
+	doc.GetRecord("@I1@").MarkEntityModified()
+	buf.Reset()
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(m) {
+		t.Fatalf("output has %d lines, LineMap has %d", len(lines), len(m))
+	}
+	for i, line := range lines {
+		switch {
+		case line == "0 @I1@ INDI":
+			if m[i] != 5 {
+				t.Errorf("%q maps to %d, want 5", line, m[i])
+			}
+		case line == "1 SEX F":
+			if m[i] != 10 {
+				t.Errorf("%q maps to %d, want 10", line, m[i])
+			}
+		case line == "1 NAME John /Doe/":
+			if m[i] != 0 {
+				t.Errorf("regenerated %q maps to %d, want 0", line, m[i])
+			}
+		}
+	}
+}
+
+func TestEncodeLineMapPreserveRaw(t *testing.T) {
+	input := strings.ReplaceAll(lineMapInput, "\n", "\r")
+	doc, err := decoder.DecodeWithOptions(strings.NewReader(input), &decoder.DecodeOptions{PreserveRaw: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m LineMap
+	opts := DefaultOptions()
+	opts.LineMap = &m
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != input {
+		t.Fatalf("output differs from input:\n%q", buf.String())
+	}
+
+	want := LineMap{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("LineMap = %v, want %v", m, want)
+	}
+}
+
+func TestLineMap(t *testing.T) {
+	m := LineMap{0, 3, 4}
+	if got := m.Input(2); got != 3 {
+		t.Errorf("Input(2) = %d, want 3", got)
+	}
+	for _, n := range []int{0, 1, 4} {
+		if got := m.Input(n); got != 0 {
+			t.Errorf("Input(%d) = %d, want 0", n, got)
+		}
+	}
+
+	var buf bytes.Buffer
+	n, err := m.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "2\t3\n3\t4\n"; buf.String() != want {
+		t.Errorf("WriteTo() wrote %q, want %q", buf.String(), want)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo() = %d, want %d", n, buf.Len())
+	}
+}
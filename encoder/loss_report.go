@@ -0,0 +1,103 @@
+package encoder
+
+import "github.com/cacack/gedcom-go/gedcom"
+
+// Reasons recorded in a Loss.
+const (
+	// LossReasonHeaderField indicates a header field the encoder does not write.
+	LossReasonHeaderField = "header field is not written by the encoder"
+
+	// LossReasonNotModeled indicates a tag dropped because the record was
+	// regenerated from its entity, which does not model the tag.
+	LossReasonNotModeled = "record was regenerated from its entity, which does not model this tag"
+)
+
+// Loss describes data present in a document that an encode did not write.
+type Loss struct {
+	// XRef is the record the data belonged to; empty for the header
+	XRef string
+
+	// Tag is the dropped tag (e.g., "COPR", "_MILT")
+	Tag string
+
+	// Count is the number of occurrences dropped
+	Count int
+
+	// Paths are sample dotted paths of the dropped occurrences
+	// (e.g., "HEAD.COPR", "INDI._MILT")
+	Paths []string
+
+	// Reason explains why the data was dropped (one of the LossReason constants)
+	Reason string
+}
+
+// LossReport lists the data an encode dropped instead of writing.
+type LossReport struct {
+	// Losses are the dropped structures, header first and then by record
+	// in document order
+	Losses []Loss
+}
+
+// HasLoss returns true if any data was dropped.
+func (r *LossReport) HasLoss() bool {
+	return r != nil && len(r.Losses) > 0
+}
+
+// XRefs returns the distinct XRefs of records that lost data, in document order.
+func (r *LossReport) XRefs() []string {
+	if r == nil {
+		return nil
+	}
+	var xrefs []string
+	seen := make(map[string]bool)
+	for _, loss := range r.Losses {
+		if loss.XRef == "" || seen[loss.XRef] {
+			continue
+		}
+		seen[loss.XRef] = true
+		xrefs = append(xrefs, loss.XRef)
+	}
+	return xrefs
+}
+
+// addHeaderLosses records typed header fields that writeHeader does not write.
+func (r *LossReport) addHeaderLosses(header *gedcom.Header) {
+	if header == nil {
+		return
+	}
+	for _, field := range []struct {
+		tag     string
+		present bool
+	}{
+		{"DATE", !header.Date.IsZero()},
+		{"_TREE", header.AncestryTreeID != ""},
+	} {
+		if !field.present {
+			continue
+		}
+		path := "HEAD." + field.tag
+		if field.tag == "_TREE" {
+			path = "HEAD.SOUR._TREE"
+		}
+		r.Losses = append(r.Losses, Loss{
+			Tag: field.tag, Count: 1, Paths: []string{path}, Reason: LossReasonHeaderField,
+		})
+	}
+}
+
+// addRecordLosses records the raw tags of a record that are not modeled by
+// its entity, before the record's tags are regenerated from the entity.
+func (r *LossReport) addRecordLosses(record *gedcom.Record) {
+	if len(record.Tags) == 0 {
+		return
+	}
+	for _, unhandled := range record.UnhandledTags() {
+		r.Losses = append(r.Losses, Loss{
+			XRef:   record.XRef,
+			Tag:    unhandled.Tag,
+			Count:  unhandled.Count,
+			Paths:  unhandled.SamplePaths,
+			Reason: LossReasonNotModeled,
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package encoder
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cacack/gedcom-go/decoder"
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func TestEncodeWithReport(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 COPR Copyright 2020
+0 @I1@ INDI
+1 NAME John /Smith/
+1 _MILT Army
+2 DATE 1918
+1 BIRT
+2 _PRIM Y
+0 @I2@ INDI
+1 NAME Jane /Smith/
+1 _MILT Navy
+0 TRLR
+`
+
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	doc.GetRecord("@I1@").MarkEntityModified()
+
+	var buf bytes.Buffer
+	report, err := EncodeWithReport(&buf, doc, nil)
+	if err != nil {
+		t.Fatalf("EncodeWithReport() error = %v", err)
+	}
+
+	want := []Loss{
+		{XRef: "@I1@", Tag: "_MILT", Count: 1, Paths: []string{"INDI._MILT"}, Reason: LossReasonNotModeled},
+		{XRef: "@I1@", Tag: "_PRIM", Count: 1, Paths: []string{"INDI.BIRT._PRIM"}, Reason: LossReasonNotModeled},
+	}
+	if !reflect.DeepEqual(report.Losses, want) {
+		t.Errorf("Losses =\n%+v\nwant\n%+v", report.Losses, want)
+	}
+	if !report.HasLoss() {
+		t.Error("HasLoss() = false, want true")
+	}
+	if got := report.XRefs(); !reflect.DeepEqual(got, []string{"@I1@"}) {
+		t.Errorf("XRefs() = %v, want [@I1@]", got)
+	}
+
+	// The header copyright is written, not lost
+	if !strings.Contains(buf.String(), "1 COPR Copyright 2020\n") {
+		t.Errorf("COPR not written:\n%s", buf.String())
+	}
+
+	// The unmodified record is written from its raw tags
+	if strings.Contains(buf.String(), "_MILT Army") || !strings.Contains(buf.String(), "_MILT Navy") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestEncodeWithReportHeaderFields(t *testing.T) {
+	doc := &gedcom.Document{Header: &gedcom.Header{
+		Version:        gedcom.Version551,
+		Date:           time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		Submitter:      "@U1@",
+		AncestryTreeID: "12345",
+	}}
+
+	report, err := EncodeWithReport(&bytes.Buffer{}, doc, nil)
+	if err != nil {
+		t.Fatalf("EncodeWithReport() error = %v", err)
+	}
+
+	var paths []string
+	for _, loss := range report.Losses {
+		paths = append(paths, loss.Paths...)
+	}
+	want := []string{"HEAD.DATE", "HEAD.SOUR._TREE"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("loss paths = %v, want %v", paths, want)
+	}
+}
+
+func TestEncodeWithReportNoLoss(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version55},
+		Records: []*gedcom.Record{{
+			XRef:   "@I1@",
+			Type:   gedcom.RecordTypeIndividual,
+			Entity: &gedcom.Individual{XRef: "@I1@", Sex: "M"},
+		}},
+	}
+
+	report, err := EncodeWithReport(&bytes.Buffer{}, doc, nil)
+	if err != nil {
+		t.Fatalf("EncodeWithReport() error = %v", err)
+	}
+	if report.HasLoss() {
+		t.Errorf("HasLoss() = true, want false: %+v", report.Losses)
+	}
+
+	var nilReport *LossReport
+	if nilReport.HasLoss() || nilReport.XRefs() != nil {
+		t.Error("nil report should have no loss")
+	}
+}
@@ -23,6 +23,37 @@ type EncodeOptions struct {
 	// DisableLineWrap disables automatic CONC splitting for long lines.
 	// When true, lines exceeding MaxLineLength will not be split.
 	DisableLineWrap bool
+
+	// SanitizeText removes control characters (other than tab), zero-width
+	// spaces, and byte order marks from every value written, as
+	// gedcom.SanitizeText does. Records written verbatim from their original
+	// lines (see decoder.DecodeOptions.PreserveRaw) are not changed.
+	SanitizeText bool
+
+	// LineMap, when non-nil, is filled with the input line number of every
+	// output line so tools can correlate the encoded file with the decoded
+	// one. Its previous contents are replaced.
+	LineMap *LineMap
+
+	// XRefStyle selects the XRefs written for records and the pointers to
+	// them. The default, XRefPreserve, keeps the document's XRefs. Other
+	// styles write every record from its tags rather than verbatim, since
+	// the original lines carry the old XRefs.
+	XRefStyle XRefStyle
+
+	// XRefPrefixes overrides DefaultXRefPrefixes for XRefSequential, for
+	// target systems that expect particular XRef shapes.
+	XRefPrefixes map[gedcom.RecordType]string
+
+	// HeaderSummary, when non-nil, records provenance in the header: the
+	// generating program in HEAD.SOUR, the export time in HEAD.DATE, and a
+	// note with the record counts appended to HEAD.NOTE, as mainstream
+	// genealogy programs do. A header decoded with PreserveRaw is
+	// regenerated rather than written verbatim.
+	HeaderSummary *HeaderSummary
+
+	// xrefRenames maps old XRefs to new ones while encoding.
+	xrefRenames map[string]string
 }
 
 // DefaultOptions returns the default encoding options.
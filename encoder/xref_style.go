@@ -0,0 +1,163 @@
+package encoder
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// XRefStyle controls the cross-reference identifiers the encoder writes.
+type XRefStyle int
+
+const (
+	// XRefPreserve writes every XRef as it is in the document (default).
+	XRefPreserve XRefStyle = iota
+
+	// XRefUppercase uppercases every XRef, for readers that reject "@i1@".
+	XRefUppercase
+
+	// XRefUUID replaces every XRef with a UUID written in GEDCOM 7.0 XRef
+	// form, such as "@3F2504E0_4F89_41D3_9A0C_0305E82C3301@". The record's
+	// UID is used when it is a UUID; otherwise a name-based (version 5)
+	// UUID is derived from gedcom.Fingerprint and the original XRef, so
+	// encoding the same document twice gives the same XRefs.
+	XRefUUID
+
+	// XRefSequential renumbers records of each type in document order with
+	// the type's prefix from EncodeOptions.XRefPrefixes: @I1@, @I2@, @F1@.
+	XRefSequential
+)
+
+// String returns the name of the style.
+func (s XRefStyle) String() string {
+	switch s {
+	case XRefPreserve:
+		return "Preserve"
+	case XRefUppercase:
+		return "Uppercase"
+	case XRefUUID:
+		return "UUID"
+	case XRefSequential:
+		return "Sequential"
+	default:
+		return fmt.Sprintf("XRefStyle(%d)", s)
+	}
+}
+
+// DefaultXRefPrefixes returns the prefixes XRefSequential uses for record
+// types missing from EncodeOptions.XRefPrefixes. Other types use "X".
+func DefaultXRefPrefixes() map[gedcom.RecordType]string {
+	return map[gedcom.RecordType]string{
+		gedcom.RecordTypeIndividual: "I",
+		gedcom.RecordTypeFamily:     "F",
+		gedcom.RecordTypeSource:     "S",
+		gedcom.RecordTypeRepository: "R",
+		gedcom.RecordTypeNote:       "N",
+		gedcom.RecordTypeSharedNote: "N",
+		gedcom.RecordTypeMedia:      "M",
+		gedcom.RecordTypeSubmitter:  "U",
+		gedcom.RecordType("SUBN"):   "SUBN",
+	}
+}
+
+// xrefRenames returns the new XRef of every record in doc under
+// opts.XRefStyle, or nil for XRefPreserve. New XRefs are unique: a
+// candidate already taken gets a "_2", "_3", ... suffix.
+func xrefRenames(doc *gedcom.Document, opts *EncodeOptions) map[string]string {
+	if opts.XRefStyle == XRefPreserve {
+		return nil
+	}
+
+	renames := make(map[string]string)
+	taken := make(map[string]bool)
+	counters := make(map[gedcom.RecordType]int)
+	var fingerprint string
+	for _, record := range doc.Records {
+		if record.XRef == "" || renames[record.XRef] != "" {
+			continue
+		}
+
+		var id string
+		switch opts.XRefStyle {
+		case XRefUppercase:
+			id = strings.ToUpper(strings.Trim(record.XRef, "@"))
+		case XRefUUID:
+			id = recordUUID(record)
+			if id == "" || taken["@"+id+"@"] {
+				if fingerprint == "" {
+					fingerprint = gedcom.Fingerprint(doc)
+				}
+				id = nameUUID(fingerprint + record.XRef)
+			}
+		case XRefSequential:
+			counters[record.Type]++
+			id = fmt.Sprintf("%s%d", xrefPrefix(record.Type, opts), counters[record.Type])
+		default:
+			continue
+		}
+
+		xref := "@" + id + "@"
+		for n := 2; taken[xref]; n++ {
+			xref = fmt.Sprintf("@%s_%d@", id, n)
+		}
+		taken[xref] = true
+		renames[record.XRef] = xref
+	}
+	return renames
+}
+
+// xrefPrefix returns the XRefSequential prefix for records of type t.
+func xrefPrefix(t gedcom.RecordType, opts *EncodeOptions) string {
+	if prefix, ok := opts.XRefPrefixes[t]; ok {
+		return prefix
+	}
+	if prefix, ok := DefaultXRefPrefixes()[t]; ok {
+		return prefix
+	}
+	return "X"
+}
+
+// recordUUID returns the first UID of an individual or family record that
+// is a UUID, in XRef form, or "" if there is none.
+func recordUUID(record *gedcom.Record) string {
+	var uids []string
+	switch entity := record.LoadEntity().(type) {
+	case *gedcom.Individual:
+		uids = append([]string{entity.UID}, entity.UIDs...)
+	case *gedcom.Family:
+		uids = append([]string{entity.UID}, entity.UIDs...)
+	}
+	for _, uid := range uids {
+		digits := strings.ToUpper(strings.NewReplacer("-", "", "{", "", "}", "").Replace(strings.TrimSpace(uid)))
+		if _, err := hex.DecodeString(digits); err == nil && len(digits) == 32 {
+			return uuidXRef(digits)
+		}
+	}
+	return ""
+}
+
+// nameUUID returns a version 5 UUID of name, in XRef form.
+func nameUUID(name string) string {
+	sum := sha1.Sum([]byte(name))
+	sum[6] = sum[6]&0x0f | 0x50
+	sum[8] = sum[8]&0x3f | 0x80
+	return uuidXRef(strings.ToUpper(hex.EncodeToString(sum[:16])))
+}
+
+// uuidXRef groups 32 hex digits as a UUID, with underscores in place of the
+// hyphens GEDCOM 7.0 does not allow in XRefs.
+func uuidXRef(digits string) string {
+	return digits[:8] + "_" + digits[8:12] + "_" + digits[12:16] + "_" + digits[16:20] + "_" + digits[20:]
+}
+
+// renamedXRef returns value with its XRef replaced when value is a pointer
+// to a renamed record.
+func renamedXRef(value string, opts *EncodeOptions) string {
+	if xref, ok := opts.xrefRenames[value]; ok {
+		return xref
+	}
+	return value
+}
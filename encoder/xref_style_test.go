@@ -0,0 +1,149 @@
+package encoder
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/decoder"
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func xrefStyleDocument() *gedcom.Document {
+	return &gedcom.Document{
+		Header: &gedcom.Header{Version: "5.5.1", Encoding: "UTF-8", Submitter: "@sub@"},
+		Records: []*gedcom.Record{
+			{XRef: "@sub@", Type: gedcom.RecordTypeSubmitter, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NAME", Value: "Jane"},
+			}},
+			{XRef: "@p7@", Type: gedcom.RecordTypeIndividual, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NAME", Value: "John /Doe/"},
+				{Level: 1, Tag: "FAMS", Value: "@fam1@"},
+				{Level: 1, Tag: "NOTE", Value: "See @p7@ in the census"},
+			}},
+			{XRef: "@p3@", Type: gedcom.RecordTypeIndividual, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NAME", Value: "Mary /Roe/"},
+				{Level: 1, Tag: "FAMS", Value: "@fam1@"},
+			}},
+			{XRef: "@fam1@", Type: gedcom.RecordTypeFamily, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "HUSB", Value: "@p7@"},
+				{Level: 1, Tag: "WIFE", Value: "@p3@"},
+				{Level: 1, Tag: "CHIL", Value: "@VOID@"},
+			}},
+		},
+	}
+}
+
+func encodeXRefStyle(t *testing.T, doc *gedcom.Document, opts *EncodeOptions) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestXRefStyleSequential(t *testing.T) {
+	opts := DefaultOptions()
+	opts.XRefStyle = XRefSequential
+	opts.XRefPrefixes = map[gedcom.RecordType]string{gedcom.RecordTypeIndividual: "P"}
+	output := encodeXRefStyle(t, xrefStyleDocument(), opts)
+
+	for _, want := range []string{
+		"1 SUBM @U1@\n",
+		"0 @U1@ SUBM\n",
+		"0 @P1@ INDI\n",
+		"0 @P2@ INDI\n",
+		"0 @F1@ FAM\n",
+		"1 FAMS @F1@\n",
+		"1 HUSB @P1@\n",
+		"1 WIFE @P2@\n",
+		"1 CHIL @VOID@\n",
+		"1 NOTE See @p7@ in the census\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if opts.xrefRenames != nil {
+		t.Error("EncodeWithOptions() modified the caller's options")
+	}
+}
+
+func TestXRefStyleUppercase(t *testing.T) {
+	doc := xrefStyleDocument()
+	doc.Records = append(doc.Records, &gedcom.Record{XRef: "@P7@", Type: gedcom.RecordTypeIndividual})
+
+	opts := DefaultOptions()
+	opts.XRefStyle = XRefUppercase
+	output := encodeXRefStyle(t, doc, opts)
+
+	for _, want := range []string{"0 @P7@ INDI\n", "0 @P7_2@ INDI\n", "1 HUSB @P7@\n", "0 @FAM1@ FAM\n"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestXRefStyleUUID(t *testing.T) {
+	doc := xrefStyleDocument()
+	doc.Records[1].Entity = &gedcom.Individual{XRef: "@p7@", UID: "{3f2504e0-4f89-41d3-9a0c-0305e82c3301}"}
+
+	opts := DefaultOptions()
+	opts.XRefStyle = XRefUUID
+	output := encodeXRefStyle(t, doc, opts)
+
+	if !strings.Contains(output, "0 @3F2504E0_4F89_41D3_9A0C_0305E82C3301@ INDI\n") {
+		t.Errorf("UID not used as XRef:\n%s", output)
+	}
+	renames := xrefRenames(doc, opts)
+	for old, xref := range renames {
+		if len(xref) != 38 || strings.ContainsAny(xref, "-abcdef") {
+			t.Errorf("%s renamed to %s, want an uppercase UUID XRef", old, xref)
+		}
+		if !strings.Contains(output, "0 "+xref+" ") {
+			t.Errorf("output missing record %s", xref)
+		}
+	}
+	if again := encodeXRefStyle(t, doc, opts); again != output {
+		t.Error("XRefUUID output differs between runs")
+	}
+}
+
+func TestXRefStylePreserveRaw(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n0 @i1@ INDI\n1 NAME John /Doe/\n1 FAMC @f1@\n0 @f1@ FAM\n1 CHIL @i1@\n0 TRLR\n"
+	decOpts := decoder.DefaultOptions()
+	decOpts.PreserveRaw = true
+	doc, err := decoder.DecodeWithOptions(strings.NewReader(input), decOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := encodeXRefStyle(t, doc, DefaultOptions()); got != input {
+		t.Errorf("XRefPreserve changed raw output:\n%s", got)
+	}
+
+	opts := DefaultOptions()
+	opts.XRefStyle = XRefUppercase
+	output := encodeXRefStyle(t, doc, opts)
+	for _, want := range []string{"0 @I1@ INDI\n", "1 FAMC @F1@\n", "0 @F1@ FAM\n", "1 CHIL @I1@\n"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestXRefStyleString(t *testing.T) {
+	tests := map[XRefStyle]string{
+		XRefPreserve:   "Preserve",
+		XRefUppercase:  "Uppercase",
+		XRefUUID:       "UUID",
+		XRefSequential: "Sequential",
+		XRefStyle(9):   "XRefStyle(9)",
+	}
+	for style, want := range tests {
+		if got := style.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
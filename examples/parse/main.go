@@ -19,14 +19,11 @@ func main() {
 
 	filename := os.Args[1]
 
-	// Open and parse GEDCOM file
-	f, err := os.Open(filename) // #nosec G304 -- CLI tool accepts user-provided paths
-	if err != nil {
-		log.Fatalf("Failed to open file: %v", err)
-	}
-	defer f.Close()
-
-	doc, err := decoder.Decode(f)
+	// Parse GEDCOM file (.ged, .ged.gz, or .zip), collecting decode statistics
+	var stats decoder.DecodeStats
+	opts := decoder.DefaultOptions()
+	opts.Stats = &stats
+	doc, err := decoder.DecodeFile(filename, opts)
 	if err != nil {
 		log.Fatalf("Failed to decode GEDCOM: %v", err)
 	}
@@ -38,18 +35,24 @@ func main() {
 	if doc.Header.SourceSystem != "" {
 		fmt.Printf("Source System: %s\n", doc.Header.SourceSystem)
 	}
+	fmt.Printf("Decoded %d bytes, %d lines in %v (parse %v, build %v, entities %v)\n",
+		stats.BytesRead, stats.Lines, stats.Total, stats.Parse, stats.Build, stats.Entities)
 	fmt.Printf("\nTotal Records: %d\n", len(doc.Records))
 	fmt.Printf("Cross-references: %d\n", len(doc.XRefMap))
 
-	// Count record types
+	// Count record types, in the order each type first appears
 	recordCounts := make(map[string]int)
+	var recordTypes []string
 	for _, record := range doc.Records {
+		if recordCounts[string(record.Type)] == 0 {
+			recordTypes = append(recordTypes, string(record.Type))
+		}
 		recordCounts[string(record.Type)]++
 	}
 
 	fmt.Println("\nRecord Types:")
-	for recordType, count := range recordCounts {
-		fmt.Printf("  %s: %d\n", recordType, count)
+	for _, recordType := range recordTypes {
+		fmt.Printf("  %s: %d\n", recordType, recordCounts[recordType])
 	}
 
 	// Validate the document
@@ -19,14 +19,8 @@ func main() {
 
 	filename := os.Args[1]
 
-	// Open and parse GEDCOM file
-	f, err := os.Open(filename) // #nosec G304 -- CLI tool accepts user-provided paths
-	if err != nil {
-		log.Fatalf("Failed to open file: %v", err)
-	}
-	defer f.Close()
-
-	doc, err := decoder.Decode(f)
+	// Parse GEDCOM file (.ged, .ged.gz, or .zip)
+	doc, err := decoder.DecodeFile(filename, nil)
 	if err != nil {
 		log.Fatalf("Failed to decode GEDCOM: %v", err)
 	}
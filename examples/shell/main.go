@@ -0,0 +1,281 @@
+// Example: Interactive shell for exploring a GEDCOM file
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cacack/gedcom-go/decoder"
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/query"
+	"github.com/cacack/gedcom-go/validator"
+)
+
+const help = `Commands:
+  find <text>                 individuals whose name contains text
+  show <xref>                 names, events, and close family of a record
+  source <xref>               the record's GEDCOM lines
+  ancestors <xref> [n]        ancestors up to n generations (default 4)
+  descendants <xref> [n]      descendants up to n generations (default 4)
+  events <from> [to]          events between two dates, such as 1850 or "ABT 1900"
+  validate                    validation issues by severity
+  help                        this list
+  quit                        leave the shell`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: go run main.go <gedcom_file>")
+		fmt.Println("Example: go run main.go ../../testdata/gedcom-5.5/royal92.ged")
+		os.Exit(1)
+	}
+
+	doc, err := decoder.DecodeFile(os.Args[1], nil)
+	if err != nil {
+		log.Fatalf("Failed to decode GEDCOM: %v", err)
+	}
+
+	fmt.Printf("Loaded %s: %d individuals, %d families. Type \"help\" for commands.\n",
+		os.Args[1], len(doc.Individuals()), len(doc.Families()))
+	sh := &shell{doc: doc, out: os.Stdout}
+	sh.run(os.Stdin)
+}
+
+// shell runs commands against a decoded document.
+type shell struct {
+	doc    *gedcom.Document
+	out    io.Writer
+	events *query.DateIndex // built on first use
+}
+
+// run reads commands from in until it ends or the user quits.
+func (sh *shell) run(in io.Reader) {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(sh.out, "gedcom> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(sh.out)
+			return
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "quit" || fields[0] == "exit" {
+			return
+		}
+		if err := sh.exec(fields[0], fields[1:]); err != nil {
+			fmt.Fprintf(sh.out, "error: %v\n", err)
+		}
+	}
+}
+
+// exec runs one command.
+func (sh *shell) exec(cmd string, args []string) error {
+	switch cmd {
+	case "help":
+		fmt.Fprintln(sh.out, help)
+	case "find":
+		if len(args) == 0 {
+			return fmt.Errorf("usage: find <text>")
+		}
+		sh.find(strings.Join(args, " "))
+	case "show":
+		ind, err := sh.individual(args)
+		if err != nil {
+			return err
+		}
+		sh.show(ind)
+	case "source":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: source <xref>")
+		}
+		text, ok := sh.doc.RawRecord(normalizeXRef(args[0]))
+		if !ok {
+			return fmt.Errorf("no record %s", args[0])
+		}
+		fmt.Fprint(sh.out, text)
+	case "ancestors", "descendants":
+		ind, err := sh.individual(args[:min(len(args), 1)])
+		if err != nil {
+			return err
+		}
+		depth := 4
+		if len(args) > 1 {
+			if depth, err = strconv.Atoi(args[1]); err != nil || depth < 1 {
+				return fmt.Errorf("generations must be a positive number")
+			}
+		}
+		sh.lineage(ind, cmd == "ancestors", depth)
+	case "events":
+		return sh.eventsBetween(args)
+	case "validate":
+		sh.validate()
+	default:
+		return fmt.Errorf("unknown command %q; type \"help\" for commands", cmd)
+	}
+	return nil
+}
+
+// individual looks up the individual named by the single argument.
+func (sh *shell) individual(args []string) (*gedcom.Individual, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("expected one xref, such as @I1@")
+	}
+	ind := sh.doc.GetIndividual(normalizeXRef(args[0]))
+	if ind == nil {
+		return nil, fmt.Errorf("no individual %s", args[0])
+	}
+	return ind, nil
+}
+
+func (sh *shell) find(text string) {
+	text = strings.ToLower(text)
+	found := 0
+	for _, ind := range sh.doc.Individuals() {
+		for _, name := range ind.Names {
+			if strings.Contains(strings.ToLower(name.Full), text) {
+				fmt.Fprintf(sh.out, "  %s\n", gedcom.LabelNameXRef(ind))
+				found++
+				break
+			}
+		}
+	}
+	fmt.Fprintf(sh.out, "%d found\n", found)
+}
+
+func (sh *shell) show(ind *gedcom.Individual) {
+	fmt.Fprintf(sh.out, "%s %s\n", ind.XRef, gedcom.LabelNameLifespan(ind))
+	if ind.Sex != "" {
+		fmt.Fprintf(sh.out, "  Sex: %s\n", ind.Sex)
+	}
+	for _, name := range ind.Names[min(len(ind.Names), 1):] {
+		fmt.Fprintf(sh.out, "  Also known as: %s\n", name.Full)
+	}
+	for _, event := range ind.Events {
+		fmt.Fprintf(sh.out, "  %s: %s\n", event.Type, joinNonEmpty(", ", event.Date, event.Place))
+	}
+	for _, rel := range []struct {
+		label  string
+		people []*gedcom.Individual
+	}{
+		{"Parent", ind.Parents(sh.doc)},
+		{"Spouse", ind.Spouses(sh.doc)},
+		{"Child", ind.Children(sh.doc)},
+	} {
+		for _, person := range rel.people {
+			fmt.Fprintf(sh.out, "  %s: %s\n", rel.label, gedcom.LabelNameXRef(person))
+		}
+	}
+}
+
+// lineage lists ancestors or descendants of ind up to depth generations,
+// nearest generations first.
+func (sh *shell) lineage(ind *gedcom.Individual, ancestors bool, depth int) {
+	type relative struct {
+		ind        *gedcom.Individual
+		generation int
+	}
+	var relatives []relative
+	// Generations numbers ancestors positively and descendants negatively;
+	// iterate individuals rather than the map for a stable order
+	generations := gedcom.Generations(sh.doc, ind.XRef)
+	for _, other := range sh.doc.Individuals() {
+		g, ok := generations[other.XRef]
+		if !ancestors {
+			g = -g
+		}
+		if ok && g > 0 && g <= depth {
+			relatives = append(relatives, relative{other, g})
+		}
+	}
+	sort.SliceStable(relatives, func(i, j int) bool {
+		return relatives[i].generation < relatives[j].generation
+	})
+
+	for _, r := range relatives {
+		fmt.Fprintf(sh.out, "  %d %s%s\n", r.generation, strings.Repeat("  ", r.generation-1), gedcom.LabelNameXRef(r.ind))
+	}
+	fmt.Fprintf(sh.out, "%d found\n", len(relatives))
+}
+
+func (sh *shell) eventsBetween(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: events <from> [to]")
+	}
+	// Dates may contain spaces; split the arguments at "to" or "-" if given
+	fromText, toText := strings.Join(args, " "), ""
+	for i, arg := range args {
+		if arg == "to" || arg == "-" {
+			fromText, toText = strings.Join(args[:i], " "), strings.Join(args[i+1:], " ")
+		}
+	}
+	from, err := gedcom.ParseDate(fromText)
+	if err != nil {
+		return err
+	}
+	to := from
+	if toText != "" {
+		if to, err = gedcom.ParseDate(toText); err != nil {
+			return err
+		}
+	}
+
+	if sh.events == nil {
+		sh.events = query.NewDateIndex(sh.doc)
+	}
+	events := sh.events.Between(from, to)
+	for _, e := range events {
+		owner := e.RecordXRef
+		if ind := sh.doc.GetIndividual(owner); ind != nil {
+			owner = gedcom.LabelNameXRef(ind)
+		}
+		fmt.Fprintf(sh.out, "  %s %s: %s\n", e.Event.Date, e.Event.Type, joinNonEmpty(", ", owner, e.Event.Place))
+	}
+	fmt.Fprintf(sh.out, "%d found\n", len(events))
+	return nil
+}
+
+func (sh *shell) validate() {
+	issues := validator.New().ValidateAll(sh.doc)
+	counts := make(map[validator.Severity]int)
+	for _, issue := range issues {
+		counts[issue.Severity]++
+	}
+	fmt.Fprintf(sh.out, "%d errors, %d warnings, %d info\n",
+		counts[validator.SeverityError], counts[validator.SeverityWarning], counts[validator.SeverityInfo])
+	for i, issue := range issues {
+		if i == 20 {
+			fmt.Fprintf(sh.out, "  ... and %d more\n", len(issues)-20)
+			break
+		}
+		fmt.Fprintf(sh.out, "  %s\n", issue)
+	}
+}
+
+// normalizeXRef adds the @ delimiters if they were left out, so "I1" and
+// "@I1@" both work.
+func normalizeXRef(xref string) string {
+	if !strings.HasPrefix(xref, "@") {
+		xref = "@" + xref
+	}
+	if !strings.HasSuffix(xref, "@") || len(xref) == 1 {
+		xref += "@"
+	}
+	return xref
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
 	"os"
@@ -20,29 +19,15 @@ func main() {
 	}
 	defer f.Close()
 
-	p := parser.NewParser()
-	scanner := bufio.NewScanner(f)
-	scanner.Split(parser.ScanGEDCOMLines)
-
 	individualCount := 0
-	handleLine := func(line *parser.Line) error {
+	err = parser.NewParser().ParseFunc(f, func(line *parser.Line) error {
 		if line.Level == 0 && line.Tag == "INDI" {
 			individualCount++
 		}
 		return nil
-	}
-	for scanner.Scan() {
-		lineText := scanner.Text()
-		line, err := p.ParseLine(lineText)
-		if err != nil {
-			log.Fatalf("parse line: %v", err)
-		}
-		if err := handleLine(line); err != nil {
-			log.Fatalf("handle line: %v", err)
-		}
-	}
-	if err := scanner.Err(); err != nil {
-		log.Fatalf("scan: %v", err)
+	})
+	if err != nil {
+		log.Fatalf("parse: %v", err)
 	}
 
 	fmt.Printf("Individuals: %d\n", individualCount)
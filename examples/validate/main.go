@@ -20,14 +20,8 @@ func main() {
 
 	filename := os.Args[1]
 
-	// Open and parse GEDCOM file
-	f, err := os.Open(filename) // #nosec G304 -- CLI tool accepts user-provided paths
-	if err != nil {
-		log.Fatalf("Failed to open file: %v", err)
-	}
-	defer f.Close()
-
-	doc, err := decoder.Decode(f)
+	// Parse GEDCOM file (.ged, .ged.gz, or .zip)
+	doc, err := decoder.DecodeFile(filename, nil)
 	if err != nil {
 		log.Fatalf("Failed to decode GEDCOM: %v", err)
 	}
@@ -51,17 +45,22 @@ func main() {
 
 	// Group errors by code
 	errorsByCode := make(map[string][]error)
+	var codes []string
 	for _, err := range errors {
 		// Try to get the code from ValidationError
 		code := "UNKNOWN"
 		if verr, ok := err.(*validator.ValidationError); ok {
 			code = verr.Code
 		}
+		if _, seen := errorsByCode[code]; !seen {
+			codes = append(codes, code)
+		}
 		errorsByCode[code] = append(errorsByCode[code], err)
 	}
 
-	// Display errors grouped by code
-	for code, errs := range errorsByCode {
+	// Display errors grouped by code, in the order each code first appears
+	for _, code := range codes {
+		errs := errorsByCode[code]
 		fmt.Printf("Error Code: %s (%d occurrence(s))\n", code, len(errs))
 
 		// Show first 3 examples
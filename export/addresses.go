@@ -0,0 +1,89 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// addressCSVHeader lists the address columns shared by CSV tables.
+var addressCSVHeader = []string{
+	"address", "address_line1", "address_line2", "address_line3",
+	"city", "state", "postal_code", "country",
+}
+
+// addressCSVFields returns the address columns for a CSV row.
+func addressCSVFields(a *gedcom.Address) []string {
+	if a == nil {
+		return make([]string, len(addressCSVHeader))
+	}
+	return []string{
+		a.OneLine(), a.Line1, a.Line2, a.Line3,
+		a.City, a.State, a.PostalCode, a.Country,
+	}
+}
+
+// WriteRepositoriesCSV writes all repositories in the document as CSV with a
+// header row. Repository keys are the records' XRefs.
+//
+// Columns: repository_key, name, address, address_line1, address_line2,
+// address_line3, city, state, postal_code, country, phone, email, website.
+func WriteRepositoriesCSV(w io.Writer, doc *gedcom.Document) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	header := append([]string{"repository_key", "name"}, addressCSVHeader...)
+	header = append(header, "phone", "email", "website")
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	if doc != nil {
+		for _, repo := range doc.Repositories() {
+			row := append([]string{key(repo.XRef), repo.Name}, addressCSVFields(repo.Address)...)
+			var phone, email, website string
+			if repo.Address != nil {
+				phone, email, website = repo.Address.Phone, repo.Address.Email, repo.Address.Website
+			}
+			row = append(row, phone, email, website)
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteSubmittersCSV writes all submitters in the document as CSV with a
+// header row. Submitter keys are the records' XRefs. Multiple phone numbers
+// or email addresses are joined with "; ".
+//
+// Columns: submitter_key, name, address, address_line1, address_line2,
+// address_line3, city, state, postal_code, country, phone, email.
+func WriteSubmittersCSV(w io.Writer, doc *gedcom.Document) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	header := append([]string{"submitter_key", "name"}, addressCSVHeader...)
+	header = append(header, "phone", "email")
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	if doc != nil {
+		for _, subm := range doc.Submitters() {
+			row := append([]string{key(subm.XRef), subm.Name}, addressCSVFields(subm.Address)...)
+			row = append(row, strings.Join(subm.Phone, "; "), strings.Join(subm.Email, "; "))
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
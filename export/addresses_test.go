@@ -0,0 +1,61 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func TestWriteRepositoriesCSV(t *testing.T) {
+	repo := &gedcom.Repository{XRef: "@R1@", Name: "State Archive", Address: &gedcom.Address{
+		Line1: "1 Archive Way", City: "Boston", State: "MA", Country: "USA",
+		Phone: "555-1234", Website: "https://example.org",
+	}}
+	doc := &gedcom.Document{Records: []*gedcom.Record{
+		{XRef: "@R1@", Type: gedcom.RecordTypeRepository, Entity: repo},
+		{XRef: "@R2@", Type: gedcom.RecordTypeRepository, Entity: &gedcom.Repository{XRef: "@R2@", Name: "Library"}},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteRepositoriesCSV(&buf, doc); err != nil {
+		t.Fatalf("WriteRepositoriesCSV() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"repository_key,name,address,address_line1,address_line2,address_line3,city,state,postal_code,country,phone,email,website",
+		`@R1@,State Archive,"1 Archive Way, Boston, MA, USA",1 Archive Way,,,Boston,MA,,USA,555-1234,,https://example.org`,
+		"@R2@,Library,,,,,,,,,,,",
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteSubmittersCSV(t *testing.T) {
+	subm := &gedcom.Submitter{
+		XRef:    "@U1@",
+		Name:    "Jane Researcher",
+		Address: &gedcom.Address{Text: "10 Elm St\nSpringfield"},
+		Phone:   []string{"555-1111", "555-2222"},
+		Email:   []string{"jane@example.com"},
+	}
+	doc := &gedcom.Document{Records: []*gedcom.Record{{XRef: "@U1@", Type: gedcom.RecordTypeSubmitter, Entity: subm}}}
+
+	var buf bytes.Buffer
+	if err := WriteSubmittersCSV(&buf, doc); err != nil {
+		t.Fatalf("WriteSubmittersCSV() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"submitter_key,name,address,address_line1,address_line2,address_line3,city,state,postal_code,country,phone,email",
+		`@U1@,Jane Researcher,"10 Elm St, Springfield",,,,,,,,555-1111; 555-2222,jane@example.com`,
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteSubmittersCSV(&buf, nil); err != nil || strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("nil document should write header only: %q, %v", buf.String(), err)
+	}
+}
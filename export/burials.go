@@ -0,0 +1,134 @@
+package export
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// burialProperties returns the feature properties of a burial in GeoJSON and
+// KML exports, with the individual's key passed through key.
+func burialProperties(b gedcom.Burial, key func(string) string) [][2]string {
+	return [][2]string{
+		{"individual_key", key(b.IndividualXRef)},
+		{"name", b.Name},
+		{"date", b.Event.Date},
+		{"place", b.Event.Place},
+		{"cemetery", b.Event.Cemetery},
+		{"plot", b.Event.Plot},
+	}
+}
+
+// geoJSONPointFeature and geoJSONPoint model the point features written by
+// WriteBurialsGeoJSON.
+type geoJSONPointFeature struct {
+	Type       string            `json:"type"`
+	Geometry   geoJSONPoint      `json:"geometry"`
+	Properties map[string]string `json:"properties"`
+}
+
+type geoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// WriteBurialsGeoJSON writes burials as a GeoJSON FeatureCollection of Point
+// features for grave mapping. Burials are included only when their place
+// carries MAP coordinates.
+// Feature properties: individual_key, name, date, place, cemetery, plot.
+func WriteBurialsGeoJSON(w io.Writer, doc *gedcom.Document) error {
+	collection := struct {
+		Type     string                `json:"type"`
+		Features []geoJSONPointFeature `json:"features"`
+	}{Type: "FeatureCollection", Features: []geoJSONPointFeature{}}
+	key := keyPrefix(w)
+
+	for _, b := range gedcom.Burials(doc) {
+		lat, lon, ok := eventCoordinates(b.Event).Decimal()
+		if !ok {
+			continue
+		}
+		properties := make(map[string]string)
+		for _, p := range burialProperties(b, key) {
+			properties[p[0]] = p[1]
+		}
+		collection.Features = append(collection.Features, geoJSONPointFeature{
+			Type:       "Feature",
+			Geometry:   geoJSONPoint{Type: "Point", Coordinates: [2]float64{lon, lat}},
+			Properties: properties,
+		})
+	}
+
+	return json.NewEncoder(w).Encode(collection)
+}
+
+// kmlDocument, kmlPlacemark, and kmlData model the subset of KML 2.2 written
+// by WriteBurialsKML.
+type kmlDocument struct {
+	XMLName    xml.Name       `xml:"kml"`
+	Namespace  string         `xml:"xmlns,attr"`
+	Placemarks []kmlPlacemark `xml:"Document>Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string    `xml:"name"`
+	Description string    `xml:"description,omitempty"`
+	Data        []kmlData `xml:"ExtendedData>Data"`
+	Coordinates string    `xml:"Point>coordinates"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// WriteBurialsKML writes burials as a KML document of placemarks for grave
+// mapping, named after the individual and described by cemetery and plot.
+// Burials are included only when their place carries MAP coordinates.
+// Extended data: individual_key, name, date, place, cemetery, plot.
+func WriteBurialsKML(w io.Writer, doc *gedcom.Document) error {
+	kml := kmlDocument{Namespace: "http://www.opengis.net/kml/2.2"}
+	key := keyPrefix(w)
+
+	for _, b := range gedcom.Burials(doc) {
+		lat, lon, ok := eventCoordinates(b.Event).Decimal()
+		if !ok {
+			continue
+		}
+		placemark := kmlPlacemark{
+			Name:        b.Name,
+			Description: joinNonEmpty(", ", b.Event.Cemetery, b.Event.Plot),
+			Coordinates: strconv.FormatFloat(lon, 'f', -1, 64) + "," + strconv.FormatFloat(lat, 'f', -1, 64),
+		}
+		for _, p := range burialProperties(b, key) {
+			placemark.Data = append(placemark.Data, kmlData{Name: p[0], Value: p[1]})
+		}
+		kml.Placemarks = append(kml.Placemarks, placemark)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(kml); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// joinNonEmpty joins the non-empty parts with sep.
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteBurialsGeoJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBurialsGeoJSON(&buf, loadTestDocument(t, "burials")); err != nil {
+		t.Fatalf("WriteBurialsGeoJSON() error = %v", err)
+	}
+
+	// Only John's burial place has coordinates
+	want := `{"type":"FeatureCollection","features":[{"type":"Feature",` +
+		`"geometry":{"type":"Point","coordinates":[-71.1469,42.3708]},` +
+		`"properties":{"cemetery":"Mount Auburn Cemetery","date":"3 MAR 1920","individual_key":"@I1@",` +
+		`"name":"John Smith","place":"Boston, MA","plot":"Section B, Lot 12"}}]}` + "\n"
+	if buf.String() != want {
+		t.Errorf("WriteBurialsGeoJSON() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteBurialsKML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBurialsKML(&buf, loadTestDocument(t, "burials")); err != nil {
+		t.Fatalf("WriteBurialsKML() error = %v", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <Placemark>
+      <name>John Smith</name>
+      <description>Mount Auburn Cemetery, Section B, Lot 12</description>
+      <ExtendedData>
+        <Data name="individual_key">
+          <value>@I1@</value>
+        </Data>
+        <Data name="name">
+          <value>John Smith</value>
+        </Data>
+        <Data name="date">
+          <value>3 MAR 1920</value>
+        </Data>
+        <Data name="place">
+          <value>Boston, MA</value>
+        </Data>
+        <Data name="cemetery">
+          <value>Mount Auburn Cemetery</value>
+        </Data>
+        <Data name="plot">
+          <value>Section B, Lot 12</value>
+        </Data>
+      </ExtendedData>
+      <Point>
+        <coordinates>-71.1469,42.3708</coordinates>
+      </Point>
+    </Placemark>
+  </Document>
+</kml>
+`
+	if buf.String() != want {
+		t.Errorf("WriteBurialsKML() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
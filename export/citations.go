@@ -0,0 +1,145 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// forEachCitation calls fn for each source citation on individuals,
+// families, their events, and individual attributes, in record order. The
+// citing record's citations are numbered from 0: first those on the record
+// itself, then those on its events, then those on its attributes.
+func forEachCitation(doc *gedcom.Document, fn func(recordKey string, index int, c *gedcom.SourceCitation) error) error {
+	if doc == nil {
+		return nil
+	}
+	for _, record := range doc.Records {
+		var xref string
+		var lists [][]*gedcom.SourceCitation
+		switch entity := record.LoadEntity().(type) {
+		case *gedcom.Individual:
+			xref = entity.XRef
+			lists = append(lists, entity.SourceCitations)
+			for _, event := range entity.Events {
+				lists = append(lists, event.SourceCitations)
+			}
+			for _, attr := range entity.Attributes {
+				lists = append(lists, attr.SourceCitations)
+			}
+		case *gedcom.Family:
+			xref = entity.XRef
+			lists = append(lists, entity.SourceCitations)
+			for _, event := range entity.Events {
+				lists = append(lists, event.SourceCitations)
+			}
+		default:
+			continue
+		}
+
+		index := 0
+		for _, list := range lists {
+			for _, c := range list {
+				if c == nil {
+					continue
+				}
+				if err := fn(xref, index, c); err != nil {
+					return err
+				}
+				index++
+			}
+		}
+	}
+	return nil
+}
+
+// CitationKey returns the key WriteCitationsCSV and WriteCitationTextsCSV
+// use for the index-th citation of a record (see WriteCitationsCSV), such as
+// "@I1@:0".
+func CitationKey(recordKey string, index int) string {
+	return recordKey + ":" + strconv.Itoa(index)
+}
+
+// WriteCitationsCSV writes the source citations of individuals, families,
+// their events, and individual attributes as CSV with a header row, one row
+// per citation in record order.
+//
+// A citation's key joins the citing record's XRef with the citation's
+// position among the record's citations, counted from 0: first those on the
+// record itself, then those on its events, then those on its attributes.
+// The quote column carries DATA.TEXT; when maxQuote is positive, quotes
+// longer than maxQuote characters are cut to that length and truncated is
+// "true". WriteCitationTextsCSV writes the full text of the cut quotes.
+//
+// Columns: citation_key, record_key, source_key, page, date, quote,
+// truncated.
+func WriteCitationsCSV(w io.Writer, doc *gedcom.Document, maxQuote int) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	if err := cw.Write([]string{
+		"citation_key", "record_key", "source_key", "page", "date", "quote", "truncated",
+	}); err != nil {
+		return err
+	}
+
+	err := forEachCitation(doc, func(recordKey string, index int, c *gedcom.SourceCitation) error {
+		recordKey = key(recordKey)
+		var date, quote string
+		if c.Data != nil {
+			date, quote = c.Data.Date, c.Data.Text
+		}
+		truncated := false
+		if maxQuote > 0 && utf8.RuneCountInString(quote) > maxQuote {
+			quote = string([]rune(quote)[:maxQuote])
+			truncated = true
+		}
+		return cw.Write([]string{
+			CitationKey(recordKey, index), recordKey, key(c.SourceXRef), c.Page, date, quote,
+			strconv.FormatBool(truncated),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteCitationTextsCSV writes the full quotes that WriteCitationsCSV cuts
+// at maxQuote characters, as CSV with a header row keyed by citation key.
+// With a maxQuote of zero or less nothing is cut, and only the header is
+// written.
+//
+// Columns: citation_key, length, text.
+func WriteCitationTextsCSV(w io.Writer, doc *gedcom.Document, maxQuote int) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	if err := cw.Write([]string{"citation_key", "length", "text"}); err != nil {
+		return err
+	}
+
+	if maxQuote > 0 {
+		err := forEachCitation(doc, func(recordKey string, index int, c *gedcom.SourceCitation) error {
+			if c.Data == nil {
+				return nil
+			}
+			n := utf8.RuneCountInString(c.Data.Text)
+			if n <= maxQuote {
+				return nil
+			}
+			return cw.Write([]string{CitationKey(key(recordKey), index), strconv.Itoa(n), c.Data.Text})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,102 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func citationsTestDocument(t *testing.T) *gedcom.Document {
+	return decodeTestDocument(t, `0 @I1@ INDI
+1 SOUR @S1@
+2 PAGE p. 4
+2 DATA
+3 DATE 1850
+3 TEXT Baptized the fourth day of May
+1 BIRT
+2 SOUR @S2@
+3 DATA
+4 TEXT Born at the mill
+1 OCCU
+2 SOUR @S3@
+0 @F1@ FAM
+1 MARR
+2 SOUR @S1@
+3 DATA
+4 TEXT Café owner married
+`)
+}
+
+func TestWriteCitationsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCitationsCSV(&buf, citationsTestDocument(t), 0); err != nil {
+		t.Fatalf("WriteCitationsCSV() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"citation_key,record_key,source_key,page,date,quote,truncated",
+		"@I1@:0,@I1@,@S1@,p. 4,1850,Baptized the fourth day of May,false",
+		"@I1@:1,@I1@,@S2@,,,Born at the mill,false",
+		"@I1@:2,@I1@,@S3@,,,,false",
+		"@F1@:0,@F1@,@S1@,,,Café owner married,false",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("WriteCitationsCSV() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteCitationsCSVTruncated(t *testing.T) {
+	doc := citationsTestDocument(t)
+
+	var buf bytes.Buffer
+	if err := WriteCitationsCSV(&buf, doc, 4); err != nil {
+		t.Fatalf("WriteCitationsCSV() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"citation_key,record_key,source_key,page,date,quote,truncated",
+		"@I1@:0,@I1@,@S1@,p. 4,1850,Bapt,true",
+		"@I1@:1,@I1@,@S2@,,,Born,true",
+		"@I1@:2,@I1@,@S3@,,,,false",
+		"@F1@:0,@F1@,@S1@,,,Café,true",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("WriteCitationsCSV() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteCitationTextsCSV(&buf, doc, 16); err != nil {
+		t.Fatalf("WriteCitationTextsCSV() error = %v", err)
+	}
+	want = strings.Join([]string{
+		"citation_key,length,text",
+		"@I1@:0,30,Baptized the fourth day of May",
+		"@F1@:0,18,Café owner married",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("WriteCitationTextsCSV() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteCitationTextsCSVNoLimit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCitationTextsCSV(&buf, citationsTestDocument(t), 0); err != nil {
+		t.Fatalf("WriteCitationTextsCSV() error = %v", err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("expected header row only, got %q", buf.String())
+	}
+}
+
+func TestWriteCitationsCSVEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCitationsCSV(&buf, nil, 10); err != nil {
+		t.Fatalf("WriteCitationsCSV() error = %v", err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("expected header row only, got %q", buf.String())
+	}
+}
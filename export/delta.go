@@ -0,0 +1,208 @@
+package export
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// Operations in the op column of WriteCSVDelta.
+const (
+	DeltaAdd    = "add"
+	DeltaChange = "change"
+	DeltaRemove = "remove"
+)
+
+// CSVManifest maps the row keys of a CSV export to hashes of the rows, so a
+// later export can be written as a delta against it (see WriteCSVDelta).
+type CSVManifest map[string]string
+
+// ReadCSVManifest reads a manifest written by CSVManifest.WriteCSV.
+func ReadCSVManifest(r io.Reader) (CSVManifest, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || records[0][0] != "row_key" || records[0][1] != "hash" {
+		return nil, errors.New("manifest must start with a row_key,hash header")
+	}
+	m := make(CSVManifest, len(records)-1)
+	for _, record := range records[1:] {
+		m[record[0]] = record[1]
+	}
+	return m, nil
+}
+
+// WriteCSV writes the manifest as CSV with a header row, sorted by row key.
+//
+// Columns: row_key, hash.
+func (m CSVManifest) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"row_key", "hash"}); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := cw.Write([]string{key, m[key]}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteCSVDelta writes the rows of export that were added, changed, or
+// removed since the export that prev describes, and returns the manifest of
+// the current export to keep for the next run. With a nil prev every row is
+// added, which writes a full bundle.
+//
+// Rows are identified by their keyColumns, joined with "|"; by default this
+// is the first column of the export. Rows with the same key are numbered in
+// export order ("@I1@#1" is the second row keyed "@I1@"). Numbered keys
+// depend on row order: adding or removing one of the rows renumbers those
+// after it, which then show as changed. For exports with several rows per
+// record, such as WriteEventsCSV, pass the columns that tell them apart
+// ("record_key", "event_index").
+//
+// The delta has the export's columns preceded by op (DeltaAdd, DeltaChange,
+// or DeltaRemove) and row_key. Added and changed rows follow export order;
+// removed rows, whose other columns are empty, follow at the end sorted by
+// key. A row hash covers the export's header, so a change of columns
+// changes every row. An export that writes nothing, not even a header,
+// removes every row of prev.
+//
+// Columns: op, row_key, then the columns of export.
+func WriteCSVDelta(w io.Writer, doc *gedcom.Document, export CSVExport, prev CSVManifest, keyColumns ...string) (CSVManifest, error) {
+	var buf bytes.Buffer
+	if err := export(&buf, doc); err != nil {
+		return nil, err
+	}
+	cr := csv.NewReader(&buf)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	var keyIndexes []int
+	if header != nil {
+		if keyIndexes, err = deltaKeyIndexes(header, keyColumns); err != nil {
+			return nil, err
+		}
+	}
+	headerHash := sha256.Sum256([]byte(strings.Join(header, "\x1f")))
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{"op", "row_key"}, header...)); err != nil {
+		return nil, err
+	}
+
+	current := make(CSVManifest)
+	seen := make(map[string]int)
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parts := make([]string, len(keyIndexes))
+		for i, col := range keyIndexes {
+			if col < len(row) {
+				parts[i] = row[col]
+			}
+		}
+		key := strings.Join(parts, "|")
+		if n := seen[key]; n > 0 {
+			seen[key]++
+			key += "#" + strconv.Itoa(n)
+		} else {
+			seen[key] = 1
+		}
+
+		h := sha256.New()
+		h.Write(headerHash[:])
+		h.Write([]byte(strings.Join(row, "\x1f")))
+		hash := hex.EncodeToString(h.Sum(nil))
+		current[key] = hash
+
+		op := DeltaAdd
+		if old, ok := prev[key]; ok {
+			if old == hash {
+				continue
+			}
+			op = DeltaChange
+		}
+		if err := cw.Write(append([]string{op, key}, row...)); err != nil {
+			return nil, err
+		}
+	}
+
+	var removed []string
+	for key := range prev {
+		if _, ok := current[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	for _, key := range removed {
+		row := make([]string, 2+len(header))
+		row[0], row[1] = DeltaRemove, key
+		if err := cw.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	cw.Flush()
+	return current, cw.Error()
+}
+
+// deltaKeyIndexes returns the indexes in header of the named key columns,
+// or of the first column when none are named.
+func deltaKeyIndexes(header, keyColumns []string) ([]int, error) {
+	var indexes []int
+	if len(keyColumns) == 0 {
+		if len(header) > 0 {
+			indexes = []int{0}
+		}
+		return indexes, nil
+	}
+	for _, name := range keyColumns {
+		i := indexOf(header, name)
+		if i < 0 {
+			return nil, fmt.Errorf("key column %q not in export header", name)
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes, nil
+}
+
+// indexOf returns the index of s in list, or -1.
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
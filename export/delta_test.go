@@ -0,0 +1,160 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func deltaLines(t *testing.T, buf *bytes.Buffer) []string {
+	t.Helper()
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
+
+func TestWriteCSVDelta(t *testing.T) {
+	doc := decodeTestDocument(t, `0 @I1@ INDI
+1 NAME John /Smith/
+1 SEX M
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 SEX F
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+`)
+	export := func(w io.Writer, doc *gedcom.Document) error {
+		_, err := io.WriteString(w, "person_key,name\n")
+		for _, ind := range doc.Individuals() {
+			if err == nil {
+				_, err = io.WriteString(w, ind.XRef+","+gedcom.LabelName(ind)+"\n")
+			}
+		}
+		return err
+	}
+
+	// First run: everything is added
+	var buf bytes.Buffer
+	manifest, err := WriteCSVDelta(&buf, doc, export, nil)
+	if err != nil {
+		t.Fatalf("WriteCSVDelta() error = %v", err)
+	}
+	want := []string{"op,row_key,person_key,name", "add,@I1@,@I1@,John Smith", "add,@I2@,@I2@,Mary Jones"}
+	if got := deltaLines(t, &buf); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("first delta = %q, want %q", got, want)
+	}
+
+	// Round-trip the manifest through its CSV form
+	var stored bytes.Buffer
+	if err := manifest.WriteCSV(&stored); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	prev, err := ReadCSVManifest(&stored)
+	if err != nil {
+		t.Fatalf("ReadCSVManifest() error = %v", err)
+	}
+	if len(prev) != 2 || prev["@I1@"] != manifest["@I1@"] {
+		t.Errorf("ReadCSVManifest() = %v, want %v", prev, manifest)
+	}
+
+	// Unchanged: only the header
+	buf.Reset()
+	if _, err := WriteCSVDelta(&buf, doc, export, prev); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "op,row_key,person_key,name\n" {
+		t.Errorf("unchanged delta = %q, want header only", got)
+	}
+
+	// Change Mary, remove John, add Ann
+	doc = decodeTestDocument(t, `0 @I2@ INDI
+1 NAME Mary /Smith/
+1 SEX F
+0 @I3@ INDI
+1 NAME Ann /Smith/
+`)
+	buf.Reset()
+	next, err := WriteCSVDelta(&buf, doc, export, prev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []string{
+		"op,row_key,person_key,name",
+		"change,@I2@,@I2@,Mary Smith",
+		"add,@I3@,@I3@,Ann Smith",
+		"remove,@I1@,,",
+	}
+	if got := deltaLines(t, &buf); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("delta = %q, want %q", got, want)
+	}
+	if len(next) != 2 || next["@I1@"] != "" {
+		t.Errorf("next manifest = %v, want @I2@ and @I3@", next)
+	}
+}
+
+func TestWriteCSVDelta_KeyColumns(t *testing.T) {
+	doc := decodeTestDocument(t, `0 @I1@ INDI
+1 BIRT
+2 DATE 1900
+1 DEAT
+2 DATE 1950
+1 OCCU Farmer
+`)
+
+	var buf bytes.Buffer
+	manifest, err := WriteCSVDelta(&buf, doc, WriteEventsCSV, nil, "record_key", "event_index", "is_attribute")
+	if err != nil {
+		t.Fatalf("WriteCSVDelta() error = %v", err)
+	}
+	for key := range manifest {
+		if strings.Contains(key, "#") {
+			t.Errorf("row key %q numbered, want unique keys from the key columns", key)
+		}
+	}
+
+	// Default keys number repeated record keys
+	buf.Reset()
+	manifest, err = WriteCSVDelta(&buf, doc, WriteEventsCSV, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	numbered := 0
+	for key := range manifest {
+		if strings.Contains(key, "#") {
+			numbered++
+		}
+	}
+	if numbered == 0 {
+		t.Error("default row keys not numbered for records with several events")
+	}
+
+	if _, err := WriteCSVDelta(&buf, doc, WriteEventsCSV, nil, "missing"); err == nil {
+		t.Error("WriteCSVDelta() with unknown key column error = nil")
+	}
+}
+
+func TestWriteCSVDelta_EmptyExport(t *testing.T) {
+	prev := CSVManifest{"@I1@": "a", "@I2@": "b"}
+	empty := func(w io.Writer, doc *gedcom.Document) error { return nil }
+
+	var buf bytes.Buffer
+	next, err := WriteCSVDelta(&buf, nil, empty, prev, "person_key")
+	if err != nil {
+		t.Fatalf("WriteCSVDelta() error = %v", err)
+	}
+	want := []string{"op,row_key", "remove,@I1@", "remove,@I2@"}
+	if got := deltaLines(t, &buf); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("delta = %q, want %q", got, want)
+	}
+	if len(next) != 0 {
+		t.Errorf("manifest = %v, want empty", next)
+	}
+}
+
+func TestReadCSVManifest_BadHeader(t *testing.T) {
+	if _, err := ReadCSVManifest(strings.NewReader("key,value\n")); err == nil {
+		t.Error("ReadCSVManifest() error = nil, want header error")
+	}
+}
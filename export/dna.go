@@ -0,0 +1,60 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// WriteDNAMatchesCSV writes all DNA matches in the document as CSV with a
+// header row. Person keys are the individuals' XRefs.
+//
+// Columns: person_key, match_key, shared_cm, segments, longest_segment_cm,
+// predicted_relationship, provider.
+func WriteDNAMatchesCSV(w io.Writer, doc *gedcom.Document) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	if err := cw.Write([]string{
+		"person_key", "match_key", "shared_cm", "segments",
+		"longest_segment_cm", "predicted_relationship", "provider",
+	}); err != nil {
+		return err
+	}
+
+	for _, link := range gedcom.DNAMatches(doc) {
+		m := link.Match
+		if err := cw.Write([]string{
+			key(link.PersonXRef),
+			key(link.MatchXRef),
+			formatCM(m.SharedCM),
+			formatCount(m.SharedSegments),
+			formatCM(m.LongestSegmentCM),
+			m.Relationship,
+			m.Provider,
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// formatCM formats a centimorgan value, leaving zero values empty.
+func formatCM(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatCount formats a count, leaving zero values empty.
+func formatCount(v int) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.Itoa(v)
+}
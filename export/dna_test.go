@@ -0,0 +1,34 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteDNAMatchesCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDNAMatchesCSV(&buf, loadTestDocument(t, "dna")); err != nil {
+		t.Fatalf("WriteDNAMatchesCSV() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"person_key,match_key,shared_cm,segments,longest_segment_cm,predicted_relationship,provider",
+		"@I1@,@I2@,212.5,9,,2nd cousin,AncestryDNA",
+		"@I3@,@I1@,1700,,120.25,,",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("WriteDNAMatchesCSV() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteDNAMatchesCSVEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDNAMatchesCSV(&buf, nil); err != nil {
+		t.Fatalf("WriteDNAMatchesCSV() error = %v", err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("expected header row only, got %q", buf.String())
+	}
+}
@@ -0,0 +1,40 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// WritePersonEventLinksCSV writes all event links in the document as CSV with
+// a header row. Person and record keys are the records' XRefs; an event is
+// identified by its record key and index within that record.
+//
+// Columns: person_key, record_key, event_index, event_type, role.
+func WritePersonEventLinksCSV(w io.Writer, doc *gedcom.Document) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	if err := cw.Write([]string{
+		"person_key", "record_key", "event_index", "event_type", "role",
+	}); err != nil {
+		return err
+	}
+
+	for _, link := range gedcom.EventLinks(doc) {
+		if err := cw.Write([]string{
+			key(link.PersonXRef),
+			key(link.RecordXRef),
+			strconv.Itoa(link.EventIndex),
+			string(link.Event.Type),
+			link.Role,
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
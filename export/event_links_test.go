@@ -0,0 +1,25 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWritePersonEventLinksCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePersonEventLinksCSV(&buf, loadTestDocument(t, "event_links")); err != nil {
+		t.Fatalf("WritePersonEventLinksCSV() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if lines[0] != "person_key,record_key,event_index,event_type,role" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if len(lines) != 10 {
+		t.Fatalf("got %d lines, want 10", len(lines))
+	}
+	if lines[1] != "@I3@,@I1@,1,BAPM,GODP" {
+		t.Errorf("line 1 = %q", lines[1])
+	}
+}
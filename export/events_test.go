@@ -0,0 +1,63 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteEventsCSV(t *testing.T) {
+	doc := decodeTestDocument(t, `0 @I1@ INDI
+1 BIRT
+2 DATE 1 JAN 1900
+2 PLAC Boston
+1 DEAT
+2 DATE 1950
+2 CAUS Pneumonia
+2 AGE 72y
+2 AGNC County Coroner
+1 CONF
+2 RELI Lutheran
+1 EVEN Ran away
+2 TYPE Escapade
+2 _WITN John Smith
+2 _WITN Mary Jones
+1 OCCU Farmer
+2 TYPE Primary
+1 FACT Blue
+2 TYPE Eye color
+0 @F1@ FAM
+1 MARR Y
+`)
+	doc.GetIndividual("@I1@").Attributes[0].NormalizedValue = "farmer"
+
+	var buf bytes.Buffer
+	if err := WriteEventsCSV(&buf, doc); err != nil {
+		t.Fatalf("WriteEventsCSV() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"record_key,event_index,event_type,subtype,is_attribute,value,normalized_value,date,place,cause,religion,witnesses,age,agency",
+		"@I1@,0,BIRT,,false,,,1 JAN 1900,Boston,,,,,",
+		"@I1@,1,DEAT,,false,,,1950,,Pneumonia,,,72y,County Coroner",
+		"@I1@,2,CONF,,false,,,,,,Lutheran,,,",
+		"@I1@,3,EVEN,Escapade,false,Ran away,,,,,,John Smith; Mary Jones,,",
+		"@I1@,0,OCCU,Primary,true,Farmer,farmer,,,,,,,",
+		"@I1@,1,FACT,Eye color,true,Blue,,,,,,,,",
+		"@F1@,0,MARR,,false,Y,,,,,,,,",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("WriteEventsCSV() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteEventsCSVEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteEventsCSV(&buf, nil); err != nil {
+		t.Fatalf("WriteEventsCSV() error = %v", err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("expected header row only, got %q", buf.String())
+	}
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/decoder"
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// loadTestDocument decodes testdata/<name>.ged.
+func loadTestDocument(t *testing.T, name string) *gedcom.Document {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name+".ged"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer f.Close()
+	doc, err := decoder.Decode(f)
+	if err != nil {
+		t.Fatalf("Decode(%s) error = %v", name, err)
+	}
+	return doc
+}
+
+// decodeTestDocument decodes the given GEDCOM records between a 5.5.1
+// header and a trailer.
+func decodeTestDocument(t *testing.T, records string) *gedcom.Document {
+	t.Helper()
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n" + records + "0 TRLR\n"
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
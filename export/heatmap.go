@@ -0,0 +1,43 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// WriteEventHeatmapCSV writes the heatmap as CSV with a header row of "place" followed
+// by one column per decade, and one row per place.
+func WriteEventHeatmapCSV(w io.Writer, h *gedcom.EventHeatmap) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"place"}
+	for _, decade := range h.Decades {
+		header = append(header, strconv.Itoa(decade))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for i, place := range h.Places {
+		row := []string{place}
+		for _, n := range h.Counts[i] {
+			row = append(row, strconv.Itoa(n))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteEventHeatmapJSON writes the heatmap as a JSON object with decades, places, and
+// counts arrays.
+func WriteEventHeatmapJSON(w io.Writer, h *gedcom.EventHeatmap) error {
+	return json.NewEncoder(w).Encode(h)
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func TestWriteEventHeatmapCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteEventHeatmapCSV(&buf, gedcom.BuildEventHeatmap(loadTestDocument(t, "heatmap"), 1)); err != nil {
+		t.Fatalf("WriteEventHeatmapCSV() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"place,1880,1890,1900,1910,1920",
+		"USA,0,0,2,0,1",
+		"Ireland,2,0,0,0,0",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("WriteEventHeatmapCSV() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteEventHeatmapJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteEventHeatmapJSON(&buf, gedcom.BuildEventHeatmap(loadTestDocument(t, "heatmap"), 1)); err != nil {
+		t.Fatalf("WriteEventHeatmapJSON() error = %v", err)
+	}
+
+	want := `{"decades":[1880,1890,1900,1910,1920],"places":["USA","Ireland"],"counts":[[0,0,2,0,1],[2,0,0,0,0]]}` + "\n"
+	if buf.String() != want {
+		t.Errorf("WriteEventHeatmapJSON() = %s, want %s", buf.String(), want)
+	}
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// recordIdentifiers returns the identifiers of a record's entity, or nil
+// for entities that carry none.
+func recordIdentifiers(record *gedcom.Record) []*gedcom.Identifier {
+	switch entity := record.LoadEntity().(type) {
+	case *gedcom.Individual:
+		return entity.Identifiers
+	case *gedcom.Family:
+		return entity.Identifiers
+	case *gedcom.Source:
+		return entity.Identifiers
+	case *gedcom.Repository:
+		return entity.Identifiers
+	case *gedcom.Submitter:
+		return entity.Identifiers
+	case *gedcom.MediaObject:
+		return entity.Identifiers
+	}
+	return nil
+}
+
+// WriteIdentifiersCSV writes the REFN and EXID identifiers of every record
+// in the document as CSV with a header row, one row per identifier. Record
+// keys are the records' XRefs.
+//
+// Columns: record_key, record_type, kind, value, type.
+func WriteIdentifiersCSV(w io.Writer, doc *gedcom.Document) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	if err := cw.Write([]string{"record_key", "record_type", "kind", "value", "type"}); err != nil {
+		return err
+	}
+
+	if doc != nil {
+		for _, record := range doc.Records {
+			for _, id := range recordIdentifiers(record) {
+				if err := cw.Write([]string{key(record.XRef), string(record.Type), id.Kind, id.Value, id.Type}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
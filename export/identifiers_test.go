@@ -0,0 +1,41 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func TestWriteIdentifiersCSV(t *testing.T) {
+	indi := &gedcom.Individual{XRef: "@I1@", Identifiers: []*gedcom.Identifier{
+		{Kind: gedcom.IdentifierEXID, Value: "KWCJ-QN7", Type: "https://www.familysearch.org/tree/person/"},
+		{Kind: gedcom.IdentifierREFN, Value: "42", Type: "Ancestry member tree"},
+	}}
+	src := &gedcom.Source{XRef: "@S1@", Identifiers: []*gedcom.Identifier{{Kind: gedcom.IdentifierREFN, Value: "MS 1234"}}}
+	doc := &gedcom.Document{Records: []*gedcom.Record{
+		{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: indi},
+		{XRef: "@N1@", Type: gedcom.RecordTypeNote, Entity: &gedcom.Note{XRef: "@N1@"}},
+		{XRef: "@S1@", Type: gedcom.RecordTypeSource, Entity: src},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteIdentifiersCSV(&buf, doc); err != nil {
+		t.Fatalf("WriteIdentifiersCSV() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"record_key,record_type,kind,value,type",
+		"@I1@,INDI,EXID,KWCJ-QN7,https://www.familysearch.org/tree/person/",
+		"@I1@,INDI,REFN,42,Ancestry member tree",
+		"@S1@,SOUR,REFN,MS 1234,",
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteIdentifiersCSV(&buf, nil); err != nil || buf.String() != "record_key,record_type,kind,value,type\n" {
+		t.Errorf("WriteIdentifiersCSV(nil) = %q, %v", buf.String(), err)
+	}
+}
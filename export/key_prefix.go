@@ -0,0 +1,131 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// CSVExport writes a CSV export of doc to w, such as WriteEventsCSV.
+// Exports that take further arguments are adapted with a closure:
+//
+//	func(w io.Writer, doc *gedcom.Document) error {
+//		return WriteCitationsCSV(w, doc, 500)
+//	}
+type CSVExport func(w io.Writer, doc *gedcom.Document) error
+
+// WithKeyPrefix returns an export that writes export with prefix prepended
+// to its record keys, so exports of several trees can be loaded into one
+// database without key collisions. Use the same prefix, such as a tree ID
+// with a separator ("tree42:"), for all exports of a run so keys still join
+// across files. Empty keys stay empty.
+//
+// The exports of this package, including the GeoJSON, KML, and JSON ones,
+// prefix every key they write themselves, among them the XRefs in list
+// columns such as the repositories of WriteSourcesCSV; keyColumns are not
+// used for them. Other exports, such as validator.WriteDuplicatesCSV, must
+// be CSV and name the columns that hold record keys in keyColumns; a name
+// missing from the header is an error, as is naming none. Writers that take
+// other arguments are adapted with a closure:
+//
+//	dups := WithKeyPrefix("tree42:", func(w io.Writer, _ *gedcom.Document) error {
+//		return validator.WriteDuplicatesCSV(w, pairs)
+//	}, "person_a", "person_b")
+//
+// Exports of this package that write no record keys, such as
+// WriteSurnameIndexCSV, return an error unless keyColumns are named.
+func WithKeyPrefix(prefix string, export CSVExport, keyColumns ...string) CSVExport {
+	return func(w io.Writer, doc *gedcom.Document) error {
+		if prefix == "" {
+			return export(w, doc)
+		}
+
+		pw := &keyPrefixWriter{prefix: prefix}
+		if err := export(pw, doc); err != nil {
+			return err
+		}
+		if pw.applied {
+			_, err := pw.WriteTo(w)
+			return err
+		}
+		if len(keyColumns) == 0 {
+			return errors.New("export does not prefix its keys and no key columns were named")
+		}
+		return prefixKeyColumns(w, &pw.Buffer, prefix, keyColumns)
+	}
+}
+
+// keyPrefixWriter buffers an export run by WithKeyPrefix and carries the
+// prefix to the exports of this package (see keyPrefix).
+type keyPrefixWriter struct {
+	bytes.Buffer
+	prefix  string
+	applied bool
+}
+
+// keyPrefix returns the function an export applies to each record key it
+// writes to w. When w is the writer of WithKeyPrefix, the function prepends
+// its prefix to non-empty keys; otherwise keys are returned unchanged.
+func keyPrefix(w io.Writer) func(key string) string {
+	pw, ok := w.(*keyPrefixWriter)
+	if !ok {
+		return func(key string) string { return key }
+	}
+	pw.applied = true
+	return func(key string) string {
+		if key == "" {
+			return key
+		}
+		return pw.prefix + key
+	}
+}
+
+// prefixKeyColumns copies the CSV in r to w with prefix prepended to the
+// non-empty values of keyColumns.
+func prefixKeyColumns(w io.Writer, r io.Reader, prefix string, keyColumns []string) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cw := csv.NewWriter(w)
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	keyIndexes := make([]int, len(keyColumns))
+	for i, name := range keyColumns {
+		if keyIndexes[i] = indexOf(header, name); keyIndexes[i] < 0 {
+			return fmt.Errorf("key column %q not in export header", name)
+		}
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for _, i := range keyIndexes {
+			if i < len(row) && row[i] != "" {
+				row[i] = prefix + row[i]
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
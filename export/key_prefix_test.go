@@ -0,0 +1,128 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/validator"
+)
+
+func TestWithKeyPrefix(t *testing.T) {
+	doc := loadTestDocument(t, "parent_links")
+
+	var plain, prefixed bytes.Buffer
+	if err := WriteParentLinksCSV(&plain, doc); err != nil {
+		t.Fatal(err)
+	}
+	if err := WithKeyPrefix("tree42:", WriteParentLinksCSV)(&prefixed, doc); err != nil {
+		t.Fatalf("WithKeyPrefix() error = %v", err)
+	}
+
+	lines := bytes.Split(bytes.TrimSuffix(prefixed.Bytes(), []byte("\n")), []byte("\n"))
+	if got := string(lines[0]); got != "child_key,parent_key,family_key,parent_type,pedigree,linkage,status" {
+		t.Errorf("header = %q, want unchanged", got)
+	}
+	if got := string(lines[3]); got != "tree42:@I4@,tree42:@I3@,tree42:@F2@,ADOP_HUSB,,adopted," {
+		t.Errorf("line 3 = %q, want prefixed keys", got)
+	}
+	if bytes.Count(plain.Bytes(), []byte("\n")) != len(lines) {
+		t.Errorf("prefixed export has %d lines, want the same as the plain export", len(lines))
+	}
+}
+
+func TestWithKeyPrefix_ListsAndGeoJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WithKeyPrefix("tree42:", WriteSourcesCSV)(&buf, createSourcesTestDocument()); err != nil {
+		t.Fatalf("WithKeyPrefix() error = %v", err)
+	}
+	if want := "tree42:@S1@,Parish Registers,St. Mary,,St. Mary Parish,tree42:@R1@; State Library,"; !strings.Contains(buf.String(), want) {
+		t.Errorf("WithKeyPrefix(WriteSourcesCSV) =\n%s\nwant the repository list prefixed", buf.String())
+	}
+
+	buf.Reset()
+	if err := WithKeyPrefix("tree42:", WriteBurialsGeoJSON)(&buf, loadTestDocument(t, "burials")); err != nil {
+		t.Fatalf("WithKeyPrefix() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"individual_key":"tree42:@I1@"`) {
+		t.Errorf("WithKeyPrefix(WriteBurialsGeoJSON) = %s, want a prefixed individual_key", buf.String())
+	}
+
+	report := &gedcom.OutlierReport{LargestFamilies: []gedcom.Outlier{{XRef: "@F1@", Value: 3}}}
+	buf.Reset()
+	outliers := WithKeyPrefix("tree42:", func(w io.Writer, _ *gedcom.Document) error {
+		return WriteOutlierReportJSON(w, report)
+	})
+	if err := outliers(&buf, nil); err != nil {
+		t.Fatalf("WithKeyPrefix() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"xref":"tree42:@F1@"`) || report.LargestFamilies[0].XRef != "@F1@" {
+		t.Errorf("WithKeyPrefix(WriteOutlierReportJSON) = %s, want a prefixed copy of the report", buf.String())
+	}
+}
+
+func TestWithKeyPrefix_NamedColumnsAndEmptyKeys(t *testing.T) {
+	export := func(w io.Writer, doc *gedcom.Document) error {
+		_, err := io.WriteString(w, "xref,label,match_key,note_key\n@I1@,\"Smith, John\",,@N1@\n")
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := WithKeyPrefix("t1/", export, "xref", "match_key")(&buf, nil); err != nil {
+		t.Fatalf("WithKeyPrefix() error = %v", err)
+	}
+	if want := "xref,label,match_key,note_key\nt1/@I1@,\"Smith, John\",,@N1@\n"; buf.String() != want {
+		t.Errorf("WithKeyPrefix() = %q, want %q", buf.String(), want)
+	}
+
+	if err := WithKeyPrefix("t1/", export, "missing")(io.Discard, nil); err == nil {
+		t.Error("WithKeyPrefix() with unknown key column error = nil")
+	}
+	if err := WithKeyPrefix("t1/", export)(io.Discard, nil); err == nil {
+		t.Error("WithKeyPrefix() without key columns error = nil")
+	}
+	if err := WithKeyPrefix("t1/", WriteSurnameIndexCSV)(io.Discard, nil); err == nil {
+		t.Error("WithKeyPrefix() of an export without keys error = nil")
+	}
+}
+
+func TestWithKeyPrefix_Duplicates(t *testing.T) {
+	pairs := []validator.DuplicatePair{{
+		Individual1:  &gedcom.Individual{XRef: "@I1@"},
+		Individual2:  &gedcom.Individual{XRef: "@I2@"},
+		Confidence:   0.9,
+		MatchReasons: []string{"same name"},
+	}}
+	dups := WithKeyPrefix("tree42:", func(w io.Writer, _ *gedcom.Document) error {
+		return validator.WriteDuplicatesCSV(w, pairs)
+	}, "person_a", "person_b")
+
+	var buf bytes.Buffer
+	if err := dups(&buf, nil); err != nil {
+		t.Fatalf("WithKeyPrefix() error = %v", err)
+	}
+	if want := "person_a,person_b,score,reasons\ntree42:@I1@,tree42:@I2@,0.9000,same name\n"; buf.String() != want {
+		t.Errorf("WithKeyPrefix() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWithKeyPrefix_Passthrough(t *testing.T) {
+	wantErr := errors.New("export failed")
+	failing := func(w io.Writer, doc *gedcom.Document) error { return wantErr }
+	if err := WithKeyPrefix("t1/", failing)(io.Discard, nil); !errors.Is(err, wantErr) {
+		t.Errorf("WithKeyPrefix() error = %v, want %v", err, wantErr)
+	}
+
+	var plain, unprefixed bytes.Buffer
+	doc := loadTestDocument(t, "parent_links")
+	_ = WriteParentLinksCSV(&plain, doc)
+	if err := WithKeyPrefix("", WriteParentLinksCSV, "child_key")(&unprefixed, doc); err != nil {
+		t.Fatal(err)
+	}
+	if plain.String() != unprefixed.String() {
+		t.Error("WithKeyPrefix with an empty prefix should not change the export")
+	}
+}
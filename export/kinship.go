@@ -0,0 +1,82 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// KinshipOption is a functional option for configuring WriteKinshipCSV.
+type KinshipOption func(*kinshipOptions)
+
+type kinshipOptions struct {
+	generation bool
+}
+
+// WithGenerationColumn returns a KinshipOption that adds a generation
+// column with each individual's generation relative to root, as computed by
+// Generations: positive for ancestors, negative for descendants, 0 for root,
+// and empty for everyone outside root's direct line.
+func WithGenerationColumn() KinshipOption {
+	return func(o *kinshipOptions) {
+		o.generation = true
+	}
+}
+
+// WriteKinshipCSV writes the relationship of every individual to root as CSV
+// with a header row, one row per individual in record order, for labeling
+// people in downstream apps. Labels and degrees are computed by
+// Relationships; degree is empty for relatives by marriage, and all three
+// values are empty for individuals not connected to root.
+//
+// Columns: person_key, relationship_label, degree, path_length, and
+// generation with WithGenerationColumn.
+func WriteKinshipCSV(w io.Writer, doc *gedcom.Document, root string, opts ...KinshipOption) error {
+	var o kinshipOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	header := []string{"person_key", "relationship_label", "degree", "path_length"}
+	if o.generation {
+		header = append(header, "generation")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	if doc != nil {
+		relationships := gedcom.Relationships(doc, root)
+		var generations map[string]int
+		if o.generation {
+			generations = gedcom.Generations(doc, root)
+		}
+		for _, ind := range doc.Individuals() {
+			row := []string{key(ind.XRef), "", "", ""}
+			if rel := relationships[ind.XRef]; rel != nil {
+				row[1] = rel.Label
+				if rel.Blood {
+					row[2] = strconv.Itoa(rel.Degree())
+				}
+				row[3] = strconv.Itoa(rel.PathLength)
+			}
+			if o.generation {
+				generation := ""
+				if g, ok := generations[ind.XRef]; ok {
+					generation = strconv.Itoa(g)
+				}
+				row = append(row, generation)
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
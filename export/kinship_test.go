@@ -0,0 +1,58 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteKinshipCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteKinshipCSV(&buf, loadTestDocument(t, "kinship"), "@I9@"); err != nil {
+		t.Fatalf("WriteKinshipCSV() error = %v", err)
+	}
+
+	want := "person_key,relationship_label,degree,path_length\n" +
+		"@I1@,grandfather,2,2\n" +
+		"@I2@,grandmother,2,2\n" +
+		"@I3@,uncle,3,3\n" +
+		"@I4@,mother,1,1\n" +
+		"@I5@,uncle's wife,,4\n" +
+		"@I6@,1st cousin,4,4\n" +
+		"@I7@,1st cousin,4,4\n" +
+		"@I8@,father,1,1\n" +
+		"@I9@,self,0,0\n" +
+		"@I10@,1st cousin's wife,,5\n" +
+		"@I11@,1st cousin once removed,5,5\n" +
+		"@I12@,relative by marriage,,6\n" +
+		"@I13@,daughter,1,1\n" +
+		"@I14@,,,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteKinshipCSV() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteKinshipCSV_Generation(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteKinshipCSV(&buf, loadTestDocument(t, "kinship"), "@I9@", WithGenerationColumn()); err != nil {
+		t.Fatalf("WriteKinshipCSV() error = %v", err)
+	}
+
+	want := "person_key,relationship_label,degree,path_length,generation\n" +
+		"@I1@,grandfather,2,2,2\n" +
+		"@I2@,grandmother,2,2,2\n" +
+		"@I3@,uncle,3,3,\n" +
+		"@I4@,mother,1,1,1\n" +
+		"@I5@,uncle's wife,,4,\n" +
+		"@I6@,1st cousin,4,4,\n" +
+		"@I7@,1st cousin,4,4,\n" +
+		"@I8@,father,1,1,1\n" +
+		"@I9@,self,0,0,0\n" +
+		"@I10@,1st cousin's wife,,5,\n" +
+		"@I11@,1st cousin once removed,5,5,\n" +
+		"@I12@,relative by marriage,,6,\n" +
+		"@I13@,daughter,1,1,-1\n" +
+		"@I14@,,,,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteKinshipCSV() =\n%s\nwant\n%s", got, want)
+	}
+}
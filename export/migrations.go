@@ -0,0 +1,102 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// WriteMigrationsCSV writes all inferred migration segments as CSV with a
+// header row. Individual keys are the records' XRefs.
+//
+// Columns: individual_key, from_place, to_place, from_date, to_date,
+// from_event, to_event.
+func WriteMigrationsCSV(w io.Writer, doc *gedcom.Document) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	if err := cw.Write([]string{
+		"individual_key", "from_place", "to_place", "from_date", "to_date",
+		"from_event", "to_event",
+	}); err != nil {
+		return err
+	}
+
+	for _, seg := range gedcom.MigrationSegments(doc) {
+		if err := cw.Write([]string{
+			key(seg.IndividualXRef),
+			seg.FromPlace(),
+			seg.ToPlace(),
+			seg.FromEvent.Date,
+			seg.ToEvent.Date,
+			string(seg.FromEvent.Type),
+			string(seg.ToEvent.Type),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// geoJSONFeatureCollection, geoJSONFeature, and geoJSONLineString model the
+// subset of GeoJSON (RFC 7946) written by WriteMigrationsGeoJSON.
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	Geometry   geoJSONLineString `json:"geometry"`
+	Properties map[string]string `json:"properties"`
+}
+
+type geoJSONLineString struct {
+	Type        string       `json:"type"`
+	Coordinates [][2]float64 `json:"coordinates"`
+}
+
+// WriteMigrationsGeoJSON writes the inferred migration segments as a GeoJSON
+// FeatureCollection of LineString features from origin to destination.
+// Segments are included only when both places carry MAP coordinates.
+// Feature properties: individual_key, from_place, to_place, from_date, to_date.
+func WriteMigrationsGeoJSON(w io.Writer, doc *gedcom.Document) error {
+	key := keyPrefix(w)
+	collection := geoJSONFeatureCollection{Type: "FeatureCollection", Features: []geoJSONFeature{}}
+
+	for _, seg := range gedcom.MigrationSegments(doc) {
+		fromLat, fromLon, fromOK := eventCoordinates(seg.FromEvent).Decimal()
+		toLat, toLon, toOK := eventCoordinates(seg.ToEvent).Decimal()
+		if !fromOK || !toOK {
+			continue
+		}
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type: "Feature",
+			Geometry: geoJSONLineString{
+				Type:        "LineString",
+				Coordinates: [][2]float64{{fromLon, fromLat}, {toLon, toLat}},
+			},
+			Properties: map[string]string{
+				"individual_key": key(seg.IndividualXRef),
+				"from_place":     seg.FromPlace(),
+				"to_place":       seg.ToPlace(),
+				"from_date":      seg.FromEvent.Date,
+				"to_date":        seg.ToEvent.Date,
+			},
+		})
+	}
+
+	return json.NewEncoder(w).Encode(collection)
+}
+
+// eventCoordinates returns the MAP coordinates of an event's place, or nil.
+func eventCoordinates(event *gedcom.Event) *gedcom.Coordinates {
+	if event.PlaceDetail == nil {
+		return nil
+	}
+	return event.PlaceDetail.Coordinates
+}
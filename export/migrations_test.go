@@ -0,0 +1,48 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteMigrationsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMigrationsCSV(&buf, loadTestDocument(t, "migrations")); err != nil {
+		t.Fatalf("WriteMigrationsCSV() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"individual_key,from_place,to_place,from_date,to_date,from_event,to_event",
+		`@I1@,"Cork, Ireland","Boston, Massachusetts",1902,1905,EMIG,MARR`,
+		`@I1@,"Boston, Massachusetts","Chicago, Illinois",1905,1930,MARR,DEAT`,
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("WriteMigrationsCSV() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteMigrationsGeoJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMigrationsGeoJSON(&buf, loadTestDocument(t, "migrations")); err != nil {
+		t.Fatalf("WriteMigrationsGeoJSON() error = %v", err)
+	}
+
+	// Only the Cork -> Boston segment has coordinates at both ends
+	want := `{"type":"FeatureCollection","features":[{"type":"Feature",` +
+		`"geometry":{"type":"LineString","coordinates":[[-8.4756,51.8985],[-71.0589,42.3601]]},` +
+		`"properties":{"from_date":"1902","from_place":"Cork, Ireland","individual_key":"@I1@",` +
+		`"to_date":"1905","to_place":"Boston, Massachusetts"}}]}` + "\n"
+	if buf.String() != want {
+		t.Errorf("WriteMigrationsGeoJSON() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteMigrationsGeoJSON(&buf, nil); err != nil {
+		t.Fatalf("WriteMigrationsGeoJSON(nil) error = %v", err)
+	}
+	if buf.String() != `{"type":"FeatureCollection","features":[]}`+"\n" {
+		t.Errorf("WriteMigrationsGeoJSON(nil) = %s", buf.String())
+	}
+}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// WriteOutlierReportCSV writes the report as CSV with a header row and one row per entry.
+// Columns: category, rank, xref, value, label. Categories are
+// longest_lifespans, largest_families, marriage_age_gaps, and
+// most_cited_sources; ranks start at 1 within each category.
+func WriteOutlierReportCSV(w io.Writer, r *gedcom.OutlierReport) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+	if err := cw.Write([]string{"category", "rank", "xref", "value", "label"}); err != nil {
+		return err
+	}
+	for _, category := range []struct {
+		name    string
+		entries []gedcom.Outlier
+	}{
+		{"longest_lifespans", r.LongestLifespans},
+		{"largest_families", r.LargestFamilies},
+		{"marriage_age_gaps", r.MarriageAgeGaps},
+		{"most_cited_sources", r.MostCitedSources},
+	} {
+		for i, entry := range category.entries {
+			if err := cw.Write([]string{
+				category.name, strconv.Itoa(i + 1), key(entry.XRef), strconv.Itoa(entry.Value), entry.Label,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteOutlierReportJSON writes the report as a JSON object with one array per category.
+func WriteOutlierReportJSON(w io.Writer, r *gedcom.OutlierReport) error {
+	key := keyPrefix(w)
+	prefixed := *r
+	for _, entries := range []*[]gedcom.Outlier{
+		&prefixed.LongestLifespans, &prefixed.LargestFamilies, &prefixed.MarriageAgeGaps, &prefixed.MostCitedSources,
+	} {
+		copied := make([]gedcom.Outlier, len(*entries))
+		for i, entry := range *entries {
+			entry.XRef = key(entry.XRef)
+			copied[i] = entry
+		}
+		*entries = copied
+	}
+	return json.NewEncoder(w).Encode(prefixed)
+}
@@ -0,0 +1,36 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func TestWriteOutlierReport(t *testing.T) {
+	report := gedcom.BuildOutlierReport(loadTestDocument(t, "outliers"), 1)
+
+	var buf bytes.Buffer
+	if err := WriteOutlierReportCSV(&buf, report); err != nil {
+		t.Fatalf("WriteOutlierReportCSV() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"category,rank,xref,value,label",
+		"longest_lifespans,1,@I1@,150,John Smith",
+		"largest_families,1,@F2@,2,",
+		"marriage_age_gaps,1,@F1@,60,John Smith & Mary Jones",
+		"most_cited_sources,1,@S1@,3,Parish register",
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Errorf("WriteOutlierReportCSV() =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteOutlierReportJSON(&buf, report); err != nil {
+		t.Fatalf("WriteOutlierReportJSON() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"longest_lifespans":[{"xref":"@I1@","value":150,"label":"John Smith"}]`) {
+		t.Errorf("WriteOutlierReportJSON() = %s", buf.String())
+	}
+}
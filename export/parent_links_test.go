@@ -0,0 +1,28 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteParentLinksCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteParentLinksCSV(&buf, loadTestDocument(t, "parent_links")); err != nil {
+		t.Fatalf("WriteParentLinksCSV() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if lines[0] != "child_key,parent_key,family_key,parent_type,pedigree,linkage,status" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if len(lines) != 11 {
+		t.Fatalf("got %d lines, want 11", len(lines))
+	}
+	if lines[3] != "@I4@,@I3@,@F2@,ADOP_HUSB,,adopted," {
+		t.Errorf("line 3 = %q, want adoptive husband row", lines[3])
+	}
+	if lines[9] != "@I9@,@I6@,@F3@,HUSB,FOSTER,foster,proven" {
+		t.Errorf("line 9 = %q, want foster row", lines[9])
+	}
+}
@@ -0,0 +1,43 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// WritePhoneticIndexCSV writes the phonetic surname index as CSV with a
+// header row, one row per individual, surname, and Daitch-Mokotoff code so
+// that either code can be joined against census or immigration indexes.
+// Individual keys are the records' XRefs; birth_date is the first birth
+// event's date.
+//
+// Columns: soundex, daitch_mokotoff, surname, given, birth_date, individual_key.
+func WritePhoneticIndexCSV(w io.Writer, doc *gedcom.Document) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	if err := cw.Write([]string{
+		"soundex", "daitch_mokotoff", "surname", "given", "birth_date", "individual_key",
+	}); err != nil {
+		return err
+	}
+
+	for _, entry := range gedcom.PhoneticIndex(doc) {
+		birthDate := ""
+		if birth := doc.GetIndividual(entry.IndividualXRef).BirthEvent(); birth != nil {
+			birthDate = birth.Date
+		}
+		for _, dm := range entry.DaitchMokotoff {
+			if err := cw.Write([]string{
+				entry.Soundex, dm, entry.Surname, entry.Given, birthDate, key(entry.IndividualXRef),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,26 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWritePhoneticIndexCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePhoneticIndexCSV(&buf, loadTestDocument(t, "phonetic")); err != nil {
+		t.Fatalf("WritePhoneticIndexCSV() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"soundex,daitch_mokotoff,surname,given,birth_date,individual_key",
+		"M232,645740,Moskowitz,Anna,3 MAR 1880,@I1@",
+		"M232,645740,Moskowitz,Max,,@I2@",
+		"P362,734000,Peters,Anna,3 MAR 1880,@I1@",
+		"P362,739400,Peters,Anna,3 MAR 1880,@I1@",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("WritePhoneticIndexCSV() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
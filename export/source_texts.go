@@ -0,0 +1,95 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// DefaultInlineTextLength is the longest source text, in characters, that
+// WriteSourceTextsCSV leaves inline when no length is given.
+const DefaultInlineTextLength = 255
+
+// WriteSourceTextsCSV writes source texts too long to sit inline in other
+// exports as CSV with a header row: the TEXT of source records and the
+// DATA.TEXT quotes of citations on individuals, families, their events, and
+// individual attributes. Texts of inlineLength characters or fewer are
+// skipped; zero means DefaultInlineTextLength, and a negative length writes
+// every text. Rows follow record order.
+//
+// For a source record, record_key and source_key are both its XRef and kind
+// is "source". For a citation, record_key is the citing record, source_key
+// the cited source, page its PAGE, and kind "citation". Texts keep the line
+// breaks of their CONT lines.
+//
+// Columns: record_key, source_key, kind, page, length, text.
+func WriteSourceTextsCSV(w io.Writer, doc *gedcom.Document, inlineLength int) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	if err := cw.Write([]string{"record_key", "source_key", "kind", "page", "length", "text"}); err != nil {
+		return err
+	}
+	if inlineLength == 0 {
+		inlineLength = DefaultInlineTextLength
+	}
+
+	write := func(recordKey, sourceKey, kind, page, text string) error {
+		n := utf8.RuneCountInString(text)
+		if text == "" || n <= inlineLength {
+			return nil
+		}
+		return cw.Write([]string{key(recordKey), key(sourceKey), kind, page, strconv.Itoa(n), text})
+	}
+	writeCitations := func(recordKey string, citations []*gedcom.SourceCitation) error {
+		for _, c := range citations {
+			if c == nil || c.Data == nil {
+				continue
+			}
+			if err := write(recordKey, c.SourceXRef, "citation", c.Page, c.Data.Text); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	writeEvents := func(recordKey string, events []*gedcom.Event) error {
+		for _, event := range events {
+			if err := writeCitations(recordKey, event.SourceCitations); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if doc != nil {
+		for _, record := range doc.Records {
+			var err error
+			switch entity := record.LoadEntity().(type) {
+			case *gedcom.Source:
+				err = write(entity.XRef, entity.XRef, "source", "", entity.Text)
+			case *gedcom.Individual:
+				if err = writeCitations(entity.XRef, entity.SourceCitations); err == nil {
+					err = writeEvents(entity.XRef, entity.Events)
+				}
+				for _, attr := range entity.Attributes {
+					if err == nil {
+						err = writeCitations(entity.XRef, attr.SourceCitations)
+					}
+				}
+			case *gedcom.Family:
+				if err = writeCitations(entity.XRef, entity.SourceCitations); err == nil {
+					err = writeEvents(entity.XRef, entity.Events)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteSourceTextsCSV(t *testing.T) {
+	long := strings.Repeat("x", 20)
+	doc := decodeTestDocument(t, `0 @I1@ INDI
+1 SOUR @S1@
+2 PAGE p. 4
+2 DATA
+3 TEXT Born to John
+4 CONT and Mary `+long+`
+1 SOUR @S1@
+2 DATA
+3 TEXT short
+1 BIRT
+2 SOUR @S2@
+3 DATA
+4 TEXT `+long+`
+0 @F1@ FAM
+1 SOUR @S1@
+0 @S1@ SOUR
+1 TEXT Parish register `+long+`
+0 @S2@ SOUR
+1 TEXT tiny
+`)
+
+	var buf bytes.Buffer
+	if err := WriteSourceTextsCSV(&buf, doc, 10); err != nil {
+		t.Fatalf("WriteSourceTextsCSV() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"record_key,source_key,kind,page,length,text",
+		"@I1@,@S1@,citation,p. 4,42,\"Born to John\nand Mary " + long + "\"",
+		"@I1@,@S2@,citation,,20," + long,
+		"@S1@,@S1@,source,,36,Parish register " + long,
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("WriteSourceTextsCSV() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteSourceTextsCSVInlineLength(t *testing.T) {
+	doc := decodeTestDocument(t, "0 @S1@ SOUR\n1 TEXT "+strings.Repeat("x", DefaultInlineTextLength)+"\n"+
+		"0 @S2@ SOUR\n1 TEXT "+strings.Repeat("x", DefaultInlineTextLength+1)+"\n")
+
+	tests := []struct {
+		length int
+		rows   int
+	}{
+		{0, 1},  // default skips @S1@
+		{-1, 2}, // negative writes all
+		{1000, 0},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := WriteSourceTextsCSV(&buf, doc, tt.length); err != nil {
+			t.Fatalf("WriteSourceTextsCSV() error = %v", err)
+		}
+		if got := strings.Count(buf.String(), "\n") - 1; got != tt.rows {
+			t.Errorf("WriteSourceTextsCSV(%d) wrote %d rows, want %d", tt.length, got, tt.rows)
+		}
+	}
+}
+
+func TestWriteSourceTextsCSVEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSourceTextsCSV(&buf, nil, 0); err != nil {
+		t.Fatalf("WriteSourceTextsCSV() error = %v", err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("expected header row only, got %q", buf.String())
+	}
+}
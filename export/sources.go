@@ -0,0 +1,122 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// WriteSourcesCSV writes source records as CSV with a header row, one row
+// per source in record order.
+//
+// repositories lists the cited repositories by XRef, or by name for inline
+// repositories, and call_numbers their call numbers; recorded_events lists
+// the event types of DATA.EVEN without repeats. Multiple values are joined
+// with "; ". WriteSourceCoverageCSV writes the period and place of each
+// DATA.EVEN.
+//
+// Columns: source_key, title, author, publication, agency, repositories,
+// call_numbers, recorded_events.
+func WriteSourcesCSV(w io.Writer, doc *gedcom.Document) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	if err := cw.Write([]string{
+		"source_key", "title", "author", "publication", "agency", "repositories", "call_numbers", "recorded_events",
+	}); err != nil {
+		return err
+	}
+
+	for _, src := range sourceRecords(doc) {
+		var repositories, callNumbers, events []string
+		for _, citation := range sourceRepositories(src) {
+			repositories = append(repositories, joinNonEmpty("", key(citation.RepositoryXRef), citation.Name))
+			for _, caln := range citation.CallNumbers {
+				callNumbers = append(callNumbers, caln.Number)
+			}
+		}
+		var agency string
+		if src.Data != nil {
+			agency = src.Data.Agency
+			seen := make(map[gedcom.EventType]bool)
+			for _, event := range src.Data.Events {
+				for _, t := range event.EventTypes() {
+					if !seen[t] {
+						seen[t] = true
+						events = append(events, string(t))
+					}
+				}
+			}
+		}
+
+		if err := cw.Write([]string{
+			key(src.XRef),
+			src.Title,
+			src.Author,
+			src.Publication,
+			agency,
+			strings.Join(repositories, "; "),
+			strings.Join(callNumbers, "; "),
+			strings.Join(events, "; "),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteSourceCoverageCSV writes what sources cover as CSV with a header row,
+// one row per recorded event group (SOUR.DATA.EVEN) in record order. events
+// is the EVEN value, such as "BIRT, DEAT", date the period it covers, and
+// place the jurisdiction.
+//
+// Columns: source_key, events, date, place.
+func WriteSourceCoverageCSV(w io.Writer, doc *gedcom.Document) error {
+	cw := csv.NewWriter(w)
+	key := keyPrefix(w)
+
+	if err := cw.Write([]string{"source_key", "events", "date", "place"}); err != nil {
+		return err
+	}
+
+	for _, src := range sourceRecords(doc) {
+		if src.Data == nil {
+			continue
+		}
+		for _, event := range src.Data.Events {
+			if err := cw.Write([]string{key(src.XRef), event.Types, event.Date, event.Place}); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// sourceRecords returns the sources of doc in record order, or nil for a
+// nil document.
+func sourceRecords(doc *gedcom.Document) []*gedcom.Source {
+	if doc == nil {
+		return nil
+	}
+	return doc.Sources()
+}
+
+// sourceRepositories returns the repository citations of src, falling back
+// to RepositoryRef and Repository for sources built without Repositories.
+func sourceRepositories(src *gedcom.Source) []*gedcom.RepositoryCitation {
+	switch {
+	case len(src.Repositories) > 0:
+		return src.Repositories
+	case src.RepositoryRef != "":
+		return []*gedcom.RepositoryCitation{{RepositoryXRef: src.RepositoryRef}}
+	case src.Repository != nil && src.Repository.Name != "":
+		return []*gedcom.RepositoryCitation{{Name: src.Repository.Name}}
+	}
+	return nil
+}
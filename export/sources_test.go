@@ -0,0 +1,79 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func createSourcesTestDocument() *gedcom.Document {
+	sources := []*gedcom.Source{
+		{
+			XRef:   "@S1@",
+			Title:  "Parish Registers",
+			Author: "St. Mary",
+			Repositories: []*gedcom.RepositoryCitation{
+				{RepositoryXRef: "@R1@", CallNumbers: []*gedcom.CallNumber{{Number: "MS 123"}, {Number: "MS 124"}}},
+				{Name: "State Library"},
+			},
+			Data: &gedcom.SourceData{
+				Agency: "St. Mary Parish",
+				Events: []*gedcom.SourceDataEvent{
+					{Types: "BIRT, DEAT", Date: "FROM 1820 TO 1860", Place: "Boston, MA"},
+					{Types: "marr, BIRT", Date: "1830"},
+				},
+			},
+		},
+		{XRef: "@S2@", Title: "Family Bible", RepositoryRef: "@R2@"},
+	}
+	doc := &gedcom.Document{XRefMap: make(map[string]*gedcom.Record)}
+	for _, src := range sources {
+		r := &gedcom.Record{XRef: src.XRef, Type: gedcom.RecordTypeSource, Entity: src}
+		doc.Records = append(doc.Records, r)
+		doc.XRefMap[src.XRef] = r
+	}
+	return doc
+}
+
+func TestWriteSourcesCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSourcesCSV(&buf, createSourcesTestDocument()); err != nil {
+		t.Fatalf("WriteSourcesCSV() error = %v", err)
+	}
+
+	want := "source_key,title,author,publication,agency,repositories,call_numbers,recorded_events\n" +
+		"@S1@,Parish Registers,St. Mary,,St. Mary Parish,@R1@; State Library,MS 123; MS 124,BIRT; DEAT; MARR\n" +
+		"@S2@,Family Bible,,,,@R2@,,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteSourcesCSV() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteSourceCoverageCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSourceCoverageCSV(&buf, createSourcesTestDocument()); err != nil {
+		t.Fatalf("WriteSourceCoverageCSV() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	want := []string{
+		"source_key,events,date,place",
+		`@S1@,"BIRT, DEAT",FROM 1820 TO 1860,"Boston, MA"`,
+		`@S1@,"marr, BIRT",1830,`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("WriteSourceCoverageCSV() = %q, want %q", lines, want)
+	}
+}
+
+func TestWriteSourcesCSV_NilDocument(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSourcesCSV(&buf, nil); err != nil {
+		t.Fatalf("WriteSourcesCSV() error = %v", err)
+	}
+	if got := buf.String(); got != "source_key,title,author,publication,agency,repositories,call_numbers,recorded_events\n" {
+		t.Errorf("WriteSourcesCSV(nil) = %q, want header only", got)
+	}
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// WriteSurnameIndexCSV writes one row per surname spelling used by the
+// document's individuals, with its variant group, as CSV with a header row.
+// Rows are ordered by group (see ClusterSurnames) and then by variant.
+//
+// Columns: surname, canonical_surname, soundex, individuals, group_individuals.
+func WriteSurnameIndexCSV(w io.Writer, doc *gedcom.Document) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{
+		"surname", "canonical_surname", "soundex", "individuals", "group_individuals",
+	}); err != nil {
+		return err
+	}
+
+	for _, group := range gedcom.SurnameClusters(doc) {
+		total := strconv.Itoa(group.Total())
+		for _, variant := range group.Variants {
+			if err := cw.Write([]string{
+				variant,
+				group.Canonical,
+				gedcom.Soundex(variant),
+				strconv.Itoa(group.Counts[variant]),
+				total,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,36 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteSurnameIndexCSV(t *testing.T) {
+	doc := decodeTestDocument(t, `0 @I1@ INDI
+1 NAME John /Smith/
+0 @I2@ INDI
+1 NAME Jane /Smith/
+0 @I3@ INDI
+1 NAME Ann /Smyth/
+0 @I4@ INDI
+1 NAME Tom /Brown/
+0 @I5@ INDI
+`)
+
+	var buf bytes.Buffer
+	if err := WriteSurnameIndexCSV(&buf, doc); err != nil {
+		t.Fatalf("WriteSurnameIndexCSV() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"surname,canonical_surname,soundex,individuals,group_individuals",
+		"Smith,Smith,S530,2,3",
+		"Smyth,Smith,S530,1,3",
+		"Brown,Brown,B650,1,1",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("WriteSurnameIndexCSV() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
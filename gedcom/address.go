@@ -0,0 +1,70 @@
+package gedcom
+
+import (
+	"strings"
+)
+
+// Lines returns the non-empty street address lines (ADR1, ADR2, ADR3).
+func (a *Address) Lines() []string {
+	if a == nil {
+		return nil
+	}
+	var lines []string
+	for _, line := range []string{a.Line1, a.Line2, a.Line3} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Locality returns the city, state, and postal code formatted as
+// "City, State PostalCode", omitting empty components.
+func (a *Address) Locality() string {
+	if a == nil {
+		return ""
+	}
+	locality := strings.TrimSpace(a.State + " " + a.PostalCode)
+	if a.City != "" && locality != "" {
+		return a.City + ", " + locality
+	}
+	return a.City + locality
+}
+
+// Formatted returns the address as multi-line text. The free-form Text is
+// used when present; otherwise the address is composed from its components:
+// street lines, locality, and country, one per line.
+func (a *Address) Formatted() string {
+	if a == nil {
+		return ""
+	}
+	if a.Text != "" {
+		return a.Text
+	}
+	lines := a.Lines()
+	if locality := a.Locality(); locality != "" {
+		lines = append(lines, locality)
+	}
+	if a.Country != "" {
+		lines = append(lines, a.Country)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// OneLine returns the formatted address on a single line, with lines
+// separated by ", ".
+func (a *Address) OneLine() string {
+	lines := strings.Split(a.Formatted(), "\n")
+	var parts []string
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			parts = append(parts, line)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// IsEmpty returns true if the address has no location or contact details.
+func (a *Address) IsEmpty() bool {
+	return a == nil || (a.Formatted() == "" && a.Phone == "" && a.Email == "" && a.Website == "")
+}
@@ -0,0 +1,69 @@
+package gedcom
+
+import (
+	"testing"
+)
+
+func TestAddressAccessors(t *testing.T) {
+	addr := &Address{
+		Line1:      "123 Main St",
+		Line3:      "Suite 4",
+		City:       "Boston",
+		State:      "MA",
+		PostalCode: "02101",
+		Country:    "USA",
+	}
+
+	if got := addr.Lines(); len(got) != 2 || got[1] != "Suite 4" {
+		t.Errorf("Lines() = %q", got)
+	}
+	if got := addr.Locality(); got != "Boston, MA 02101" {
+		t.Errorf("Locality() = %q", got)
+	}
+	if got, want := addr.Formatted(), "123 Main St\nSuite 4\nBoston, MA 02101\nUSA"; got != want {
+		t.Errorf("Formatted() = %q, want %q", got, want)
+	}
+	if got, want := addr.OneLine(), "123 Main St, Suite 4, Boston, MA 02101, USA"; got != want {
+		t.Errorf("OneLine() = %q, want %q", got, want)
+	}
+	if addr.IsEmpty() {
+		t.Error("IsEmpty() = true")
+	}
+
+	// Free-form text takes precedence over components
+	addr.Text = "123 Main St\nBoston MA"
+	if got := addr.Formatted(); got != addr.Text {
+		t.Errorf("Formatted() = %q, want free-form text", got)
+	}
+	if got := addr.OneLine(); got != "123 Main St, Boston MA" {
+		t.Errorf("OneLine() = %q", got)
+	}
+}
+
+func TestAddressPartialLocality(t *testing.T) {
+	tests := []struct {
+		addr *Address
+		want string
+	}{
+		{&Address{City: "Boston"}, "Boston"},
+		{&Address{State: "MA"}, "MA"},
+		{&Address{City: "Boston", PostalCode: "02101"}, "Boston, 02101"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.addr.Locality(); got != tt.want {
+			t.Errorf("Locality(%+v) = %q, want %q", tt.addr, got, tt.want)
+		}
+	}
+
+	var nilAddr *Address
+	if !nilAddr.IsEmpty() || nilAddr.Formatted() != "" || nilAddr.Lines() != nil {
+		t.Error("nil address should be empty")
+	}
+	if !(&Address{}).IsEmpty() {
+		t.Error("zero address should be empty")
+	}
+	if (&Address{Email: "a@b.c"}).IsEmpty() {
+		t.Error("address with email should not be empty")
+	}
+}
@@ -0,0 +1,88 @@
+package gedcom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Age is a parsed age at event (AGE), such as "32y 5m" or "< 1y".
+type Age struct {
+	// Original is the age string as recorded
+	Original string
+
+	// Qualifier is "<" (younger than), ">" (older than), or empty for an
+	// exact age
+	Qualifier string
+
+	// Years, Months, Weeks, and Days are the age components. Weeks are
+	// GEDCOM 7.0 only.
+	Years  int
+	Months int
+	Weeks  int
+	Days   int
+
+	// Keyword is CHILD, INFANT, or STILLBORN for GEDCOM 5.5.1 age keywords,
+	// and empty otherwise
+	Keyword string
+
+	// Phrase is the free-text form of the age (AGE.PHRASE, GEDCOM 7.0),
+	// such as "about thirty". The decoder sets it; ParseAge does not.
+	Phrase string
+}
+
+// ParseAge parses a GEDCOM age value. It accepts an optional "<" or ">"
+// qualifier followed by one or more number-unit pairs (y, m, w, d, case
+// insensitive), or one of the GEDCOM 5.5.1 keywords CHILD, INFANT, and
+// STILLBORN.
+//
+// Examples:
+//   - "32y 5m" -> 32 years, 5 months
+//   - "> 60y" -> older than 60 years
+//   - "3w" -> 3 weeks (GEDCOM 7.0)
+//   - "INFANT" -> keyword
+func ParseAge(s string) (*Age, error) {
+	age := &Age{Original: s}
+	rest := strings.TrimSpace(s)
+	if rest == "" {
+		return nil, fmt.Errorf("empty age string")
+	}
+
+	switch keyword := strings.ToUpper(rest); keyword {
+	case "CHILD", "INFANT", "STILLBORN":
+		age.Keyword = keyword
+		return age, nil
+	}
+
+	if rest[0] == '<' || rest[0] == '>' {
+		age.Qualifier = rest[:1]
+		rest = strings.TrimSpace(rest[1:])
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid age '%s': missing duration", s)
+	}
+	seen := make(map[byte]bool, len(fields))
+	for _, field := range fields {
+		unit := field[len(field)-1] | 0x20 // lowercase ASCII
+		n, err := strconv.Atoi(field[:len(field)-1])
+		if err != nil || n < 0 || seen[unit] {
+			return nil, fmt.Errorf("invalid age '%s': bad component '%s'", s, field)
+		}
+		seen[unit] = true
+		switch unit {
+		case 'y':
+			age.Years = n
+		case 'm':
+			age.Months = n
+		case 'w':
+			age.Weeks = n
+		case 'd':
+			age.Days = n
+		default:
+			return nil, fmt.Errorf("invalid age '%s': bad component '%s'", s, field)
+		}
+	}
+	return age, nil
+}
@@ -0,0 +1,42 @@
+package gedcom
+
+import "testing"
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Age
+		wantErr bool
+	}{
+		{input: "32y", want: Age{Years: 32}},
+		{input: "32y 5m 10d", want: Age{Years: 32, Months: 5, Days: 10}},
+		{input: "3w", want: Age{Weeks: 3}},
+		{input: "8Y 2M", want: Age{Years: 8, Months: 2}},
+		{input: "< 1y", want: Age{Qualifier: "<", Years: 1}},
+		{input: ">60y", want: Age{Qualifier: ">", Years: 60}},
+		{input: "INFANT", want: Age{Keyword: "INFANT"}},
+		{input: "stillborn", want: Age{Keyword: "STILLBORN"}},
+		{input: "", wantErr: true},
+		{input: "<", wantErr: true},
+		{input: "y", wantErr: true},
+		{input: "5x", wantErr: true},
+		{input: "5y 6y", wantErr: true},
+		{input: "-5y", wantErr: true},
+		{input: "about 5", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAge(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAge(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			tt.want.Original = tt.input
+			if *got != tt.want {
+				t.Errorf("ParseAge(%q) = %+v, want %+v", tt.input, *got, tt.want)
+			}
+		})
+	}
+}
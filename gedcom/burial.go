@@ -0,0 +1,34 @@
+package gedcom
+
+// Burial is an individual's burial event, with the cemetery and plot
+// recorded on it.
+type Burial struct {
+	// IndividualXRef is the XRef of the buried individual
+	IndividualXRef string
+
+	// Name labels the individual, as LabelName
+	Name string
+
+	// Event is the BURI event
+	Event *Event
+}
+
+// Burials returns the burial events of all individuals in record order.
+func Burials(doc *Document) []Burial {
+	if doc == nil {
+		return nil
+	}
+	var burials []Burial
+	for _, record := range doc.Records {
+		ind, ok := record.LoadEntity().(*Individual)
+		if !ok {
+			continue
+		}
+		for _, event := range ind.Events {
+			if event != nil && event.Type == EventBurial {
+				burials = append(burials, Burial{IndividualXRef: ind.XRef, Name: LabelName(ind), Event: event})
+			}
+		}
+	}
+	return burials
+}
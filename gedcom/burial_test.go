@@ -0,0 +1,48 @@
+package gedcom
+
+import (
+	"testing"
+)
+
+func createBurialTestDocument() *Document {
+	john := &Individual{
+		XRef:  "@I1@",
+		Names: []*PersonalName{{Full: "John /Smith/"}},
+		Events: []*Event{
+			{Type: EventBirth, Date: "1850"},
+			{
+				Type:     EventBurial,
+				Date:     "3 MAR 1920",
+				Place:    "Boston, MA",
+				Cemetery: "Mount Auburn Cemetery",
+				Plot:     "Section B, Lot 12",
+				PlaceDetail: &PlaceDetail{
+					Name:        "Boston, MA",
+					Coordinates: &Coordinates{Latitude: "N42.3708", Longitude: "W71.1469"},
+				},
+			},
+		},
+	}
+	mary := &Individual{
+		XRef:   "@I2@",
+		Names:  []*PersonalName{{Full: "Mary /Jones/"}},
+		Events: []*Event{{Type: EventBurial, Place: "Salem", Cemetery: "Harmony Grove"}},
+	}
+	return createRelationshipTestDocument([]*Individual{john, mary}, nil)
+}
+
+func TestBurials(t *testing.T) {
+	burials := Burials(createBurialTestDocument())
+	if len(burials) != 2 {
+		t.Fatalf("len(Burials()) = %d, want 2", len(burials))
+	}
+	if b := burials[0]; b.IndividualXRef != "@I1@" || b.Name != "John Smith" || b.Event.Plot != "Section B, Lot 12" {
+		t.Errorf("Burials()[0] = %+v", b)
+	}
+	if b := burials[1]; b.IndividualXRef != "@I2@" || b.Event.Cemetery != "Harmony Grove" {
+		t.Errorf("Burials()[1] = %+v", b)
+	}
+	if Burials(nil) != nil {
+		t.Error("Burials(nil) should be nil")
+	}
+}
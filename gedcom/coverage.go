@@ -0,0 +1,315 @@
+package gedcom
+
+import "sort"
+
+// maxUnhandledSamplePaths limits the sample paths kept per unhandled tag.
+const maxUnhandledSamplePaths = 3
+
+// UnhandledTag summarizes the occurrences of a tag that the decoder does not
+// map into any typed entity field. Such tags survive in Record.Tags and are
+// re-encoded from there, but are lost when an entity is edited directly (see
+// Record.MarkEntityModified) or exported from typed fields.
+type UnhandledTag struct {
+	// Tag is the GEDCOM tag name (e.g., "_MILT", "FAX")
+	Tag string
+
+	// Count is the number of occurrences across all records
+	Count int
+
+	// SamplePaths are up to three distinct dotted paths where the tag
+	// occurs, starting with the record type (e.g., "INDI.BIRT._PRIM")
+	SamplePaths []string
+}
+
+// tagSchema maps each tag the decoder handles at a position to the schema
+// of its own subordinates. A nil schema means none of the tag's subordinates
+// are mapped.
+type tagSchema map[string]tagSchema
+
+// Schemas mirroring the structures parsed by the decoder (decoder/entity.go).
+// Keep them in sync when the decoder maps new tags.
+var (
+	changeDateSchema = tagSchema{"DATE": {"TIME": nil}}
+
+	citationSchema = tagSchema{
+		"PAGE": nil, "QUAY": nil, "_APID": nil,
+		"DATA": {"DATE": nil, "TEXT": {"CONT": nil, "CONC": nil}},
+	}
+
+	identifierSchema = tagSchema{"TYPE": nil}
+
+	addressSchema = tagSchema{
+		"ADR1": nil, "ADR2": nil, "ADR3": nil, "CITY": nil, "STAE": nil,
+		"POST": nil, "CTRY": nil, "CONT": nil, "CONC": nil,
+	}
+
+	mediaLinkSchema = tagSchema{
+		"TITL": nil,
+		"CROP": {"TOP": nil, "LEFT": nil, "HEIGHT": nil, "WIDTH": nil},
+	}
+
+	associationSchema = tagSchema{
+		"RELA": nil, "ROLE": nil, "PHRASE": nil, "NOTE": nil, "SNOTE": nil,
+		"SOUR": citationSchema,
+		"_DNA": {"_CM": nil, "_SEG": nil, "_LSEG": nil, "_REL": nil, "TYPE": nil},
+	}
+
+	eventSchema = tagSchema{
+		"DATE":  {"PHRASE": nil},
+		"PLAC":  {"FORM": nil, "MAP": {"LATI": nil, "LONG": nil}},
+		"TYPE":  nil,
+		"CAUS":  nil,
+		"AGE":   {"PHRASE": nil},
+		"HUSB":  {"AGE": nil},
+		"WIFE":  {"AGE": nil},
+		"FAMC":  {"ADOP": nil},
+		"AGNC":  nil,
+		"RELI":  nil,
+		"ADDR":  addressSchema,
+		"PHON":  nil,
+		"EMAIL": nil,
+		"FAX":   nil,
+		"WWW":   nil,
+		"RESN":  nil,
+		"UID":   nil,
+		"SDATE": nil,
+		"NOTE":  nil,
+		"SNOTE": nil,
+		"SOUR":  citationSchema,
+		"ASSO":  associationSchema,
+		"OBJE":  mediaLinkSchema,
+		// Vendor participant tags
+		"_WITN": associationSchema, "_WITNESS": associationSchema,
+		"_OFFICIATOR": associationSchema, "_OFFICIANT": associationSchema, "_OFFI": associationSchema,
+		"_CLERGY": associationSchema, "_GODP": associationSchema, "_GODPARENT": associationSchema,
+		// Vendor cemetery tags
+		"CEME": nil, "_CEME": nil, "_CEMETERY": nil, "PLOT": nil, "_PLOT": nil,
+	}
+
+	attributeSchema = tagSchema{
+		"DATE": nil, "PLAC": nil, "TYPE": nil, "CONT": nil, "CONC": nil,
+		"SOUR": citationSchema,
+	}
+
+	ldsOrdinanceSchema = tagSchema{
+		"DATE": nil, "TEMP": nil, "PLAC": nil, "STAT": nil, "FAMC": nil,
+	}
+
+	noteSchema = tagSchema{
+		"CONT": nil, "CONC": nil, "LANG": nil, "MIME": nil,
+		"TRAN": {"CONT": nil, "CONC": nil, "LANG": nil, "MIME": nil},
+	}
+
+	individualSchema = withTags(withTags(withTags(tagSchema{
+		"NAME": {
+			"GIVN": nil, "SURN": nil, "NPFX": nil, "NSFX": nil, "NICK": nil, "SPFX": nil, "TYPE": nil,
+			"TRAN": {"LANG": nil, "GIVN": nil, "SURN": nil, "NPFX": nil, "NSFX": nil, "NICK": nil, "SPFX": nil},
+		},
+		"SEX":     nil,
+		"FAMC":    {"PEDI": nil, "STAT": nil},
+		"FAMS":    nil,
+		"ASSO":    associationSchema,
+		"SOUR":    citationSchema,
+		"NOTE":    nil,
+		"SNOTE":   nil,
+		"OBJE":    mediaLinkSchema,
+		"CHAN":    changeDateSchema,
+		"CREA":    changeDateSchema,
+		"REFN":    identifierSchema,
+		"EXID":    identifierSchema,
+		"UID":     nil,
+		"_UID":    nil,
+		"_FSFTID": nil,
+		"LANG":    nil,
+		"ALIA":    nil,
+		"ANCI":    nil,
+		"DESI":    nil,
+		"RESN":    nil,
+		"RIN":     nil,
+		"AFN":     nil,
+	}, eventSchema,
+		"BIRT", "DEAT", "BAPM", "BURI", "CENS", "CHR", "ADOP", "RESI", "IMMI", "EMIG",
+		"BARM", "BASM", "BLES", "CHRA", "CONF", "FCOM",
+		"GRAD", "RETI", "NATU", "ORDN", "PROB", "WILL", "CREM", "EVEN",
+	), ldsOrdinanceSchema,
+		"BAPL", "CONL", "ENDL", "SLGC",
+	), attributeSchema,
+		"OCCU", "CAST", "DSCR", "EDUC", "IDNO", "NATI", "SSN", "TITL", "RELI", "NCHI", "NMR", "PROP", "FACT",
+	)
+
+	familySchema = withTags(tagSchema{
+		"HUSB":  nil,
+		"WIFE":  nil,
+		"CHIL":  nil,
+		"NCHI":  nil,
+		"SLGS":  ldsOrdinanceSchema,
+		"SOUR":  citationSchema,
+		"NOTE":  nil,
+		"SNOTE": nil,
+		"OBJE":  mediaLinkSchema,
+		"CHAN":  changeDateSchema,
+		"CREA":  changeDateSchema,
+		"REFN":  identifierSchema,
+		"EXID":  identifierSchema,
+		"UID":   nil,
+		"_UID":  nil,
+	}, eventSchema,
+		"MARR", "DIV", "ENGA", "ANUL", "MARB", "MARC", "MARL", "MARS", "DIVF", "EVEN",
+	)
+
+	recordSchemas = map[RecordType]tagSchema{
+		RecordTypeIndividual: individualSchema,
+		RecordTypeFamily:     familySchema,
+		RecordTypeSource: {
+			"TITL": nil, "AUTH": nil, "PUBL": nil,
+			"TEXT":  {"CONT": nil, "CONC": nil},
+			"REPO":  {"NAME": nil, "CALN": {"MEDI": nil}, "NOTE": nil, "SNOTE": nil},
+			"DATA":  {"EVEN": {"DATE": nil, "PLAC": nil}, "AGNC": nil, "NOTE": nil, "SNOTE": nil},
+			"NOTE":  nil,
+			"SNOTE": nil,
+			"OBJE":  mediaLinkSchema,
+			"CHAN":  changeDateSchema,
+			"CREA":  changeDateSchema,
+			"REFN":  identifierSchema,
+			"EXID":  identifierSchema,
+			"UID":   nil,
+		},
+		RecordTypeSubmitter: {
+			"NAME": nil, "ADDR": addressSchema, "PHON": nil, "EMAIL": nil, "LANG": nil, "NOTE": nil, "SNOTE": nil,
+			"REFN": identifierSchema, "EXID": identifierSchema,
+		},
+		RecordTypeRepository: {
+			"NAME": nil, "ADDR": addressSchema, "PHON": nil, "EMAIL": nil, "WWW": nil, "NOTE": nil, "SNOTE": nil,
+			"REFN": identifierSchema, "EXID": identifierSchema,
+		},
+		RecordTypeNote:       noteSchema,
+		RecordTypeSharedNote: noteSchema,
+		RecordTypeMedia: {
+			"FILE":  {"FORM": {"MEDI": {"PHRASE": nil}}, "TITL": nil, "TRAN": {"FORM": nil}},
+			"NOTE":  nil,
+			"SNOTE": nil,
+			"SOUR":  citationSchema,
+			"CHAN":  changeDateSchema,
+			"CREA":  changeDateSchema,
+			"REFN":  identifierSchema,
+			"EXID":  identifierSchema,
+			"UID":   nil,
+			"RESN":  nil,
+		},
+	}
+)
+
+// withTags returns schema with each of tags mapped to sub.
+func withTags(schema, sub tagSchema, tags ...string) tagSchema {
+	for _, tag := range tags {
+		schema[tag] = sub
+	}
+	return schema
+}
+
+// UnhandledTags returns a frequency table of the tags in the document's
+// records that the decoder does not map into any typed entity field, sorted
+// by descending count and then by tag. Only the outermost unhandled tag of a
+// structure is reported; its subordinates are lost with it. Records of a type
+// without an entity (e.g., vendor record types) are reported by record type.
+// The header is not included.
+func (d *Document) UnhandledTags() []UnhandledTag {
+	if d == nil {
+		return nil
+	}
+
+	table := make(unhandledTable)
+	for _, record := range d.Records {
+		record.walkUnhandled(table.add)
+	}
+	return table.sorted()
+}
+
+// UnhandledTags returns a frequency table of the record's tags that the
+// decoder does not map into any typed entity field. See
+// Document.UnhandledTags for details.
+func (r *Record) UnhandledTags() []UnhandledTag {
+	if r == nil {
+		return nil
+	}
+
+	table := make(unhandledTable)
+	r.walkUnhandled(table.add)
+	return table.sorted()
+}
+
+// walkUnhandled calls add for each outermost tag in the record that the
+// decoder does not map, or once for the record itself if its type has no
+// entity.
+func (r *Record) walkUnhandled(add func(tag, path string)) {
+	root, ok := recordSchemas[r.Type]
+	if !ok {
+		add(string(r.Type), string(r.Type))
+		return
+	}
+
+	type frame struct {
+		schema  tagSchema
+		path    string
+		handled bool
+	}
+	stack := []frame{{schema: root, path: string(r.Type), handled: true}}
+	for _, tag := range r.Tags {
+		// The parent is the nearest open tag one level up; clamp
+		// malformed level jumps to the deepest open tag.
+		depth := tag.Level
+		if depth < 1 {
+			depth = 1
+		}
+		if depth < len(stack) {
+			stack = stack[:depth]
+		}
+		parent := stack[len(stack)-1]
+
+		child := frame{path: parent.path + "." + tag.Tag}
+		if parent.handled {
+			if schema, mapped := parent.schema[tag.Tag]; mapped {
+				child.schema, child.handled = schema, true
+			} else {
+				add(tag.Tag, child.path)
+			}
+		}
+		stack = append(stack, child)
+	}
+}
+
+// unhandledTable accumulates UnhandledTag entries by tag.
+type unhandledTable map[string]*UnhandledTag
+
+// add counts one occurrence of tag at path.
+func (t unhandledTable) add(tag, path string) {
+	entry := t[tag]
+	if entry == nil {
+		entry = &UnhandledTag{Tag: tag}
+		t[tag] = entry
+	}
+	entry.Count++
+	if len(entry.SamplePaths) < maxUnhandledSamplePaths {
+		for _, p := range entry.SamplePaths {
+			if p == path {
+				return
+			}
+		}
+		entry.SamplePaths = append(entry.SamplePaths, path)
+	}
+}
+
+// sorted returns the entries by descending count and then by tag.
+func (t unhandledTable) sorted() []UnhandledTag {
+	result := make([]UnhandledTag, 0, len(t))
+	for _, entry := range t {
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Tag < result[j].Tag
+	})
+	return result
+}
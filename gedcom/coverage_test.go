@@ -0,0 +1,74 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDocument_UnhandledTags(t *testing.T) {
+	doc := &Document{Records: []*Record{
+		{Type: RecordTypeIndividual, XRef: "@I1@", Tags: []*Tag{
+			{Level: 1, Tag: "NAME", Value: "John /Smith/"},
+			{Level: 2, Tag: "_AKA", Value: "Jack"},
+			{Level: 1, Tag: "BIRT"},
+			{Level: 2, Tag: "DATE", Value: "1 JAN 1900"},
+			{Level: 2, Tag: "PLAC", Value: "Boston"},
+			{Level: 3, Tag: "MAP"},
+			{Level: 4, Tag: "LATI", Value: "N42.36"},
+			{Level: 2, Tag: "_PRIM", Value: "Y"},
+			{Level: 1, Tag: "_MILT", Value: "Army"},
+			{Level: 2, Tag: "DATE", Value: "1918"},
+			{Level: 1, Tag: "SOUR", Value: "@S1@"},
+			{Level: 2, Tag: "PAGE", Value: "p. 4"},
+			{Level: 2, Tag: "EVEN", Value: "BIRT"},
+		}},
+		{Type: RecordTypeIndividual, XRef: "@I2@", Tags: []*Tag{
+			{Level: 1, Tag: "DEAT"},
+			{Level: 2, Tag: "_PRIM", Value: "Y"},
+		}},
+		{Type: RecordTypeRepository, XRef: "@R1@", Tags: []*Tag{
+			{Level: 1, Tag: "NAME", Value: "Archive"},
+			{Level: 1, Tag: "FAX", Value: "555-1234"},
+		}},
+		{Type: RecordType("_PLAC"), XRef: "@P1@", Tags: []*Tag{
+			{Level: 1, Tag: "NAME", Value: "Boston"},
+		}},
+	}}
+
+	want := []UnhandledTag{
+		{Tag: "_PRIM", Count: 2, SamplePaths: []string{"INDI.BIRT._PRIM", "INDI.DEAT._PRIM"}},
+		{Tag: "EVEN", Count: 1, SamplePaths: []string{"INDI.SOUR.EVEN"}},
+		{Tag: "FAX", Count: 1, SamplePaths: []string{"REPO.FAX"}},
+		{Tag: "_AKA", Count: 1, SamplePaths: []string{"INDI.NAME._AKA"}},
+		{Tag: "_MILT", Count: 1, SamplePaths: []string{"INDI._MILT"}},
+		{Tag: "_PLAC", Count: 1, SamplePaths: []string{"_PLAC"}},
+	}
+	if got := doc.UnhandledTags(); !reflect.DeepEqual(got, want) {
+		t.Errorf("UnhandledTags() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestDocument_UnhandledTagsSamplePathLimit(t *testing.T) {
+	record := &Record{Type: RecordTypeIndividual}
+	for _, event := range []string{"BIRT", "DEAT", "BURI", "CHR", "BIRT"} {
+		record.Tags = append(record.Tags, &Tag{Level: 1, Tag: event}, &Tag{Level: 2, Tag: "_PRIM"})
+	}
+	doc := &Document{Records: []*Record{record}}
+
+	got := doc.UnhandledTags()
+	if len(got) != 1 || got[0].Count != 5 {
+		t.Fatalf("UnhandledTags() = %+v, want one tag with count 5", got)
+	}
+	if len(got[0].SamplePaths) != maxUnhandledSamplePaths {
+		t.Errorf("len(SamplePaths) = %d, want %d", len(got[0].SamplePaths), maxUnhandledSamplePaths)
+	}
+}
+
+func TestDocument_UnhandledTagsEmpty(t *testing.T) {
+	if got := (*Document)(nil).UnhandledTags(); got != nil {
+		t.Errorf("UnhandledTags() on nil = %+v, want nil", got)
+	}
+	if got := (&Document{}).UnhandledTags(); len(got) != 0 {
+		t.Errorf("UnhandledTags() on empty = %+v, want none", got)
+	}
+}
@@ -0,0 +1,180 @@
+package gedcom
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// dmRule is a Daitch-Mokotoff coding rule. Each code may list alternatives
+// separated by '|', which produce multiple codes for a name.
+type dmRule struct {
+	pattern     string
+	atStart     string
+	beforeVowel string
+	otherwise   string
+}
+
+// dmRules is the Daitch-Mokotoff coding table, grouped by first letter with
+// longer patterns first so the longest match wins.
+var dmRules = map[byte][]dmRule{
+	'a': {{"ai", "0", "1", ""}, {"aj", "0", "1", ""}, {"ay", "0", "1", ""}, {"au", "0", "7", ""}, {"a", "0", "", ""}},
+	'b': {{"b", "7", "7", "7"}},
+	'c': {
+		{"chs", "5", "54", "54"}, {"csz", "4", "4", "4"}, {"czs", "4", "4", "4"},
+		{"ch", "5|4", "5|4", "5|4"}, {"ck", "5|45", "5|45", "5|45"},
+		{"cz", "4", "4", "4"}, {"cs", "4", "4", "4"}, {"c", "5|4", "5|4", "5|4"},
+	},
+	'd': {
+		{"drz", "4", "4", "4"}, {"drs", "4", "4", "4"}, {"dsh", "4", "4", "4"}, {"dsz", "4", "4", "4"},
+		{"dzh", "4", "4", "4"}, {"dzs", "4", "4", "4"},
+		{"ds", "4", "4", "4"}, {"dz", "4", "4", "4"}, {"dt", "3", "3", "3"}, {"d", "3", "3", "3"},
+	},
+	'e': {{"ei", "0", "1", ""}, {"ej", "0", "1", ""}, {"ey", "0", "1", ""}, {"eu", "1", "1", ""}, {"e", "0", "", ""}},
+	'f': {{"fb", "7", "7", "7"}, {"f", "7", "7", "7"}},
+	'g': {{"g", "5", "5", "5"}},
+	'h': {{"h", "5", "5", ""}},
+	'i': {{"ia", "1", "", ""}, {"ie", "1", "", ""}, {"io", "1", "", ""}, {"iu", "1", "", ""}, {"i", "0", "", ""}},
+	'j': {{"j", "1|4", "|4", "|4"}},
+	'k': {{"ks", "5", "54", "54"}, {"kh", "5", "5", "5"}, {"k", "5", "5", "5"}},
+	'l': {{"l", "8", "8", "8"}},
+	'm': {{"mn", "66", "66", "66"}, {"m", "6", "6", "6"}},
+	'n': {{"nm", "66", "66", "66"}, {"n", "6", "6", "6"}},
+	'o': {{"oi", "0", "1", ""}, {"oj", "0", "1", ""}, {"oy", "0", "1", ""}, {"o", "0", "", ""}},
+	'p': {{"pf", "7", "7", "7"}, {"ph", "7", "7", "7"}, {"p", "7", "7", "7"}},
+	'q': {{"q", "5", "5", "5"}},
+	'r': {{"rz", "94|4", "94|4", "94|4"}, {"rs", "94|4", "94|4", "94|4"}, {"r", "9", "9", "9"}},
+	's': {
+		{"schtsch", "2", "4", "4"}, {"schtsh", "2", "4", "4"}, {"schtch", "2", "4", "4"},
+		{"shtch", "2", "4", "4"}, {"stsch", "2", "4", "4"},
+		{"shch", "2", "4", "4"}, {"shtsh", "2", "4", "4"}, {"scht", "2", "43", "43"}, {"schd", "2", "43", "43"},
+		{"stch", "2", "4", "4"}, {"strz", "2", "4", "4"}, {"strs", "2", "4", "4"}, {"stsh", "2", "4", "4"},
+		{"szcz", "2", "4", "4"}, {"szcs", "2", "4", "4"},
+		{"sch", "4", "4", "4"}, {"sht", "2", "43", "43"}, {"szt", "2", "43", "43"}, {"shd", "2", "43", "43"},
+		{"szd", "2", "43", "43"},
+		{"sh", "4", "4", "4"}, {"sc", "2", "4", "4"}, {"st", "2", "43", "43"}, {"sd", "2", "43", "43"},
+		{"sz", "4", "4", "4"}, {"s", "4", "4", "4"},
+	},
+	't': {
+		{"ttsch", "4", "4", "4"}, {"ttch", "4", "4", "4"}, {"tsch", "4", "4", "4"}, {"ttsz", "4", "4", "4"},
+		{"tch", "4", "4", "4"}, {"trz", "4", "4", "4"}, {"trs", "4", "4", "4"}, {"tsh", "4", "4", "4"},
+		{"tts", "4", "4", "4"}, {"ttz", "4", "4", "4"}, {"tzs", "4", "4", "4"}, {"tsz", "4", "4", "4"},
+		{"th", "3", "3", "3"}, {"ts", "4", "4", "4"}, {"tc", "4", "4", "4"}, {"tz", "4", "4", "4"},
+		{"t", "3", "3", "3"},
+	},
+	'u': {{"ui", "0", "1", ""}, {"uj", "0", "1", ""}, {"uy", "0", "1", ""}, {"ue", "0", "", ""}, {"u", "0", "", ""}},
+	'v': {{"v", "7", "7", "7"}},
+	'w': {{"w", "7", "7", "7"}},
+	'x': {{"x", "5", "54", "54"}},
+	'y': {{"y", "1", "", ""}},
+	'z': {
+		{"zhdzh", "2", "4", "4"}, {"zdzh", "2", "4", "4"}, {"zsch", "4", "4", "4"},
+		{"zdz", "2", "4", "4"}, {"zhd", "2", "43", "43"}, {"zsh", "4", "4", "4"},
+		{"zd", "2", "43", "43"}, {"zh", "4", "4", "4"}, {"zs", "4", "4", "4"}, {"z", "4", "4", "4"},
+	},
+}
+
+// dmBranch is one in-progress coding of a name.
+type dmBranch struct {
+	code string
+	last string
+}
+
+// DaitchMokotoff returns the Daitch-Mokotoff Soundex codes of name, each six
+// digits (e.g., "Peters" -> ["734000", "739400"]). Letters with ambiguous
+// pronunciations produce multiple codes. Accents are removed before coding
+// and characters other than letters are ignored. Returns nil if name
+// contains no codable letters.
+func DaitchMokotoff(name string) []string {
+	input := foldName(name)
+	if input == "" {
+		return nil
+	}
+
+	branches := []dmBranch{{}}
+	var lastChar byte
+	for i := 0; i < len(input); {
+		rule, ok := matchDMRule(input[i:])
+		if !ok {
+			i++
+			continue
+		}
+
+		var codes string
+		next := i + len(rule.pattern)
+		switch {
+		case lastChar == 0:
+			codes = rule.atStart
+		case next < len(input) && strings.IndexByte("aeiou", input[next]) >= 0:
+			codes = rule.beforeVowel
+		default:
+			codes = rule.otherwise
+		}
+		// Adjacent M and N are both coded even though they share a digit
+		force := (lastChar == 'm' && input[i] == 'n') || (lastChar == 'n' && input[i] == 'm')
+
+		alternatives := strings.Split(codes, "|")
+		nextBranches := make([]dmBranch, 0, len(branches)*len(alternatives))
+		for _, b := range branches {
+			for _, alt := range alternatives {
+				nb := b
+				if (force || !strings.HasSuffix(nb.last, alt)) && len(nb.code) < 6 {
+					nb.code += alt
+				}
+				nb.last = alt
+				nextBranches = append(nextBranches, nb)
+			}
+		}
+		branches = nextBranches
+
+		lastChar = input[i]
+		i = next
+	}
+
+	seen := make(map[string]bool)
+	var result []string
+	for _, b := range branches {
+		code := b.code
+		if code == "" {
+			continue
+		}
+		code = (code + "000000")[:6]
+		if !seen[code] {
+			seen[code] = true
+			result = append(result, code)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// matchDMRule returns the longest Daitch-Mokotoff rule matching the start of s.
+func matchDMRule(s string) (dmRule, bool) {
+	for _, rule := range dmRules[s[0]] {
+		if strings.HasPrefix(s, rule.pattern) {
+			return rule, true
+		}
+	}
+	return dmRule{}, false
+}
+
+// foldName lowercases name, removes accents, and drops everything except the
+// ASCII letters a-z.
+func foldName(name string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, strings.ToLower(name))
+	if err != nil {
+		folded = strings.ToLower(name)
+	}
+	var b strings.Builder
+	for i := 0; i < len(folded); i++ {
+		if c := folded[i]; c >= 'a' && c <= 'z' {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
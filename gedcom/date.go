@@ -126,6 +126,11 @@ type Date struct {
 
 	// IsPhrase is true when the date is a phrase, not a parseable date
 	IsPhrase bool
+
+	// Lenient is true when Original is not a valid GEDCOM date and was
+	// parsed by the rules for nonstandard forms of ParseDateLenient or
+	// ParseDateWithOptions
+	Lenient bool
 }
 
 // monthNames maps three-letter month abbreviations to month numbers.
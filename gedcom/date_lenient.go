@@ -0,0 +1,215 @@
+package gedcom
+
+import (
+	"strconv"
+	"strings"
+)
+
+// lenientKeywords maps date keywords and their nonstandard spellings,
+// after uppercasing and removing a trailing period ("abt."), to their
+// GEDCOM form.
+var lenientKeywords = map[string]string{
+	"ABT": "ABT", "CAL": "CAL", "EST": "EST", "BEF": "BEF", "AFT": "AFT",
+	"BET": "BET", "AND": "AND", "FROM": "FROM", "TO": "TO",
+	"ABOUT": "ABT", "CIRCA": "ABT", "CA": "ABT", "C": "ABT", "APPROX": "ABT", "APPROXIMATELY": "ABT",
+	"BEFORE": "BEF", "AFTER": "AFT",
+	"ESTIMATED": "EST", "CALCULATED": "CAL",
+	"BETWEEN": "BET", "&": "AND",
+}
+
+// lenientMonths maps full and alternative English month names, after
+// uppercasing and removing a trailing period, to GEDCOM month codes.
+var lenientMonths = map[string]string{
+	"JANUARY": "JAN", "FEBRUARY": "FEB", "MARCH": "MAR", "APRIL": "APR",
+	"JUNE": "JUN", "JULY": "JUL", "AUGUST": "AUG", "SEPTEMBER": "SEP",
+	"SEPT": "SEP", "OCTOBER": "OCT", "NOVEMBER": "NOV", "DECEMBER": "DEC",
+}
+
+// ParseDateLenient parses s like ParseDate, additionally accepting
+// nonstandard forms common in files from RootsMagic, Legacy, and
+// hand-edited exports:
+//   - ISO dates: "1900-01-01", "1900-01"
+//   - month before day: "Jan 1 1900", "January 1, 1900"
+//   - full month names and abbreviations with periods: "1 Sept. 1900"
+//   - spelled-out or punctuated modifiers: "circa 1850", "abt. 1850",
+//     "c. 1850", "before 1900", "between 1850 & 1860"
+//
+// Dates that ParseDate accepts are returned unchanged. Dates accepted only
+// by the lenient rules have Lenient set, so callers can tell which values
+// are not valid GEDCOM; Original keeps s as written. See
+// ParseDateWithOptions for quarter and season phrases.
+func ParseDateLenient(s string) (*Date, error) {
+	return ParseDateWithOptions(s, DateOptions{Lenient: true})
+}
+
+// DateOptions selects the nonstandard date forms ParseDateWithOptions
+// accepts in addition to GEDCOM dates.
+type DateOptions struct {
+	// Lenient accepts the forms described at ParseDateLenient.
+	Lenient bool
+
+	// Periods maps quarter and season phrases to date ranges:
+	//   - quarters: "Q1 1880", "1880 Q1", "3Q 1880" (Q1 is JAN-MAR)
+	//   - British GRO index quarters, named by their last month:
+	//     "Mar Q 1880", "Jun Qtr 1880", "Sep Quarter 1880", "JAS 1880",
+	//     "Oct-Nov-Dec 1880"
+	//   - seasons, northern hemisphere: "Spring 1865" (MAR-MAY), "Summer",
+	//     "Autumn" or "Fall" (SEP-NOV), and "Winter 1865" (DEC 1865-FEB 1866)
+	//
+	// The result is a range, such as BET JAN 1880 AND MAR 1880.
+	Periods bool
+}
+
+// ParseDateWithOptions parses s like ParseDate, additionally accepting the
+// nonstandard forms opts enables. Dates that ParseDate accepts are returned
+// unchanged; others have Lenient set and keep s as Original.
+func ParseDateWithOptions(s string, opts DateOptions) (*Date, error) {
+	date, err := ParseDate(s)
+	if err == nil {
+		return date, nil
+	}
+
+	normalized, ok := "", false
+	if opts.Periods {
+		normalized, ok = periodRange(s)
+	}
+	if !ok && opts.Lenient {
+		normalized, ok = normalizeLenientDate(s)
+	}
+	if !ok {
+		return nil, err
+	}
+	date, lenientErr := ParseDate(normalized)
+	if lenientErr != nil {
+		return nil, err
+	}
+	date.Original = s
+	date.Lenient = true
+	return date, nil
+}
+
+// normalizeLenientDate rewrites a nonstandard date into GEDCOM form,
+// reporting whether anything changed.
+func normalizeLenientDate(s string) (string, bool) {
+	var tokens []string
+	for _, field := range strings.Fields(strings.ReplaceAll(s, ",", " ")) {
+		word := strings.TrimSuffix(strings.ToUpper(field), ".")
+		switch {
+		case lenientKeywords[word] != "":
+			tokens = append(tokens, lenientKeywords[word])
+		case lenientMonths[word] != "":
+			tokens = append(tokens, lenientMonths[word])
+		case monthNames[word] != 0:
+			tokens = append(tokens, word)
+		default:
+			if iso, ok := isoDateTokens(field); ok {
+				tokens = append(tokens, iso...)
+			} else {
+				tokens = append(tokens, field)
+			}
+		}
+	}
+
+	// Move days written after the month before it: "JAN 1 1900"
+	for i := 0; i+2 < len(tokens); i++ {
+		if monthNames[tokens[i]] != 0 && isDayNumber(tokens[i+1]) && !isDayNumber(tokens[i+2]) {
+			tokens[i], tokens[i+1] = tokens[i+1], tokens[i]
+		}
+	}
+
+	normalized := strings.Join(tokens, " ")
+	return normalized, normalized != normalizeWhitespace(s)
+}
+
+// isoDateTokens splits an ISO 8601 date, "YYYY-MM-DD" or "YYYY-MM", into
+// GEDCOM day, month, and year tokens.
+func isoDateTokens(s string) ([]string, bool) {
+	parts := strings.Split(s, "-")
+	if len(parts) < 2 || len(parts) > 3 || len(parts[0]) != 4 {
+		return nil, false
+	}
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || (i > 0 && len(part) != 2) {
+			return nil, false
+		}
+		numbers[i] = n
+	}
+	if numbers[1] < 1 || numbers[1] > 12 {
+		return nil, false
+	}
+	tokens := []string{monthCode(numbers[1]), parts[0]}
+	if len(parts) == 3 {
+		if numbers[2] < 1 || numbers[2] > 31 {
+			return nil, false
+		}
+		tokens = append([]string{strconv.Itoa(numbers[2])}, tokens...)
+	}
+	return tokens, true
+}
+
+// isDayNumber reports whether s is a one- or two-digit day of the month.
+func isDayNumber(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && len(s) <= 2 && n >= 1 && n <= 31
+}
+
+// periodMonths maps quarter and season names to their first and last
+// months. Winter runs from December into February of the next year.
+var periodMonths = map[string][2]int{
+	"Q1": {1, 3}, "Q2": {4, 6}, "Q3": {7, 9}, "Q4": {10, 12},
+	"1Q": {1, 3}, "2Q": {4, 6}, "3Q": {7, 9}, "4Q": {10, 12},
+	"JFM": {1, 3}, "AMJ": {4, 6}, "JAS": {7, 9}, "OND": {10, 12},
+	"JANFEBMAR": {1, 3}, "APRMAYJUN": {4, 6}, "JULAUGSEP": {7, 9}, "OCTNOVDEC": {10, 12},
+	"SPRING": {3, 5}, "SUMMER": {6, 8}, "AUTUMN": {9, 11}, "FALL": {9, 11}, "WINTER": {12, 2},
+}
+
+// groQuarterWords are the words that mark a GRO quarter after its month:
+// "Mar Q 1880", "Mar Qtr 1880", "Mar Quarter 1880".
+var groQuarterWords = map[string]bool{"Q": true, "QTR": true, "QUARTER": true}
+
+// periodRange rewrites a quarter or season phrase as a GEDCOM range,
+// reporting whether s is one.
+func periodRange(s string) (string, bool) {
+	fields := strings.Fields(strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(s, ",", " "), ".", "")))
+
+	var name, year string
+	switch {
+	case len(fields) == 2 && isPeriodYear(fields[1]):
+		name, year = strings.ReplaceAll(fields[0], "-", ""), fields[1]
+	case len(fields) == 2 && isPeriodYear(fields[0]) && strings.Contains(fields[1], "Q"):
+		name, year = fields[1], fields[0]
+	case len(fields) == 3 && groQuarterWords[fields[1]] && isPeriodYear(fields[2]):
+		// GRO quarters are named by their last month
+		month := monthNames[fields[0]]
+		if month%3 != 0 {
+			return "", false
+		}
+		name, year = "Q"+strconv.Itoa(month/3), fields[2]
+	default:
+		return "", false
+	}
+
+	months, ok := periodMonths[name]
+	if !ok {
+		return "", false
+	}
+	endYear := year
+	if months[1] < months[0] {
+		y, _ := strconv.Atoi(year)
+		endYear = strconv.Itoa(y + 1)
+	}
+	return "BET " + monthCode(months[0]) + " " + year + " AND " + monthCode(months[1]) + " " + endYear, true
+}
+
+// isPeriodYear reports whether s is a year of three or four digits.
+func isPeriodYear(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil && len(s) >= 3 && len(s) <= 4
+}
+
+// monthCode returns the GEDCOM code of a Gregorian month, "JAN" for 1.
+func monthCode(month int) string {
+	return strings.ToUpper(getMonthName(month)[:3])
+}
@@ -0,0 +1,120 @@
+package gedcom
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestParseDateLenient(t *testing.T) {
+	tests := []struct {
+		input            string
+		day, month, year int
+		modifier         DateModifier
+		lenient          bool
+		endDay, endYear  int
+	}{
+		{input: "1 JAN 1900", day: 1, month: 1, year: 1900},
+		{input: "abt 1850", year: 1850, modifier: ModifierAbout},
+		{input: "Jan 1 1900", day: 1, month: 1, year: 1900, lenient: true},
+		{input: "January 1, 1900", day: 1, month: 1, year: 1900, lenient: true},
+		{input: "1 Sept. 1900", day: 1, month: 9, year: 1900, lenient: true},
+		{input: "1900-01-02", day: 2, month: 1, year: 1900, lenient: true},
+		{input: "1900-03", month: 3, year: 1900, lenient: true},
+		{input: "circa 1850", year: 1850, modifier: ModifierAbout, lenient: true},
+		{input: "abt. 1850", year: 1850, modifier: ModifierAbout, lenient: true},
+		{input: "c. 1850", year: 1850, modifier: ModifierAbout, lenient: true},
+		{input: "before 1900-06-30", day: 30, month: 6, year: 1900, modifier: ModifierBefore, lenient: true},
+		{input: "between 1850 & 1860", year: 1850, modifier: ModifierBetween, lenient: true, endYear: 1860},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			date, err := ParseDateLenient(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDateLenient() error = %v", err)
+			}
+			if date.Day != tt.day || date.Month != tt.month || date.Year != tt.year || date.Modifier != tt.modifier {
+				t.Errorf("ParseDateLenient() = %d/%d/%d %v, want %d/%d/%d %v",
+					date.Day, date.Month, date.Year, date.Modifier, tt.day, tt.month, tt.year, tt.modifier)
+			}
+			if date.Lenient != tt.lenient {
+				t.Errorf("Lenient = %v, want %v", date.Lenient, tt.lenient)
+			}
+			if date.Original != tt.input {
+				t.Errorf("Original = %q, want %q", date.Original, tt.input)
+			}
+			if tt.endYear != 0 && (date.EndDate == nil || date.EndDate.Year != tt.endYear) {
+				t.Errorf("EndDate = %+v, want year %d", date.EndDate, tt.endYear)
+			}
+		})
+	}
+}
+
+func TestParseDateLenientRejects(t *testing.T) {
+	for _, input := range []string{"", "sometime", "1900-13-01", "1900-1-1", "Jan 1 1900 extra words"} {
+		if date, err := ParseDateLenient(input); err == nil {
+			t.Errorf("ParseDateLenient(%q) = %+v, want error", input, date)
+		}
+	}
+
+	// ParseDate itself stays strict
+	if _, err := ParseDate("Jan 1 1900"); err == nil {
+		t.Error("ParseDate(\"Jan 1 1900\") should fail")
+	}
+}
+
+func TestParseDateWithOptionsPeriods(t *testing.T) {
+	tests := []struct {
+		input      string
+		start, end string // "MON YEAR"
+	}{
+		{"Q1 1880", "JAN 1880", "MAR 1880"},
+		{"1880 Q3", "JUL 1880", "SEP 1880"},
+		{"4Q 1880", "OCT 1880", "DEC 1880"},
+		{"Mar Q 1880", "JAN 1880", "MAR 1880"},
+		{"Jun Qtr 1880", "APR 1880", "JUN 1880"},
+		{"Sep. Quarter 1880", "JUL 1880", "SEP 1880"},
+		{"JAS 1880", "JUL 1880", "SEP 1880"},
+		{"Oct-Nov-Dec 1880", "OCT 1880", "DEC 1880"},
+		{"Spring 1865", "MAR 1865", "MAY 1865"},
+		{"fall 1865", "SEP 1865", "NOV 1865"},
+		{"Winter 1865", "DEC 1865", "FEB 1866"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			date, err := ParseDateWithOptions(tt.input, DateOptions{Periods: true})
+			if err != nil {
+				t.Fatalf("ParseDateWithOptions() error = %v", err)
+			}
+			if date.Modifier != ModifierBetween || date.EndDate == nil {
+				t.Fatalf("ParseDateWithOptions() = %+v, want a range", date)
+			}
+			start := monthCode(date.Month) + " " + strconv.Itoa(date.Year)
+			end := monthCode(date.EndDate.Month) + " " + strconv.Itoa(date.EndDate.Year)
+			if start != tt.start || end != tt.end {
+				t.Errorf("range = %s to %s, want %s to %s", start, end, tt.start, tt.end)
+			}
+			if !date.Lenient || date.Original != tt.input {
+				t.Errorf("Lenient, Original = %v, %q, want true, %q", date.Lenient, date.Original, tt.input)
+			}
+		})
+	}
+
+	// Periods are off unless requested, and GRO quarters need a quarter month
+	for _, input := range []string{"Q1 1880", "Spring 1865"} {
+		if _, err := ParseDateLenient(input); err == nil {
+			t.Errorf("ParseDateLenient(%q) should fail without Periods", input)
+		}
+	}
+	for _, input := range []string{"Q5 1880", "Feb Q 1880", "Spring", "Spring 18650"} {
+		if _, err := ParseDateWithOptions(input, DateOptions{Periods: true}); err == nil {
+			t.Errorf("ParseDateWithOptions(%q) should fail", input)
+		}
+	}
+
+	// Both options together
+	if date, err := ParseDateWithOptions("circa 1850", DateOptions{Lenient: true, Periods: true}); err != nil || date.Modifier != ModifierAbout {
+		t.Errorf("ParseDateWithOptions(circa 1850) = %+v, %v", date, err)
+	}
+}
@@ -0,0 +1,119 @@
+package gedcom
+
+import "fmt"
+
+// LineRange is an inclusive range of source line numbers (1-based).
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// Len returns the number of lines in the range.
+func (r LineRange) Len() int {
+	if r.End < r.Start {
+		return 0
+	}
+	return r.End - r.Start + 1
+}
+
+// String returns "N" for a single line or "N-M" for a range.
+func (r LineRange) String() string {
+	if r.Start == r.End {
+		return fmt.Sprintf("%d", r.Start)
+	}
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// SkippedLines describes consecutive malformed lines dropped during error recovery.
+type SkippedLines struct {
+	// Lines is the range of dropped lines
+	Lines LineRange
+
+	// RecordXRef is the XRef of the record the lines appeared in, if any
+	RecordXRef string
+
+	// Reason is the parse error message for the first line in the range
+	Reason string
+}
+
+// SkippedRecord describes a level-0 record dropped during error recovery or
+// because its type is unknown (see decoder.UnknownRecordSkip).
+type SkippedRecord struct {
+	// XRef is the record's cross-reference identifier (empty if it had none)
+	XRef string
+
+	// Type is the record type (e.g., INDI, FAM)
+	Type RecordType
+
+	// Lines is the range of source lines the record occupied
+	Lines LineRange
+}
+
+// DecodeReport records data discarded while decoding with error recovery or
+// an unknown record policy that skips records, so callers can quantify loss
+// instead of discovering missing records later.
+type DecodeReport struct {
+	// SkippedLines lists malformed lines that were dropped
+	SkippedLines []SkippedLines
+
+	// SkippedRecords lists whole records that were dropped
+	SkippedRecords []SkippedRecord
+}
+
+// HasLoss returns true if any lines or records were skipped.
+func (r *DecodeReport) HasLoss() bool {
+	return r != nil && (len(r.SkippedLines) > 0 || len(r.SkippedRecords) > 0)
+}
+
+// LinesSkipped returns the total number of source lines discarded, counting
+// both malformed lines and every line of dropped records.
+func (r *DecodeReport) LinesSkipped() int {
+	if r == nil {
+		return 0
+	}
+
+	total := 0
+	for _, rec := range r.SkippedRecords {
+		total += rec.Lines.Len()
+	}
+	for _, skipped := range r.SkippedLines {
+		if !r.inSkippedRecord(skipped.Lines) {
+			total += skipped.Lines.Len()
+		}
+	}
+	return total
+}
+
+// SkippedXRefs returns the XRefs of records that were dropped or lost lines.
+// Each XRef appears once, in order of first occurrence.
+func (r *DecodeReport) SkippedXRefs() []string {
+	if r == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var xrefs []string
+	add := func(xref string) {
+		if xref != "" && !seen[xref] {
+			seen[xref] = true
+			xrefs = append(xrefs, xref)
+		}
+	}
+	for _, rec := range r.SkippedRecords {
+		add(rec.XRef)
+	}
+	for _, skipped := range r.SkippedLines {
+		add(skipped.RecordXRef)
+	}
+	return xrefs
+}
+
+// inSkippedRecord reports whether a line range lies within a dropped record.
+func (r *DecodeReport) inSkippedRecord(lines LineRange) bool {
+	for _, rec := range r.SkippedRecords {
+		if lines.Start >= rec.Lines.Start && lines.End <= rec.Lines.End {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package gedcom
+
+import "testing"
+
+func TestLineRange(t *testing.T) {
+	if got := (LineRange{Start: 3, End: 3}).String(); got != "3" {
+		t.Errorf("String() = %q, want 3", got)
+	}
+	if got := (LineRange{Start: 3, End: 7}).String(); got != "3-7" {
+		t.Errorf("String() = %q, want 3-7", got)
+	}
+	if got := (LineRange{Start: 3, End: 7}).Len(); got != 5 {
+		t.Errorf("Len() = %d, want 5", got)
+	}
+	if got := (LineRange{Start: 3, End: 2}).Len(); got != 0 {
+		t.Errorf("Len() = %d, want 0", got)
+	}
+}
+
+func TestDecodeReport(t *testing.T) {
+	var nilReport *DecodeReport
+	if nilReport.HasLoss() || nilReport.LinesSkipped() != 0 || nilReport.SkippedXRefs() != nil {
+		t.Error("nil report should report no loss")
+	}
+
+	r := &DecodeReport{
+		SkippedRecords: []SkippedRecord{
+			{XRef: "@I1@", Type: RecordTypeIndividual, Lines: LineRange{Start: 10, End: 14}},
+		},
+		SkippedLines: []SkippedLines{
+			{Lines: LineRange{Start: 12, End: 12}, RecordXRef: "@I1@"},
+			{Lines: LineRange{Start: 20, End: 21}, RecordXRef: "@F1@"},
+		},
+	}
+	if !r.HasLoss() {
+		t.Error("HasLoss() = false")
+	}
+	// 5 record lines + 2 lines outside dropped records
+	if got := r.LinesSkipped(); got != 7 {
+		t.Errorf("LinesSkipped() = %d, want 7", got)
+	}
+	xrefs := r.SkippedXRefs()
+	if len(xrefs) != 2 || xrefs[0] != "@I1@" || xrefs[1] != "@F1@" {
+		t.Errorf("SkippedXRefs() = %v", xrefs)
+	}
+}
@@ -0,0 +1,109 @@
+package gedcom
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// DNAMatch represents genetic-genealogy match data attached to an association.
+// It is populated from the _DNA vendor extension under ASSO, or from an
+// association NOTE that mentions shared centimorgans (e.g., "Shared 212 cM
+// across 9 segments") when the association is marked as a DNA match by a
+// RELA, ROLE, PHRASE, or TYPE naming DNA.
+//
+// Recognized _DNA subordinates:
+//   - _CM: total shared centimorgans
+//   - _SEG: number of shared segments
+//   - _LSEG: longest shared segment in centimorgans
+//   - _REL: predicted relationship reported by the testing company
+//   - TYPE: testing company or kit provider (e.g., "AncestryDNA")
+type DNAMatch struct {
+	// SharedCM is the total shared DNA in centimorgans
+	SharedCM float64
+
+	// SharedSegments is the number of shared DNA segments
+	SharedSegments int
+
+	// LongestSegmentCM is the longest shared segment in centimorgans
+	LongestSegmentCM float64
+
+	// Relationship is the predicted relationship (e.g., "2nd cousin")
+	Relationship string
+
+	// Provider is the testing company or kit provider
+	Provider string
+
+	// Text is the original free-text value the match was read from, if any
+	Text string
+}
+
+var (
+	dnaLongestPattern  = regexp.MustCompile(`(?i)longest[^0-9]*(\d+(?:\.\d+)?)\s*cm\b`)
+	dnaSharedPattern   = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*cm\b`)
+	dnaSegmentsPattern = regexp.MustCompile(`(?i)(\d+)\s*segments?\b`)
+)
+
+// ParseDNAMatch extracts shared centimorgans, segment count, and longest
+// segment from free text such as "Shared 212.5 cM across 9 segments, longest
+// 41 cM". Returns nil if the text does not mention a centimorgan value.
+func ParseDNAMatch(text string) *DNAMatch {
+	if text == "" {
+		return nil
+	}
+
+	match := &DNAMatch{Text: text}
+	remaining := text
+
+	// Extract the longest segment first so it is not mistaken for the total
+	if loc := dnaLongestPattern.FindStringSubmatchIndex(remaining); loc != nil {
+		match.LongestSegmentCM, _ = strconv.ParseFloat(remaining[loc[2]:loc[3]], 64)
+		remaining = remaining[:loc[0]] + remaining[loc[1]:]
+	}
+
+	m := dnaSharedPattern.FindStringSubmatch(remaining)
+	if m == nil {
+		return nil
+	}
+	match.SharedCM, _ = strconv.ParseFloat(m[1], 64)
+
+	if m := dnaSegmentsPattern.FindStringSubmatch(remaining); m != nil {
+		match.SharedSegments, _ = strconv.Atoi(m[1])
+	}
+
+	return match
+}
+
+// DNAMatchLink pairs a DNA match with the two individuals it connects.
+type DNAMatchLink struct {
+	// PersonXRef is the individual holding the association
+	PersonXRef string
+
+	// MatchXRef is the associated individual who shares DNA with PersonXRef
+	MatchXRef string
+
+	// Match is the DNA match data
+	Match *DNAMatch
+}
+
+// DNAMatches returns every association in the document that carries DNA
+// match data, in document order.
+func DNAMatches(doc *Document) []DNAMatchLink {
+	if doc == nil {
+		return nil
+	}
+
+	var links []DNAMatchLink
+	for _, ind := range doc.Individuals() {
+		for _, assoc := range ind.Associations {
+			if assoc.DNAMatch == nil {
+				continue
+			}
+			links = append(links, DNAMatchLink{
+				PersonXRef: ind.XRef,
+				MatchXRef:  assoc.IndividualXRef,
+				Match:      assoc.DNAMatch,
+			})
+		}
+	}
+	return links
+}
@@ -0,0 +1,100 @@
+package gedcom
+
+import (
+	"testing"
+)
+
+func TestParseDNAMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantNil  bool
+		shared   float64
+		segments int
+		longest  float64
+	}{
+		{name: "empty", text: "", wantNil: true},
+		{name: "no cM value", text: "Met at reunion", wantNil: true},
+		{name: "shared only", text: "Shared 212 cM", shared: 212},
+		{name: "decimal", text: "shares 87.5 cM", shared: 87.5},
+		{name: "segments", text: "Shared 212.5 cM across 9 segments", shared: 212.5, segments: 9},
+		{name: "single segment", text: "45 cM, 1 segment", shared: 45, segments: 1},
+		{
+			name:     "longest before total",
+			text:     "Longest segment 41 cM; total 212 cM over 9 segments",
+			shared:   212,
+			segments: 9,
+			longest:  41,
+		},
+		{name: "lowercase unit", text: "shared 30cm", shared: 30},
+		{name: "unit must be whole word", text: "about 30 cms", wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseDNAMatch(tt.text)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("ParseDNAMatch(%q) = %+v, want nil", tt.text, got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("ParseDNAMatch(%q) = nil", tt.text)
+			}
+			if got.SharedCM != tt.shared {
+				t.Errorf("SharedCM = %v, want %v", got.SharedCM, tt.shared)
+			}
+			if got.SharedSegments != tt.segments {
+				t.Errorf("SharedSegments = %v, want %v", got.SharedSegments, tt.segments)
+			}
+			if got.LongestSegmentCM != tt.longest {
+				t.Errorf("LongestSegmentCM = %v, want %v", got.LongestSegmentCM, tt.longest)
+			}
+			if got.Text != tt.text {
+				t.Errorf("Text = %q, want %q", got.Text, tt.text)
+			}
+		})
+	}
+}
+
+func createDNATestDocument() *Document {
+	i1 := &Individual{
+		XRef: "@I1@",
+		Associations: []*Association{
+			{IndividualXRef: "@I2@", DNAMatch: &DNAMatch{SharedCM: 212.5, SharedSegments: 9, Relationship: "2nd cousin", Provider: "AncestryDNA"}},
+			{IndividualXRef: "@I3@", Role: "GODP"},
+		},
+	}
+	i2 := &Individual{XRef: "@I2@"}
+	i3 := &Individual{
+		XRef: "@I3@",
+		Associations: []*Association{
+			{IndividualXRef: "@I1@", DNAMatch: &DNAMatch{SharedCM: 1700, LongestSegmentCM: 120.25}},
+		},
+	}
+	return &Document{
+		Records: []*Record{
+			{XRef: "@I1@", Type: RecordTypeIndividual, Entity: i1},
+			{XRef: "@I2@", Type: RecordTypeIndividual, Entity: i2},
+			{XRef: "@I3@", Type: RecordTypeIndividual, Entity: i3},
+		},
+	}
+}
+
+func TestDNAMatches(t *testing.T) {
+	links := DNAMatches(createDNATestDocument())
+	if len(links) != 2 {
+		t.Fatalf("len(DNAMatches) = %d, want 2", len(links))
+	}
+	if links[0].PersonXRef != "@I1@" || links[0].MatchXRef != "@I2@" {
+		t.Errorf("links[0] = %s -> %s, want @I1@ -> @I2@", links[0].PersonXRef, links[0].MatchXRef)
+	}
+	if links[1].PersonXRef != "@I3@" || links[1].MatchXRef != "@I1@" {
+		t.Errorf("links[1] = %s -> %s, want @I3@ -> @I1@", links[1].PersonXRef, links[1].MatchXRef)
+	}
+
+	if got := DNAMatches(nil); got != nil {
+		t.Errorf("DNAMatches(nil) = %v, want nil", got)
+	}
+}
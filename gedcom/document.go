@@ -43,6 +43,18 @@ type Document struct {
 	// Vendor identifies the software that created this GEDCOM file.
 	// Detected from the HEAD.SOUR tag during decoding.
 	Vendor Vendor
+
+	// Warnings lists non-fatal problems detected or corrected during decoding
+	Warnings []Warning
+
+	// DecodeReport records lines and records dropped during error recovery or
+	// by the unknown record policy. Nil unless the document was decoded with
+	// error recovery enabled or unknown records were skipped.
+	DecodeReport *DecodeReport
+
+	// Raw holds the original header and trailer lines when the document was
+	// decoded with PreserveRaw, and is nil otherwise (see Record.Raw).
+	Raw *RawDocument
 }
 
 // GetRecord returns the record with the given cross-reference ID.
@@ -150,6 +162,25 @@ func (d *Document) Submitters() []*Submitter {
 	return submitters
 }
 
+// HeaderSubmitter returns the submitter referenced by the header (HEAD.SUBM).
+// Returns nil if the header has no submitter or the record is missing.
+func (d *Document) HeaderSubmitter() *Submitter {
+	if d.Header == nil || d.Header.Submitter == "" {
+		return nil
+	}
+	return d.GetSubmitter(d.Header.Submitter)
+}
+
+// DefaultLanguage returns the document's default language from HEAD.LANG,
+// which applies to names and notes that do not declare their own. It
+// returns an empty string if d is nil or the header has no language.
+func (d *Document) DefaultLanguage() string {
+	if d == nil || d.Header == nil {
+		return ""
+	}
+	return d.Header.Language
+}
+
 // GetRepository returns the repository record with the given XRef.
 // Returns nil if not found or if the record is not a repository.
 func (d *Document) GetRepository(xref string) *Repository {
@@ -174,6 +205,25 @@ func (d *Document) Repositories() []*Repository {
 	return repositories
 }
 
+// RepositorySources returns the sources citing the repository with the given
+// XRef, in document order.
+func (d *Document) RepositorySources(xref string) []*Source {
+	var sources []*Source
+	for _, src := range d.Sources() {
+		if len(src.Repositories) == 0 && src.RepositoryRef == xref {
+			sources = append(sources, src)
+			continue
+		}
+		for _, citation := range src.Repositories {
+			if citation.RepositoryXRef == xref {
+				sources = append(sources, src)
+				break
+			}
+		}
+	}
+	return sources
+}
+
 // GetNote returns the note record with the given XRef.
 // Returns nil if not found or if the record is not a note.
 func (d *Document) GetNote(xref string) *Note {
@@ -187,7 +237,8 @@ func (d *Document) GetNote(xref string) *Note {
 	return nil
 }
 
-// Notes returns all note records in the document.
+// Notes returns all note records in the document, including GEDCOM 7.0
+// shared notes (SNOTE).
 func (d *Document) Notes() []*Note {
 	var notes []*Note
 	for _, record := range d.Records {
@@ -221,3 +272,18 @@ func (d *Document) MediaObjects() []*MediaObject {
 	}
 	return objects
 }
+
+// LoadEntities builds every entity still pending from a lazy decode (see
+// Record.LoadEntity) and returns the number built. Call it before handing the
+// document to concurrent readers or to code that reads Record.Entity
+// directly.
+func (d *Document) LoadEntities() int {
+	n := 0
+	for _, record := range d.Records {
+		if record.entityBuilder != nil {
+			record.LoadEntity()
+			n++
+		}
+	}
+	return n
+}
@@ -1,5 +1,10 @@
 package gedcom
 
+import (
+	"strconv"
+	"strings"
+)
+
 // EventType represents the type of life event.
 type EventType string
 
@@ -46,6 +51,9 @@ const (
 	EventMarriageLicense    EventType = "MARL" // Marriage License
 	EventMarriageSettlement EventType = "MARS" // Marriage Settlement
 	EventDivorceFiling      EventType = "DIVF" // Divorce Filing
+
+	// Generic event, qualified by its TYPE subordinate (individual or family)
+	EventGeneric EventType = "EVEN" // Event
 )
 
 // Coordinates represents geographic coordinates for a place.
@@ -57,6 +65,39 @@ type Coordinates struct {
 	Longitude string
 }
 
+// Decimal returns the coordinates as signed decimal degrees, with south and
+// west negative. Plain signed numbers are also accepted. ok is false if
+// either value is missing or malformed.
+func (c *Coordinates) Decimal() (lat, lon float64, ok bool) {
+	if c == nil {
+		return 0, 0, false
+	}
+	lat, latOK := parseCoordinate(c.Latitude, 'N', 'S')
+	lon, lonOK := parseCoordinate(c.Longitude, 'E', 'W')
+	return lat, lon, latOK && lonOK
+}
+
+// parseCoordinate parses a GEDCOM coordinate such as "N42.3601", where pos
+// and neg are the hemisphere prefixes.
+func parseCoordinate(value string, pos, neg byte) (float64, bool) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" {
+		return 0, false
+	}
+	sign := 1.0
+	switch value[0] {
+	case pos:
+		value = value[1:]
+	case neg:
+		sign, value = -1, value[1:]
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return sign * v, true
+}
+
 // PlaceDetail represents a structured place with optional coordinates and format.
 type PlaceDetail struct {
 	// Name is the place name string
@@ -69,6 +110,19 @@ type PlaceDetail struct {
 	Coordinates *Coordinates
 }
 
+// EffectiveForm returns the place's hierarchy format: its own FORM when
+// present, and otherwise the document default from HEAD.PLAC.FORM. It
+// returns an empty string if neither is set. doc may be nil.
+func (p *PlaceDetail) EffectiveForm(doc *Document) string {
+	if p != nil && p.Form != "" {
+		return p.Form
+	}
+	if doc == nil || doc.Header == nil {
+		return ""
+	}
+	return doc.Header.PlaceForm
+}
+
 // Event represents a life event with date, place, and source information.
 type Event struct {
 	// Type is the event type (birth, death, marriage, etc.)
@@ -87,7 +141,8 @@ type Event struct {
 	// PlaceDetail provides structured place information with optional coordinates
 	PlaceDetail *PlaceDetail
 
-	// Description provides additional details
+	// Description provides additional details (the event line's value,
+	// e.g., "1 EVEN Ran away from home")
 	Description string
 
 	// EventTypeDetail provides a descriptive type of the event (TYPE subordinate)
@@ -99,9 +154,40 @@ type Event struct {
 	// Age is the age at the time of the event (AGE subordinate)
 	Age string
 
+	// ParsedAge is the parsed representation of Age, including its PHRASE
+	// subordinate. This is nil if there is no AGE or it could not be parsed
+	// and has no phrase.
+	ParsedAge *Age
+
+	// HusbandAge is the husband's age at the time of a family event (HUSB.AGE subordinate)
+	HusbandAge string
+
+	// WifeAge is the wife's age at the time of a family event (WIFE.AGE subordinate)
+	WifeAge string
+
+	// FamilyXRef is the family the event links the individual to (FAMC subordinate).
+	// Used by BIRT, CHR, and ADOP events; for ADOP it is the adoptive family.
+	FamilyXRef string
+
+	// AdoptedBy identifies the adopting parent for an ADOP event (FAMC.ADOP subordinate).
+	// One of AdoptedByHusband, AdoptedByWife, or AdoptedByBoth; empty if not specified.
+	AdoptedBy string
+
 	// Agency is the responsible agency (AGNC subordinate)
 	Agency string
 
+	// Cemetery is the cemetery name of a burial, from the vendor tags CEME,
+	// _CEME, and _CEMETERY
+	Cemetery string
+
+	// Plot is the grave plot of a burial, such as "Section B, Row 4", from
+	// the vendor tags PLOT and _PLOT
+	Plot string
+
+	// Religion is the religious affiliation associated with the event
+	// (RELI subordinate), e.g., the denomination of a baptism or confirmation
+	Religion string
+
 	// Address is the event address structure (ADDR subordinate)
 	Address *Address
 
@@ -131,6 +217,16 @@ type Event struct {
 	// SourceCitations are source citations with page/quality details
 	SourceCitations []*SourceCitation
 
+	// Associations link other individuals to the event with a role,
+	// such as witnesses or godparents (ASSO subordinate, GEDCOM 7.0).
+	// Vendor participant tags (_WITN, _OFFICIATOR, ...) whose value is a
+	// pointer are also converted into associations.
+	Associations []*Association
+
+	// Witnesses are names of witnesses, officiants, and similar participants
+	// recorded as text in vendor participant tags (_WITN, _OFFICIATOR, ...)
+	Witnesses []string
+
 	// Notes are references to note records
 	Notes []string
 
@@ -0,0 +1,139 @@
+package gedcom
+
+import (
+	"regexp"
+	"strings"
+)
+
+// GEDCOM 7.0 role enumeration values used in EventLink.Role.
+const (
+	RoleChild      = "CHIL"
+	RoleClergy     = "CLERGY"
+	RoleFather     = "FATH"
+	RoleFriend     = "FRIEND"
+	RoleGodparent  = "GODP"
+	RoleHusband    = "HUSB"
+	RoleMother     = "MOTH"
+	RoleMultiple   = "MULTIPLE"
+	RoleNeighbor   = "NGHBR"
+	RoleOfficiator = "OFFICIATOR"
+	RoleParent     = "PARENT"
+	RoleSpouse     = "SPOU"
+	RoleWife       = "WIFE"
+	RoleWitness    = "WITN"
+	RoleOther      = "OTHER"
+)
+
+// EventLink links an individual to an event owned by an individual or family
+// record, with the individual's role in the event.
+type EventLink struct {
+	// PersonXRef is the linked individual
+	PersonXRef string
+
+	// RecordXRef is the individual or family record that owns the event
+	RecordXRef string
+
+	// EventIndex is the event's position in the owning record's Events
+	EventIndex int
+
+	// Event is the linked event
+	Event *Event
+
+	// Role is a GEDCOM 7.0 role enumeration value (e.g., "WITN", "GODP").
+	// Association roles are uppercased; an association without a role, or a
+	// role outside the enumeration, is reported as RoleOther.
+	Role string
+}
+
+// roleEnum is the set of GEDCOM 7.0 role enumeration values.
+var roleEnum = map[string]bool{
+	RoleChild: true, RoleClergy: true, RoleFather: true, RoleFriend: true,
+	RoleGodparent: true, RoleHusband: true, RoleMother: true, RoleMultiple: true,
+	RoleNeighbor: true, RoleOfficiator: true, RoleParent: true, RoleSpouse: true,
+	RoleWife: true, RoleWitness: true, RoleOther: true,
+}
+
+// pointerPattern matches XRef pointers embedded in note text.
+var pointerPattern = regexp.MustCompile(`@[A-Za-z0-9_]+@`)
+
+// EventLinks returns the links between individuals and events beyond an
+// individual's own events, in record order and then event order:
+//   - family events link the family's husband (HUSB), wife (WIFE), and
+//     children (CHIL)
+//   - event ASSO structures link the associated individual with its role
+//   - individuals referenced by pointer in an event's notes, either inline
+//     or in a referenced NOTE record, are linked with role OTHER
+func EventLinks(doc *Document) []EventLink {
+	if doc == nil {
+		return nil
+	}
+
+	var links []EventLink
+	for _, record := range doc.Records {
+		switch entity := record.LoadEntity().(type) {
+		case *Individual:
+			for i, event := range entity.Events {
+				links = appendEventLinks(links, doc, entity.XRef, i, event)
+			}
+		case *Family:
+			for i, event := range entity.Events {
+				base := EventLink{RecordXRef: entity.XRef, EventIndex: i, Event: event}
+				if entity.Husband != "" {
+					links = append(links, withPerson(base, entity.Husband, RoleHusband))
+				}
+				if entity.Wife != "" {
+					links = append(links, withPerson(base, entity.Wife, RoleWife))
+				}
+				for _, child := range entity.Children {
+					links = append(links, withPerson(base, child, RoleChild))
+				}
+				links = appendEventLinks(links, doc, entity.XRef, i, event)
+			}
+		}
+	}
+	return links
+}
+
+// appendEventLinks appends the association and note-pointer links of an event.
+func appendEventLinks(links []EventLink, doc *Document, recordXRef string, index int, event *Event) []EventLink {
+	base := EventLink{RecordXRef: recordXRef, EventIndex: index, Event: event}
+
+	for _, assoc := range event.Associations {
+		if assoc.IndividualXRef == "" || assoc.IndividualXRef == "@VOID@" {
+			continue
+		}
+		links = append(links, withPerson(base, assoc.IndividualXRef, normalizeRole(assoc.Role)))
+	}
+
+	seen := make(map[string]bool)
+	for _, note := range event.Notes {
+		text := note
+		if n := doc.GetNote(note); n != nil {
+			text = n.Text + "\n" + strings.Join(n.Continuation, "\n")
+		}
+		for _, xref := range pointerPattern.FindAllString(text, -1) {
+			if seen[xref] || doc.GetIndividual(xref) == nil {
+				continue
+			}
+			seen[xref] = true
+			links = append(links, withPerson(base, xref, RoleOther))
+		}
+	}
+	return links
+}
+
+// withPerson returns a copy of link for the given individual and role.
+func withPerson(link EventLink, personXRef, role string) EventLink {
+	link.PersonXRef = personXRef
+	link.Role = role
+	return link
+}
+
+// normalizeRole maps an association role to a GEDCOM 7.0 role enumeration value.
+func normalizeRole(role string) string {
+	role = strings.ToUpper(strings.TrimSpace(role))
+	if roleEnum[role] {
+		return role
+	}
+	return RoleOther
+}
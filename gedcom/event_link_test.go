@@ -0,0 +1,70 @@
+package gedcom
+
+import (
+	"testing"
+)
+
+func createEventLinkTestDocument() *Document {
+	baptism := &Event{
+		Type: EventBaptism,
+		Associations: []*Association{
+			{IndividualXRef: "@I3@", Role: "godp"},
+			{IndividualXRef: "@I4@", Role: "OFFICIATOR"},
+			{IndividualXRef: "@I5@", Role: "cousin"},
+			{IndividualXRef: "@VOID@", Role: "WITN"},
+		},
+	}
+	marriage := &Event{
+		Type:         EventMarriage,
+		Associations: []*Association{{IndividualXRef: "@I5@", Role: "WITN"}},
+		Notes:        []string{"Witnessed by @I4@ and @I99@", "@N1@"},
+	}
+
+	doc := createRelationshipTestDocument(
+		[]*Individual{
+			{XRef: "@I1@", Events: []*Event{{Type: EventBirth}, baptism}},
+			{XRef: "@I2@"}, {XRef: "@I3@"}, {XRef: "@I4@"}, {XRef: "@I5@"},
+		},
+		[]*Family{{XRef: "@F1@", Husband: "@I2@", Wife: "@I3@", Children: []string{"@I1@"}, Events: []*Event{marriage}}},
+	)
+	note := &Note{XRef: "@N1@", Text: "Banns read by", Continuation: []string{"@I2@ and @I4@"}}
+	record := &Record{XRef: "@N1@", Type: RecordTypeNote, Entity: note}
+	doc.Records = append(doc.Records, record)
+	doc.XRefMap["@N1@"] = record
+	return doc
+}
+
+func TestEventLinks(t *testing.T) {
+	links := EventLinks(createEventLinkTestDocument())
+
+	want := []struct {
+		person, record string
+		index          int
+		role           string
+	}{
+		{"@I3@", "@I1@", 1, RoleGodparent},
+		{"@I4@", "@I1@", 1, RoleOfficiator},
+		{"@I5@", "@I1@", 1, RoleOther},
+		{"@I2@", "@F1@", 0, RoleHusband},
+		{"@I3@", "@F1@", 0, RoleWife},
+		{"@I1@", "@F1@", 0, RoleChild},
+		{"@I5@", "@F1@", 0, RoleWitness},
+		{"@I4@", "@F1@", 0, RoleOther},
+		{"@I2@", "@F1@", 0, RoleOther},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("len(EventLinks) = %d, want %d: %+v", len(links), len(want), links)
+	}
+	for i, w := range want {
+		got := links[i]
+		if got.PersonXRef != w.person || got.RecordXRef != w.record || got.EventIndex != w.index || got.Role != w.role {
+			t.Errorf("links[%d] = %s %s[%d] %s, want %s %s[%d] %s",
+				i, got.PersonXRef, got.RecordXRef, got.EventIndex, got.Role,
+				w.person, w.record, w.index, w.role)
+		}
+	}
+
+	if got := EventLinks(nil); got != nil {
+		t.Errorf("EventLinks(nil) = %v, want nil", got)
+	}
+}
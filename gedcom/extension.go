@@ -0,0 +1,39 @@
+package gedcom
+
+// Extension is a typed value built from a vendor extension tag (such as
+// _MILT or _APID) by a handler registered with the decoder (see
+// decoder.ExtensionRegistry).
+type Extension struct {
+	// Tag is the extension tag name (e.g., "_MILT")
+	Tag string
+
+	// Value is the value returned by the handler
+	Value interface{}
+
+	// Source is the tag the value was built from; its subordinates follow
+	// it in Record.Tags
+	Source *Tag
+}
+
+// Extension returns the value of the record's first extension with the
+// given tag, and false if there is none.
+func (r *Record) Extension(tag string) (interface{}, bool) {
+	for _, ext := range r.Extensions {
+		if ext.Tag == tag {
+			return ext.Value, true
+		}
+	}
+	return nil, false
+}
+
+// ExtensionValues returns the values of all the record's extensions with
+// the given tag, in document order.
+func (r *Record) ExtensionValues(tag string) []interface{} {
+	var values []interface{}
+	for _, ext := range r.Extensions {
+		if ext.Tag == tag {
+			values = append(values, ext.Value)
+		}
+	}
+	return values
+}
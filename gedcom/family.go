@@ -38,12 +38,23 @@ type Family struct {
 	// CreationDate is when the record was created (CREA tag, GEDCOM 7.0)
 	CreationDate *ChangeDate
 
-	// RefNumber is the user reference number (REFN tag)
+	// RefNumber is the user reference number (REFN tag). It mirrors the
+	// first REFN in Identifiers.
 	RefNumber string
 
-	// UID is the unique identifier (UID tag)
+	// UID is the unique identifier (UID tag). It mirrors the first UID in
+	// UIDs.
 	UID string
 
+	// UIDs are the record's stable identifiers in document order: UID values
+	// (which can repeat in GEDCOM 7.0) and the vendor _UID values written
+	// by PAF, Legacy, RootsMagic, and others. See Document.FindByUID.
+	UIDs []string
+
+	// Identifiers are the record's REFN and EXID identifiers in other
+	// systems, in document order
+	Identifiers []*Identifier
+
 	// Tags contains all raw tags for this family (for unknown/custom tags)
 	Tags []*Tag
 }
@@ -90,14 +101,13 @@ func (f *Family) AllMembers(doc *Document) []*Individual {
 	if doc == nil {
 		return []*Individual{}
 	}
-	result := make([]*Individual, 0, 2+len(f.Children))
-
-	if husband := f.HusbandIndividual(doc); husband != nil {
-		result = append(result, husband)
-	}
-	if wife := f.WifeIndividual(doc); wife != nil {
-		result = append(result, wife)
+	xrefs := append([]string{f.Husband, f.Wife}, f.Children...)
+	members := doc.ResolveIndividuals(xrefs...)
+	result := make([]*Individual, 0, len(xrefs))
+	for _, xref := range xrefs {
+		if member := members[xref]; member != nil {
+			result = append(result, member)
+		}
 	}
-	result = append(result, f.ChildrenIndividuals(doc)...)
 	return result
 }
@@ -0,0 +1,154 @@
+package gedcom
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fingerprintPart is a piece of a record's canonical form: literal text, or
+// a pointer to the record at target (-1 if the pointer dangles).
+type fingerprintPart struct {
+	text   string
+	target int
+	ptr    bool
+}
+
+// Fingerprint returns a hex-encoded SHA-256 hash of the document's records
+// that is stable across cosmetic differences, so two files can be compared
+// for containing the same tree.
+//
+// The hash ignores:
+//   - XRef naming: pointers are identified by the content of the records they
+//     point to, not by their identifiers
+//   - Record order
+//   - Line formatting: CONT and CONC lines are merged into their parent's
+//     value, and values are trimmed of surrounding whitespace
+//   - The header, which describes the exporting software rather than the tree
+//
+// Fingerprint hashes the raw tags (Record.Tags). Records built or edited
+// through their entities should be synchronized first (see encoder.SyncTags).
+func Fingerprint(doc *Document) string {
+	sum := sha256.New()
+	if doc == nil || len(doc.Records) == 0 {
+		return hex.EncodeToString(sum.Sum(nil))
+	}
+
+	index := make(map[string]int, len(doc.Records))
+	for i, record := range doc.Records {
+		if record.XRef != "" {
+			index[record.XRef] = i
+		}
+	}
+	parts := make([][]fingerprintPart, len(doc.Records))
+	for i, record := range doc.Records {
+		parts[i] = canonicalRecord(record, index)
+	}
+
+	// Refine record labels by the labels of the records they point to until
+	// the partition of records into equal labels stops changing. Each label
+	// includes the previous one, so every round refines the last.
+	labels := make([]string, len(doc.Records))
+	for i, record := range doc.Records {
+		labels[i] = string(record.Type)
+	}
+	distinct := countDistinct(labels)
+	for round := 0; round < len(doc.Records); round++ {
+		next := make([]string, len(labels))
+		for i := range parts {
+			h := sha256.New()
+			h.Write([]byte(labels[i]))
+			for _, part := range parts[i] {
+				switch {
+				case !part.ptr:
+					h.Write([]byte(part.text))
+				case part.target < 0:
+					h.Write([]byte("\x00?"))
+				default:
+					h.Write([]byte("\x00@"))
+					h.Write([]byte(labels[part.target]))
+				}
+			}
+			next[i] = string(h.Sum(nil))
+		}
+		labels = next
+		n := countDistinct(labels)
+		if round > 0 && n == distinct {
+			break
+		}
+		distinct = n
+	}
+
+	sort.Strings(labels)
+	for _, label := range labels {
+		sum.Write([]byte(label))
+	}
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// canonicalRecord serializes a record with continuation lines merged and
+// pointers split out as separate parts.
+func canonicalRecord(record *Record, index map[string]int) []fingerprintPart {
+	type node struct {
+		level int
+		tag   string
+		value string
+	}
+
+	// Merge CONT and CONC into their parent's value; level 0 is the record
+	nodes := []*node{{level: 0, tag: string(record.Type), value: record.Value}}
+	var stack []*node
+	stack = append(stack, nodes[0])
+	for _, tag := range record.Tags {
+		for len(stack) > 1 && stack[len(stack)-1].level >= tag.Level {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1]
+		switch tag.Tag {
+		case "CONT":
+			parent.value += "\n" + tag.Value
+			continue
+		case "CONC":
+			parent.value += tag.Value
+			continue
+		}
+		n := &node{level: tag.Level, tag: tag.Tag, value: tag.Value}
+		nodes = append(nodes, n)
+		stack = append(stack, n)
+	}
+
+	var parts []fingerprintPart
+	for _, n := range nodes {
+		value := strings.TrimSpace(n.value)
+		parts = append(parts, fingerprintPart{text: strconv.Itoa(n.level) + " " + n.tag + " "})
+		if isPointerValue(value) {
+			target, ok := index[value]
+			if !ok {
+				target = -1
+			}
+			parts = append(parts, fingerprintPart{ptr: true, target: target})
+		} else {
+			parts = append(parts, fingerprintPart{text: strconv.Quote(value)})
+		}
+		parts = append(parts, fingerprintPart{text: "\n"})
+	}
+	return parts
+}
+
+// isPointerValue reports whether value is a cross-reference pointer such as
+// "@I1@" (escapes such as "@#DJULIAN@" are not pointers).
+func isPointerValue(value string) bool {
+	return len(value) > 2 && value[0] == '@' && value[len(value)-1] == '@' && value[1] != '#' &&
+		!strings.ContainsAny(value[1:len(value)-1], "@ ")
+}
+
+// countDistinct returns the number of distinct strings in values.
+func countDistinct(values []string) int {
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		seen[v] = true
+	}
+	return len(seen)
+}
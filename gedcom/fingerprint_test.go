@@ -0,0 +1,91 @@
+package gedcom
+
+import "testing"
+
+// fingerprintDoc builds a document from records, indexing them by XRef.
+func fingerprintDoc(records ...*Record) *Document {
+	doc := &Document{Records: records, XRefMap: make(map[string]*Record)}
+	for _, r := range records {
+		doc.XRefMap[r.XRef] = r
+	}
+	return doc
+}
+
+func TestFingerprint_IgnoresXRefsOrderAndFormatting(t *testing.T) {
+	a := fingerprintDoc(
+		&Record{XRef: "@I1@", Type: RecordTypeIndividual, Tags: []*Tag{
+			{Level: 1, Tag: "NAME", Value: "John /Smith/"},
+			{Level: 1, Tag: "NOTE", Value: "Served in the"},
+			{Level: 2, Tag: "CONC", Value: " army"},
+			{Level: 1, Tag: "FAMS", Value: "@F1@"},
+		}},
+		&Record{XRef: "@F1@", Type: RecordTypeFamily, Tags: []*Tag{
+			{Level: 1, Tag: "HUSB", Value: "@I1@"},
+		}},
+	)
+	b := fingerprintDoc(
+		&Record{XRef: "@FAM7@", Type: RecordTypeFamily, Tags: []*Tag{
+			{Level: 1, Tag: "HUSB", Value: "@P42@"},
+		}},
+		&Record{XRef: "@P42@", Type: RecordTypeIndividual, Tags: []*Tag{
+			{Level: 1, Tag: "NAME", Value: "John /Smith/ "},
+			{Level: 1, Tag: "NOTE", Value: "Served in the army"},
+			{Level: 1, Tag: "FAMS", Value: "@FAM7@"},
+		}},
+	)
+
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("Fingerprint() differs for documents with the same tree")
+	}
+}
+
+func TestFingerprint_DetectsChanges(t *testing.T) {
+	person := func(xref, name, fams string) *Record {
+		return &Record{XRef: xref, Type: RecordTypeIndividual, Tags: []*Tag{
+			{Level: 1, Tag: "NAME", Value: name},
+			{Level: 1, Tag: "FAMC", Value: fams},
+		}}
+	}
+	family := func(xref, husb, chil string) *Record {
+		return &Record{XRef: xref, Type: RecordTypeFamily, Tags: []*Tag{
+			{Level: 1, Tag: "HUSB", Value: husb},
+			{Level: 1, Tag: "CHIL", Value: chil},
+		}}
+	}
+	parent := func(xref, name string) *Record {
+		return &Record{XRef: xref, Type: RecordTypeIndividual, Tags: []*Tag{{Level: 1, Tag: "NAME", Value: name}}}
+	}
+
+	base := fingerprintDoc(
+		parent("@I1@", "Bob"), parent("@I2@", "Tom"),
+		person("@I3@", "John", "@F1@"), person("@I4@", "Mary", "@F2@"),
+		family("@F1@", "@I1@", "@I3@"), family("@F2@", "@I2@", "@I4@"),
+	)
+	// Same records, but the children are swapped between the fathers
+	swapped := fingerprintDoc(
+		parent("@I1@", "Bob"), parent("@I2@", "Tom"),
+		person("@I3@", "John", "@F2@"), person("@I4@", "Mary", "@F1@"),
+		family("@F1@", "@I1@", "@I4@"), family("@F2@", "@I2@", "@I3@"),
+	)
+	renamed := fingerprintDoc(
+		parent("@I1@", "Robert"), parent("@I2@", "Tom"),
+		person("@I3@", "John", "@F1@"), person("@I4@", "Mary", "@F2@"),
+		family("@F1@", "@I1@", "@I3@"), family("@F2@", "@I2@", "@I4@"),
+	)
+
+	if Fingerprint(base) == Fingerprint(swapped) {
+		t.Error("Fingerprint() should change when relationships change")
+	}
+	if Fingerprint(base) == Fingerprint(renamed) {
+		t.Error("Fingerprint() should change when values change")
+	}
+}
+
+func TestFingerprint_Empty(t *testing.T) {
+	if Fingerprint(nil) != Fingerprint(&Document{}) {
+		t.Error("nil and empty documents should have the same fingerprint")
+	}
+	if len(Fingerprint(nil)) != 64 {
+		t.Errorf("Fingerprint() length = %d, want 64", len(Fingerprint(nil)))
+	}
+}
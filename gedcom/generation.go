@@ -0,0 +1,73 @@
+package gedcom
+
+// Generations assigns a generation number to every individual related to root
+// by direct descent or ancestry.
+//
+// The root individual is generation 0. Ancestors receive positive numbers
+// (parents are 1, grandparents are 2, and so on) and descendants receive
+// negative numbers (children are -1, grandchildren are -2). Spouses, siblings,
+// and other collateral relatives are not included.
+//
+// When the same person is reachable along several lines (pedigree collapse),
+// the minimum distance from root is used. If a malformed file makes a person
+// both an ancestor and a descendant of root, the smaller absolute distance
+// wins, with ancestry preferred on ties.
+//
+// The returned map is keyed by individual XRef. Returns nil if doc is nil or
+// root is not an individual in the document.
+func Generations(doc *Document, root string) map[string]int {
+	if doc == nil {
+		return nil
+	}
+	start := doc.GetIndividual(root)
+	if start == nil {
+		return nil
+	}
+
+	result := map[string]int{start.XRef: 0}
+
+	ancestors := generationWalk(doc, start, func(ind *Individual) []*Individual {
+		return ind.Parents(doc)
+	})
+	for xref, dist := range ancestors {
+		result[xref] = dist
+	}
+
+	descendants := generationWalk(doc, start, func(ind *Individual) []*Individual {
+		return ind.Children(doc)
+	})
+	for xref, dist := range descendants {
+		if existing, ok := result[xref]; ok && existing <= dist {
+			continue
+		}
+		result[xref] = -dist
+	}
+
+	return result
+}
+
+// generationWalk performs a breadth-first traversal from start using next to
+// find the following generation. It returns the minimum distance to every
+// reached individual, excluding start itself.
+func generationWalk(doc *Document, start *Individual, next func(*Individual) []*Individual) map[string]int {
+	distances := make(map[string]int)
+	visited := map[string]bool{start.XRef: true}
+	current := []*Individual{start}
+
+	for depth := 1; len(current) > 0; depth++ {
+		var following []*Individual
+		for _, ind := range current {
+			for _, rel := range next(ind) {
+				if visited[rel.XRef] {
+					continue
+				}
+				visited[rel.XRef] = true
+				distances[rel.XRef] = depth
+				following = append(following, rel)
+			}
+		}
+		current = following
+	}
+
+	return distances
+}
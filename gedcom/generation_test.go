@@ -0,0 +1,150 @@
+package gedcom
+
+import "testing"
+
+// createGenerationTestDocument builds a small tree with pedigree collapse:
+//
+//	@G1@ + @G2@ -> @P1@, @P2@ (siblings)
+//	@P1@ + @S1@ -> @C1@
+//	@P2@ + @S2@ -> @C2@
+//	@C1@ + @C2@ -> @R@ (first cousins marry)
+//	@R@  + @RS@ -> @K1@
+//	@K1@        -> @GK@
+func createGenerationTestDocument() *Document {
+	doc := &Document{XRefMap: make(map[string]*Record)}
+
+	addIndi := func(xref string, famc []string, fams []string) {
+		ind := &Individual{XRef: xref, SpouseInFamilies: fams}
+		for _, f := range famc {
+			ind.ChildInFamilies = append(ind.ChildInFamilies, FamilyLink{FamilyXRef: f})
+		}
+		rec := &Record{XRef: xref, Type: RecordTypeIndividual, Entity: ind}
+		doc.Records = append(doc.Records, rec)
+		doc.XRefMap[xref] = rec
+	}
+	addFam := func(xref, husb, wife string, children ...string) {
+		fam := &Family{XRef: xref, Husband: husb, Wife: wife, Children: children}
+		rec := &Record{XRef: xref, Type: RecordTypeFamily, Entity: fam}
+		doc.Records = append(doc.Records, rec)
+		doc.XRefMap[xref] = rec
+	}
+
+	addIndi("@G1@", nil, []string{"@FG@"})
+	addIndi("@G2@", nil, []string{"@FG@"})
+	addIndi("@P1@", []string{"@FG@"}, []string{"@FP1@"})
+	addIndi("@P2@", []string{"@FG@"}, []string{"@FP2@"})
+	addIndi("@S1@", nil, []string{"@FP1@"})
+	addIndi("@S2@", nil, []string{"@FP2@"})
+	addIndi("@C1@", []string{"@FP1@"}, []string{"@FC@"})
+	addIndi("@C2@", []string{"@FP2@"}, []string{"@FC@"})
+	addIndi("@R@", []string{"@FC@"}, []string{"@FR@"})
+	addIndi("@RS@", nil, []string{"@FR@"})
+	addIndi("@K1@", []string{"@FR@"}, []string{"@FK@"})
+	addIndi("@GK@", []string{"@FK@"}, nil)
+	addIndi("@X@", nil, nil)
+
+	addFam("@FG@", "@G1@", "@G2@", "@P1@", "@P2@")
+	addFam("@FP1@", "@P1@", "@S1@", "@C1@")
+	addFam("@FP2@", "@P2@", "@S2@", "@C2@")
+	addFam("@FC@", "@C1@", "@C2@", "@R@")
+	addFam("@FR@", "@R@", "@RS@", "@K1@")
+	addFam("@FK@", "@K1@", "", "@GK@")
+
+	return doc
+}
+
+func TestGenerations(t *testing.T) {
+	doc := createGenerationTestDocument()
+
+	got := Generations(doc, "@R@")
+
+	want := map[string]int{
+		"@R@":  0,
+		"@C1@": 1,
+		"@C2@": 1,
+		"@P1@": 2,
+		"@P2@": 2,
+		"@S1@": 2,
+		"@S2@": 2,
+		"@G1@": 3, // reachable via both cousins, counted once
+		"@G2@": 3,
+		"@K1@": -1,
+		"@GK@": -2,
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("Generations() returned %d entries, want %d: %v", len(got), len(want), got)
+	}
+	for xref, gen := range want {
+		if g, ok := got[xref]; !ok || g != gen {
+			t.Errorf("Generations()[%s] = %d (present=%v), want %d", xref, g, ok, gen)
+		}
+	}
+
+	// Spouses and unrelated individuals are excluded
+	for _, xref := range []string{"@RS@", "@X@"} {
+		if _, ok := got[xref]; ok {
+			t.Errorf("Generations() should not include %s", xref)
+		}
+	}
+}
+
+func TestGenerationsFromMiddle(t *testing.T) {
+	doc := createGenerationTestDocument()
+
+	got := Generations(doc, "@P1@")
+
+	want := map[string]int{
+		"@P1@": 0,
+		"@G1@": 1,
+		"@G2@": 1,
+		"@C1@": -1,
+		"@R@":  -2,
+		"@K1@": -3,
+		"@GK@": -4,
+	}
+	for xref, gen := range want {
+		if g, ok := got[xref]; !ok || g != gen {
+			t.Errorf("Generations()[%s] = %d (present=%v), want %d", xref, g, ok, gen)
+		}
+	}
+	if _, ok := got["@P2@"]; ok {
+		t.Error("Generations() should not include siblings")
+	}
+}
+
+func TestGenerationsCycle(t *testing.T) {
+	// Malformed: @A@ is both parent and child of @B@
+	a := &Individual{XRef: "@A@", SpouseInFamilies: []string{"@F1@"}, ChildInFamilies: []FamilyLink{{FamilyXRef: "@F2@"}}}
+	b := &Individual{XRef: "@B@", SpouseInFamilies: []string{"@F2@"}, ChildInFamilies: []FamilyLink{{FamilyXRef: "@F1@"}}}
+	f1 := &Family{XRef: "@F1@", Husband: "@A@", Children: []string{"@B@"}}
+	f2 := &Family{XRef: "@F2@", Husband: "@B@", Children: []string{"@A@"}}
+	doc := &Document{XRefMap: map[string]*Record{
+		"@A@":  {XRef: "@A@", Type: RecordTypeIndividual, Entity: a},
+		"@B@":  {XRef: "@B@", Type: RecordTypeIndividual, Entity: b},
+		"@F1@": {XRef: "@F1@", Type: RecordTypeFamily, Entity: f1},
+		"@F2@": {XRef: "@F2@", Type: RecordTypeFamily, Entity: f2},
+	}}
+
+	got := Generations(doc, "@A@")
+	if got["@B@"] != 1 {
+		t.Errorf("Generations()[@B@] = %d, want 1 (ancestry preferred on ties)", got["@B@"])
+	}
+	if len(got) != 2 {
+		t.Errorf("Generations() returned %d entries, want 2", len(got))
+	}
+}
+
+func TestGenerationsInvalidInput(t *testing.T) {
+	if got := Generations(nil, "@I1@"); got != nil {
+		t.Errorf("Generations(nil) = %v, want nil", got)
+	}
+
+	doc := createGenerationTestDocument()
+	if got := Generations(doc, "@MISSING@"); got != nil {
+		t.Errorf("Generations(missing) = %v, want nil", got)
+	}
+	if got := Generations(doc, "@FG@"); got != nil {
+		t.Errorf("Generations(family xref) = %v, want nil", got)
+	}
+}
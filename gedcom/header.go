@@ -13,6 +13,15 @@ type Header struct {
 	// SourceSystem identifies the software that created the file
 	SourceSystem string
 
+	// SourceVersion is the producing program's version (HEAD.SOUR.VERS)
+	SourceVersion string
+
+	// SourceName is the producing program's product name (HEAD.SOUR.NAME)
+	SourceName string
+
+	// SourceCorporation is the producing program's publisher (HEAD.SOUR.CORP)
+	SourceCorporation string
+
 	// Date is when the file was created
 	Date time.Time
 
@@ -22,7 +31,17 @@ type Header struct {
 	// Copyright notice (optional)
 	Copyright string
 
-	// Submitter reference (optional)
+	// Note is the file-level note (HEAD.NOTE, optional), with CONT lines
+	// joined by newlines
+	Note string
+
+	// PlaceForm is the default place hierarchy (e.g., "City, County, State,
+	// Country") for places without their own FORM (HEAD.PLAC.FORM, optional);
+	// PlaceDetail.EffectiveForm falls back to it
+	PlaceForm string
+
+	// Submitter is the XRef of the submitter record (HEAD.SUBM, optional);
+	// Document.HeaderSubmitter resolves it
 	Submitter string
 
 	// AncestryTreeID is the Ancestry.com tree identifier from HEAD.SOUR._TREE.
@@ -0,0 +1,125 @@
+package gedcom
+
+import (
+	"sort"
+	"strings"
+)
+
+// EventHeatmap is a matrix of event counts by place and decade, built from
+// the dated and placed events of all individuals and families.
+type EventHeatmap struct {
+	// Decades are the first years of each decade column, ascending and
+	// contiguous from the earliest to the latest decade with events
+	Decades []int `json:"decades"`
+
+	// Places are the row labels, ordered by descending total count and then
+	// alphabetically
+	Places []string `json:"places"`
+
+	// Counts holds the event count for Places[i] in Decades[j] at Counts[i][j]
+	Counts [][]int `json:"counts"`
+}
+
+// BuildEventHeatmap counts the document's individual and family events by
+// place and decade. Events without a date year or a place are skipped.
+// Non-Gregorian dates are converted before bucketing; ranges and periods
+// use their start year.
+//
+// placeLevels limits places to their most general jurisdictions: 2 maps
+// "Boston, Suffolk, Massachusetts, USA" to "Massachusetts, USA". Zero keeps
+// the full place name.
+func BuildEventHeatmap(doc *Document, placeLevels int) *EventHeatmap {
+	heatmap := &EventHeatmap{}
+	if doc == nil {
+		return heatmap
+	}
+
+	counts := make(map[string]map[int]int)
+	totals := make(map[string]int)
+	minDecade, maxDecade := 0, 0
+	for _, record := range doc.Records {
+		var events []*Event
+		switch entity := record.LoadEntity().(type) {
+		case *Individual:
+			events = entity.Events
+		case *Family:
+			events = entity.Events
+		default:
+			continue
+		}
+
+		for _, event := range events {
+			year := eventYear(event)
+			place := heatmapPlace(event.Place, placeLevels)
+			if year == 0 || place == "" {
+				continue
+			}
+			decade := year - year%10
+			if len(totals) == 0 || decade < minDecade {
+				minDecade = decade
+			}
+			if len(totals) == 0 || decade > maxDecade {
+				maxDecade = decade
+			}
+			if counts[place] == nil {
+				counts[place] = make(map[int]int)
+				heatmap.Places = append(heatmap.Places, place)
+			}
+			counts[place][decade]++
+			totals[place]++
+		}
+	}
+	if len(totals) == 0 {
+		return heatmap
+	}
+
+	for decade := minDecade; decade <= maxDecade; decade += 10 {
+		heatmap.Decades = append(heatmap.Decades, decade)
+	}
+	sort.Slice(heatmap.Places, func(i, j int) bool {
+		pi, pj := heatmap.Places[i], heatmap.Places[j]
+		if totals[pi] != totals[pj] {
+			return totals[pi] > totals[pj]
+		}
+		return pi < pj
+	})
+	for _, place := range heatmap.Places {
+		row := make([]int, len(heatmap.Decades))
+		for j, decade := range heatmap.Decades {
+			row[j] = counts[place][decade]
+		}
+		heatmap.Counts = append(heatmap.Counts, row)
+	}
+	return heatmap
+}
+
+// eventYear returns the Gregorian year of an event's date, or 0 if unknown.
+func eventYear(event *Event) int {
+	date := event.ParsedDate
+	if date == nil {
+		return 0
+	}
+	if date.Calendar != CalendarGregorian && date.Calendar != CalendarJulian {
+		converted, err := date.ToGregorian()
+		if err != nil {
+			return 0
+		}
+		date = converted
+	}
+	return date.Year
+}
+
+// heatmapPlace returns the last levels comma-separated components of place,
+// or the whole trimmed place if levels is zero.
+func heatmapPlace(place string, levels int) string {
+	var parts []string
+	for _, part := range strings.Split(place, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if levels > 0 && len(parts) > levels {
+		parts = parts[len(parts)-levels:]
+	}
+	return strings.Join(parts, ", ")
+}
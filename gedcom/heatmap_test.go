@@ -0,0 +1,56 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func createHeatmapTestDocument() *Document {
+	event := func(typ EventType, date, place string) *Event {
+		parsed, _ := ParseDate(date)
+		return &Event{Type: typ, Date: date, ParsedDate: parsed, Place: place}
+	}
+	return createRelationshipTestDocument(
+		[]*Individual{
+			{XRef: "@I1@", Events: []*Event{
+				event(EventBirth, "1 JAN 1881", "Cork, Ireland"),
+				event(EventImmigration, "1905", "Boston, Suffolk, Massachusetts, USA"),
+				event(EventDeath, "ABT 1921", "Boston, Suffolk, Massachusetts, USA"),
+			}},
+			{XRef: "@I2@", Events: []*Event{
+				event(EventBirth, "BET 1884 AND 1886", "Dublin, Ireland"),
+				event(EventDeath, "", "Boston, Suffolk, Massachusetts, USA"),
+				event(EventBurial, "1930", ""),
+			}},
+		},
+		[]*Family{{XRef: "@F1@", Events: []*Event{event(EventMarriage, "1908", "Boston, Suffolk, Massachusetts, USA")}}},
+	)
+}
+
+func TestBuildEventHeatmap(t *testing.T) {
+	h := BuildEventHeatmap(createHeatmapTestDocument(), 0)
+
+	wantDecades := []int{1880, 1890, 1900, 1910, 1920}
+	if !reflect.DeepEqual(h.Decades, wantDecades) {
+		t.Errorf("Decades = %v, want %v", h.Decades, wantDecades)
+	}
+	wantPlaces := []string{"Boston, Suffolk, Massachusetts, USA", "Cork, Ireland", "Dublin, Ireland"}
+	if !reflect.DeepEqual(h.Places, wantPlaces) {
+		t.Errorf("Places = %v, want %v", h.Places, wantPlaces)
+	}
+	wantCounts := [][]int{{0, 0, 2, 0, 1}, {1, 0, 0, 0, 0}, {1, 0, 0, 0, 0}}
+	if !reflect.DeepEqual(h.Counts, wantCounts) {
+		t.Errorf("Counts = %v, want %v", h.Counts, wantCounts)
+	}
+
+	// Collapsing to the country merges Cork and Dublin
+	h = BuildEventHeatmap(createHeatmapTestDocument(), 1)
+	if !reflect.DeepEqual(h.Places, []string{"USA", "Ireland"}) || h.Counts[1][0] != 2 {
+		t.Errorf("country heatmap = %v %v", h.Places, h.Counts)
+	}
+
+	empty := BuildEventHeatmap(nil, 0)
+	if len(empty.Decades) != 0 || len(empty.Places) != 0 {
+		t.Errorf("BuildEventHeatmap(nil) = %+v, want empty", empty)
+	}
+}
@@ -0,0 +1,27 @@
+package gedcom
+
+// Identifier kinds, the tag an Identifier was read from.
+const (
+	// IdentifierREFN is a user reference number (REFN), whose TYPE is free
+	// text such as "Ancestry member tree"
+	IdentifierREFN = "REFN"
+
+	// IdentifierEXID is a GEDCOM 7.0 external identifier (EXID), whose TYPE
+	// is a URI naming the authority, such as "https://www.familysearch.org/tree/person/"
+	IdentifierEXID = "EXID"
+)
+
+// Identifier is an identifier a record carries in another system, such as a
+// FamilySearch person ID, an Ancestry tree ID, or an archive reference.
+type Identifier struct {
+	// Kind is the tag the identifier was read from: IdentifierREFN or
+	// IdentifierEXID
+	Kind string
+
+	// Value is the identifier itself
+	Value string
+
+	// Type qualifies the identifier (TYPE): free text for REFN, the
+	// authority URI for EXID. It may be empty.
+	Type string
+}
@@ -1,5 +1,7 @@
 package gedcom
 
+import "strings"
+
 // Individual represents a person in the GEDCOM file.
 type Individual struct {
 	// XRef is the cross-reference identifier for this individual
@@ -44,17 +46,57 @@ type Individual struct {
 	// CreationDate is when the record was created (CREA tag, GEDCOM 7.0)
 	CreationDate *ChangeDate
 
-	// RefNumber is the user reference number (REFN tag)
+	// RefNumber is the user reference number (REFN tag). It mirrors the
+	// first REFN in Identifiers.
 	RefNumber string
 
-	// UID is the unique identifier (UID tag)
+	// UID is the unique identifier (UID tag). It mirrors the first UID in
+	// UIDs.
 	UID string
 
+	// UIDs are the record's stable identifiers in document order: UID values
+	// (which can repeat in GEDCOM 7.0) and the vendor _UID values written
+	// by PAF, Legacy, RootsMagic, and others. See Document.FindByUID.
+	UIDs []string
+
+	// Identifiers are the record's REFN and EXID identifiers in other
+	// systems, in document order
+	Identifiers []*Identifier
+
 	// FamilySearchID is the FamilySearch Family Tree ID (_FSFTID tag).
 	// This is a vendor extension from FamilySearch.org that uniquely identifies
 	// an individual in their Family Tree database. Format: alphanumeric like "KWCJ-QN7".
 	FamilySearchID string
 
+	// Aliases are references to other individual records that may describe
+	// the same person (ALIA tag, can repeat)
+	Aliases []string
+
+	// AncestorInterest are references to submitters interested in this
+	// person's ancestors (ANCI tag, can repeat)
+	AncestorInterest []string
+
+	// DescendantInterest are references to submitters interested in this
+	// person's descendants (DESI tag, can repeat)
+	DescendantInterest []string
+
+	// Restriction is the privacy restriction of the record (RESN tag)
+	// Common values: "confidential", "locked", "privacy" (or combinations)
+	Restriction string
+
+	// AutomatedRecordID is the record number assigned by the originating
+	// program (RIN tag)
+	AutomatedRecordID string
+
+	// AncestralFileNumber is the record's number in the LDS Ancestral File
+	// (AFN tag, GEDCOM 5.5)
+	AncestralFileNumber string
+
+	// Language is the language the individual's names are written in (LANG
+	// tag, e.g., "Hungarian" or "hu"). It selects the name ordering used by
+	// FormattedName; see NameOrderForLanguage.
+	Language string
+
 	// Tags contains all raw tags for this individual (for unknown/custom tags)
 	Tags []*Tag
 }
@@ -131,6 +173,10 @@ type FamilyLink struct {
 	// Pedigree is the pedigree linkage type (e.g., "birth", "adopted", "foster", "sealing")
 	// Empty string if not specified. Preserves original casing from GEDCOM.
 	Pedigree string
+
+	// Status is the confidence in the link (STAT tag), e.g., "challenged",
+	// "disproven", or "proven". Empty string if not specified.
+	Status string
 }
 
 // Association represents a link to an associated individual with a role.
@@ -154,6 +200,10 @@ type Association struct {
 
 	// Notes are note references for this association
 	Notes []string
+
+	// DNAMatch is genetic-genealogy match data for this association (_DNA extension
+	// or a NOTE describing shared centimorgans). Nil if no DNA data is present.
+	DNAMatch *DNAMatch
 }
 
 // Attribute represents a personal attribute.
@@ -164,6 +214,14 @@ type Attribute struct {
 	// Value is the attribute value
 	Value string
 
+	// NormalizedValue is a normalized form of Value for grouping, set by
+	// OccupationNormalizer for OCCU attributes. It is not encoded.
+	NormalizedValue string
+
+	// TypeDetail is a descriptive classification of the attribute (TYPE subordinate).
+	// Required for FACT, e.g., "Skills" or "Favorite color".
+	TypeDetail string
+
 	// Date when the attribute was applicable (optional)
 	Date string
 
@@ -178,6 +236,60 @@ type Attribute struct {
 	SourceCitations []*SourceCitation
 }
 
+// CauseOfDeath returns the cause (CAUS) of the individual's first death event,
+// or an empty string if none is recorded.
+func (i *Individual) CauseOfDeath() string {
+	if death := i.DeathEvent(); death != nil {
+		return death.Cause
+	}
+	return ""
+}
+
+// PhysicalDescription returns the individual's physical description (DSCR),
+// with multiple DSCR attributes separated by newlines. Returns an empty string
+// if none is recorded.
+func (i *Individual) PhysicalDescription() string {
+	var parts []string
+	for _, attr := range i.Attributes {
+		if attr.Type == "DSCR" && attr.Value != "" {
+			parts = append(parts, attr.Value)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Titles returns the values of the individual's TITL attributes (e.g., nobility
+// or professional titles such as "Duke of Wellington"), in record order.
+func (i *Individual) Titles() []string {
+	var titles []string
+	for _, attr := range i.Attributes {
+		if attr.Type == "TITL" && strings.TrimSpace(attr.Value) != "" {
+			titles = append(titles, strings.TrimSpace(attr.Value))
+		}
+	}
+	return titles
+}
+
+// DisplayTitle combines the primary name's prefix (NPFX), the individual's
+// TITL attributes, and the primary name's suffix (NSFX) into a single string
+// joined by ", " (e.g., "Sir, 1st Duke of Wellington, KG"). Returns an empty
+// string if none are recorded.
+func (i *Individual) DisplayTitle() string {
+	var parts []string
+	var name *PersonalName
+	if len(i.Names) > 0 {
+		name = i.Names[0]
+	}
+	if name != nil && strings.TrimSpace(name.Prefix) != "" {
+		parts = append(parts, strings.TrimSpace(name.Prefix))
+	}
+	parts = append(parts, i.Titles()...)
+	if name != nil && strings.TrimSpace(name.Suffix) != "" {
+		parts = append(parts, strings.TrimSpace(name.Suffix))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // BirthEvent returns the first birth event for this individual, or nil if none found.
 func (i *Individual) BirthEvent() *Event {
 	for _, event := range i.Events {
@@ -274,6 +274,71 @@ func TestIndividual_DeathDate(t *testing.T) {
 	}
 }
 
+func TestIndividual_CauseOfDeath(t *testing.T) {
+	ind := &Individual{Events: []*Event{
+		{Type: EventBurial, Cause: "Misplaced"},
+		{Type: EventDeath, Cause: "Influenza"},
+	}}
+	if got := ind.CauseOfDeath(); got != "Influenza" {
+		t.Errorf("CauseOfDeath() = %q, want Influenza", got)
+	}
+	if got := (&Individual{}).CauseOfDeath(); got != "" {
+		t.Errorf("CauseOfDeath() = %q, want empty", got)
+	}
+}
+
+func TestIndividual_PhysicalDescription(t *testing.T) {
+	ind := &Individual{Attributes: []*Attribute{
+		{Type: "DSCR", Value: "Tall"},
+		{Type: "OCCU", Value: "Farmer"},
+		{Type: "DSCR", Value: "Red hair"},
+	}}
+	if got := ind.PhysicalDescription(); got != "Tall\nRed hair" {
+		t.Errorf("PhysicalDescription() = %q", got)
+	}
+	if got := (&Individual{}).PhysicalDescription(); got != "" {
+		t.Errorf("PhysicalDescription() = %q, want empty", got)
+	}
+}
+
+func TestIndividual_DisplayTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		ind  *Individual
+		want string
+	}{
+		{
+			name: "prefix, titles, and suffix",
+			ind: &Individual{
+				Names: []*PersonalName{{Full: "Arthur /Wellesley/", Prefix: "Sir", Suffix: "KG"}},
+				Attributes: []*Attribute{
+					{Type: "TITL", Value: "1st Duke of Wellington"},
+					{Type: "OCCU", Value: "Soldier"},
+					{Type: "TITL", Value: " Prime Minister "},
+				},
+			},
+			want: "Sir, 1st Duke of Wellington, Prime Minister, KG",
+		},
+		{
+			name: "title only",
+			ind:  &Individual{Attributes: []*Attribute{{Type: "TITL", Value: "Baron"}}},
+			want: "Baron",
+		},
+		{
+			name: "name without title pieces",
+			ind:  &Individual{Names: []*PersonalName{{Full: "John /Smith/"}}},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ind.DisplayTitle(); got != tt.want {
+				t.Errorf("DisplayTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestIndividual_FamilySearchURL tests the FamilySearchURL helper method.
 // This returns the FamilySearch.org URL for the individual's record.
 // Ref: Issue #80
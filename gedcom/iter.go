@@ -0,0 +1,40 @@
+//go:build go1.23
+
+package gedcom
+
+import "iter"
+
+// AllRecords returns an iterator over the document's records in file order.
+func (d *Document) AllRecords() iter.Seq[*Record] {
+	return func(yield func(*Record) bool) {
+		for _, record := range d.Records {
+			if !yield(record) {
+				return
+			}
+		}
+	}
+}
+
+// AllIndividuals returns an iterator over the document's individuals. Unlike
+// Individuals, it does not build a slice.
+func (d *Document) AllIndividuals() iter.Seq[*Individual] {
+	return func(yield func(*Individual) bool) {
+		for _, record := range d.Records {
+			if ind, ok := record.GetIndividual(); ok && !yield(ind) {
+				return
+			}
+		}
+	}
+}
+
+// AllFamilies returns an iterator over the document's families. Unlike
+// Families, it does not build a slice.
+func (d *Document) AllFamilies() iter.Seq[*Family] {
+	return func(yield func(*Family) bool) {
+		for _, record := range d.Records {
+			if fam, ok := record.GetFamily(); ok && !yield(fam) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+//go:build go1.23
+
+package gedcom
+
+import "testing"
+
+func TestDocumentIterators(t *testing.T) {
+	doc := createRelationshipTestDocument(
+		[]*Individual{{XRef: "@I1@"}, {XRef: "@I2@"}},
+		[]*Family{{XRef: "@F1@"}},
+	)
+
+	var records []*Record
+	var individuals, families []string
+	for record := range doc.AllRecords() {
+		records = append(records, record)
+	}
+	for ind := range doc.AllIndividuals() {
+		individuals = append(individuals, ind.XRef)
+	}
+	for fam := range doc.AllFamilies() {
+		families = append(families, fam.XRef)
+	}
+	if len(records) != 3 || len(individuals) != 2 || individuals[1] != "@I2@" || len(families) != 1 {
+		t.Errorf("records = %d, individuals = %v, families = %v", len(records), individuals, families)
+	}
+
+	// Breaking out of the loop stops the iteration
+	n := 0
+	for range doc.AllIndividuals() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("iterations after break = %d, want 1", n)
+	}
+}
@@ -0,0 +1,256 @@
+package gedcom
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Relationship describes how an individual is related to a root individual.
+type Relationship struct {
+	// Label names the relationship from root's point of view, such as
+	// "mother", "1st cousin once removed", "sister's husband", or
+	// "wife's father". Labels follow the individual's sex and fall back to
+	// neutral terms ("parent", "aunt or uncle") when it is not M or F.
+	Label string
+
+	// Up and Down are the generations from root up to the nearest common
+	// ancestor and from there down to the individual. Both are 0 for root
+	// and for relatives by marriage.
+	Up, Down int
+
+	// Blood reports whether the individual shares an ancestor with root (or
+	// is root, or a direct ancestor or descendant of root).
+	Blood bool
+
+	// PathLength is the number of parent, child, and spouse links on the
+	// shortest path from root to the individual.
+	PathLength int
+}
+
+// Degree returns the degree of kinship, Up + Down, as counted in civil law:
+// 1 for parents and children, 2 for siblings and grandparents, 4 for first
+// cousins. It returns -1 for relatives by marriage.
+func (r *Relationship) Degree() int {
+	if !r.Blood {
+		return -1
+	}
+	return r.Up + r.Down
+}
+
+// Relationships returns the relationship to root of every individual
+// connected to root through parent, child, and spouse links, keyed by XRef;
+// root itself is included with the label "self".
+//
+// Blood relatives are labeled through their nearest common ancestor with
+// root; half-siblings count as siblings. Spouses of root, spouses of blood
+// relatives, and blood relatives of root's spouses receive in-law labels;
+// anyone else reached only through marriages is a "relative by marriage".
+// Returns nil if doc is nil or root is not an individual in the document.
+func Relationships(doc *Document, root string) map[string]*Relationship {
+	if doc == nil {
+		return nil
+	}
+	start := doc.GetIndividual(root)
+	if start == nil {
+		return nil
+	}
+
+	paths := kinshipPathLengths(doc, start)
+	blood := bloodRelationships(doc, start, paths)
+	spouses := start.Spouses(doc)
+	spouseBlood := make([]map[string]*Relationship, len(spouses))
+	for i, spouse := range spouses {
+		spouseBlood[i] = bloodRelationships(doc, spouse, paths)
+	}
+
+	result := make(map[string]*Relationship, len(paths))
+	for _, ind := range doc.Individuals() {
+		length, ok := paths[ind.XRef]
+		if !ok {
+			continue
+		}
+		if rel := blood[ind.XRef]; rel != nil {
+			result[ind.XRef] = rel
+			continue
+		}
+		result[ind.XRef] = &Relationship{
+			Label:      marriageLabel(doc, ind, spouses, blood, spouseBlood),
+			PathLength: length,
+		}
+	}
+	return result
+}
+
+// marriageLabel returns the label of ind, who is not a blood relative of
+// root: root's spouse, the spouse of root's closest blood relative among
+// ind's spouses, the closest blood relative of one of root's spouses, or a
+// relative by marriage.
+func marriageLabel(doc *Document, ind *Individual, spouses []*Individual, blood map[string]*Relationship, spouseBlood []map[string]*Relationship) string {
+	for _, spouse := range spouses {
+		if spouse.XRef == ind.XRef {
+			return kinNoun(ind.Sex, "husband", "wife", "spouse")
+		}
+	}
+
+	var best *Relationship
+	for _, partner := range ind.Spouses(doc) {
+		if rel := blood[partner.XRef]; rel != nil && rel.Label != "self" && closerKin(rel, best) {
+			best = rel
+		}
+	}
+	if best != nil {
+		return best.Label + "'s " + kinNoun(ind.Sex, "husband", "wife", "spouse")
+	}
+
+	var via *Individual
+	for i, spouse := range spouses {
+		if rel := spouseBlood[i][ind.XRef]; rel != nil && closerKin(rel, best) {
+			best, via = rel, spouse
+		}
+	}
+	if best != nil {
+		return kinNoun(via.Sex, "husband", "wife", "spouse") + "'s " + best.Label
+	}
+	return "relative by marriage"
+}
+
+// closerKin reports whether rel is a closer relative than best, which may
+// be nil.
+func closerKin(rel, best *Relationship) bool {
+	return best == nil || rel.Up+rel.Down < best.Up+best.Down
+}
+
+// bloodRelationships returns the relationship to root of every blood
+// relative of root, with path lengths taken from paths.
+func bloodRelationships(doc *Document, root *Individual, paths map[string]int) map[string]*Relationship {
+	rootAncestors := ancestorDistances(doc, root)
+	result := make(map[string]*Relationship)
+	for _, ind := range doc.Individuals() {
+		up, down, ok := -1, -1, false
+		for xref, d := range ancestorDistances(doc, ind) {
+			u, shared := rootAncestors[xref]
+			if !shared {
+				continue
+			}
+			if !ok || u+d < up+down || (u+d == up+down && u < up) {
+				up, down, ok = u, d, true
+			}
+		}
+		if !ok {
+			continue
+		}
+		result[ind.XRef] = &Relationship{
+			Label:      bloodLabel(up, down, ind.Sex),
+			Up:         up,
+			Down:       down,
+			Blood:      true,
+			PathLength: paths[ind.XRef],
+		}
+	}
+	return result
+}
+
+// ancestorDistances returns the generation distance from ind to each of its
+// ancestors, including ind itself at distance 0.
+func ancestorDistances(doc *Document, ind *Individual) map[string]int {
+	distances := generationWalk(doc, ind, func(i *Individual) []*Individual {
+		return i.Parents(doc)
+	})
+	distances[ind.XRef] = 0
+	return distances
+}
+
+// kinshipPathLengths returns the length of the shortest path of parent,
+// child, and spouse links from start to every connected individual.
+func kinshipPathLengths(doc *Document, start *Individual) map[string]int {
+	lengths := generationWalk(doc, start, func(i *Individual) []*Individual {
+		var next []*Individual
+		next = append(next, i.Parents(doc)...)
+		next = append(next, i.Children(doc)...)
+		return append(next, i.Spouses(doc)...)
+	})
+	lengths[start.XRef] = 0
+	return lengths
+}
+
+// bloodLabel names the relative up generations above and down generations
+// below a common ancestor, from the point of view of the other descendant.
+func bloodLabel(up, down int, sex string) string {
+	switch {
+	case up == 0 && down == 0:
+		return "self"
+	case down == 0:
+		return lineagePrefix(up) + kinNoun(sex, "father", "mother", "parent")
+	case up == 0:
+		return lineagePrefix(down) + kinNoun(sex, "son", "daughter", "child")
+	case up == 1 && down == 1:
+		return kinNoun(sex, "brother", "sister", "sibling")
+	case down == 1:
+		p := greatPrefix(up - 2)
+		return kinNoun(sex, p+"uncle", p+"aunt", p+"aunt or "+p+"uncle")
+	case up == 1:
+		p := greatPrefix(down - 2)
+		return kinNoun(sex, p+"nephew", p+"niece", p+"niece or "+p+"nephew")
+	}
+
+	label := ordinal(min(up, down)-1) + " cousin"
+	switch removed := up - down; {
+	case removed == 1 || removed == -1:
+		label += " once removed"
+	case removed == 2 || removed == -2:
+		label += " twice removed"
+	case removed != 0:
+		label += fmt.Sprintf(" %d times removed", max(removed, -removed))
+	}
+	return label
+}
+
+// lineagePrefix returns the prefix of a direct ancestor or descendant
+// generations away: "" for parents, "grand", "great-grand", then
+// "2nd great-grand" and so on.
+func lineagePrefix(generations int) string {
+	if generations == 1 {
+		return ""
+	}
+	return greatPrefix(generations-2) + "grand"
+}
+
+// greatPrefix returns "", "great-", "2nd great-", "3rd great-", and so on.
+func greatPrefix(n int) string {
+	switch {
+	case n <= 0:
+		return ""
+	case n == 1:
+		return "great-"
+	default:
+		return ordinal(n) + " great-"
+	}
+}
+
+// kinNoun returns male or female for sex M or F, and neutral otherwise.
+func kinNoun(sex, male, female, neutral string) string {
+	switch sex {
+	case "M":
+		return male
+	case "F":
+		return female
+	default:
+		return neutral
+	}
+}
+
+// ordinal returns n with its English ordinal suffix: 1st, 2nd, 3rd, 4th, 11th.
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
@@ -0,0 +1,108 @@
+package gedcom
+
+import (
+	"testing"
+)
+
+// createKinshipTestDocument builds three generations around @I6@:
+// grandparents @I1@/@I2@, parents @I3@/@I5@, aunt @I4@ married to @I8@,
+// sister @I7@, first cousin @I9@ and her daughter @I13@, wife @I10@ and her
+// father @I12@, son @I11@, and the unconnected @I14@.
+func createKinshipTestDocument() *Document {
+	person := func(xref, sex string, famc []string, fams ...string) *Individual {
+		ind := &Individual{XRef: xref, Sex: sex, SpouseInFamilies: fams}
+		for _, f := range famc {
+			ind.ChildInFamilies = append(ind.ChildInFamilies, FamilyLink{FamilyXRef: f})
+		}
+		return ind
+	}
+	individuals := []*Individual{
+		person("@I1@", "M", nil, "@F1@"),
+		person("@I2@", "F", nil, "@F1@"),
+		person("@I3@", "M", []string{"@F1@"}, "@F2@"),
+		person("@I4@", "F", []string{"@F1@"}, "@F3@"),
+		person("@I5@", "F", nil, "@F2@"),
+		person("@I6@", "M", []string{"@F2@"}, "@F4@"),
+		person("@I7@", "F", []string{"@F2@"}),
+		person("@I8@", "M", nil, "@F3@"),
+		person("@I9@", "", []string{"@F3@"}, "@F6@"),
+		person("@I10@", "F", []string{"@F5@"}, "@F4@"),
+		person("@I11@", "M", []string{"@F4@"}),
+		person("@I12@", "M", nil, "@F5@"),
+		person("@I13@", "F", []string{"@F6@"}),
+		person("@I14@", "M", nil),
+	}
+	families := []*Family{
+		{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@", Children: []string{"@I3@", "@I4@"}},
+		{XRef: "@F2@", Husband: "@I3@", Wife: "@I5@", Children: []string{"@I6@", "@I7@"}},
+		{XRef: "@F3@", Husband: "@I8@", Wife: "@I4@", Children: []string{"@I9@"}},
+		{XRef: "@F4@", Husband: "@I6@", Wife: "@I10@", Children: []string{"@I11@"}},
+		{XRef: "@F5@", Husband: "@I12@", Children: []string{"@I10@"}},
+		{XRef: "@F6@", Wife: "@I9@", Children: []string{"@I13@"}},
+	}
+	return createRelationshipTestDocument(individuals, families)
+}
+
+func TestRelationships(t *testing.T) {
+	rels := Relationships(createKinshipTestDocument(), "@I6@")
+
+	tests := []struct {
+		xref   string
+		label  string
+		degree int
+		path   int
+	}{
+		{"@I1@", "grandfather", 2, 2},
+		{"@I3@", "father", 1, 1},
+		{"@I4@", "aunt", 3, 3},
+		{"@I6@", "self", 0, 0},
+		{"@I7@", "sister", 2, 2},
+		{"@I8@", "aunt's husband", -1, 4},
+		{"@I9@", "1st cousin", 4, 4},
+		{"@I10@", "wife", -1, 1},
+		{"@I11@", "son", 1, 1},
+		{"@I12@", "wife's father", -1, 2},
+		{"@I13@", "1st cousin once removed", 5, 5},
+	}
+	for _, tt := range tests {
+		rel := rels[tt.xref]
+		if rel == nil {
+			t.Errorf("Relationships()[%s] missing", tt.xref)
+			continue
+		}
+		if rel.Label != tt.label || rel.Degree() != tt.degree || rel.PathLength != tt.path {
+			t.Errorf("Relationships()[%s] = %q degree %d path %d, want %q degree %d path %d",
+				tt.xref, rel.Label, rel.Degree(), rel.PathLength, tt.label, tt.degree, tt.path)
+		}
+	}
+	if rels["@I14@"] != nil {
+		t.Errorf("unconnected individual has relationship %+v", rels["@I14@"])
+	}
+	if Relationships(nil, "@I6@") != nil || Relationships(createKinshipTestDocument(), "@X@") != nil {
+		t.Error("Relationships() should be nil for nil document or unknown root")
+	}
+}
+
+func TestBloodLabel(t *testing.T) {
+	tests := []struct {
+		up, down int
+		sex      string
+		want     string
+	}{
+		{1, 0, "", "parent"},
+		{3, 0, "F", "great-grandmother"},
+		{5, 0, "M", "3rd great-grandfather"},
+		{0, 2, "", "grandchild"},
+		{3, 1, "", "great-aunt or great-uncle"},
+		{1, 4, "M", "2nd great-nephew"},
+		{3, 3, "", "2nd cousin"},
+		{2, 4, "", "1st cousin twice removed"},
+		{6, 2, "", "1st cousin 4 times removed"},
+		{12, 12, "", "11th cousin"},
+	}
+	for _, tt := range tests {
+		if got := bloodLabel(tt.up, tt.down, tt.sex); got != tt.want {
+			t.Errorf("bloodLabel(%d, %d, %q) = %q, want %q", tt.up, tt.down, tt.sex, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package gedcom
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PersonLabel formats an individual as a display label in exports, such as
+// the Label of OutlierReport entries. It is called with nil for a missing
+// individual and should return "" then.
+type PersonLabel func(ind *Individual) string
+
+// LabelName labels an individual by their primary name without surname
+// slashes, such as "John Doe", also for names written "John/Doe/". It is the
+// default label of exports.
+func LabelName(ind *Individual) string {
+	if ind == nil || len(ind.Names) == 0 {
+		return ""
+	}
+	return strings.Join(strings.Fields(strings.ReplaceAll(ind.Names[0].Full, "/", " ")), " ")
+}
+
+// LabelNameLifespan labels an individual by name and birth and death years,
+// such as "John Doe (1850-1920)" or "Jane Doe (1852-)". The lifespan is left
+// out when neither year is known.
+func LabelNameLifespan(ind *Individual) string {
+	if ind == nil {
+		return ""
+	}
+	birth, death := labelYear(ind.BirthDate()), labelYear(ind.DeathDate())
+	if birth == "" && death == "" {
+		return LabelName(ind)
+	}
+	return joinNonEmpty(" ", LabelName(ind), "("+birth+"-"+death+")")
+}
+
+// LabelNameXRef labels an individual by name and XRef, such as
+// "John Doe [@I1@]", which keeps namesakes apart.
+func LabelNameXRef(ind *Individual) string {
+	if ind == nil {
+		return ""
+	}
+	return joinNonEmpty(" ", LabelName(ind), "["+ind.XRef+"]")
+}
+
+// LabelTemplate returns a PersonLabel that fills template with the
+// individual's details. Placeholders are {name} (as LabelName), {given} and
+// {surname} (from GIVN and SURN or the slashes of the primary name), {birth}
+// and {death} (years), and {xref}. Placeholders without a value become
+// empty, and surrounding whitespace is trimmed.
+//
+//	gedcom.LabelTemplate("{surname}, {given} b. {birth}")
+func LabelTemplate(template string) PersonLabel {
+	return func(ind *Individual) string {
+		if ind == nil {
+			return ""
+		}
+		var given, surname string
+		if len(ind.Names) > 0 {
+			parts := splitName(ind.Names[0])
+			given, surname = parts.given, joinNonEmpty(" ", parts.particle, parts.surname)
+		}
+		r := strings.NewReplacer(
+			"{name}", LabelName(ind),
+			"{given}", given,
+			"{surname}", surname,
+			"{birth}", labelYear(ind.BirthDate()),
+			"{death}", labelYear(ind.DeathDate()),
+			"{xref}", ind.XRef,
+		)
+		return strings.TrimSpace(r.Replace(template))
+	}
+}
+
+// labelYear returns the year of d, or "" if it has none.
+func labelYear(d *Date) string {
+	if d == nil || d.IsPhrase || d.Year == 0 {
+		return ""
+	}
+	return strconv.Itoa(d.Year)
+}
@@ -0,0 +1,54 @@
+package gedcom
+
+import "testing"
+
+func TestPersonLabels(t *testing.T) {
+	doc := createOutlierTestDocument()
+	john := doc.GetIndividual("@I1@")
+	noName := doc.GetIndividual("@I3@")
+	unknownBirth := doc.GetIndividual("@I4@")
+
+	tests := []struct {
+		name  string
+		label PersonLabel
+		ind   *Individual
+		want  string
+	}{
+		{"name", LabelName, john, "John Smith"},
+		{"name without space before surname", LabelName, &Individual{Names: []*PersonalName{{Full: "Elizabeth/Bowes-Lyon/"}}}, "Elizabeth Bowes-Lyon"},
+		{"name lifespan", LabelNameLifespan, john, "John Smith (1800-1950)"},
+		{"lifespan without name", LabelNameLifespan, noName, "(1880-)"},
+		{"lifespan with phrase birth", LabelNameLifespan, unknownBirth, "(-1900)"},
+		{"name xref", LabelNameXRef, john, "John Smith [@I1@]"},
+		{"xref without name", LabelNameXRef, noName, "[@I3@]"},
+		{"template", LabelTemplate("{surname}, {given} ({birth}) {xref}"), john, "Smith, John (1800) @I1@"},
+		{"template trims", LabelTemplate("{name} d. {death}"), noName, "d."},
+		{"nil", LabelNameLifespan, nil, ""},
+		{"nil template", LabelTemplate("{name}"), nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.label(tt.ind); got != tt.want {
+				t.Errorf("label = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutlierReportRelabel(t *testing.T) {
+	doc := createOutlierTestDocument()
+	report := BuildOutlierReport(doc, -1)
+	report.Relabel(doc, LabelNameLifespan)
+
+	if got := report.LongestLifespans[0].Label; got != "John Smith (1800-1950)" {
+		t.Errorf("LongestLifespans[0].Label = %q", got)
+	}
+	for _, entry := range report.LargestFamilies {
+		if entry.XRef == "@F1@" && entry.Label != "John Smith (1800-1950) & Mary Jones (1860-1920)" {
+			t.Errorf("@F1@ label = %q", entry.Label)
+		}
+	}
+	if got := report.MostCitedSources[0].Label; got != "Parish register" {
+		t.Errorf("source label = %q, want title kept", got)
+	}
+}
@@ -1,5 +1,7 @@
 package gedcom
 
+import "strings"
+
 // CropRegion defines a subregion of an image to display (GEDCOM 7.0 CROP).
 // Used to specify which portion of an image should be displayed when referenced.
 type CropRegion struct {
@@ -28,6 +30,10 @@ type MediaFile struct {
 	// MediaType is the category (MEDI tag): AUDIO, BOOK, CARD, ELECTRONIC, PHOTO, VIDEO, etc.
 	MediaType string
 
+	// MediaPhrase is the free-text form of the category (MEDI.PHRASE),
+	// typically given with MediaType OTHER
+	MediaPhrase string
+
 	// Title is a descriptive title for this file
 	Title string
 
@@ -60,10 +66,15 @@ type MediaObject struct {
 	// Files contains 1:M file references (required, at least one)
 	Files []*MediaFile
 
+	// Identifiers are the record's REFN and EXID identifiers in other
+	// systems, in document order
+	Identifiers []*Identifier
+
 	// Notes are references to note records
 	Notes []string
 
-	// RefNumbers are user reference numbers (REFN tag, can have multiple)
+	// RefNumbers are user reference numbers (REFN tag, can have multiple).
+	// They mirror the REFN values in Identifiers.
 	RefNumbers []string
 
 	// Restriction is the access restriction level (RESN tag)
@@ -91,3 +102,93 @@ type MediaTranslation struct {
 	// Form is the MIME type of the translation file
 	Form string
 }
+
+// legacyMediaForms maps GEDCOM 5.5 file formats to the MIME types GEDCOM
+// 7.0 uses in FORM.
+var legacyMediaForms = map[string]string{
+	"bmp":  "image/bmp",
+	"gif":  "image/gif",
+	"jpg":  "image/jpeg",
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"tif":  "image/tiff",
+	"tiff": "image/tiff",
+	"pcx":  "image/x-pcx",
+	"ole":  "application/x-oleobject",
+	"pdf":  "application/pdf",
+	"wav":  "audio/wav",
+	"mp3":  "audio/mpeg",
+	"mp4":  "video/mp4",
+	"avi":  "video/x-msvideo",
+	"mov":  "video/quicktime",
+	"txt":  "text/plain",
+	"htm":  "text/html",
+	"html": "text/html",
+}
+
+// MIMEType returns the file's media type. GEDCOM 7.0 FORM values are MIME
+// types already and are returned lowercased; GEDCOM 5.5 formats such as
+// "jpg" or "TIFF" are mapped to their MIME type. Unknown formats without a
+// slash return "".
+func (f *MediaFile) MIMEType() string {
+	form := strings.ToLower(strings.TrimSpace(f.Form))
+	if strings.Contains(form, "/") {
+		return form
+	}
+	return legacyMediaForms[strings.TrimPrefix(form, ".")]
+}
+
+// MediaRegion is a link from a record to a media object, such as a photo
+// tag marking where an individual appears in a group picture.
+type MediaRegion struct {
+	// RecordXRef is the XRef of the linking individual, family, or source
+	RecordXRef string
+
+	// EventType is the type of the linking event, or empty when the record
+	// links the media object directly
+	EventType EventType
+
+	// Crop is the region of the image the link shows, or nil for the whole
+	// image
+	Crop *CropRegion
+
+	// Title is the link's title
+	Title string
+}
+
+// MediaRegions returns the links to the media object xref from
+// individuals, families, sources, and their events, in record order. Links
+// with a Crop are the photo tags of the image.
+func (d *Document) MediaRegions(xref string) []MediaRegion {
+	var regions []MediaRegion
+	add := func(recordXRef string, eventType EventType, links []*MediaLink) {
+		for _, link := range links {
+			if link != nil && link.MediaXRef == xref {
+				regions = append(regions, MediaRegion{
+					RecordXRef: recordXRef, EventType: eventType, Crop: link.Crop, Title: link.Title,
+				})
+			}
+		}
+	}
+	addEvents := func(recordXRef string, events []*Event) {
+		for _, event := range events {
+			if event != nil {
+				add(recordXRef, event.Type, event.Media)
+			}
+		}
+	}
+
+	for _, record := range d.Records {
+		switch entity := record.LoadEntity().(type) {
+		case *Individual:
+			add(entity.XRef, "", entity.Media)
+			addEvents(entity.XRef, entity.Events)
+		case *Family:
+			add(entity.XRef, "", entity.Media)
+			addEvents(entity.XRef, entity.Events)
+		case *Source:
+			add(entity.XRef, "", entity.Media)
+		}
+	}
+	return regions
+}
@@ -0,0 +1,59 @@
+package gedcom
+
+import "testing"
+
+func TestMediaFileMIMEType(t *testing.T) {
+	tests := []struct {
+		form string
+		want string
+	}{
+		{"image/jpeg", "image/jpeg"},
+		{"Image/PNG", "image/png"},
+		{"jpg", "image/jpeg"},
+		{"JPEG", "image/jpeg"},
+		{".tif", "image/tiff"},
+		{"wav", "audio/wav"},
+		{"pdf", "application/pdf"},
+		{"xyz", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := (&MediaFile{Form: tt.form}).MIMEType(); got != tt.want {
+			t.Errorf("MIMEType(%q) = %q, want %q", tt.form, got, tt.want)
+		}
+	}
+}
+
+func TestDocumentMediaRegions(t *testing.T) {
+	face := &CropRegion{Top: 10, Left: 20, Height: 100, Width: 80}
+	john := &Individual{
+		XRef:  "@I1@",
+		Media: []*MediaLink{{MediaXRef: "@O1@", Crop: face, Title: "John"}, {MediaXRef: "@O2@"}},
+		Events: []*Event{
+			{Type: EventBirth, Media: []*MediaLink{{MediaXRef: "@O1@"}}},
+		},
+	}
+	fam := &Family{
+		XRef:   "@F1@",
+		Events: []*Event{{Type: EventMarriage, Media: []*MediaLink{{MediaXRef: "@O1@", Title: "Wedding"}}}},
+	}
+	doc := createRelationshipTestDocument([]*Individual{john}, []*Family{fam})
+
+	regions := doc.MediaRegions("@O1@")
+	want := []MediaRegion{
+		{RecordXRef: "@I1@", Crop: face, Title: "John"},
+		{RecordXRef: "@I1@", EventType: EventBirth},
+		{RecordXRef: "@F1@", EventType: EventMarriage, Title: "Wedding"},
+	}
+	if len(regions) != len(want) {
+		t.Fatalf("len(MediaRegions) = %d, want %d", len(regions), len(want))
+	}
+	for i := range want {
+		if regions[i] != want[i] {
+			t.Errorf("MediaRegions()[%d] = %+v, want %+v", i, regions[i], want[i])
+		}
+	}
+	if got := doc.MediaRegions("@O9@"); len(got) != 0 {
+		t.Errorf("MediaRegions(@O9@) = %+v, want none", got)
+	}
+}
@@ -0,0 +1,70 @@
+package gedcom
+
+import (
+	"sort"
+	"strings"
+)
+
+// MigrationSegment is an inferred move of an individual between two places,
+// bounded by the last event at the origin and the first event at the
+// destination.
+type MigrationSegment struct {
+	// IndividualXRef is the individual who moved
+	IndividualXRef string
+
+	// FromEvent is the last event recorded at the origin
+	FromEvent *Event
+
+	// ToEvent is the first event recorded at the destination
+	ToEvent *Event
+}
+
+// FromPlace returns the origin place name.
+func (s MigrationSegment) FromPlace() string { return s.FromEvent.Place }
+
+// ToPlace returns the destination place name.
+func (s MigrationSegment) ToPlace() string { return s.ToEvent.Place }
+
+// MigrationSegments infers migration segments for every individual. Each
+// individual's dated and placed events, including events of families in
+// which they are a spouse, are ordered chronologically; a segment is emitted
+// whenever consecutive events change place. Place names are compared
+// case-insensitively after trimming. Events with equal dates keep document
+// order.
+func MigrationSegments(doc *Document) []MigrationSegment {
+	if doc == nil {
+		return nil
+	}
+
+	var segments []MigrationSegment
+	for _, ind := range doc.Individuals() {
+		var events []*Event
+		collect := func(list []*Event) {
+			for _, event := range list {
+				if event.ParsedDate != nil && strings.TrimSpace(event.Place) != "" {
+					events = append(events, event)
+				}
+			}
+		}
+		collect(ind.Events)
+		for _, fam := range ind.SpouseFamilies(doc) {
+			collect(fam.Events)
+		}
+
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].ParsedDate.Compare(events[j].ParsedDate) < 0
+		})
+
+		for i := 1; i < len(events); i++ {
+			prev, cur := events[i-1], events[i]
+			if !strings.EqualFold(strings.TrimSpace(prev.Place), strings.TrimSpace(cur.Place)) {
+				segments = append(segments, MigrationSegment{
+					IndividualXRef: ind.XRef,
+					FromEvent:      prev,
+					ToEvent:        cur,
+				})
+			}
+		}
+	}
+	return segments
+}
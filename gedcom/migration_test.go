@@ -0,0 +1,79 @@
+package gedcom
+
+import (
+	"testing"
+)
+
+func TestCoordinatesDecimal(t *testing.T) {
+	tests := []struct {
+		coords   *Coordinates
+		lat, lon float64
+		ok       bool
+	}{
+		{&Coordinates{Latitude: "N42.3601", Longitude: "W71.0589"}, 42.3601, -71.0589, true},
+		{&Coordinates{Latitude: "S33.8688", Longitude: "E151.2093"}, -33.8688, 151.2093, true},
+		{&Coordinates{Latitude: "51.5", Longitude: "-0.12"}, 51.5, -0.12, true},
+		{&Coordinates{Latitude: "N42.3601"}, 0, 0, false},
+		{&Coordinates{Latitude: "Nabc", Longitude: "W1"}, 0, 0, false},
+		{nil, 0, 0, false},
+	}
+	for _, tt := range tests {
+		lat, lon, ok := tt.coords.Decimal()
+		if ok != tt.ok || (ok && (lat != tt.lat || lon != tt.lon)) {
+			t.Errorf("Decimal(%+v) = %v, %v, %v; want %v, %v, %v", tt.coords, lat, lon, ok, tt.lat, tt.lon, tt.ok)
+		}
+	}
+}
+
+func createMigrationTestDocument() *Document {
+	event := func(typ EventType, date, place string, coords *Coordinates) *Event {
+		parsed, _ := ParseDate(date)
+		e := &Event{Type: typ, Date: date, ParsedDate: parsed, Place: place}
+		if coords != nil {
+			e.PlaceDetail = &PlaceDetail{Name: place, Coordinates: coords}
+		}
+		return e
+	}
+	cork := &Coordinates{Latitude: "N51.8985", Longitude: "W8.4756"}
+	boston := &Coordinates{Latitude: "N42.3601", Longitude: "W71.0589"}
+
+	return createRelationshipTestDocument(
+		[]*Individual{
+			{
+				XRef:             "@I1@",
+				SpouseInFamilies: []string{"@F1@"},
+				Events: []*Event{
+					event(EventDeath, "1930", "Chicago, Illinois", nil),
+					event(EventBirth, "1880", "Cork, Ireland", cork),
+					event(EventBaptism, "1880", "cork, ireland ", nil),
+					event(EventEmigration, "1902", "Cork, Ireland", cork),
+					event(EventResidence, "", "Nowhere", nil),
+				},
+			},
+			{XRef: "@I2@", Events: []*Event{event(EventBirth, "1885", "Boston", nil)}},
+		},
+		[]*Family{{XRef: "@F1@", Husband: "@I1@", Events: []*Event{event(EventMarriage, "1905", "Boston, Massachusetts", boston)}}},
+	)
+}
+
+func TestMigrationSegments(t *testing.T) {
+	segments := MigrationSegments(createMigrationTestDocument())
+	if len(segments) != 2 {
+		t.Fatalf("len(MigrationSegments) = %d, want 2: %+v", len(segments), segments)
+	}
+
+	first := segments[0]
+	if first.IndividualXRef != "@I1@" || first.FromEvent.Type != EventEmigration || first.ToEvent.Type != EventMarriage {
+		t.Errorf("segments[0] = %s %s -> %s", first.IndividualXRef, first.FromEvent.Type, first.ToEvent.Type)
+	}
+	if first.FromPlace() != "Cork, Ireland" || first.ToPlace() != "Boston, Massachusetts" {
+		t.Errorf("segments[0] places = %q -> %q", first.FromPlace(), first.ToPlace())
+	}
+	if second := segments[1]; second.FromPlace() != "Boston, Massachusetts" || second.ToPlace() != "Chicago, Illinois" {
+		t.Errorf("segments[1] places = %q -> %q", second.FromPlace(), second.ToPlace())
+	}
+
+	if got := MigrationSegments(nil); got != nil {
+		t.Errorf("MigrationSegments(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,212 @@
+package gedcom
+
+import "strings"
+
+// NameOrder is the order in which given and family names are written.
+type NameOrder int
+
+const (
+	// GivenNameFirst writes the given name before the family name ("John Smith").
+	GivenNameFirst NameOrder = iota
+
+	// FamilyNameFirst writes the family name before the given name, as in
+	// Hungarian and East Asian conventions ("Bartók Béla").
+	FamilyNameFirst
+)
+
+// familyFirstLanguages lists the languages that write the family name first,
+// by ISO 639-1 code.
+var familyFirstLanguages = map[string]bool{
+	"hu": true, // Hungarian
+	"ja": true, // Japanese
+	"zh": true, // Chinese
+	"ko": true, // Korean
+	"vi": true, // Vietnamese
+	"km": true, // Khmer
+	"mn": true, // Mongolian
+}
+
+// detachedParticleLanguages lists the languages whose surname particles
+// ("van", "von") are sorted after the given name ("Beethoven, Ludwig van")
+// rather than with the surname ("de la Cruz, Juana").
+var detachedParticleLanguages = map[string]bool{
+	"nl": true, // Dutch
+	"de": true, // German
+	"af": true, // Afrikaans
+}
+
+// languageNames maps GEDCOM 5.5 LANG values, which are language names, to
+// ISO 639-1 codes. GEDCOM 7.0 uses BCP 47 tags directly.
+var languageNames = map[string]string{
+	"afrikaans":  "af",
+	"chinese":    "zh",
+	"dutch":      "nl",
+	"english":    "en",
+	"flemish":    "nl",
+	"french":     "fr",
+	"german":     "de",
+	"hungarian":  "hu",
+	"italian":    "it",
+	"japanese":   "ja",
+	"khmer":      "km",
+	"korean":     "ko",
+	"mongolian":  "mn",
+	"portuguese": "pt",
+	"spanish":    "es",
+	"vietnamese": "vi",
+}
+
+// surnameParticles are the words recognized as surname particles when a
+// name has no SPFX value.
+var surnameParticles = map[string]bool{
+	"af": true, "av": true, "da": true, "das": true, "de": true, "del": true,
+	"della": true, "den": true, "der": true, "des": true, "di": true, "do": true, "dos": true,
+	"du": true, "la": true, "le": true, "ten": true, "ter": true, "van": true, "von": true,
+	"zu": true,
+}
+
+// languageCode returns the lowercase primary language subtag of a BCP 47
+// tag ("zh-Hans" -> "zh") or the code of a GEDCOM 5.5 language name
+// ("Hungarian" -> "hu").
+func languageCode(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if code, ok := languageNames[lang]; ok {
+		return code
+	}
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// NameOrderForLanguage returns the name order conventional for a language,
+// given as a BCP 47 tag ("hu", "ja-Latn") or a GEDCOM 5.5 language name
+// ("Hungarian"). Unknown and empty languages use GivenNameFirst.
+func NameOrderForLanguage(lang string) NameOrder {
+	if familyFirstLanguages[languageCode(lang)] {
+		return FamilyNameFirst
+	}
+	return GivenNameFirst
+}
+
+// nameParts holds the components of a personal name used for formatting.
+type nameParts struct {
+	prefix, given, particle, surname, suffix string
+}
+
+// splitName returns the components of name, preferring the GIVN, SURN, SPFX,
+// NPFX, and NSFX values and falling back to the slashes of the full name.
+// Leading particles are split off the surname when SPFX is absent.
+func splitName(name *PersonalName) nameParts {
+	parts := nameParts{
+		prefix:   strings.TrimSpace(name.Prefix),
+		given:    strings.TrimSpace(name.Given),
+		particle: strings.TrimSpace(name.SurnamePrefix),
+		surname:  nameSurname(name),
+		suffix:   strings.TrimSpace(name.Suffix),
+	}
+
+	start := strings.Index(name.Full, "/")
+	end := strings.LastIndex(name.Full, "/")
+	if parts.given == "" {
+		if start >= 0 {
+			parts.given = strings.TrimSpace(name.Full[:start])
+		} else if parts.surname == "" {
+			parts.given = strings.TrimSpace(name.Full)
+		}
+		if parts.prefix != "" {
+			parts.given = strings.TrimSpace(strings.TrimPrefix(parts.given, parts.prefix))
+		}
+	}
+	if parts.suffix == "" && end > start {
+		parts.suffix = strings.TrimSpace(name.Full[end+1:])
+	}
+
+	if parts.particle == "" {
+		words := strings.Fields(parts.surname)
+		n := 0
+		for n < len(words)-1 && surnameParticles[strings.ToLower(words[n])] {
+			n++
+		}
+		if n > 0 {
+			parts.particle = strings.Join(words[:n], " ")
+			parts.surname = strings.Join(words[n:], " ")
+		}
+	} else {
+		parts.surname = strings.TrimSpace(strings.TrimPrefix(parts.surname, parts.particle))
+	}
+	return parts
+}
+
+// FormatName formats a personal name for display in the order conventional
+// for lang (see NameOrderForLanguage). Surname particles stay with the
+// surname: "Ludwig van Beethoven", or "Bartók Béla" for Hungarian. Returns
+// an empty string for a nil name.
+func FormatName(name *PersonalName, lang string) string {
+	if name == nil {
+		return ""
+	}
+	p := splitName(name)
+	family := joinNonEmpty(" ", p.particle, p.surname)
+	if NameOrderForLanguage(lang) == FamilyNameFirst {
+		return joinNonEmpty(" ", p.prefix, family, p.given, p.suffix)
+	}
+	return joinNonEmpty(" ", p.prefix, p.given, family, p.suffix)
+}
+
+// SortName formats a personal name for alphabetical indexes as
+// "Surname, Given". Particles of Dutch, German, and Afrikaans names follow
+// the given name ("Beethoven, Ludwig van"); in other languages they stay
+// with the surname ("de la Cruz, Juana"). Returns an empty string for a nil
+// name.
+func SortName(name *PersonalName, lang string) string {
+	if name == nil {
+		return ""
+	}
+	p := splitName(name)
+	family, given := joinNonEmpty(" ", p.particle, p.surname), p.given
+	if detachedParticleLanguages[languageCode(lang)] {
+		family, given = p.surname, joinNonEmpty(" ", p.given, p.particle)
+	}
+	return joinNonEmpty(", ", family, given, p.suffix)
+}
+
+// nameLanguage returns the individual's LANG, or the document default from
+// the header.
+func (i *Individual) nameLanguage(doc *Document) string {
+	if i.Language != "" {
+		return i.Language
+	}
+	return doc.DefaultLanguage()
+}
+
+// FormattedName formats the individual's primary name for display using
+// FormatName, in the convention of the individual's LANG or, if absent, the
+// document's HEAD.LANG. doc may be nil.
+func (i *Individual) FormattedName(doc *Document) string {
+	if len(i.Names) == 0 {
+		return ""
+	}
+	return FormatName(i.Names[0], i.nameLanguage(doc))
+}
+
+// SortName formats the individual's primary name for alphabetical indexes
+// using SortName, in the convention of the individual's LANG or, if absent,
+// the document's HEAD.LANG. doc may be nil.
+func (i *Individual) SortName(doc *Document) string {
+	if len(i.Names) == 0 {
+		return ""
+	}
+	return SortName(i.Names[0], i.nameLanguage(doc))
+}
+
+// joinNonEmpty joins the non-empty parts with sep.
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
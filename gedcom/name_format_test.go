@@ -0,0 +1,76 @@
+package gedcom
+
+import "testing"
+
+func TestNameOrderForLanguage(t *testing.T) {
+	tests := []struct {
+		lang string
+		want NameOrder
+	}{
+		{"hu", FamilyNameFirst},
+		{"Hungarian", FamilyNameFirst},
+		{"ja-Latn", FamilyNameFirst},
+		{"zh_Hans", FamilyNameFirst},
+		{"en-US", GivenNameFirst},
+		{"Dutch", GivenNameFirst},
+		{"", GivenNameFirst},
+	}
+	for _, tt := range tests {
+		if got := NameOrderForLanguage(tt.lang); got != tt.want {
+			t.Errorf("NameOrderForLanguage(%q) = %v, want %v", tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestFormatName(t *testing.T) {
+	tests := []struct {
+		name     string
+		pn       *PersonalName
+		lang     string
+		wantFull string
+		wantSort string
+	}{
+		{"english", &PersonalName{Full: "John /Smith/ Jr."}, "en", "John Smith Jr.", "Smith, John, Jr."},
+		{"hungarian", &PersonalName{Full: "Béla /Bartók/"}, "Hungarian", "Bartók Béla", "Bartók, Béla"},
+		{"japanese components", &PersonalName{Given: "Akira", Surname: "Kurosawa"}, "ja", "Kurosawa Akira", "Kurosawa, Akira"},
+		{"dutch particle", &PersonalName{Full: "Vincent /van Gogh/"}, "nl", "Vincent van Gogh", "Gogh, Vincent van"},
+		{"german SPFX", &PersonalName{Full: "Ludwig /van Beethoven/", Given: "Ludwig", Surname: "Beethoven", SurnamePrefix: "van"}, "de", "Ludwig van Beethoven", "Beethoven, Ludwig van"},
+		{"spanish particles", &PersonalName{Full: "Juana /de la Cruz/"}, "es", "Juana de la Cruz", "de la Cruz, Juana"},
+		{"particle only surname", &PersonalName{Full: "Anne /De/"}, "en", "Anne De", "De, Anne"},
+		{"prefix", &PersonalName{Full: "Dr. János /Kovács/", Prefix: "Dr."}, "hu", "Dr. Kovács János", "Kovács, János"},
+		{"no surname", &PersonalName{Full: "Cher"}, "", "Cher", "Cher"},
+		{"nil", nil, "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatName(tt.pn, tt.lang); got != tt.wantFull {
+				t.Errorf("FormatName() = %q, want %q", got, tt.wantFull)
+			}
+			if got := SortName(tt.pn, tt.lang); got != tt.wantSort {
+				t.Errorf("SortName() = %q, want %q", got, tt.wantSort)
+			}
+		})
+	}
+}
+
+func TestIndividual_FormattedName(t *testing.T) {
+	doc := &Document{Header: &Header{Language: "Hungarian"}}
+	hungarian := &Individual{Names: []*PersonalName{{Full: "Béla /Bartók/"}}}
+	english := &Individual{Language: "English", Names: []*PersonalName{{Full: "John /Smith/"}}}
+
+	if got := hungarian.FormattedName(doc); got != "Bartók Béla" {
+		t.Errorf("FormattedName() with document default = %q, want %q", got, "Bartók Béla")
+	}
+	if got := hungarian.FormattedName(nil); got != "Béla Bartók" {
+		t.Errorf("FormattedName(nil) = %q, want %q", got, "Béla Bartók")
+	}
+	if got := english.FormattedName(doc); got != "John Smith" {
+		t.Errorf("FormattedName() with individual LANG = %q, want %q", got, "John Smith")
+	}
+	if got := english.SortName(doc); got != "Smith, John" {
+		t.Errorf("SortName() = %q, want %q", got, "Smith, John")
+	}
+	if got := (&Individual{}).FormattedName(doc); got != "" {
+		t.Errorf("FormattedName() without names = %q, want empty", got)
+	}
+}
@@ -1,6 +1,7 @@
 package gedcom
 
-// Note represents a textual note or annotation.
+// Note represents a textual note or annotation: a NOTE record, or a GEDCOM 7.0
+// shared note (SNOTE) record.
 type Note struct {
 	// XRef is the cross-reference identifier for this note
 	XRef string
@@ -11,10 +12,34 @@ type Note struct {
 	// Continuation lines for multi-line notes
 	Continuation []string
 
+	// Language is the language of the note text (LANG, GEDCOM 7.0). Use
+	// EffectiveLanguage to fall back to the document default.
+	Language string
+
+	// MediaType is the media type of the note text (MIME, GEDCOM 7.0), such
+	// as "text/html"; empty means text/plain
+	MediaType string
+
+	// Translations are versions of the note in other languages or media
+	// types (TRAN, GEDCOM 7.0)
+	Translations []*NoteTranslation
+
 	// Tags contains all raw tags for this note (for unknown/custom tags)
 	Tags []*Tag
 }
 
+// NoteTranslation is a translation of a note's text (GEDCOM 7.0 TRAN).
+type NoteTranslation struct {
+	// Text is the translated text, with continuation lines joined by newlines
+	Text string
+
+	// Language is the language of the translation (LANG)
+	Language string
+
+	// MediaType is the media type of the translation (MIME)
+	MediaType string
+}
+
 // FullText returns the complete note text including continuation lines.
 func (n *Note) FullText() string {
 	if len(n.Continuation) == 0 {
@@ -27,3 +52,12 @@ func (n *Note) FullText() string {
 	}
 	return result
 }
+
+// EffectiveLanguage returns the note's LANG or, if absent, the document's
+// default language (see Document.DefaultLanguage). doc may be nil.
+func (n *Note) EffectiveLanguage(doc *Document) string {
+	if n.Language != "" {
+		return n.Language
+	}
+	return doc.DefaultLanguage()
+}
@@ -0,0 +1,106 @@
+package gedcom
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultOccupationVariants maps common historical occupation spellings,
+// abbreviations, and synonyms to a normalized form. Keys are lowercase with
+// single spaces, as produced by OccupationNormalizer before lookup.
+var DefaultOccupationVariants = map[string]string{
+	"ag lab":               "agricultural labourer",
+	"ag. lab.":             "agricultural labourer",
+	"ag. lab":              "agricultural labourer",
+	"agric labourer":       "agricultural labourer",
+	"agricultural laborer": "agricultural labourer",
+	"farm labourer":        "agricultural labourer",
+	"farm laborer":         "agricultural labourer",
+	"husbandman":           "farmer",
+	"yeoman":               "farmer",
+	"planter":              "farmer",
+	"smith":                "blacksmith",
+	"black smith":          "blacksmith",
+	"cordwainer":           "shoemaker",
+	"cordwinder":           "shoemaker",
+	"shoe maker":           "shoemaker",
+	"joyner":               "joiner",
+	"taylor":               "tailor",
+	"tayler":               "tailor",
+	"carpenter & joiner":   "carpenter",
+	"carpenter and joiner": "carpenter",
+	"housewife":            "keeping house",
+	"keeping house":        "keeping house",
+	"at home":              "keeping house",
+	"domestic":             "domestic servant",
+	"servant":              "domestic servant",
+	"serv":                 "domestic servant",
+	"mariner":              "seaman",
+	"sailor":               "seaman",
+	"coal miner":           "miner",
+	"collier":              "miner",
+	"scholar":              "student",
+}
+
+// OccupationNormalizer maps occupation values to a normalized form for
+// grouping. Values are trimmed, lowercased, and have internal whitespace
+// collapsed; the result is then replaced by its dictionary entry, if any.
+type OccupationNormalizer struct {
+	variants map[string]string
+}
+
+// NewOccupationNormalizer creates a normalizer using the given variant
+// dictionary. A nil dictionary uses DefaultOccupationVariants; to extend the
+// defaults, copy them into a new map and add entries. Dictionary keys are
+// normalized the same way as values, so "Ag Lab" and "ag lab" are equivalent;
+// if such keys map to different values, the alphabetically first key wins.
+func NewOccupationNormalizer(variants map[string]string) *OccupationNormalizer {
+	if variants == nil {
+		variants = DefaultOccupationVariants
+	}
+	// Keys that normalize alike keep the value of the alphabetically first
+	// key, independent of map iteration order
+	keys := make([]string, 0, len(variants))
+	for from := range variants {
+		keys = append(keys, from)
+	}
+	sort.Strings(keys)
+	n := &OccupationNormalizer{variants: make(map[string]string, len(variants))}
+	for _, from := range keys {
+		cleaned := cleanOccupation(from)
+		if _, ok := n.variants[cleaned]; !ok {
+			n.variants[cleaned] = variants[from]
+		}
+	}
+	return n
+}
+
+// Normalize returns the normalized form of occupation. Returns an empty
+// string if occupation is blank.
+func (n *OccupationNormalizer) Normalize(occupation string) string {
+	cleaned := cleanOccupation(occupation)
+	if mapped, ok := n.variants[cleaned]; ok {
+		return mapped
+	}
+	return cleaned
+}
+
+// NormalizeDocument sets NormalizedValue on every individual's OCCU
+// attribute. Value is left unchanged, so the original text is preserved.
+func (n *OccupationNormalizer) NormalizeDocument(doc *Document) {
+	if doc == nil {
+		return
+	}
+	for _, indi := range doc.Individuals() {
+		for _, attr := range indi.Attributes {
+			if attr.Type == "OCCU" {
+				attr.NormalizedValue = n.Normalize(attr.Value)
+			}
+		}
+	}
+}
+
+// cleanOccupation trims, lowercases, and collapses whitespace in s.
+func cleanOccupation(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
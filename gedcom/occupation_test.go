@@ -0,0 +1,55 @@
+package gedcom
+
+import "testing"
+
+func TestOccupationNormalizer_Normalize(t *testing.T) {
+	n := NewOccupationNormalizer(nil)
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"  Ag   Lab ", "agricultural labourer"},
+		{"Cordwainer", "shoemaker"},
+		{"Farmer", "farmer"},
+		{"Ship  Carpenter", "ship carpenter"},
+		{"   ", ""},
+	}
+	for _, tt := range tests {
+		if got := n.Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOccupationNormalizer_CustomDictionary(t *testing.T) {
+	n := NewOccupationNormalizer(map[string]string{"Ship Wright": "shipwright"})
+	if got := n.Normalize("ship  wright"); got != "shipwright" {
+		t.Errorf("Normalize() = %q, want shipwright", got)
+	}
+	// Custom dictionaries replace the defaults
+	if got := n.Normalize("Cordwainer"); got != "cordwainer" {
+		t.Errorf("Normalize() = %q, want cordwainer", got)
+	}
+}
+
+func TestOccupationNormalizer_NormalizeDocument(t *testing.T) {
+	doc := createRelationshipTestDocument([]*Individual{{
+		XRef: "@I1@",
+		Attributes: []*Attribute{
+			{Type: "OCCU", Value: "Ag Lab"},
+			{Type: "EDUC", Value: "Ag Lab"},
+		},
+	}}, nil)
+
+	NewOccupationNormalizer(nil).NormalizeDocument(doc)
+
+	attrs := doc.GetIndividual("@I1@").Attributes
+	if attrs[0].Value != "Ag Lab" || attrs[0].NormalizedValue != "agricultural labourer" {
+		t.Errorf("OCCU = %q/%q, want original preserved and normalized", attrs[0].Value, attrs[0].NormalizedValue)
+	}
+	if attrs[1].NormalizedValue != "" {
+		t.Errorf("EDUC NormalizedValue = %q, want empty", attrs[1].NormalizedValue)
+	}
+
+	NewOccupationNormalizer(nil).NormalizeDocument(nil)
+}
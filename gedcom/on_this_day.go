@@ -0,0 +1,88 @@
+package gedcom
+
+import "sort"
+
+// Anniversary is a birth, death, or marriage that fell on the day asked of
+// OnThisDay.
+type Anniversary struct {
+	// RecordXRef is the individual or family record that owns the event
+	RecordXRef string
+
+	// Event is the birth, death, or marriage event
+	Event *Event
+
+	// Year is the year the event happened
+	Year int
+
+	// IsBC is true if Year is B.C.
+	IsBC bool
+}
+
+// OnThisDay returns the births, deaths, and marriages in doc that happened
+// on the given month and day in any year, ordered from the earliest year to
+// the latest and then by document order. Individuals contribute their BIRT
+// and DEAT events and families their MARR events.
+//
+// Only exact dates with a day and month match; approximate, open-ended, and
+// ranged dates are skipped. Hebrew and French Republican dates are converted
+// to Gregorian first, while Julian dates match on the day as recorded.
+func OnThisDay(doc *Document, month, day int) []Anniversary {
+	var result []Anniversary
+	if doc == nil {
+		return result
+	}
+	for _, record := range doc.Records {
+		var xref string
+		var events []*Event
+		var types []EventType
+		switch entity := record.LoadEntity().(type) {
+		case *Individual:
+			xref, events, types = entity.XRef, entity.Events, []EventType{EventBirth, EventDeath}
+		case *Family:
+			xref, events, types = entity.XRef, entity.Events, []EventType{EventMarriage}
+		default:
+			continue
+		}
+
+		for _, event := range events {
+			if !containsEventType(types, event.Type) {
+				continue
+			}
+			date := anniversaryDate(event)
+			if date == nil || date.Month != month || date.Day != day {
+				continue
+			}
+			result = append(result, Anniversary{RecordXRef: xref, Event: event, Year: date.Year, IsBC: date.IsBC})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return AstronomicalYear(result[i].Year, result[i].IsBC) < AstronomicalYear(result[j].Year, result[j].IsBC)
+	})
+	return result
+}
+
+// anniversaryDate returns the event's exact, complete date in the calendar
+// used for matching, or nil if it has none.
+func anniversaryDate(event *Event) *Date {
+	date := event.ParsedDate
+	if date == nil || date.IsPhrase || date.Modifier != ModifierNone || date.Day == 0 || date.Month == 0 {
+		return nil
+	}
+	if date.Calendar != CalendarGregorian && date.Calendar != CalendarJulian {
+		converted, err := date.ToGregorian()
+		if err != nil {
+			return nil
+		}
+		date = converted
+	}
+	return date
+}
+
+func containsEventType(types []EventType, t EventType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOnThisDay(t *testing.T) {
+	event := func(typ EventType, date string) *Event {
+		parsed, _ := ParseDate(date)
+		return &Event{Type: typ, Date: date, ParsedDate: parsed}
+	}
+	doc := createRelationshipTestDocument(
+		[]*Individual{
+			{XRef: "@I1@", Events: []*Event{
+				event(EventBirth, "12 MAR 1890"),
+				event(EventChristening, "12 MAR 1890"),
+				event(EventDeath, "12 MAR 1950"),
+			}},
+			{XRef: "@I2@", Events: []*Event{
+				event(EventBirth, "ABT 12 MAR 1850"),
+				event(EventDeath, "@#DJULIAN@ 12 MAR 1700"),
+			}},
+			{XRef: "@I3@", Events: []*Event{
+				event(EventBirth, "MAR 1800"),
+				event(EventDeath, "BET 12 MAR 1900 AND 13 MAR 1900"),
+			}},
+		},
+		[]*Family{{XRef: "@F1@", Events: []*Event{event(EventMarriage, "12 MAR 1915")}}},
+	)
+
+	var got []string
+	for _, a := range OnThisDay(doc, 3, 12) {
+		got = append(got, a.RecordXRef+" "+string(a.Event.Type))
+		if a.Year == 0 {
+			t.Errorf("%s %s has no year", a.RecordXRef, a.Event.Type)
+		}
+	}
+	want := []string{"@I2@ DEAT", "@I1@ BIRT", "@F1@ MARR", "@I1@ DEAT"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("OnThisDay(3, 12) = %v, want %v", got, want)
+	}
+
+	if len(OnThisDay(doc, 3, 13)) != 0 {
+		t.Error("OnThisDay(3, 13) should skip ranges")
+	}
+	if len(OnThisDay(nil, 3, 12)) != 0 {
+		t.Error("nil document should return no anniversaries")
+	}
+}
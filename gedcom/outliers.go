@@ -0,0 +1,162 @@
+package gedcom
+
+import (
+	"sort"
+)
+
+// DefaultOutlierLimit is the number of entries per category in an
+// OutlierReport when BuildOutlierReport is given a limit of zero.
+const DefaultOutlierLimit = 10
+
+// Outlier is one entry of an OutlierReport category.
+type Outlier struct {
+	// XRef is the individual, family, or source the entry is about
+	XRef string `json:"xref"`
+
+	// Value is the measured quantity: years for lifespans and age gaps,
+	// children for families, and citations for sources
+	Value int `json:"value"`
+
+	// Label is a readable description: the individual's name, the spouses'
+	// names, or the source title
+	Label string `json:"label"`
+}
+
+// OutlierReport lists the extremes of a document, largest first. Besides
+// telling stories, the entries point at likely data errors such as a
+// 140-year lifespan or a marriage between people born 60 years apart.
+type OutlierReport struct {
+	// LongestLifespans are individuals by years between birth and death
+	LongestLifespans []Outlier `json:"longest_lifespans"`
+
+	// LargestFamilies are families by number of children
+	LargestFamilies []Outlier `json:"largest_families"`
+
+	// MarriageAgeGaps are families by years between the spouses' births
+	MarriageAgeGaps []Outlier `json:"marriage_age_gaps"`
+
+	// MostCitedSources are sources by number of citations from individuals,
+	// their events and attributes, and families and their events
+	MostCitedSources []Outlier `json:"most_cited_sources"`
+}
+
+// BuildOutlierReport collects the limit largest entries of each category in
+// doc. A limit of zero uses DefaultOutlierLimit and a negative limit keeps
+// every entry. Entries with equal values keep document order, and
+// individuals without both a birth and a death year (or spouses without a
+// birth year) are skipped.
+func BuildOutlierReport(doc *Document, limit int) *OutlierReport {
+	report := &OutlierReport{}
+	if doc == nil {
+		return report
+	}
+	if limit == 0 {
+		limit = DefaultOutlierLimit
+	}
+
+	citations := make(map[string]int)
+	var sources []string
+	cite := func(list []*SourceCitation) {
+		for _, c := range list {
+			if c == nil || c.SourceXRef == "" {
+				continue
+			}
+			if citations[c.SourceXRef] == 0 {
+				sources = append(sources, c.SourceXRef)
+			}
+			citations[c.SourceXRef]++
+		}
+	}
+	citeEvents := func(events []*Event) {
+		for _, event := range events {
+			cite(event.SourceCitations)
+		}
+	}
+
+	for _, record := range doc.Records {
+		switch entity := record.LoadEntity().(type) {
+		case *Individual:
+			if years, ok := yearsApart(entity.BirthDate(), entity.DeathDate()); ok {
+				report.LongestLifespans = append(report.LongestLifespans,
+					Outlier{XRef: entity.XRef, Value: years, Label: LabelName(entity)})
+			}
+			cite(entity.SourceCitations)
+			citeEvents(entity.Events)
+			for _, attr := range entity.Attributes {
+				cite(attr.SourceCitations)
+			}
+		case *Family:
+			husband, wife := entity.HusbandIndividual(doc), entity.WifeIndividual(doc)
+			label := joinNonEmpty(" & ", LabelName(husband), LabelName(wife))
+			if len(entity.Children) > 0 {
+				report.LargestFamilies = append(report.LargestFamilies,
+					Outlier{XRef: entity.XRef, Value: len(entity.Children), Label: label})
+			}
+			if husband != nil && wife != nil {
+				if years, ok := yearsApart(husband.BirthDate(), wife.BirthDate()); ok {
+					report.MarriageAgeGaps = append(report.MarriageAgeGaps,
+						Outlier{XRef: entity.XRef, Value: years, Label: label})
+				}
+			}
+			cite(entity.SourceCitations)
+			citeEvents(entity.Events)
+		}
+	}
+
+	for _, xref := range sources {
+		var title string
+		if source := doc.GetSource(xref); source != nil {
+			title = source.Title
+		}
+		report.MostCitedSources = append(report.MostCitedSources,
+			Outlier{XRef: xref, Value: citations[xref], Label: title})
+	}
+
+	report.LongestLifespans = topOutliers(report.LongestLifespans, limit)
+	report.LargestFamilies = topOutliers(report.LargestFamilies, limit)
+	report.MarriageAgeGaps = topOutliers(report.MarriageAgeGaps, limit)
+	report.MostCitedSources = topOutliers(report.MostCitedSources, limit)
+	return report
+}
+
+// Relabel replaces the labels of the report's individual and family entries
+// using label, such as LabelNameLifespan; source entries keep their titles.
+// Reports are built with LabelName.
+func (r *OutlierReport) Relabel(doc *Document, label PersonLabel) {
+	if doc == nil || label == nil {
+		return
+	}
+	for i := range r.LongestLifespans {
+		r.LongestLifespans[i].Label = label(doc.GetIndividual(r.LongestLifespans[i].XRef))
+	}
+	for _, entries := range [][]Outlier{r.LargestFamilies, r.MarriageAgeGaps} {
+		for i := range entries {
+			fam := doc.GetFamily(entries[i].XRef)
+			if fam == nil {
+				continue
+			}
+			entries[i].Label = joinNonEmpty(" & ", label(fam.HusbandIndividual(doc)), label(fam.WifeIndividual(doc)))
+		}
+	}
+}
+
+// yearsApart returns the whole years between two dates that have a year.
+func yearsApart(d1, d2 *Date) (int, bool) {
+	if d1 == nil || d2 == nil || d1.IsPhrase || d2.IsPhrase {
+		return 0, false
+	}
+	years, _, err := YearsBetween(d1, d2)
+	return years, err == nil
+}
+
+// topOutliers sorts entries by descending value, keeping document order for
+// ties, and truncates them to limit unless limit is negative.
+func topOutliers(entries []Outlier, limit int) []Outlier {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Value > entries[j].Value
+	})
+	if limit >= 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
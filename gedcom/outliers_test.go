@@ -0,0 +1,60 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func createOutlierTestDocument() *Document {
+	event := func(typ EventType, date string, sources ...string) *Event {
+		parsed, _ := ParseDate(date)
+		e := &Event{Type: typ, Date: date, ParsedDate: parsed}
+		for _, s := range sources {
+			e.SourceCitations = append(e.SourceCitations, &SourceCitation{SourceXRef: s})
+		}
+		return e
+	}
+	doc := createRelationshipTestDocument(
+		[]*Individual{
+			{XRef: "@I1@", Names: []*PersonalName{{Full: "John /Smith/"}}, Events: []*Event{
+				event(EventBirth, "1 JAN 1800", "@S1@"), event(EventDeath, "1 JAN 1950", "@S1@"),
+			}},
+			{XRef: "@I2@", Names: []*PersonalName{{Full: "Mary /Jones/"}}, Events: []*Event{
+				event(EventBirth, "1860", "@S2@"), event(EventDeath, "1920"),
+			}, SourceCitations: []*SourceCitation{{SourceXRef: "@S1@"}}},
+			{XRef: "@I3@", Events: []*Event{event(EventBirth, "1880")}},
+			{XRef: "@I4@", Events: []*Event{event(EventBirth, "(unknown)"), event(EventDeath, "1900")}},
+		},
+		[]*Family{
+			{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@", Children: []string{"@I3@"}},
+			{XRef: "@F2@", Husband: "@I3@", Children: []string{"@I4@", "@I5@"},
+				SourceCitations: []*SourceCitation{{SourceXRef: "@S2@"}}},
+		},
+	)
+	source := &Record{XRef: "@S1@", Type: RecordTypeSource, Entity: &Source{XRef: "@S1@", Title: "Parish register"}}
+	doc.Records = append(doc.Records, source)
+	doc.XRefMap["@S1@"] = source
+	return doc
+}
+
+func TestBuildOutlierReport(t *testing.T) {
+	report := BuildOutlierReport(createOutlierTestDocument(), 0)
+
+	want := &OutlierReport{
+		LongestLifespans: []Outlier{{"@I1@", 150, "John Smith"}, {"@I2@", 60, "Mary Jones"}},
+		LargestFamilies:  []Outlier{{"@F2@", 2, ""}, {"@F1@", 1, "John Smith & Mary Jones"}},
+		MarriageAgeGaps:  []Outlier{{"@F1@", 60, "John Smith & Mary Jones"}},
+		MostCitedSources: []Outlier{{"@S1@", 3, "Parish register"}, {"@S2@", 2, ""}},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("BuildOutlierReport() = %+v, want %+v", report, want)
+	}
+
+	limited := BuildOutlierReport(createOutlierTestDocument(), 1)
+	if len(limited.LongestLifespans) != 1 || len(limited.MostCitedSources) != 1 {
+		t.Errorf("limit 1 = %+v", limited)
+	}
+	if empty := BuildOutlierReport(nil, 0); !reflect.DeepEqual(empty, &OutlierReport{}) {
+		t.Errorf("nil document = %+v", empty)
+	}
+}
@@ -0,0 +1,160 @@
+package gedcom
+
+import (
+	"strings"
+)
+
+// Adopting parent values for Event.AdoptedBy (FAMC.ADOP under an ADOP event).
+const (
+	AdoptedByHusband = "HUSB"
+	AdoptedByWife    = "WIFE"
+	AdoptedByBoth    = "BOTH"
+)
+
+// Parent types reported in ParentLink.ParentType.
+const (
+	// ParentTypeHusband is the husband of a family the child belongs to.
+	ParentTypeHusband = "HUSB"
+
+	// ParentTypeWife is the wife of a family the child belongs to.
+	ParentTypeWife = "WIFE"
+
+	// ParentTypeAdoptiveHusband is a husband who adopted the child.
+	ParentTypeAdoptiveHusband = "ADOP_HUSB"
+
+	// ParentTypeAdoptiveWife is a wife who adopted the child.
+	ParentTypeAdoptiveWife = "ADOP_WIFE"
+)
+
+// Linkage values reported in ParentLink.Linkage.
+const (
+	LinkageBirth   = "birth"
+	LinkageAdopted = "adopted"
+	LinkageFoster  = "foster"
+	LinkageSealing = "sealing"
+)
+
+// ParentLink is a single child-to-parent relationship derived from a
+// family's HUSB/WIFE and the child's FAMC links and ADOP events.
+type ParentLink struct {
+	// ChildXRef is the child individual
+	ChildXRef string
+
+	// ParentXRef is the parent individual
+	ParentXRef string
+
+	// FamilyXRef is the family through which the link exists
+	FamilyXRef string
+
+	// ParentType is one of the ParentType constants
+	ParentType string
+
+	// Pedigree is the FAMC PEDI value, if any (e.g., "birth", "adopted", "foster")
+	Pedigree string
+
+	// Linkage is the kind of link in lowercase: LinkageAdopted for an
+	// adoptive parent, and otherwise the pedigree (LinkageBirth,
+	// LinkageFoster, LinkageSealing, or another PEDI value such as "other").
+	// It is empty when no pedigree is recorded, and for the non-adopting
+	// spouse of a family whose pedigree is "adopted".
+	Linkage string
+
+	// Status is the FAMC STAT value, if any (e.g., "challenged", "proven")
+	Status string
+}
+
+// ParentLinks returns every child-to-parent link in the document, in
+// individual order and then family order.
+//
+// A parent is adoptive when the child's FAMC link has pedigree "adopted", or
+// when the child has an ADOP event pointing to the family. In the latter case
+// the event's AdoptedBy limits which spouse is adoptive; an unspecified value
+// is treated as both. Families referenced only from an ADOP event are included.
+func ParentLinks(doc *Document) []ParentLink {
+	if doc == nil {
+		return nil
+	}
+
+	var links []ParentLink
+	for _, ind := range doc.Individuals() {
+		adoptions := make(map[string]string)
+		var adoptionOrder []string
+		for _, event := range ind.Events {
+			if event.Type != EventAdoption || event.FamilyXRef == "" {
+				continue
+			}
+			if _, seen := adoptions[event.FamilyXRef]; !seen {
+				adoptionOrder = append(adoptionOrder, event.FamilyXRef)
+			}
+			adoptions[event.FamilyXRef] = event.AdoptedBy
+		}
+
+		familyLinks := ind.ChildInFamilies
+		for _, famXRef := range adoptionOrder {
+			if !hasFamilyLink(familyLinks, famXRef) {
+				familyLinks = append(familyLinks, FamilyLink{FamilyXRef: famXRef})
+			}
+		}
+
+		for _, famLink := range familyLinks {
+			fam := doc.GetFamily(famLink.FamilyXRef)
+			if fam == nil {
+				continue
+			}
+
+			adoptedBy, adopted := adoptions[famLink.FamilyXRef]
+			if strings.EqualFold(famLink.Pedigree, "adopted") && !adopted {
+				adoptedBy, adopted = AdoptedByBoth, true
+			}
+
+			for _, parent := range []struct {
+				xref, role, adoptiveType string
+			}{
+				{fam.Husband, AdoptedByHusband, ParentTypeAdoptiveHusband},
+				{fam.Wife, AdoptedByWife, ParentTypeAdoptiveWife},
+			} {
+				if parent.xref == "" {
+					continue
+				}
+				parentType := parent.role
+				linkage := strings.ToLower(famLink.Pedigree)
+				if adopted && isAdoptingParent(adoptedBy, parent.role) {
+					parentType, linkage = parent.adoptiveType, LinkageAdopted
+				} else if linkage == LinkageAdopted {
+					linkage = ""
+				}
+				links = append(links, ParentLink{
+					ChildXRef:  ind.XRef,
+					ParentXRef: parent.xref,
+					FamilyXRef: famLink.FamilyXRef,
+					ParentType: parentType,
+					Pedigree:   famLink.Pedigree,
+					Linkage:    linkage,
+					Status:     famLink.Status,
+				})
+			}
+		}
+	}
+	return links
+}
+
+// hasFamilyLink reports whether links contains a link to famXRef.
+func hasFamilyLink(links []FamilyLink, famXRef string) bool {
+	for _, link := range links {
+		if link.FamilyXRef == famXRef {
+			return true
+		}
+	}
+	return false
+}
+
+// isAdoptingParent reports whether the spouse with the given role (HUSB or
+// WIFE) adopted the child according to an ADOP value.
+func isAdoptingParent(adoptedBy, role string) bool {
+	switch strings.ToUpper(adoptedBy) {
+	case "", AdoptedByBoth:
+		return true
+	default:
+		return strings.EqualFold(adoptedBy, role)
+	}
+}
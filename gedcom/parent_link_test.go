@@ -0,0 +1,73 @@
+package gedcom
+
+import (
+	"testing"
+)
+
+func createParentLinkTestDocument() *Document {
+	birth := &Family{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@"}
+	stepAdoption := &Family{XRef: "@F2@", Husband: "@I3@", Wife: "@I2@"}
+	foster := &Family{XRef: "@F3@", Husband: "@I6@", Wife: "@I7@"}
+
+	child := &Individual{
+		XRef: "@I4@",
+		ChildInFamilies: []FamilyLink{
+			{FamilyXRef: "@F1@", Pedigree: "birth"},
+			{FamilyXRef: "@F2@"},
+		},
+		Events: []*Event{
+			{Type: EventAdoption, FamilyXRef: "@F2@", AdoptedBy: AdoptedByHusband},
+		},
+	}
+	adoptee := &Individual{
+		XRef: "@I5@",
+		Events: []*Event{
+			{Type: EventAdoption, FamilyXRef: "@F3@"},
+		},
+	}
+	pedigreeOnly := &Individual{
+		XRef:            "@I8@",
+		ChildInFamilies: []FamilyLink{{FamilyXRef: "@F3@", Pedigree: "Adopted"}},
+	}
+	fostered := &Individual{
+		XRef:            "@I9@",
+		ChildInFamilies: []FamilyLink{{FamilyXRef: "@F3@", Pedigree: "FOSTER", Status: "proven"}},
+	}
+
+	return createRelationshipTestDocument(
+		[]*Individual{
+			{XRef: "@I1@"}, {XRef: "@I2@"}, {XRef: "@I3@"}, child, adoptee,
+			{XRef: "@I6@"}, {XRef: "@I7@"}, pedigreeOnly, fostered,
+		},
+		[]*Family{birth, stepAdoption, foster},
+	)
+}
+
+func TestParentLinks(t *testing.T) {
+	links := ParentLinks(createParentLinkTestDocument())
+
+	want := []ParentLink{
+		{ChildXRef: "@I4@", ParentXRef: "@I1@", FamilyXRef: "@F1@", ParentType: ParentTypeHusband, Pedigree: "birth", Linkage: LinkageBirth},
+		{ChildXRef: "@I4@", ParentXRef: "@I2@", FamilyXRef: "@F1@", ParentType: ParentTypeWife, Pedigree: "birth", Linkage: LinkageBirth},
+		{ChildXRef: "@I4@", ParentXRef: "@I3@", FamilyXRef: "@F2@", ParentType: ParentTypeAdoptiveHusband, Linkage: LinkageAdopted},
+		{ChildXRef: "@I4@", ParentXRef: "@I2@", FamilyXRef: "@F2@", ParentType: ParentTypeWife},
+		{ChildXRef: "@I5@", ParentXRef: "@I6@", FamilyXRef: "@F3@", ParentType: ParentTypeAdoptiveHusband, Linkage: LinkageAdopted},
+		{ChildXRef: "@I5@", ParentXRef: "@I7@", FamilyXRef: "@F3@", ParentType: ParentTypeAdoptiveWife, Linkage: LinkageAdopted},
+		{ChildXRef: "@I8@", ParentXRef: "@I6@", FamilyXRef: "@F3@", ParentType: ParentTypeAdoptiveHusband, Pedigree: "Adopted", Linkage: LinkageAdopted},
+		{ChildXRef: "@I8@", ParentXRef: "@I7@", FamilyXRef: "@F3@", ParentType: ParentTypeAdoptiveWife, Pedigree: "Adopted", Linkage: LinkageAdopted},
+		{ChildXRef: "@I9@", ParentXRef: "@I6@", FamilyXRef: "@F3@", ParentType: ParentTypeHusband, Pedigree: "FOSTER", Linkage: LinkageFoster, Status: "proven"},
+		{ChildXRef: "@I9@", ParentXRef: "@I7@", FamilyXRef: "@F3@", ParentType: ParentTypeWife, Pedigree: "FOSTER", Linkage: LinkageFoster, Status: "proven"},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("len(ParentLinks) = %d, want %d: %+v", len(links), len(want), links)
+	}
+	for i := range want {
+		if links[i] != want[i] {
+			t.Errorf("links[%d] = %+v, want %+v", i, links[i], want[i])
+		}
+	}
+
+	if got := ParentLinks(nil); got != nil {
+		t.Errorf("ParentLinks(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,82 @@
+package gedcom
+
+import (
+	"sort"
+	"strings"
+)
+
+// PhoneticIndexEntry is an individual's surname with its phonetic codes.
+type PhoneticIndexEntry struct {
+	// IndividualXRef is the indexed individual
+	IndividualXRef string
+
+	// Surname is one of the individual's surnames
+	Surname string
+
+	// Given is the given name of the name carrying Surname
+	Given string
+
+	// Soundex is the American Soundex code of Surname
+	Soundex string
+
+	// DaitchMokotoff are the Daitch-Mokotoff codes of Surname
+	DaitchMokotoff []string
+}
+
+// PhoneticIndex returns an entry for every distinct surname of every
+// individual, including alternate and married names, sorted by Soundex code,
+// then surname, then individual XRef. Surnames without codable letters are
+// omitted.
+func PhoneticIndex(doc *Document) []PhoneticIndexEntry {
+	if doc == nil {
+		return nil
+	}
+
+	var entries []PhoneticIndexEntry
+	for _, ind := range doc.Individuals() {
+		seen := make(map[string]bool)
+		for _, name := range ind.Names {
+			surname := nameSurname(name)
+			key := strings.ToLower(surname)
+			if surname == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			soundex := Soundex(surname)
+			if soundex == "" {
+				continue
+			}
+			entries = append(entries, PhoneticIndexEntry{
+				IndividualXRef: ind.XRef,
+				Surname:        surname,
+				Given:          nameGiven(name),
+				Soundex:        soundex,
+				DaitchMokotoff: DaitchMokotoff(surname),
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Soundex != entries[j].Soundex {
+			return entries[i].Soundex < entries[j].Soundex
+		}
+		if entries[i].Surname != entries[j].Surname {
+			return entries[i].Surname < entries[j].Surname
+		}
+		return entries[i].IndividualXRef < entries[j].IndividualXRef
+	})
+	return entries
+}
+
+// nameGiven returns the GIVN value of name or, if absent, the part of the
+// full name before the surname.
+func nameGiven(name *PersonalName) string {
+	if name.Given != "" {
+		return strings.TrimSpace(name.Given)
+	}
+	if idx := strings.Index(name.Full, "/"); idx != -1 {
+		return strings.TrimSpace(name.Full[:idx])
+	}
+	return strings.TrimSpace(name.Full)
+}
@@ -0,0 +1,63 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDaitchMokotoff(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"Peters", []string{"734000", "739400"}},
+		{"Moskowitz", []string{"645740"}},
+		{"Auerbach", []string{"097400", "097500"}},
+		{"Schwarzenegger", []string{"474659", "479465"}},
+		{"Kleinman", []string{"586660"}},
+		{"Jackson", []string{"145460", "154600", "445460", "454600"}},
+		{"Müller", []string{"689000"}},
+		{"", nil},
+		{"123", nil},
+	}
+	for _, tt := range tests {
+		if got := DaitchMokotoff(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("DaitchMokotoff(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func createPhoneticTestDocument() *Document {
+	birth := &Event{Type: EventBirth, Date: "3 MAR 1880"}
+	return createRelationshipTestDocument([]*Individual{
+		{
+			XRef:   "@I1@",
+			Names:  []*PersonalName{{Full: "Anna /Peters/"}, {Full: "Anna /Moskowitz/"}, {Full: "Anna /peters/"}},
+			Events: []*Event{birth},
+		},
+		{XRef: "@I2@", Names: []*PersonalName{{Given: "Max", Surname: "Moskowitz"}}},
+		{XRef: "@I3@", Names: []*PersonalName{{Full: "Unknown"}}},
+	}, nil)
+}
+
+func TestPhoneticIndex(t *testing.T) {
+	entries := PhoneticIndex(createPhoneticTestDocument())
+	if len(entries) != 3 {
+		t.Fatalf("len(PhoneticIndex) = %d, want 3: %+v", len(entries), entries)
+	}
+
+	// Sorted by Soundex: M232 (Moskowitz) before P362 (Peters)
+	if e := entries[0]; e.IndividualXRef != "@I1@" || e.Surname != "Moskowitz" || e.Soundex != "M232" {
+		t.Errorf("entries[0] = %+v", e)
+	}
+	if e := entries[1]; e.IndividualXRef != "@I2@" || e.Given != "Max" {
+		t.Errorf("entries[1] = %+v", e)
+	}
+	if e := entries[2]; e.Surname != "Peters" || e.Given != "Anna" || len(e.DaitchMokotoff) != 2 {
+		t.Errorf("entries[2] = %+v", e)
+	}
+
+	if got := PhoneticIndex(nil); got != nil {
+		t.Errorf("PhoneticIndex(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,185 @@
+package gedcom
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Product identifies a specific program that produces GEDCOM files.
+// Products are finer-grained than vendors: Family Tree Maker and Ancestry's
+// online trees are distinct products of the same vendor.
+type Product string
+
+// Known GEDCOM producing programs. ProductUnknown is returned when HEAD.SOUR
+// does not match a known program.
+const (
+	// ProductUnknown indicates the producing program could not be identified.
+	ProductUnknown Product = ""
+
+	// ProductAncestry represents Ancestry.com online family trees.
+	ProductAncestry Product = "ancestry"
+
+	// ProductFamilyTreeMaker represents Family Tree Maker desktop software.
+	ProductFamilyTreeMaker Product = "familytreemaker"
+
+	// ProductFamilySearch represents the FamilySearch Family Tree.
+	ProductFamilySearch Product = "familysearch"
+
+	// ProductPAF represents Personal Ancestral File.
+	ProductPAF Product = "paf"
+
+	// ProductRootsMagic represents RootsMagic.
+	ProductRootsMagic Product = "rootsmagic"
+
+	// ProductLegacy represents Legacy Family Tree.
+	ProductLegacy Product = "legacy"
+
+	// ProductGramps represents Gramps.
+	ProductGramps Product = "gramps"
+
+	// ProductMyHeritage represents MyHeritage and Family Tree Builder.
+	ProductMyHeritage Product = "myheritage"
+
+	// ProductMacFamilyTree represents MacFamilyTree.
+	ProductMacFamilyTree Product = "macfamilytree"
+
+	// ProductReunion represents Reunion for Mac.
+	ProductReunion Product = "reunion"
+
+	// ProductWebtrees represents webtrees.
+	ProductWebtrees Product = "webtrees"
+
+	// ProductHeredis represents Heredis.
+	ProductHeredis Product = "heredis"
+
+	// ProductGeneWeb represents GeneWeb.
+	ProductGeneWeb Product = "geneweb"
+)
+
+// productPatterns maps lowercase substrings of HEAD.SOUR and HEAD.SOUR.NAME
+// to products. Order matters: more specific patterns come first.
+var productPatterns = []struct {
+	pattern string
+	product Product
+}{
+	{"familytreemaker", ProductFamilyTreeMaker},
+	{"family tree maker", ProductFamilyTreeMaker},
+	{"ancestry", ProductAncestry},
+	{"family tree builder", ProductMyHeritage},
+	{"myheritage", ProductMyHeritage},
+	{"familysearch", ProductFamilySearch},
+	{"personal ancestral file", ProductPAF},
+	{"rootsmagic", ProductRootsMagic},
+	{"legacy", ProductLegacy},
+	{"gramps", ProductGramps},
+	{"macfamilytree", ProductMacFamilyTree},
+	{"reunion", ProductReunion},
+	{"webtrees", ProductWebtrees},
+	{"heredis", ProductHeredis},
+	{"geneweb", ProductGeneWeb},
+}
+
+// productIDs maps HEAD.SOUR system IDs that are too short for substring
+// matching. They are compared case-insensitively against the first word.
+var productIDs = map[string]Product{
+	"ftm":  ProductFamilyTreeMaker,
+	"ftw":  ProductFamilyTreeMaker,
+	"paf":  ProductPAF,
+	"fsft": ProductFamilySearch,
+}
+
+var productVersionPattern = regexp.MustCompile(`\d+(?:\.\d+)*`)
+
+// DetectProduct identifies the producing program from the HEAD.SOUR system ID
+// and the optional HEAD.SOUR.NAME product name.
+// Returns ProductUnknown if neither is recognized.
+func DetectProduct(systemID, name string) Product {
+	if fields := strings.Fields(strings.ToLower(systemID)); len(fields) > 0 {
+		if p, ok := productIDs[fields[0]]; ok {
+			return p
+		}
+	}
+
+	lower := strings.ToLower(systemID + " " + name)
+	for _, pp := range productPatterns {
+		if strings.Contains(lower, pp.pattern) {
+			return pp.product
+		}
+	}
+	return ProductUnknown
+}
+
+// Vendor returns the vendor that publishes the product.
+// Returns VendorUnknown for products without a corresponding Vendor constant.
+func (p Product) Vendor() Vendor {
+	switch p {
+	case ProductAncestry, ProductFamilyTreeMaker:
+		return VendorAncestry
+	case ProductFamilySearch, ProductPAF:
+		return VendorFamilySearch
+	case ProductRootsMagic:
+		return VendorRootsMagic
+	case ProductLegacy:
+		return VendorLegacy
+	case ProductGramps:
+		return VendorGramps
+	case ProductMyHeritage:
+		return VendorMyHeritage
+	default:
+		return VendorUnknown
+	}
+}
+
+// String returns the string representation of the product.
+// For ProductUnknown, it returns "unknown".
+func (p Product) String() string {
+	if p == ProductUnknown {
+		return "unknown"
+	}
+	return string(p)
+}
+
+// IsKnown returns true if the product is not ProductUnknown.
+func (p Product) IsKnown() bool {
+	return p != ProductUnknown
+}
+
+// SourceProduct describes the program that produced a GEDCOM file, as
+// recorded in the HEAD.SOUR structure.
+type SourceProduct struct {
+	// Product is the normalized producing program
+	Product Product
+
+	// Version is the product version from HEAD.SOUR.VERS, or a version number
+	// found in the product name when VERS is absent
+	Version string
+
+	// SystemID is the raw HEAD.SOUR value
+	SystemID string
+
+	// Name is the product name from HEAD.SOUR.NAME
+	Name string
+
+	// Corporation is the publisher from HEAD.SOUR.CORP
+	Corporation string
+}
+
+// SourceProduct returns the normalized producing program and version parsed
+// from HEAD.SOUR and its VERS, NAME, and CORP subordinates.
+func (h *Header) SourceProduct() SourceProduct {
+	if h == nil {
+		return SourceProduct{}
+	}
+
+	sp := SourceProduct{
+		Product:     DetectProduct(h.SourceSystem, h.SourceName),
+		Version:     h.SourceVersion,
+		SystemID:    h.SourceSystem,
+		Name:        h.SourceName,
+		Corporation: h.SourceCorporation,
+	}
+	if sp.Version == "" {
+		sp.Version = productVersionPattern.FindString(h.SourceName)
+	}
+	return sp
+}
@@ -0,0 +1,108 @@
+package gedcom
+
+import "testing"
+
+func TestDetectProduct(t *testing.T) {
+	tests := []struct {
+		name     string
+		systemID string
+		prodName string
+		want     Product
+	}{
+		{"empty", "", "", ProductUnknown},
+		{"unrecognized", "GEDitCOM", "", ProductUnknown},
+		{"FTM system ID", "FTM", "Family Tree Maker for Windows", ProductFamilyTreeMaker},
+		{"FTW system ID lowercase", "ftw", "", ProductFamilyTreeMaker},
+		{"Ancestry online", "Ancestry.com Family Trees", "Ancestry.com Member Trees", ProductAncestry},
+		{"FTM by name only", "XYZ", "Family Tree Maker", ProductFamilyTreeMaker},
+		{"PAF with version", "PAF 2.2", "", ProductPAF},
+		{"FamilySearch tree", "FSFT", "FamilySearch Family Tree", ProductFamilySearch},
+		{"MyHeritage", "MYHERITAGE", "MyHeritage Family Tree Builder", ProductMyHeritage},
+		{"RootsMagic", "RootsMagic", "", ProductRootsMagic},
+		{"Legacy", "Legacy", "Legacy Family Tree", ProductLegacy},
+		{"Gramps", "Gramps", "", ProductGramps},
+		{"MacFamilyTree", "MacFamilyTree", "", ProductMacFamilyTree},
+		{"Reunion", "Reunion", "", ProductReunion},
+		{"webtrees", "webtrees", "", ProductWebtrees},
+		{"Heredis", "HEREDIS 2021 PC", "", ProductHeredis},
+		{"GeneWeb", "GeneWeb", "", ProductGeneWeb},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectProduct(tt.systemID, tt.prodName); got != tt.want {
+				t.Errorf("DetectProduct(%q, %q) = %q, want %q", tt.systemID, tt.prodName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductVendor(t *testing.T) {
+	tests := []struct {
+		product Product
+		want    Vendor
+	}{
+		{ProductFamilyTreeMaker, VendorAncestry},
+		{ProductAncestry, VendorAncestry},
+		{ProductPAF, VendorFamilySearch},
+		{ProductFamilySearch, VendorFamilySearch},
+		{ProductRootsMagic, VendorRootsMagic},
+		{ProductLegacy, VendorLegacy},
+		{ProductGramps, VendorGramps},
+		{ProductMyHeritage, VendorMyHeritage},
+		{ProductReunion, VendorUnknown},
+		{ProductUnknown, VendorUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := tt.product.Vendor(); got != tt.want {
+			t.Errorf("%s.Vendor() = %q, want %q", tt.product, got, tt.want)
+		}
+	}
+}
+
+func TestProductString(t *testing.T) {
+	if got := ProductUnknown.String(); got != "unknown" {
+		t.Errorf("ProductUnknown.String() = %q, want %q", got, "unknown")
+	}
+	if ProductUnknown.IsKnown() {
+		t.Error("ProductUnknown.IsKnown() = true")
+	}
+	if got := ProductRootsMagic.String(); got != "rootsmagic" {
+		t.Errorf("ProductRootsMagic.String() = %q", got)
+	}
+	if !ProductRootsMagic.IsKnown() {
+		t.Error("ProductRootsMagic.IsKnown() = false")
+	}
+}
+
+func TestHeaderSourceProduct(t *testing.T) {
+	h := &Header{
+		SourceSystem:      "FTM",
+		SourceVersion:     "24.0",
+		SourceName:        "Family Tree Maker for Windows",
+		SourceCorporation: "The Software MacKiev Company",
+	}
+	sp := h.SourceProduct()
+	want := SourceProduct{
+		Product:     ProductFamilyTreeMaker,
+		Version:     "24.0",
+		SystemID:    "FTM",
+		Name:        "Family Tree Maker for Windows",
+		Corporation: "The Software MacKiev Company",
+	}
+	if sp != want {
+		t.Errorf("SourceProduct() = %+v, want %+v", sp, want)
+	}
+
+	// Version falls back to a number in the product name
+	h = &Header{SourceSystem: "RootsMagic", SourceName: "RootsMagic 8.2.0"}
+	if sp := h.SourceProduct(); sp.Version != "8.2.0" {
+		t.Errorf("SourceProduct().Version = %q, want %q", sp.Version, "8.2.0")
+	}
+
+	var nilHeader *Header
+	if sp := nilHeader.SourceProduct(); sp.Product != ProductUnknown {
+		t.Errorf("nil header SourceProduct() = %+v", sp)
+	}
+}
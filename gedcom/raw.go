@@ -0,0 +1,64 @@
+package gedcom
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RawDocument holds the original text of the parts of a file that are not
+// records, retained when decoding with PreserveRaw so that an unmodified
+// document re-encodes byte for byte. Each line includes its original line
+// terminator; record lines are kept in Record.Raw.
+type RawDocument struct {
+	// BOM is true if the file started with a UTF-8 byte order mark
+	BOM bool
+
+	// Header holds the lines from HEAD up to the first record. Set it to nil
+	// after editing Header so that the encoder writes the typed fields.
+	Header []string
+
+	// Trailer holds the lines from TRLR to the end of the file
+	Trailer []string
+}
+
+// RawRecord returns the source text of the record with the given XRef, such
+// as for bug reports or a "view GEDCOM source" pane, or false if there is no
+// such record. The record starts at line Record.LineNumber of the source.
+//
+// When the document was decoded with PreserveRaw, the text is the record's
+// original lines, byte for byte, with their line terminators. Otherwise it
+// is rebuilt from the parsed lines in Record.Tags, one line per tag ending
+// in "\n": it shows what was parsed, including any edits made to the tags
+// since, but not the original spacing, line endings, or character set.
+func (d *Document) RawRecord(xref string) (string, bool) {
+	record := d.GetRecord(xref)
+	if record == nil {
+		return "", false
+	}
+	if record.Raw != nil {
+		return strings.Join(record.Raw, ""), true
+	}
+
+	var sb strings.Builder
+	sb.WriteString("0 ")
+	if record.XRef != "" {
+		sb.WriteString(record.XRef + " ")
+	}
+	sb.WriteString(string(record.Type))
+	if record.Value != "" {
+		sb.WriteString(" " + record.Value)
+	}
+	sb.WriteByte('\n')
+	for _, tag := range record.Tags {
+		sb.WriteString(strconv.Itoa(tag.Level) + " ")
+		if tag.XRef != "" {
+			sb.WriteString(tag.XRef + " ")
+		}
+		sb.WriteString(tag.Tag)
+		if tag.Value != "" {
+			sb.WriteString(" " + tag.Value)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String(), true
+}
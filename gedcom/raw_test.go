@@ -0,0 +1,27 @@
+package gedcom
+
+import "testing"
+
+func TestRawRecordRebuilt(t *testing.T) {
+	note := &Record{XRef: "@N1@", Type: RecordTypeNote, Value: "First line", Tags: []*Tag{
+		{Level: 1, Tag: "CONT", Value: "second line"},
+		{Level: 1, Tag: "CHAN"},
+		{Level: 2, Tag: "DATE", Value: "1 JAN 2000"},
+	}}
+	doc := &Document{Records: []*Record{note}, XRefMap: map[string]*Record{"@N1@": note}}
+
+	text, ok := doc.RawRecord("@N1@")
+	want := "0 @N1@ NOTE First line\n1 CONT second line\n1 CHAN\n2 DATE 1 JAN 2000\n"
+	if !ok || text != want {
+		t.Errorf("RawRecord() = %q, %v, want %q", text, ok, want)
+	}
+
+	note.Raw = []string{"0 @N1@ NOTE First line\r\n", "1 CONT second line\r\n"}
+	if text, _ := doc.RawRecord("@N1@"); text != "0 @N1@ NOTE First line\r\n1 CONT second line\r\n" {
+		t.Errorf("RawRecord() with Raw = %q", text)
+	}
+
+	if _, ok := (&Document{}).RawRecord("@N1@"); ok {
+		t.Error("RawRecord() on an empty document should return false")
+	}
+}
@@ -19,6 +19,10 @@ const (
 	// RecordTypeNote represents a note (NOTE)
 	RecordTypeNote RecordType = "NOTE"
 
+	// RecordTypeSharedNote represents a GEDCOM 7.0 shared note (SNOTE), which
+	// replaces the NOTE record and decodes to a Note like it
+	RecordTypeSharedNote RecordType = "SNOTE"
+
 	// RecordTypeMedia represents a multimedia object (OBJE)
 	RecordTypeMedia RecordType = "OBJE"
 
@@ -26,6 +30,19 @@ const (
 	RecordTypeSubmitter RecordType = "SUBM"
 )
 
+// IsStandard returns true if the record type is defined by GEDCOM 5.5, 5.5.1,
+// or 7.0, including types without a typed entity (SUBN). Other types,
+// such as vendor records like RootsMagic's _PLC, are kept as generic records
+// with their full tag subtree unless DecodeOptions says otherwise.
+func (t RecordType) IsStandard() bool {
+	switch t {
+	case RecordTypeIndividual, RecordTypeFamily, RecordTypeSource, RecordTypeRepository,
+		RecordTypeNote, RecordTypeSharedNote, RecordTypeMedia, RecordTypeSubmitter, "SUBN":
+		return true
+	}
+	return false
+}
+
 // Record represents a top-level GEDCOM record with a cross-reference identifier.
 // Records are the main entities in a GEDCOM file (individuals, families, sources, etc.).
 type Record struct {
@@ -44,9 +61,76 @@ type Record struct {
 	// LineNumber is the line number where the record starts
 	LineNumber int
 
+	// Raw holds the record's original lines, each with its line terminator,
+	// when the document was decoded with PreserveRaw. The encoder writes them
+	// verbatim as long as neither Tags nor Entity is modified.
+	Raw []string
+
 	// Parsed entity (one of: Individual, Family, Source, Repository, Note, MediaObject)
-	// Will be populated during decoding based on the Type
+	// Will be populated during decoding based on the Type, or on first access
+	// through LoadEntity when decoding with LazyEntities
 	Entity interface{}
+
+	// Extensions are typed values built from extension tags anywhere in the
+	// record by handlers registered in DecodeOptions.Extensions
+	Extensions []Extension
+
+	// entityBuilder builds Entity on first access (see SetEntityBuilder)
+	entityBuilder func(*Record)
+
+	// Sync state between Tags and Entity (see MarkEntityModified)
+	tagsModified   bool
+	entityModified bool
+}
+
+// MarkEntityModified records that Entity was edited directly, making it the
+// authoritative representation. The encoder then regenerates the record's
+// tags from the entity instead of writing the stale raw tags. Tags not
+// modeled by the entity (such as unknown vendor extensions) are not written.
+func (r *Record) MarkEntityModified() {
+	r.entityModified = true
+}
+
+// EntityModified returns true if Entity was marked as edited since decoding
+// or the last ClearModified.
+func (r *Record) EntityModified() bool {
+	return r.entityModified
+}
+
+// TagsModified returns true if Tags were edited through SetTag, AddTag, or
+// RemoveTag since decoding or the last ClearModified. Entity no longer
+// reflects the tags until it is refreshed (see decoder.RefreshEntity).
+func (r *Record) TagsModified() bool {
+	return r.tagsModified
+}
+
+// ClearModified resets the sync state after Tags and Entity were brought
+// back in agreement.
+func (r *Record) ClearModified() {
+	r.tagsModified = false
+	r.entityModified = false
+}
+
+// SetEntityBuilder defers building Entity until it is first requested
+// through LoadEntity or a typed accessor such as GetIndividual. The decoder
+// uses it for lazy entity construction. Records with a pending builder are
+// not safe for concurrent first access; call Document.LoadEntities before
+// sharing the document between goroutines.
+func (r *Record) SetEntityBuilder(build func(*Record)) {
+	r.entityBuilder = build
+}
+
+// LoadEntity returns Entity, building it first if it is still pending.
+// Prefer it to reading Entity directly on documents decoded with
+// LazyEntities.
+func (r *Record) LoadEntity() interface{} {
+	if build := r.entityBuilder; build != nil {
+		r.entityBuilder = nil
+		if r.Entity == nil {
+			build(r)
+		}
+	}
+	return r.Entity
 }
 
 // IsIndividual returns true if this record is an individual record.
@@ -66,7 +150,7 @@ func (r *Record) IsSource() bool {
 
 // GetIndividual returns the record as an Individual if it's the correct type.
 func (r *Record) GetIndividual() (*Individual, bool) {
-	if ind, ok := r.Entity.(*Individual); ok {
+	if ind, ok := r.LoadEntity().(*Individual); ok {
 		return ind, true
 	}
 	return nil, false
@@ -74,7 +158,7 @@ func (r *Record) GetIndividual() (*Individual, bool) {
 
 // GetFamily returns the record as a Family if it's the correct type.
 func (r *Record) GetFamily() (*Family, bool) {
-	if fam, ok := r.Entity.(*Family); ok {
+	if fam, ok := r.LoadEntity().(*Family); ok {
 		return fam, true
 	}
 	return nil, false
@@ -82,7 +166,7 @@ func (r *Record) GetFamily() (*Family, bool) {
 
 // GetSource returns the record as a Source if it's the correct type.
 func (r *Record) GetSource() (*Source, bool) {
-	if src, ok := r.Entity.(*Source); ok {
+	if src, ok := r.LoadEntity().(*Source); ok {
 		return src, true
 	}
 	return nil, false
@@ -90,7 +174,7 @@ func (r *Record) GetSource() (*Source, bool) {
 
 // GetSubmitter returns the record as a Submitter if it's the correct type.
 func (r *Record) GetSubmitter() (*Submitter, bool) {
-	if subm, ok := r.Entity.(*Submitter); ok {
+	if subm, ok := r.LoadEntity().(*Submitter); ok {
 		return subm, true
 	}
 	return nil, false
@@ -98,15 +182,16 @@ func (r *Record) GetSubmitter() (*Submitter, bool) {
 
 // GetRepository returns the record as a Repository if it's the correct type.
 func (r *Record) GetRepository() (*Repository, bool) {
-	if repo, ok := r.Entity.(*Repository); ok {
+	if repo, ok := r.LoadEntity().(*Repository); ok {
 		return repo, true
 	}
 	return nil, false
 }
 
-// GetNote returns the record as a Note if it's the correct type.
+// GetNote returns the record as a Note if it's the correct type (a NOTE or
+// SNOTE record).
 func (r *Record) GetNote() (*Note, bool) {
-	if note, ok := r.Entity.(*Note); ok {
+	if note, ok := r.LoadEntity().(*Note); ok {
 		return note, true
 	}
 	return nil, false
@@ -114,7 +199,7 @@ func (r *Record) GetNote() (*Note, bool) {
 
 // GetMediaObject returns the record as a MediaObject if it's the correct type.
 func (r *Record) GetMediaObject() (*MediaObject, bool) {
-	if media, ok := r.Entity.(*MediaObject); ok {
+	if media, ok := r.LoadEntity().(*MediaObject); ok {
 		return media, true
 	}
 	return nil, false
@@ -8,12 +8,29 @@ type Repository struct {
 	// Name is the repository name
 	Name string
 
-	// Address is the physical address
+	// Address is the physical address. Its Phone, Email, and Website hold
+	// the first of the repository's contact values.
 	Address *Address
 
+	// Phone numbers (PHON, can repeat)
+	Phone []string
+
+	// Email addresses (EMAIL, can repeat)
+	Email []string
+
+	// Fax numbers (FAX, can repeat)
+	Fax []string
+
+	// Websites (WWW, can repeat)
+	Website []string
+
 	// Notes are references to note records
 	Notes []string
 
+	// Identifiers are the record's REFN and EXID identifiers in other
+	// systems, in document order
+	Identifiers []*Identifier
+
 	// Tags contains all raw tags for this repository (for unknown/custom tags)
 	Tags []*Tag
 }
@@ -25,8 +42,39 @@ type InlineRepository struct {
 	Name string
 }
 
+// RepositoryCitation links a source to a repository that holds it (the
+// REPO structure under a source record).
+type RepositoryCitation struct {
+	// RepositoryXRef is the XRef of the repository record (e.g., "@R1@"),
+	// empty for an inline repository
+	RepositoryXRef string
+
+	// Name is the repository name of an inline repository (REPO.NAME)
+	Name string
+
+	// CallNumbers are the repository's call numbers for the source (CALN)
+	CallNumbers []*CallNumber
+
+	// Notes are notes on the citation (NOTE)
+	Notes []string
+}
+
+// CallNumber is a repository call number with the medium it is held in.
+type CallNumber struct {
+	// Number is the call number (CALN value)
+	Number string
+
+	// Medium is the source medium, such as book, film, or microfilm
+	// (CALN.MEDI)
+	Medium string
+}
+
 // Address represents a physical or digital address.
 type Address struct {
+	// Text is the free-form address from the ADDR value and its CONT/CONC
+	// lines, typically formatted as a mailing label
+	Text string
+
 	// Line1 is the first address line
 	Line1 string
 
@@ -0,0 +1,71 @@
+package gedcom
+
+import "sort"
+
+// Resolve looks up many cross-references at once. The result has an entry for
+// every distinct xref requested; xrefs with no matching record map to nil, so
+// MissingXRefs can report them. Empty xrefs and the GEDCOM 7 @VOID@ pointer,
+// which marks an intentionally missing reference, are skipped.
+func (d *Document) Resolve(xrefs ...string) map[string]*Record {
+	return resolveTyped(d, xrefs, func(r *Record) (*Record, bool) { return r, true })
+}
+
+// ResolveIndividuals looks up many individual pointers at once, like Resolve.
+// Xrefs that are missing or point to a record of another type map to nil.
+func (d *Document) ResolveIndividuals(xrefs ...string) map[string]*Individual {
+	return resolveTyped(d, xrefs, (*Record).GetIndividual)
+}
+
+// ResolveFamilies looks up many family pointers at once, like Resolve.
+// Xrefs that are missing or point to a record of another type map to nil.
+func (d *Document) ResolveFamilies(xrefs ...string) map[string]*Family {
+	return resolveTyped(d, xrefs, (*Record).GetFamily)
+}
+
+// ResolveSources looks up many source pointers at once, like Resolve.
+// Xrefs that are missing or point to a record of another type map to nil.
+func (d *Document) ResolveSources(xrefs ...string) map[string]*Source {
+	return resolveTyped(d, xrefs, (*Record).GetSource)
+}
+
+// ResolveNotes looks up many note pointers at once, like Resolve.
+// Xrefs that are missing or point to a record of another type map to nil.
+func (d *Document) ResolveNotes(xrefs ...string) map[string]*Note {
+	return resolveTyped(d, xrefs, (*Record).GetNote)
+}
+
+// MissingXRefs returns the sorted xrefs that did not resolve in the result
+// of Resolve or one of its typed variants.
+func MissingXRefs[T any](resolved map[string]*T) []string {
+	var missing []string
+	for xref, value := range resolved {
+		if value == nil {
+			missing = append(missing, xref)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// resolveTyped looks up xrefs in d and converts each record with get.
+func resolveTyped[T any](d *Document, xrefs []string, get func(*Record) (*T, bool)) map[string]*T {
+	resolved := make(map[string]*T, len(xrefs))
+	for _, xref := range xrefs {
+		if xref == "" || xref == "@VOID@" {
+			continue
+		}
+		if _, done := resolved[xref]; done {
+			continue
+		}
+		var value *T
+		if d != nil {
+			if record := d.GetRecord(xref); record != nil {
+				if v, ok := get(record); ok {
+					value = v
+				}
+			}
+		}
+		resolved[xref] = value
+	}
+	return resolved
+}
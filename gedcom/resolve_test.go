@@ -0,0 +1,52 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDocument_Resolve(t *testing.T) {
+	john := &Individual{XRef: "@I1@"}
+	jane := &Individual{XRef: "@I2@"}
+	family := &Family{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@"}
+	doc := createRelationshipTestDocument([]*Individual{john, jane}, []*Family{family})
+
+	records := doc.Resolve("@I1@", "@F1@", "@X9@", "@I1@", "", "@VOID@")
+	if len(records) != 3 {
+		t.Fatalf("Resolve() returned %d entries, want 3: %v", len(records), records)
+	}
+	if records["@I1@"] != doc.GetRecord("@I1@") || records["@F1@"] != doc.GetRecord("@F1@") {
+		t.Errorf("Resolve() = %v, want records for @I1@ and @F1@", records)
+	}
+	if got := MissingXRefs(records); !reflect.DeepEqual(got, []string{"@X9@"}) {
+		t.Errorf("MissingXRefs() = %v, want [@X9@]", got)
+	}
+
+	// A family pointer is not an individual
+	individuals := doc.ResolveIndividuals("@I2@", "@I1@", "@F1@")
+	if individuals["@I1@"] != john || individuals["@I2@"] != jane {
+		t.Errorf("ResolveIndividuals() = %v", individuals)
+	}
+	if got := MissingXRefs(individuals); !reflect.DeepEqual(got, []string{"@F1@"}) {
+		t.Errorf("MissingXRefs() = %v, want [@F1@]", got)
+	}
+
+	families := doc.ResolveFamilies("@F1@", "@I1@")
+	if families["@F1@"] != family || families["@I1@"] != nil {
+		t.Errorf("ResolveFamilies() = %v", families)
+	}
+	if got := doc.ResolveSources("@S1@"); !reflect.DeepEqual(MissingXRefs(got), []string{"@S1@"}) {
+		t.Errorf("ResolveSources() = %v, want @S1@ missing", got)
+	}
+}
+
+func TestDocument_ResolveNilDocument(t *testing.T) {
+	var doc *Document
+	resolved := doc.ResolveNotes("@N1@")
+	if got := MissingXRefs(resolved); !reflect.DeepEqual(got, []string{"@N1@"}) {
+		t.Errorf("MissingXRefs() = %v, want [@N1@]", got)
+	}
+	if got := MissingXRefs(doc.Resolve()); got != nil {
+		t.Errorf("MissingXRefs() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,47 @@
+package gedcom
+
+import "strings"
+
+// IsInvisibleRune returns true for characters SanitizeText removes: control
+// characters other than tab and newline, zero-width spaces and word joiners,
+// and byte order marks. These are artifacts of copying text between
+// programs; they carry no meaning in genealogy data and break CSV and JSON
+// consumers. Zero-width joiners (U+200C, U+200D), which some scripts need,
+// are kept.
+func IsInvisibleRune(r rune) bool {
+	switch {
+	case r == '\t' || r == '\n':
+		return false
+	case r < 0x20 || r == 0x7F:
+		return true
+	case r >= 0x80 && r <= 0x9F:
+		// C1 controls
+		return true
+	}
+	switch r {
+	case '\u200B', // zero width space
+		'\u2060', // word joiner
+		'\u180E', // Mongolian vowel separator
+		'\uFEFF': // byte order mark, or zero width no-break space
+		return true
+	}
+	return false
+}
+
+// SanitizeText returns s without the characters IsInvisibleRune reports,
+// and the number of characters removed. s is returned unchanged, without
+// allocating, when it has none.
+func SanitizeText(s string) (string, int) {
+	if strings.IndexFunc(s, IsInvisibleRune) < 0 {
+		return s, 0
+	}
+	removed := 0
+	clean := strings.Map(func(r rune) rune {
+		if IsInvisibleRune(r) {
+			removed++
+			return -1
+		}
+		return r
+	}, s)
+	return clean, removed
+}
@@ -0,0 +1,29 @@
+package gedcom
+
+import "testing"
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		removed int
+	}{
+		{"clean", "John Smith", "John Smith", 0},
+		{"tab and newline kept", "a\tb\nc", "a\tb\nc", 0},
+		{"zero width space", "Jo\u200bhn", "John", 1},
+		{"byte order mark mid-value", "Boston\ufeff, MA", "Boston, MA", 1},
+		{"word joiner", "a\u2060b", "ab", 1},
+		{"C0 controls", "a\x00b\x07c\rd\x1b", "abcd", 4},
+		{"DEL and C1 controls", "a\x7Fb\u0085c", "abc", 2},
+		{"zero width joiners kept", "\u0645\u200c\u0645 \U0001F468\u200d\U0001F469", "\u0645\u200c\u0645 \U0001F468\u200d\U0001F469", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, removed := SanitizeText(tt.in)
+			if got != tt.want || removed != tt.removed {
+				t.Errorf("SanitizeText(%q) = %q, %d, want %q, %d", tt.in, got, removed, tt.want, tt.removed)
+			}
+		})
+	}
+}
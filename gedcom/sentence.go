@@ -0,0 +1,282 @@
+package gedcom
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SentenceLocale holds the templates and words used to render events as
+// natural-language sentences, such as "John Smith was born on 1 Jan 1900 in
+// Boston, MA." It is shared by exporters that write prose, so a new language
+// is added by filling in a SentenceLocale rather than by changing each
+// exporter. EnglishSentences returns the English locale, which can be
+// copied and adjusted.
+//
+// Templates use the placeholders {subject}, {event}, {date}, {place},
+// {age}, {cause}, and {description}. Except for {subject} and {event}, each
+// value starts with a space and its preposition (" on 1 Jan 1900",
+// " in Boston, MA") and is empty when the event has no such detail, so
+// templates write placeholders next to each other:
+//
+//	"{subject} was born{date}{place}."
+type SentenceLocale struct {
+	// Templates are the sentence templates by event type.
+	Templates map[EventType]string
+
+	// Default is the template for event types without a template in
+	// Templates.
+	Default string
+
+	// EventNames name event types for the {event} placeholder. Types without
+	// a name use the event's TYPE, then its tag.
+	EventNames map[EventType]string
+
+	// Months are the month names of Gregorian and Julian dates, January
+	// first.
+	Months [12]string
+
+	// On and In introduce an exact date ("on 1 Jan 1900") and a month or
+	// year ("in 1900").
+	On, In string
+
+	// About, Before, After, Between, From, and Until introduce dates with
+	// the ABT (also CAL and EST), BEF, AFT, BET, FROM, and TO modifiers. And
+	// joins the dates of a BET...AND range, To those of a FROM...TO period.
+	About, Before, After, Between, And, From, To, Until string
+
+	// BC follows years before the common era.
+	BC string
+
+	// Place introduces the place ("in Boston").
+	Place string
+
+	// Age introduces the age at the event ("at age 42y").
+	Age string
+
+	// Cause introduces the cause of the event ("of pneumonia").
+	Cause string
+
+	// Label names individuals for the {subject} placeholder; nil means
+	// LabelName. Family events name both spouses joined by And.
+	Label PersonLabel
+}
+
+// EnglishSentences returns the English sentence locale.
+func EnglishSentences() *SentenceLocale {
+	return &SentenceLocale{
+		Templates: map[EventType]string{
+			EventBirth:          "{subject} was born{date}{place}.",
+			EventDeath:          "{subject} died{date}{place}{age}{cause}.",
+			EventBurial:         "{subject} was buried{date}{place}.",
+			EventCremation:      "{subject} was cremated{date}{place}.",
+			EventBaptism:        "{subject} was baptized{date}{place}.",
+			EventChristening:    "{subject} was christened{date}{place}.",
+			EventAdoption:       "{subject} was adopted{date}{place}.",
+			EventImmigration:    "{subject} immigrated{date}{place}.",
+			EventEmigration:     "{subject} emigrated{date}{place}.",
+			EventNaturalization: "{subject} was naturalized{date}{place}.",
+			EventGraduation:     "{subject} graduated{date}{place}.",
+			EventRetirement:     "{subject} retired{date}{place}.",
+			EventResidence:      "{subject} lived{place}{date}.",
+			EventCensus:         "{subject} was recorded in the census{date}{place}.",
+			EventMarriage:       "{subject} were married{date}{place}.",
+			EventDivorce:        "{subject} were divorced{date}{place}.",
+			EventEngagement:     "{subject} were engaged{date}{place}.",
+			EventAnnulment:      "{subject} had their marriage annulled{date}{place}.",
+		},
+		Default: "{subject}: {event}{date}{place}.",
+		EventNames: map[EventType]string{
+			EventBarMitzvah:         "bar mitzvah",
+			EventBasMitzvah:         "bas mitzvah",
+			EventBlessing:           "blessing",
+			EventAdultChristening:   "adult christening",
+			EventConfirmation:       "confirmation",
+			EventFirstCommunion:     "first communion",
+			EventOrdination:         "ordination",
+			EventProbate:            "probate",
+			EventWill:               "will",
+			EventOccupation:         "occupation",
+			EventMarriageBann:       "marriage bann",
+			EventMarriageContract:   "marriage contract",
+			EventMarriageLicense:    "marriage license",
+			EventMarriageSettlement: "marriage settlement",
+			EventDivorceFiling:      "divorce filing",
+			EventGeneric:            "event",
+		},
+		Months: [12]string{
+			"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+			"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+		},
+		On:      "on",
+		In:      "in",
+		About:   "about",
+		Before:  "before",
+		After:   "after",
+		Between: "between",
+		And:     "and",
+		From:    "from",
+		To:      "to",
+		Until:   "until",
+		BC:      "BC",
+		Place:   "in",
+		Age:     "at age",
+		Cause:   "of",
+	}
+}
+
+// EventSentence renders an event of subject as a sentence, such as
+// "John Smith was born on 1 Jan 1900 in Boston, MA." It returns "" for a
+// nil event.
+func (l *SentenceLocale) EventSentence(subject string, e *Event) string {
+	if e == nil {
+		return ""
+	}
+	template, ok := l.Templates[e.Type]
+	if !ok {
+		template = l.Default
+	}
+
+	age := e.Age
+	if e.ParsedAge != nil && e.ParsedAge.Phrase != "" {
+		age = e.ParsedAge.Phrase
+	}
+
+	r := strings.NewReplacer(
+		"{subject}", subject,
+		"{event}", l.eventName(e),
+		"{date}", l.datePhrase(e),
+		"{place}", l.phrase(l.Place, e.Place),
+		"{age}", l.phrase(l.Age, age),
+		"{cause}", l.phrase(l.Cause, e.Cause),
+		"{description}", l.phrase("", e.Description),
+	)
+	return strings.TrimSpace(r.Replace(template))
+}
+
+// IndividualSentences renders the events of ind as sentences in record
+// order.
+func (l *SentenceLocale) IndividualSentences(ind *Individual) []string {
+	if ind == nil {
+		return nil
+	}
+	subject := l.label(ind)
+	sentences := make([]string, 0, len(ind.Events))
+	for _, e := range ind.Events {
+		if e != nil {
+			sentences = append(sentences, l.EventSentence(subject, e))
+		}
+	}
+	return sentences
+}
+
+// FamilySentences renders the events of fam as sentences in record order,
+// naming the spouses found in doc as the subject ("John Smith and Mary
+// Jones").
+func (l *SentenceLocale) FamilySentences(doc *Document, fam *Family) []string {
+	if fam == nil {
+		return nil
+	}
+	subject := joinNonEmpty(" "+l.And+" ", l.label(fam.HusbandIndividual(doc)), l.label(fam.WifeIndividual(doc)))
+	sentences := make([]string, 0, len(fam.Events))
+	for _, e := range fam.Events {
+		if e != nil {
+			sentences = append(sentences, l.EventSentence(subject, e))
+		}
+	}
+	return sentences
+}
+
+// label names ind with the locale's Label.
+func (l *SentenceLocale) label(ind *Individual) string {
+	if ind == nil {
+		return ""
+	}
+	if l.Label != nil {
+		return l.Label(ind)
+	}
+	return LabelName(ind)
+}
+
+// eventName names the event for the {event} placeholder.
+func (l *SentenceLocale) eventName(e *Event) string {
+	if name, ok := l.EventNames[e.Type]; ok && (e.Type != EventGeneric || e.EventTypeDetail == "") {
+		return name
+	}
+	if e.EventTypeDetail != "" {
+		return e.EventTypeDetail
+	}
+	return string(e.Type)
+}
+
+// phrase returns " word value", or "" if value is empty.
+func (l *SentenceLocale) phrase(word, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	return " " + joinNonEmpty(" ", word, value)
+}
+
+// datePhrase renders the event date with its preposition, such as
+// " on 1 Jan 1900", " in 1900", or " between 1900 and 1905". Date phrases
+// are written in parentheses; dates that did not parse and dates in
+// calendars other than Gregorian and Julian are written as recorded.
+func (l *SentenceLocale) datePhrase(e *Event) string {
+	d := e.ParsedDate
+	if d == nil {
+		return l.phrase("", e.Date)
+	}
+	if d.IsPhrase {
+		return l.phrase("", "("+d.Phrase+")")
+	}
+	if d.Calendar != CalendarGregorian && d.Calendar != CalendarJulian {
+		return l.phrase("", d.Original)
+	}
+
+	point := l.datePoint(d)
+	switch d.Modifier {
+	case ModifierAbout, ModifierCalculated, ModifierEstimated:
+		return l.phrase(l.About, point)
+	case ModifierBefore:
+		return l.phrase(l.Before, point)
+	case ModifierAfter:
+		return l.phrase(l.After, point)
+	case ModifierBetween:
+		if d.EndDate != nil {
+			return l.phrase(l.Between, point+" "+l.And+" "+l.datePoint(d.EndDate))
+		}
+		return l.phrase(l.After, point)
+	case ModifierFrom:
+		return l.phrase(l.From, point)
+	case ModifierTo:
+		return l.phrase(l.Until, point)
+	case ModifierFromTo:
+		if d.EndDate != nil {
+			return l.phrase(l.From, point+" "+l.To+" "+l.datePoint(d.EndDate))
+		}
+		return l.phrase(l.From, point)
+	}
+	if d.Day != 0 {
+		return l.phrase(l.On, point)
+	}
+	return l.phrase(l.In, point)
+}
+
+// datePoint renders a single Gregorian or Julian date without modifier,
+// such as "1 Jan 1900".
+func (l *SentenceLocale) datePoint(d *Date) string {
+	var parts []string
+	if d.Day != 0 {
+		parts = append(parts, strconv.Itoa(d.Day))
+	}
+	if d.Month >= 1 && d.Month <= 12 {
+		parts = append(parts, l.Months[d.Month-1])
+	}
+	if d.Year != 0 {
+		parts = append(parts, strconv.Itoa(d.Year))
+		if d.IsBC {
+			parts = append(parts, l.BC)
+		}
+	}
+	return joinNonEmpty(" ", parts...)
+}
@@ -0,0 +1,104 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func sentenceEvent(typ EventType, date, place string) *Event {
+	e := &Event{Type: typ, Date: date, Place: place}
+	if date != "" {
+		e.ParsedDate, _ = ParseDate(date)
+	}
+	return e
+}
+
+func TestEventSentence(t *testing.T) {
+	en := EnglishSentences()
+
+	tests := []struct {
+		name  string
+		event *Event
+		want  string
+	}{
+		{"birth", sentenceEvent(EventBirth, "1 JAN 1900", "Boston, MA"), "John Smith was born on 1 Jan 1900 in Boston, MA."},
+		{"year only", sentenceEvent(EventBirth, "1900", ""), "John Smith was born in 1900."},
+		{"month and year", sentenceEvent(EventBaptism, "MAR 1900", ""), "John Smith was baptized in Mar 1900."},
+		{"no details", sentenceEvent(EventBirth, "", ""), "John Smith was born."},
+		{"about", sentenceEvent(EventBirth, "ABT 1900", ""), "John Smith was born about 1900."},
+		{"estimated", sentenceEvent(EventBirth, "EST 1900", ""), "John Smith was born about 1900."},
+		{"before", sentenceEvent(EventBurial, "BEF 5 JUN 1950", ""), "John Smith was buried before 5 Jun 1950."},
+		{"between", sentenceEvent(EventBirth, "BET 1900 AND 1905", ""), "John Smith was born between 1900 and 1905."},
+		{"period", sentenceEvent(EventResidence, "FROM 1920 TO 1930", "Ohio"), "John Smith lived in Ohio from 1920 to 1930."},
+		{"until", sentenceEvent(EventResidence, "TO 1930", ""), "John Smith lived until 1930."},
+		{"phrase", sentenceEvent(EventBirth, "(during the war)", ""), "John Smith was born (during the war)."},
+		{"unparsed", &Event{Type: EventBirth, Date: "sometime"}, "John Smith was born sometime."},
+		{"other calendar", sentenceEvent(EventBirth, "@#DHEBREW@ 1 TSH 5660", ""), "John Smith was born @#DHEBREW@ 1 TSH 5660."},
+		{"death with age and cause", &Event{Type: EventDeath, Date: "1950", ParsedDate: &Date{Year: 1950}, Age: "50y", Cause: "pneumonia"}, "John Smith died in 1950 at age 50y of pneumonia."},
+		{"age phrase", &Event{Type: EventDeath, Age: "50y", ParsedAge: &Age{Years: 50, Phrase: "fifty"}}, "John Smith died at age fifty."},
+		{"default template", sentenceEvent(EventConfirmation, "1914", "Rome"), "John Smith: confirmation in 1914 in Rome."},
+		{"generic event type", &Event{Type: EventGeneric, EventTypeDetail: "Military service", Date: "1917", ParsedDate: &Date{Year: 1917}}, "John Smith: Military service in 1917."},
+		{"unknown type", &Event{Type: "_MILT"}, "John Smith: _MILT."},
+		{"nil event", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := en.EventSentence("John Smith", tt.event); got != tt.want {
+				t.Errorf("EventSentence() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSentenceLocale_Custom(t *testing.T) {
+	de := &SentenceLocale{
+		Templates: map[EventType]string{EventBirth: "{subject} wurde{date}{place} geboren."},
+		Months:    [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sep.", "Okt.", "Nov.", "Dez."},
+		On:        "am",
+		In:        "im Jahr",
+		Place:     "in",
+	}
+
+	got := de.EventSentence("Johann Schmidt", sentenceEvent(EventBirth, "3 MAR 1900", "Berlin"))
+	if want := "Johann Schmidt wurde am 3 März 1900 in Berlin geboren."; got != want {
+		t.Errorf("EventSentence() = %q, want %q", got, want)
+	}
+}
+
+func TestIndividualAndFamilySentences(t *testing.T) {
+	john := &Individual{
+		XRef:  "@I1@",
+		Names: []*PersonalName{{Full: "John /Smith/"}},
+		Events: []*Event{
+			sentenceEvent(EventBirth, "1 JAN 1900", "Boston, MA"),
+			sentenceEvent(EventDeath, "1950", ""),
+		},
+	}
+	mary := &Individual{XRef: "@I2@", Names: []*PersonalName{{Full: "Mary /Jones/"}}}
+	fam := &Family{
+		XRef:    "@F1@",
+		Husband: "@I1@",
+		Wife:    "@I2@",
+		Events:  []*Event{sentenceEvent(EventMarriage, "JUN 1925", "Salem")},
+	}
+	doc := createRelationshipTestDocument([]*Individual{john, mary}, []*Family{fam})
+
+	en := EnglishSentences()
+	if got, want := en.IndividualSentences(john), []string{
+		"John Smith was born on 1 Jan 1900 in Boston, MA.",
+		"John Smith died in 1950.",
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IndividualSentences() = %q, want %q", got, want)
+	}
+	if got, want := en.FamilySentences(doc, fam), []string{
+		"John Smith and Mary Jones were married in Jun 1925 in Salem.",
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FamilySentences() = %q, want %q", got, want)
+	}
+
+	en.Label = LabelNameLifespan
+	if got, want := en.IndividualSentences(john)[0], "John Smith (1900-1950) was born on 1 Jan 1900 in Boston, MA."; got != want {
+		t.Errorf("IndividualSentences() with label = %q, want %q", got, want)
+	}
+}
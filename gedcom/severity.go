@@ -0,0 +1,36 @@
+package gedcom
+
+import "fmt"
+
+// Severity ranks a problem found in a document, both by the decoder (in
+// decoder.DecodeErrors) and by the validator (in validator.Issue).
+type Severity int
+
+const (
+	// SeverityError marks a problem that must be fixed: the document may be
+	// incomplete or misread, or its data contradicts itself. Examples: a
+	// skipped malformed line, death before birth.
+	SeverityError Severity = iota
+
+	// SeverityWarning marks a potential problem that should be reviewed.
+	// Examples: a broken cross-reference, an unusual age at marriage.
+	SeverityWarning
+
+	// SeverityInfo marks an informational data quality suggestion.
+	// Examples: missing sources, potential duplicates.
+	SeverityInfo
+)
+
+// String returns the human-readable name of the severity level.
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "ERROR"
+	case SeverityWarning:
+		return "WARNING"
+	case SeverityInfo:
+		return "INFO"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", s)
+	}
+}
@@ -0,0 +1,41 @@
+package gedcom
+
+import "testing"
+
+func TestSeverityString(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity Severity
+		want     string
+	}{
+		{
+			name:     "error severity",
+			severity: SeverityError,
+			want:     "ERROR",
+		},
+		{
+			name:     "warning severity",
+			severity: SeverityWarning,
+			want:     "WARNING",
+		},
+		{
+			name:     "info severity",
+			severity: SeverityInfo,
+			want:     "INFO",
+		},
+		{
+			name:     "unknown severity",
+			severity: Severity(99),
+			want:     "UNKNOWN(99)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.severity.String()
+			if got != tt.want {
+				t.Errorf("Severity.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package gedcom
 
+import "strings"
+
 // Source represents a source of genealogical information.
 type Source struct {
 	// XRef is the cross-reference identifier for this source
@@ -23,6 +25,17 @@ type Source struct {
 	// Repository is an inline repository definition (alternative to RepositoryRef)
 	Repository *InlineRepository
 
+	// Repositories are all repository citations with their call numbers.
+	// RepositoryRef and Repository mirror the first citation. The encoder
+	// writes Repositories when it is set and falls back to the two fields
+	// otherwise.
+	Repositories []*RepositoryCitation
+
+	// Data describes what the source records: the events it covers, the
+	// agency responsible for it, and notes (DATA). It is nil if the source
+	// has no DATA.
+	Data *SourceData
+
 	// Media are references to media objects with optional crop/title
 	Media []*MediaLink
 
@@ -35,16 +48,65 @@ type Source struct {
 	// CreationDate is when the record was created (CREA tag, GEDCOM 7.0)
 	CreationDate *ChangeDate
 
-	// RefNumber is the user reference number (REFN tag)
+	// RefNumber is the user reference number (REFN tag). It mirrors the
+	// first REFN in Identifiers.
 	RefNumber string
 
 	// UID is the unique identifier (UID tag)
 	UID string
 
+	// Identifiers are the record's REFN and EXID identifiers in other
+	// systems, in document order
+	Identifiers []*Identifier
+
 	// Tags contains all raw tags for this source (for unknown/custom tags)
 	Tags []*Tag
 }
 
+// SourceData describes the contents of a source (SOUR.DATA).
+type SourceData struct {
+	// Events are the kinds of events the source records, with the period
+	// and place they cover (EVEN, can repeat)
+	Events []*SourceDataEvent
+
+	// Agency is the person or institution responsible for the source (AGNC)
+	Agency string
+
+	// Notes are notes on the recorded data (NOTE)
+	Notes []string
+}
+
+// SourceDataEvent is a group of events recorded in a source
+// (SOUR.DATA.EVEN), such as the births and deaths of a parish register over
+// a period.
+type SourceDataEvent struct {
+	// Types is the EVEN value, a comma-separated list of event tags such as
+	// "BIRT, DEAT"
+	Types string
+
+	// Date is the period the recorded events cover (DATE), such as
+	// "FROM 1820 TO 1860"
+	Date string
+
+	// ParsedDate is the parsed representation of Date.
+	// This is nil if the date string could not be parsed.
+	ParsedDate *Date
+
+	// Place is the jurisdiction the recorded events cover (PLAC)
+	Place string
+}
+
+// EventTypes returns the event types listed in Types, in order.
+func (e *SourceDataEvent) EventTypes() []EventType {
+	var types []EventType
+	for _, t := range strings.Split(e.Types, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, EventType(strings.ToUpper(t)))
+		}
+	}
+	return types
+}
+
 // SourceCitationData represents extracted text and date from a source citation.
 type SourceCitationData struct {
 	// Date is the date extracted from the source
@@ -0,0 +1,475 @@
+package gedcom
+
+import "fmt"
+
+// SplitBySize partitions doc into documents of at most maxRecords records
+// each, for services that cap upload size. Every part is a self-contained
+// GEDCOM document with a copy of the header.
+//
+// Individuals and families are assigned to exactly one part, keeping each
+// connected group of relatives together when it fits in a part. Sources,
+// notes, media, repositories, and submitters are copied into every part that
+// references them, directly or through another such record, and count toward
+// the limit; those referenced by no individual or family are assigned to a
+// part like individuals. The header's submitter is copied into every part.
+//
+// Links are read from each record's Tags, or from its Entity when it has no
+// tags, as for documents built by csvimport. Pointers to records assigned to
+// another part (for example a FAMS link between two halves of a large tree)
+// are removed along with their subordinate tags, so every part has no broken
+// references. Such records are copies whose Tags and Entity both lack the
+// removed links; all other records are shared with doc.
+//
+// It returns an error if maxRecords is less than 1 or if a record together
+// with the records it needs exceeds the limit.
+func SplitBySize(doc *Document, maxRecords int) ([]*Document, error) {
+	if maxRecords < 1 {
+		return nil, fmt.Errorf("split: maxRecords must be at least 1, got %d", maxRecords)
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	s := &splitter{doc: doc, max: maxRecords, refs: make(map[*Record][]*Record)}
+	for _, record := range doc.Records {
+		var pointers []string
+		if len(record.Tags) > 0 {
+			for _, tag := range record.Tags {
+				pointers = append(pointers, tag.Value)
+			}
+		} else {
+			// Records built in code, such as by csvimport, have no tags
+			pointers = entityPointers(record.LoadEntity())
+		}
+		for _, pointer := range pointers {
+			if target := doc.GetRecord(pointer); target != nil && isPointerValue(pointer) {
+				s.refs[record] = append(s.refs[record], target)
+			}
+		}
+	}
+
+	var header []*Record
+	if doc.Header != nil {
+		if subm := doc.GetRecord(doc.Header.Submitter); subm != nil {
+			header = s.closure([]*Record{subm}, nil)
+		}
+	}
+	if len(header) >= maxRecords && len(doc.Records) > len(header) {
+		return nil, fmt.Errorf("split: header submitter needs %d records, limit is %d", len(header), maxRecords)
+	}
+
+	s.newPart(header)
+	for _, group := range s.groups() {
+		if !s.fits(group) && len(s.current.primaries) > 0 && s.fitsEmpty(group, header) {
+			s.newPart(header)
+		}
+		if s.fits(group) {
+			s.add(group)
+			continue
+		}
+		// Too large for any part: split the group record by record
+		for _, record := range group {
+			unit := []*Record{record}
+			if !s.fits(unit) && len(s.current.primaries) > 0 {
+				s.newPart(header)
+			}
+			if !s.fits(unit) {
+				return nil, fmt.Errorf("split: record %s needs %d records, limit is %d",
+					record.XRef, len(s.current.records)+len(s.closure(unit, s.current.in)), maxRecords)
+			}
+			s.add(unit)
+		}
+	}
+
+	var docs []*Document
+	for _, part := range s.parts {
+		if len(part.primaries) == 0 && len(s.parts) > 1 {
+			continue
+		}
+		docs = append(docs, part.document(doc))
+	}
+	return docs, nil
+}
+
+// splitter holds the state of SplitBySize.
+type splitter struct {
+	doc     *Document
+	max     int
+	refs    map[*Record][]*Record // pointer targets of each record
+	parts   []*splitPart
+	current *splitPart
+}
+
+// splitPart is one output document under construction.
+type splitPart struct {
+	records   []*Record // in order of addition
+	primaries []*Record // records assigned to this part only
+	in        map[*Record]bool
+}
+
+// isPrimary reports whether a record is assigned to a single part rather
+// than copied into every part that needs it.
+func isPrimary(record *Record) bool {
+	return record.Type == RecordTypeIndividual || record.Type == RecordTypeFamily
+}
+
+// groups returns the records to assign, in document order of their first
+// record: each connected group of individuals and families, and each
+// unreferenced record of another type on its own.
+func (s *splitter) groups() [][]*Record {
+	referenced := make(map[*Record]bool)
+	for _, targets := range s.refs {
+		for _, target := range targets {
+			referenced[target] = true
+		}
+	}
+
+	// Undirected links between individuals and families
+	links := make(map[*Record][]*Record)
+	for record, targets := range s.refs {
+		if !isPrimary(record) {
+			continue
+		}
+		for _, target := range targets {
+			if isPrimary(target) {
+				links[record] = append(links[record], target)
+				links[target] = append(links[target], record)
+			}
+		}
+	}
+
+	order := make(map[*Record]int, len(s.doc.Records))
+	for i, record := range s.doc.Records {
+		order[record] = i
+	}
+
+	var groups [][]*Record
+	seen := make(map[*Record]bool)
+	for _, record := range s.doc.Records {
+		if seen[record] {
+			continue
+		}
+		if !isPrimary(record) {
+			if !referenced[record] {
+				seen[record] = true
+				groups = append(groups, []*Record{record})
+			}
+			continue
+		}
+		seen[record] = true
+		group := []*Record{record}
+		for i := 0; i < len(group); i++ {
+			for _, next := range links[group[i]] {
+				if !seen[next] {
+					seen[next] = true
+					group = append(group, next)
+				}
+			}
+		}
+		// Keep document order within the group
+		sortRecords(group, order)
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// sortRecords sorts records by their index in order (insertion sort; groups
+// are mostly in order already).
+func sortRecords(records []*Record, order map[*Record]int) {
+	for i := 1; i < len(records); i++ {
+		for j := i; j > 0 && order[records[j]] < order[records[j-1]]; j-- {
+			records[j], records[j-1] = records[j-1], records[j]
+		}
+	}
+}
+
+// closure returns records and the non-primary records they reference,
+// directly or transitively, that are not in have.
+func (s *splitter) closure(records []*Record, have map[*Record]bool) []*Record {
+	var out []*Record
+	added := make(map[*Record]bool)
+	queue := append([]*Record(nil), records...)
+	for i := 0; i < len(queue); i++ {
+		record := queue[i]
+		if have[record] || added[record] {
+			continue
+		}
+		added[record] = true
+		out = append(out, record)
+		for _, target := range s.refs[record] {
+			if !isPrimary(target) {
+				queue = append(queue, target)
+			}
+		}
+	}
+	return out
+}
+
+// fits reports whether group and the records it needs fit in the current part.
+func (s *splitter) fits(group []*Record) bool {
+	return len(s.current.records)+len(s.closure(group, s.current.in)) <= s.max
+}
+
+// fitsEmpty reports whether group fits in a new part.
+func (s *splitter) fitsEmpty(group, header []*Record) bool {
+	have := make(map[*Record]bool, len(header))
+	for _, record := range header {
+		have[record] = true
+	}
+	return len(header)+len(s.closure(group, have)) <= s.max
+}
+
+// newPart starts a new part containing the header's records.
+func (s *splitter) newPart(header []*Record) {
+	s.current = &splitPart{in: make(map[*Record]bool)}
+	for _, record := range header {
+		s.current.records = append(s.current.records, record)
+		s.current.in[record] = true
+	}
+	s.parts = append(s.parts, s.current)
+}
+
+// add puts group and the records it needs into the current part.
+func (s *splitter) add(group []*Record) {
+	s.current.primaries = append(s.current.primaries, group...)
+	for _, record := range s.closure(group, s.current.in) {
+		s.current.records = append(s.current.records, record)
+		s.current.in[record] = true
+	}
+}
+
+// document builds the part's Document, in the record order of doc.
+func (p *splitPart) document(doc *Document) *Document {
+	out := &Document{
+		Records: make([]*Record, 0, len(p.records)),
+		XRefMap: make(map[string]*Record, len(p.records)),
+		Trailer: &Trailer{},
+		Vendor:  doc.Vendor,
+	}
+	if doc.Header != nil {
+		header := *doc.Header
+		out.Header = &header
+	}
+	for _, record := range doc.Records {
+		if !p.in[record] {
+			continue
+		}
+		record = p.withoutOutsideLinks(record, doc)
+		out.Records = append(out.Records, record)
+		if record.XRef != "" {
+			out.XRefMap[record.XRef] = record
+		}
+	}
+	return out
+}
+
+// withoutOutsideLinks returns record, or a copy of it without the tags (and
+// their subordinates) and entity links that point to records of doc outside
+// the part.
+func (p *splitPart) withoutOutsideLinks(record *Record, doc *Document) *Record {
+	outside := func(xref string) bool {
+		target := doc.GetRecord(xref)
+		return target != nil && !p.in[target]
+	}
+
+	var kept []*Tag
+	dropLevel := 0
+	dropped := false
+	for i, tag := range record.Tags {
+		if dropLevel > 0 && tag.Level > dropLevel {
+			continue
+		}
+		dropLevel = 0
+		if isPointerValue(tag.Value) && outside(tag.Value) {
+			if !dropped {
+				kept = append(kept, record.Tags[:i]...)
+				dropped = true
+			}
+			dropLevel = tag.Level
+			continue
+		}
+		if dropped {
+			kept = append(kept, tag)
+		}
+	}
+	if !dropped {
+		kept = record.Tags
+	}
+
+	entity := record.LoadEntity()
+	linksOutside := false
+	for _, xref := range entityPointers(entity) {
+		linksOutside = linksOutside || outside(xref)
+	}
+	if !dropped && !linksOutside {
+		return record
+	}
+	if linksOutside {
+		entity = entityWithout(entity, outside)
+	}
+	return &Record{
+		XRef:       record.XRef,
+		Type:       record.Type,
+		Value:      record.Value,
+		Tags:       kept,
+		LineNumber: record.LineNumber,
+		Entity:     entity,
+		Extensions: record.Extensions,
+	}
+}
+
+// entityPointers returns the cross-reference pointers of a record entity:
+// the records it links to and the sources, notes, and media it cites.
+func entityPointers(entity interface{}) []string {
+	var out []string
+	cite := func(citations []*SourceCitation) {
+		for _, c := range citations {
+			if c != nil {
+				out = append(out, c.SourceXRef)
+			}
+		}
+	}
+	media := func(links []*MediaLink) {
+		for _, m := range links {
+			if m != nil {
+				out = append(out, m.MediaXRef)
+			}
+		}
+	}
+	associations := func(list []*Association) {
+		for _, a := range list {
+			if a != nil {
+				out = append(out, a.IndividualXRef)
+				out = append(out, a.Notes...)
+				cite(a.SourceCitations)
+			}
+		}
+	}
+	events := func(list []*Event) {
+		for _, e := range list {
+			if e == nil {
+				continue
+			}
+			out = append(out, e.FamilyXRef)
+			out = append(out, e.Notes...)
+			cite(e.SourceCitations)
+			media(e.Media)
+			associations(e.Associations)
+		}
+	}
+
+	switch e := entity.(type) {
+	case *Individual:
+		for _, link := range e.ChildInFamilies {
+			out = append(out, link.FamilyXRef)
+		}
+		out = append(out, e.SpouseInFamilies...)
+		out = append(out, e.Aliases...)
+		out = append(out, e.AncestorInterest...)
+		out = append(out, e.DescendantInterest...)
+		out = append(out, e.Notes...)
+		associations(e.Associations)
+		cite(e.SourceCitations)
+		media(e.Media)
+		events(e.Events)
+		for _, attr := range e.Attributes {
+			if attr != nil {
+				cite(attr.SourceCitations)
+			}
+		}
+	case *Family:
+		out = append(out, e.Husband, e.Wife)
+		out = append(out, e.Children...)
+		out = append(out, e.Notes...)
+		cite(e.SourceCitations)
+		media(e.Media)
+		events(e.Events)
+	case *Source:
+		out = append(out, e.RepositoryRef)
+		for _, r := range e.Repositories {
+			if r != nil {
+				out = append(out, r.RepositoryXRef)
+				out = append(out, r.Notes...)
+			}
+		}
+		out = append(out, e.Notes...)
+		media(e.Media)
+	case *MediaObject:
+		out = append(out, e.Notes...)
+		cite(e.SourceCitations)
+	case *Repository:
+		out = append(out, e.Notes...)
+	case *Submitter:
+		out = append(out, e.Notes...)
+	}
+	return out
+}
+
+// entityWithout returns a copy of an individual or family entity without
+// the links to individuals and families for which outside returns true.
+// Sources, notes, and media always travel with the records citing them, so
+// only links between individuals and families are removed.
+func entityWithout(entity interface{}, outside func(xref string) bool) interface{} {
+	keep := func(xrefs []string) []string {
+		var out []string
+		for _, xref := range xrefs {
+			if !outside(xref) {
+				out = append(out, xref)
+			}
+		}
+		return out
+	}
+	keepAssociations := func(list []*Association) []*Association {
+		var out []*Association
+		for _, a := range list {
+			if a == nil || !outside(a.IndividualXRef) {
+				out = append(out, a)
+			}
+		}
+		return out
+	}
+	keepEvents := func(list []*Event) []*Event {
+		out := make([]*Event, len(list))
+		for i, e := range list {
+			out[i] = e
+			if e == nil || (!outside(e.FamilyXRef) && len(keepAssociations(e.Associations)) == len(e.Associations)) {
+				continue
+			}
+			copied := *e
+			if outside(copied.FamilyXRef) {
+				copied.FamilyXRef = ""
+			}
+			copied.Associations = keepAssociations(e.Associations)
+			out[i] = &copied
+		}
+		return out
+	}
+
+	switch e := entity.(type) {
+	case *Individual:
+		copied := *e
+		copied.ChildInFamilies = nil
+		for _, link := range e.ChildInFamilies {
+			if !outside(link.FamilyXRef) {
+				copied.ChildInFamilies = append(copied.ChildInFamilies, link)
+			}
+		}
+		copied.SpouseInFamilies = keep(e.SpouseInFamilies)
+		copied.Aliases = keep(e.Aliases)
+		copied.Associations = keepAssociations(e.Associations)
+		copied.Events = keepEvents(e.Events)
+		return &copied
+	case *Family:
+		copied := *e
+		if outside(copied.Husband) {
+			copied.Husband = ""
+		}
+		if outside(copied.Wife) {
+			copied.Wife = ""
+		}
+		copied.Children = keep(e.Children)
+		copied.Events = keepEvents(e.Events)
+		return &copied
+	}
+	return entity
+}
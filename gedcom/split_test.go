@@ -0,0 +1,246 @@
+package gedcom
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// splitTestDoc builds a document from records written as
+// "@XREF@ TYPE tag=value tag=value", with all tags at level 1.
+func splitTestDoc(records ...string) *Document {
+	var built []*Record
+	for _, spec := range records {
+		fields := strings.Fields(spec)
+		record := &Record{XRef: fields[0], Type: RecordType(fields[1])}
+		for _, field := range fields[2:] {
+			tag, value, _ := strings.Cut(field, "=")
+			record.Tags = append(record.Tags, &Tag{Level: 1, Tag: tag, Value: value})
+		}
+		built = append(built, record)
+	}
+	doc := fingerprintDoc(built...)
+	doc.Header = &Header{Version: Version551}
+	return doc
+}
+
+// splitXRefs returns the record XRefs of each part.
+func splitXRefs(parts []*Document) [][]string {
+	var out [][]string
+	for _, part := range parts {
+		var xrefs []string
+		for _, record := range part.Records {
+			xrefs = append(xrefs, record.XRef)
+		}
+		out = append(out, xrefs)
+	}
+	return out
+}
+
+func TestSplitBySize(t *testing.T) {
+	doc := splitTestDoc(
+		"@I1@ INDI FAMS=@F1@ SOUR=@S1@",
+		"@I2@ INDI FAMS=@F1@",
+		"@F1@ FAM HUSB=@I1@ WIFE=@I2@",
+		"@I3@ INDI SOUR=@S1@ NOTE=@N1@",
+		"@S1@ SOUR REPO=@R1@",
+		"@R1@ REPO",
+		"@N1@ NOTE",
+		"@N2@ NOTE",
+	)
+
+	parts, err := SplitBySize(doc, 5)
+	if err != nil {
+		t.Fatalf("SplitBySize() error = %v", err)
+	}
+	want := [][]string{
+		// The family stays together; its source comes with its repository
+		{"@I1@", "@I2@", "@F1@", "@S1@", "@R1@"},
+		// The shared source is duplicated; the unreferenced note is assigned
+		{"@I3@", "@S1@", "@R1@", "@N1@", "@N2@"},
+	}
+	if got := splitXRefs(parts); !reflect.DeepEqual(got, want) {
+		t.Errorf("parts = %v, want %v", got, want)
+	}
+
+	for i, part := range parts {
+		if part.Header == nil || part.Header == doc.Header || part.Header.Version != Version551 {
+			t.Errorf("part %d header = %+v, want a copy", i, part.Header)
+		}
+		if part.Trailer == nil {
+			t.Errorf("part %d has no trailer", i)
+		}
+		for _, record := range part.Records {
+			if part.XRefMap[record.XRef] != record {
+				t.Errorf("part %d XRefMap[%s] missing", i, record.XRef)
+			}
+		}
+	}
+	if parts[0].GetRecord("@S1@") != doc.GetRecord("@S1@") {
+		t.Error("unchanged record was copied")
+	}
+}
+
+func TestSplitBySizeBreaksLargeGroups(t *testing.T) {
+	doc := splitTestDoc(
+		"@I1@ INDI FAMS=@F1@",
+		"@I2@ INDI FAMS=@F1@",
+		"@F1@ FAM HUSB=@I1@ WIFE=@I2@ CHIL=@I3@",
+		"@I3@ INDI FAMC=@F1@ NAME=Child",
+	)
+
+	parts, err := SplitBySize(doc, 3)
+	if err != nil {
+		t.Fatalf("SplitBySize() error = %v", err)
+	}
+	want := [][]string{{"@I1@", "@I2@", "@F1@"}, {"@I3@"}}
+	if got := splitXRefs(parts); !reflect.DeepEqual(got, want) {
+		t.Fatalf("parts = %v, want %v", got, want)
+	}
+
+	// Links across parts are removed, leaving no broken references
+	fam := parts[0].GetRecord("@F1@")
+	if fam == doc.GetRecord("@F1@") {
+		t.Fatal("record with removed links was not copied")
+	}
+	if len(fam.Tags) != 2 || fam.Tags[1].Tag != "WIFE" {
+		t.Errorf("@F1@ tags = %v, want HUSB and WIFE", fam.Tags)
+	}
+	child := parts[1].GetRecord("@I3@")
+	if len(child.Tags) != 1 || child.Tags[0].Tag != "NAME" {
+		t.Errorf("@I3@ tags = %v, want NAME only", child.Tags)
+	}
+	if len(doc.GetRecord("@F1@").Tags) != 3 {
+		t.Error("original record was modified")
+	}
+}
+
+func TestSplitBySizeRemovesSubordinates(t *testing.T) {
+	doc := splitTestDoc("@I1@ INDI", "@I2@ INDI")
+	doc.GetRecord("@I1@").Tags = []*Tag{
+		{Level: 1, Tag: "ASSO", Value: "@I2@"},
+		{Level: 2, Tag: "RELA", Value: "Godfather"},
+		{Level: 1, Tag: "NAME", Value: "John /Smith/"},
+	}
+
+	parts, err := SplitBySize(doc, 1)
+	if err != nil {
+		t.Fatalf("SplitBySize() error = %v", err)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("len(parts) = %d, want 2", len(parts))
+	}
+	tags := parts[0].GetRecord("@I1@").Tags
+	if len(tags) != 1 || tags[0].Tag != "NAME" {
+		t.Errorf("@I1@ tags = %v, want NAME only", tags)
+	}
+}
+
+func TestSplitBySizeHeaderSubmitter(t *testing.T) {
+	doc := splitTestDoc("@U1@ SUBM", "@I1@ INDI", "@I2@ INDI")
+	doc.Header.Submitter = "@U1@"
+
+	parts, err := SplitBySize(doc, 2)
+	if err != nil {
+		t.Fatalf("SplitBySize() error = %v", err)
+	}
+	want := [][]string{{"@U1@", "@I1@"}, {"@U1@", "@I2@"}}
+	if got := splitXRefs(parts); !reflect.DeepEqual(got, want) {
+		t.Errorf("parts = %v, want %v", got, want)
+	}
+
+	if _, err := SplitBySize(doc, 1); err == nil {
+		t.Error("SplitBySize() with no room beside the submitter succeeded")
+	}
+}
+
+func TestSplitBySizeErrors(t *testing.T) {
+	doc := splitTestDoc("@I1@ INDI SOUR=@S1@", "@S1@ SOUR")
+	if _, err := SplitBySize(doc, 0); err == nil {
+		t.Error("SplitBySize(0) succeeded")
+	}
+	if _, err := SplitBySize(doc, 1); err == nil {
+		t.Error("SplitBySize() with a record needing more than the limit succeeded")
+	}
+
+	parts, err := SplitBySize(doc, 10)
+	if err != nil || len(parts) != 1 {
+		t.Errorf("SplitBySize(10) = %d parts, %v, want 1 part", len(parts), err)
+	}
+	if parts, err := SplitBySize(nil, 10); parts != nil || err != nil {
+		t.Errorf("SplitBySize(nil) = %v, %v", parts, err)
+	}
+}
+
+func TestSplitBySizeEntities(t *testing.T) {
+	// Records built in code have entities but no tags
+	doc := createRelationshipTestDocument(
+		[]*Individual{
+			{XRef: "@I1@", SpouseInFamilies: []string{"@F1@"}},
+			{XRef: "@I2@", SpouseInFamilies: []string{"@F1@"}},
+			{XRef: "@I3@", ChildInFamilies: []FamilyLink{{FamilyXRef: "@F1@"}}},
+			{XRef: "@I4@", ChildInFamilies: []FamilyLink{{FamilyXRef: "@F1@"}},
+				Associations: []*Association{{IndividualXRef: "@I1@", Role: "GODP"}}},
+		},
+		[]*Family{{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@", Children: []string{"@I3@", "@I4@"}}},
+	)
+	for xref, record := range doc.XRefMap {
+		record.XRef = xref
+	}
+
+	parts, err := SplitBySize(doc, 5)
+	if err != nil {
+		t.Fatalf("SplitBySize() error = %v", err)
+	}
+	want := [][]string{{"@I1@", "@I2@", "@I3@", "@I4@", "@F1@"}}
+	if got := splitXRefs(parts); !reflect.DeepEqual(got, want) {
+		t.Fatalf("parts = %v, want %v (the family kept together)", got, want)
+	}
+
+	parts, err = SplitBySize(doc, 3)
+	if err != nil {
+		t.Fatalf("SplitBySize() error = %v", err)
+	}
+	want = [][]string{{"@I1@", "@I2@", "@I3@"}, {"@I4@", "@F1@"}}
+	if got := splitXRefs(parts); !reflect.DeepEqual(got, want) {
+		t.Fatalf("parts = %v, want %v", got, want)
+	}
+
+	// Copies keep an entity without the links that left the part
+	husband := parts[0].GetIndividual("@I1@")
+	if husband == nil || len(husband.SpouseInFamilies) != 0 {
+		t.Errorf("@I1@ = %+v, want an individual without FAMS", husband)
+	}
+	child := parts[1].GetIndividual("@I4@")
+	if child == nil || len(child.ChildInFamilies) != 1 || len(child.Associations) != 0 {
+		t.Errorf("@I4@ = %+v, want FAMC only", child)
+	}
+	fam := parts[1].GetFamily("@F1@")
+	if fam == nil || fam.Husband != "" || fam.Wife != "" || !reflect.DeepEqual(fam.Children, []string{"@I4@"}) {
+		t.Errorf("@F1@ = %+v, want only CHIL @I4@", fam)
+	}
+	if orig := doc.GetFamily("@F1@"); orig.Husband != "@I1@" || len(orig.Children) != 2 {
+		t.Error("original entity was modified")
+	}
+	if len(parts[0].Individuals()) != 3 || len(parts[1].Individuals()) != 1 {
+		t.Errorf("Individuals() = %d and %d, want 3 and 1", len(parts[0].Individuals()), len(parts[1].Individuals()))
+	}
+}
+
+func TestSplitBySizeRebuildsEntity(t *testing.T) {
+	doc := splitTestDoc(
+		"@I1@ INDI FAMS=@F1@",
+		"@F1@ FAM HUSB=@I1@ CHIL=@I2@",
+		"@I2@ INDI FAMC=@F1@",
+	)
+	doc.GetRecord("@F1@").Entity = &Family{XRef: "@F1@", Husband: "@I1@", Children: []string{"@I2@"}}
+
+	parts, err := SplitBySize(doc, 2)
+	if err != nil {
+		t.Fatalf("SplitBySize() error = %v", err)
+	}
+	fam := parts[0].GetFamily("@F1@")
+	if fam == nil || fam.Husband != "@I1@" || len(fam.Children) != 0 {
+		t.Errorf("@F1@ entity = %+v, want HUSB only", fam)
+	}
+}
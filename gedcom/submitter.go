@@ -25,6 +25,10 @@ type Submitter struct {
 	// Notes are references to note records
 	Notes []string
 
+	// Identifiers are the record's REFN and EXID identifiers in other
+	// systems, in document order
+	Identifiers []*Identifier
+
 	// Tags contains all raw tags for this submitter (for unknown/custom tags)
 	Tags []*Tag
 }
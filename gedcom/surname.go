@@ -0,0 +1,249 @@
+package gedcom
+
+import (
+	"sort"
+	"strings"
+)
+
+// soundexCodes maps letters to American Soundex digits. Vowels and Y map to
+// '0' and separate repeated codes; H and W are absent and do not.
+var soundexCodes = map[rune]byte{
+	'A': '0', 'E': '0', 'I': '0', 'O': '0', 'U': '0', 'Y': '0',
+	'B': '1', 'F': '1', 'P': '1', 'V': '1',
+	'C': '2', 'G': '2', 'J': '2', 'K': '2', 'Q': '2', 'S': '2', 'X': '2', 'Z': '2',
+	'D': '3', 'T': '3',
+	'L': '4',
+	'M': '5', 'N': '5',
+	'R': '6',
+}
+
+// Soundex returns the American Soundex code of name (e.g., "Robert" -> "R163").
+// Accents are removed before coding and characters other than letters are
+// ignored. Returns an empty string if name contains no letters.
+func Soundex(name string) string {
+	var code []byte
+	var last byte
+	for _, r := range strings.ToUpper(foldName(name)) {
+		digit, coded := soundexCodes[r]
+		if len(code) == 0 {
+			code = append(code, byte(r))
+			last = digit
+			continue
+		}
+		if !coded {
+			// H and W do not separate letters with the same code
+			continue
+		}
+		if digit != '0' && digit != last {
+			code = append(code, digit)
+			if len(code) == 4 {
+				break
+			}
+		}
+		last = digit
+	}
+	if len(code) == 0 {
+		return ""
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+// SurnameGroup is a cluster of surname spellings believed to be variants of
+// the same name.
+type SurnameGroup struct {
+	// Canonical is the most frequent spelling in the group (alphabetically
+	// first on ties)
+	Canonical string
+
+	// Variants are all spellings in the group, including Canonical, sorted
+	// by descending frequency and then alphabetically
+	Variants []string
+
+	// Counts holds the number of occurrences of each variant
+	Counts map[string]int
+
+	// Soundex is the American Soundex code shared by the group
+	Soundex string
+}
+
+// Total returns the number of occurrences across all variants.
+func (g *SurnameGroup) Total() int {
+	total := 0
+	for _, n := range g.Counts {
+		total += n
+	}
+	return total
+}
+
+// ClusterSurnames groups surname spellings into variant clusters. Spellings
+// are compared case-insensitively; two spellings are variants when they share
+// a Soundex code and their edit distance is at most a third of the longer
+// spelling's length (minimum 1), so Smith, Smyth, and Smythe cluster while
+// Smith and Snead do not. Clustering is transitive.
+//
+// The input may repeat spellings; repetitions determine the canonical
+// spelling. The first spelling seen for each case-insensitive form is used.
+// Groups are sorted by descending total count and then by canonical spelling.
+func ClusterSurnames(surnames []string) []SurnameGroup {
+	counts := make(map[string]int)
+	spelling := make(map[string]string)
+	var keys []string
+	for _, s := range surnames {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		key := strings.ToLower(s)
+		if _, seen := spelling[key]; !seen {
+			spelling[key] = s
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+
+	// Union spellings within each Soundex bucket
+	parent := make([]int, len(keys))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	codes := make([]string, len(keys))
+	buckets := make(map[string][]int)
+	for i, key := range keys {
+		codes[i] = Soundex(key)
+		buckets[codes[i]] = append(buckets[codes[i]], i)
+	}
+	for _, bucket := range buckets {
+		for a := 0; a < len(bucket); a++ {
+			for b := a + 1; b < len(bucket); b++ {
+				i, j := bucket[a], bucket[b]
+				if codes[i] != "" && isSurnameVariant(keys[i], keys[j]) {
+					parent[find(i)] = find(j)
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	var roots []int
+	for i := range keys {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], i)
+	}
+
+	groups := make([]SurnameGroup, 0, len(roots))
+	for _, root := range roots {
+		idx := members[root]
+		sort.Slice(idx, func(a, b int) bool {
+			if counts[keys[idx[a]]] != counts[keys[idx[b]]] {
+				return counts[keys[idx[a]]] > counts[keys[idx[b]]]
+			}
+			return keys[idx[a]] < keys[idx[b]]
+		})
+		group := SurnameGroup{Counts: make(map[string]int), Soundex: codes[root]}
+		for _, i := range idx {
+			group.Variants = append(group.Variants, spelling[keys[i]])
+			group.Counts[spelling[keys[i]]] = counts[keys[i]]
+		}
+		group.Canonical = group.Variants[0]
+		groups = append(groups, group)
+	}
+
+	sort.SliceStable(groups, func(a, b int) bool {
+		if ta, tb := groups[a].Total(), groups[b].Total(); ta != tb {
+			return ta > tb
+		}
+		return strings.ToLower(groups[a].Canonical) < strings.ToLower(groups[b].Canonical)
+	})
+	return groups
+}
+
+// isSurnameVariant reports whether two lowercase spellings are close enough
+// to be variants of one surname.
+func isSurnameVariant(a, b string) bool {
+	maxLen := len([]rune(a))
+	if n := len([]rune(b)); n > maxLen {
+		maxLen = n
+	}
+	limit := maxLen / 3
+	if limit < 1 {
+		limit = 1
+	}
+	return editDistance(a, b) <= limit
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	r1, r2 := []rune(a), []rune(b)
+	prev := make([]int, len(r2)+1)
+	curr := make([]int, len(r2)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(r1); i++ {
+		curr[0] = i
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(r2)]
+}
+
+// IndividualSurname returns the surname of an individual's primary name,
+// taken from the SURN value or, if absent, the slashes of the full name.
+func IndividualSurname(ind *Individual) string {
+	if ind == nil || len(ind.Names) == 0 {
+		return ""
+	}
+	return nameSurname(ind.Names[0])
+}
+
+// nameSurname returns the SURN value of name or, if absent, the part of the
+// full name between slashes.
+func nameSurname(name *PersonalName) string {
+	if name == nil {
+		return ""
+	}
+	if name.Surname != "" {
+		return strings.TrimSpace(name.Surname)
+	}
+	start := strings.Index(name.Full, "/")
+	end := strings.LastIndex(name.Full, "/")
+	if start == -1 || end <= start {
+		return ""
+	}
+	return strings.TrimSpace(name.Full[start+1 : end])
+}
+
+// SurnameClusters clusters the primary surnames of all individuals in the
+// document. See ClusterSurnames.
+func SurnameClusters(doc *Document) []SurnameGroup {
+	if doc == nil {
+		return nil
+	}
+	var surnames []string
+	for _, ind := range doc.Individuals() {
+		if s := IndividualSurname(ind); s != "" {
+			surnames = append(surnames, s)
+		}
+	}
+	return ClusterSurnames(surnames)
+}
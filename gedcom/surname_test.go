@@ -0,0 +1,87 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSoundex(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Robert", "R163"},
+		{"Rupert", "R163"},
+		{"Rubin", "R150"},
+		{"Ashcraft", "A261"},
+		{"Ashcroft", "A261"},
+		{"Tymczak", "T522"},
+		{"Pfister", "P236"},
+		{"Honeyman", "H555"},
+		{"Lee", "L000"},
+		{"o'Brien", "O165"},
+		{"Müller", "M460"},
+		{"Çelik", "C420"},
+		{"", ""},
+		{"123", ""},
+	}
+	for _, tt := range tests {
+		if got := Soundex(tt.name); got != tt.want {
+			t.Errorf("Soundex(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClusterSurnames(t *testing.T) {
+	groups := ClusterSurnames([]string{
+		"Smith", "Smith", "Smyth", "Smythe", "smith", "Snead", "Jones", "Johns", "  ",
+	})
+
+	if len(groups) != 4 {
+		t.Fatalf("len(groups) = %d, want 4: %+v", len(groups), groups)
+	}
+
+	smith := groups[0]
+	if smith.Canonical != "Smith" {
+		t.Errorf("Canonical = %q, want Smith", smith.Canonical)
+	}
+	if !reflect.DeepEqual(smith.Variants, []string{"Smith", "Smyth", "Smythe"}) {
+		t.Errorf("Variants = %v", smith.Variants)
+	}
+	if smith.Counts["Smith"] != 3 || smith.Total() != 5 {
+		t.Errorf("Counts = %v, Total = %d", smith.Counts, smith.Total())
+	}
+	if smith.Soundex != "S530" {
+		t.Errorf("Soundex = %q, want S530", smith.Soundex)
+	}
+
+	// Same Soundex code alone is not enough: Jones/Johns and Smith/Snead
+	// are too far apart to be spelling variants
+	for i, want := range []string{"Johns", "Jones", "Snead"} {
+		if g := groups[i+1]; g.Canonical != want || len(g.Variants) != 1 {
+			t.Errorf("groups[%d] = %+v, want %s alone", i+1, g, want)
+		}
+	}
+
+	if got := ClusterSurnames(nil); len(got) != 0 {
+		t.Errorf("ClusterSurnames(nil) = %v, want empty", got)
+	}
+}
+
+func TestIndividualSurname(t *testing.T) {
+	tests := []struct {
+		ind  *Individual
+		want string
+	}{
+		{nil, ""},
+		{&Individual{}, ""},
+		{&Individual{Names: []*PersonalName{{Full: "John /Smith/"}}}, "Smith"},
+		{&Individual{Names: []*PersonalName{{Full: "John /Smith/", Surname: "Smyth"}}}, "Smyth"},
+		{&Individual{Names: []*PersonalName{{Full: "John"}}}, ""},
+	}
+	for _, tt := range tests {
+		if got := IndividualSurname(tt.ind); got != tt.want {
+			t.Errorf("IndividualSurname() = %q, want %q", got, tt.want)
+		}
+	}
+}
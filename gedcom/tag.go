@@ -1,5 +1,13 @@
 package gedcom
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cacack/gedcom-go/tags"
+)
+
 // Tag represents a GEDCOM tag-value pair with hierarchical level information.
 // Tags are the fundamental building blocks of GEDCOM files, representing
 // structured data in a hierarchical format.
@@ -31,3 +39,94 @@ func (t *Tag) HasValue() bool {
 func (t *Tag) HasXRef() bool {
 	return t.XRef != ""
 }
+
+// Typed payload values returned by Tag.TypedValue.
+type (
+	// PointerValue is a cross-reference to a record (e.g., "@I1@")
+	PointerValue string
+
+	// EnumValue is an enumerated value normalized to upper case (e.g., SEX "M")
+	EnumValue string
+
+	// LanguageValue is a language name or BCP 47 tag (e.g., "English", "en-US")
+	LanguageValue string
+
+	// MediaTypeValue is a media type or, in GEDCOM 5.x, a file format
+	// (e.g., "image/jpeg", "jpg")
+	MediaTypeValue string
+)
+
+// TypedValue parses Value according to the payload type the tags catalog
+// defines for the tag and returns one of:
+//   - string for text, names, and times
+//   - PointerValue for cross-references
+//   - *Date for dates
+//   - *Age for ages
+//   - int for integers
+//   - EnumValue, LanguageValue, or MediaTypeValue
+//   - bool for event tags whose value is empty or "Y"
+//
+// Tags whose payload may be a pointer or text (NOTE, SOUR) return a
+// PointerValue when Value is a cross-reference and a string otherwise.
+// Extension and unknown tags return Value as a string. An empty Value
+// returns nil, except on "Y" event tags. The value is parsed on each call.
+func (t *Tag) TypedValue() (interface{}, error) {
+	info, ok := tags.Lookup(tags.Tag(t.Tag))
+	if !ok {
+		if t.Value == "" {
+			return nil, nil
+		}
+		return t.Value, nil
+	}
+	if info.Payload == tags.PayloadOptionalY {
+		switch strings.TrimSpace(t.Value) {
+		case "":
+			return false, nil
+		case "Y":
+			return true, nil
+		}
+		return nil, fmt.Errorf("%s: invalid value '%s': want empty or Y", t.Tag, t.Value)
+	}
+	if t.Value == "" {
+		return nil, nil
+	}
+
+	switch info.Payload {
+	case tags.PayloadPointer:
+		if !isPointerValue(t.Value) {
+			return nil, fmt.Errorf("%s: invalid pointer '%s'", t.Tag, t.Value)
+		}
+		return PointerValue(t.Value), nil
+	case tags.PayloadPointerOrText:
+		if isPointerValue(t.Value) {
+			return PointerValue(t.Value), nil
+		}
+		return t.Value, nil
+	case tags.PayloadDate:
+		date, err := ParseDate(t.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t.Tag, err)
+		}
+		return date, nil
+	case tags.PayloadAge:
+		age, err := ParseAge(t.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t.Tag, err)
+		}
+		return age, nil
+	case tags.PayloadInteger:
+		n, err := strconv.Atoi(strings.TrimSpace(t.Value))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%s: invalid integer '%s'", t.Tag, t.Value)
+		}
+		return n, nil
+	case tags.PayloadEnum:
+		return EnumValue(strings.ToUpper(strings.TrimSpace(t.Value))), nil
+	case tags.PayloadLanguage:
+		return LanguageValue(strings.TrimSpace(t.Value)), nil
+	case tags.PayloadMediaType:
+		return MediaTypeValue(strings.TrimSpace(t.Value)), nil
+	default:
+		return t.Value, nil
+	}
+}
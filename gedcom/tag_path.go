@@ -0,0 +1,252 @@
+package gedcom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is one step of a tag path: a tag name and an optional index
+// among same-named siblings (-1 selects all).
+type pathSegment struct {
+	tag   string
+	index int
+}
+
+// parseTagPath parses a dotted tag path such as "BIRT[1].DATE".
+// Returns false if the path is malformed.
+func parseTagPath(path string) ([]pathSegment, bool) {
+	if path == "" {
+		return nil, false
+	}
+
+	parts := strings.Split(path, ".")
+	segments := make([]pathSegment, 0, len(parts))
+	for _, part := range parts {
+		seg := pathSegment{tag: part, index: -1}
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			if !strings.HasSuffix(part, "]") {
+				return nil, false
+			}
+			n, err := strconv.Atoi(part[open+1 : len(part)-1])
+			if err != nil || n < 0 {
+				return nil, false
+			}
+			seg.tag = part[:open]
+			seg.index = n
+		}
+		if seg.tag == "" {
+			return nil, false
+		}
+		segments = append(segments, seg)
+	}
+	return segments, true
+}
+
+// Find returns the first tag matching a dotted tag path, or nil if none match
+// or the path is malformed.
+//
+// Paths are relative to the record: "NAME.SURN" selects SURN under any NAME.
+// An index selects among same-named siblings, counting from 0: "BIRT[1].DATE"
+// selects the DATE of the second BIRT.
+func (r *Record) Find(path string) *Tag {
+	indices := r.findIndices(path)
+	if len(indices) == 0 {
+		return nil
+	}
+	return r.Tags[indices[0]]
+}
+
+// FindAll returns every tag matching a dotted tag path, in document order.
+// Returns nil if none match or the path is malformed. See Find for the path syntax.
+func (r *Record) FindAll(path string) []*Tag {
+	indices := r.findIndices(path)
+	if len(indices) == 0 {
+		return nil
+	}
+	tags := make([]*Tag, len(indices))
+	for i, idx := range indices {
+		tags[i] = r.Tags[idx]
+	}
+	return tags
+}
+
+// findIndices returns the positions in r.Tags of tags matching path.
+func (r *Record) findIndices(path string) []int {
+	segments, ok := parseTagPath(path)
+	if !ok || r == nil {
+		return nil
+	}
+	return r.findSegments(segments)
+}
+
+// findSegments returns the positions in r.Tags of tags matching a parsed path.
+func (r *Record) findSegments(segments []pathSegment) []int {
+	if len(r.Tags) == 0 {
+		return nil
+	}
+
+	// The first segment selects among the record's top-level tags
+	current := []int{-1}
+	for _, seg := range segments {
+		var next []int
+		for _, parent := range current {
+			matched := 0
+			for _, child := range r.childIndices(parent) {
+				if r.Tags[child].Tag != seg.tag {
+					continue
+				}
+				if seg.index < 0 || seg.index == matched {
+					next = append(next, child)
+				}
+				matched++
+			}
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
+
+// childIndices returns the positions of the direct children of the tag at
+// position parent, or of the record's top-level tags when parent is -1.
+func (r *Record) childIndices(parent int) []int {
+	var children []int
+	if parent < 0 {
+		base := r.baseLevel()
+		for i, tag := range r.Tags {
+			if tag.Level == base {
+				children = append(children, i)
+			}
+		}
+		return children
+	}
+
+	level := r.Tags[parent].Level
+	for i := parent + 1; i < len(r.Tags) && r.Tags[i].Level > level; i++ {
+		if r.Tags[i].Level == level+1 {
+			children = append(children, i)
+		}
+	}
+	return children
+}
+
+// baseLevel returns the level of the record's top-level tags (1 if the record
+// has no tags).
+func (r *Record) baseLevel() int {
+	if len(r.Tags) == 0 {
+		return 1
+	}
+	base := r.Tags[0].Level
+	for _, tag := range r.Tags {
+		if tag.Level < base {
+			base = tag.Level
+		}
+	}
+	return base
+}
+
+// subtreeEnd returns the position just past the subtree rooted at pos.
+func (r *Record) subtreeEnd(pos int) int {
+	end := pos + 1
+	for end < len(r.Tags) && r.Tags[end].Level > r.Tags[pos].Level {
+		end++
+	}
+	return end
+}
+
+// insertChild inserts a new tag as the last child of the tag at position
+// parent (or at the end of the record when parent is -1) and returns its
+// position. The new tag has LineNumber 0, marking it as not read from source.
+func (r *Record) insertChild(parent int, name, value string) int {
+	level, pos := r.baseLevel(), len(r.Tags)
+	if parent >= 0 {
+		level, pos = r.Tags[parent].Level+1, r.subtreeEnd(parent)
+	}
+
+	r.Tags = append(r.Tags, nil)
+	copy(r.Tags[pos+1:], r.Tags[pos:])
+	r.Tags[pos] = &Tag{Level: level, Tag: name, Value: value}
+	r.tagsModified = true
+	return pos
+}
+
+// Tag mutators mark the record's tags as modified (see Record.TagsModified).
+
+// SetTag sets the value of the first tag matching a dotted tag path, creating
+// the tag and any missing ancestors if needed. New tags are appended as the
+// last child of their parent with the correct level. An indexed segment may
+// create at most the next sibling: "NAME[1]" creates a second NAME only if
+// exactly one exists. See Find for the path syntax.
+func (r *Record) SetTag(path, value string) error {
+	segments, ok := parseTagPath(path)
+	if !ok {
+		return fmt.Errorf("invalid tag path %q", path)
+	}
+
+	parent := -1
+	for _, seg := range segments {
+		var matches []int
+		for _, child := range r.childIndices(parent) {
+			if r.Tags[child].Tag == seg.tag {
+				matches = append(matches, child)
+			}
+		}
+
+		switch {
+		case seg.index < 0 && len(matches) > 0:
+			parent = matches[0]
+		case seg.index >= 0 && seg.index < len(matches):
+			parent = matches[seg.index]
+		case seg.index < 0 || seg.index == len(matches):
+			parent = r.insertChild(parent, seg.tag, "")
+		default:
+			return fmt.Errorf("tag path %q: index %d out of range (%d %s tags)", path, seg.index, len(matches), seg.tag)
+		}
+	}
+
+	r.Tags[parent].Value = value
+	r.tagsModified = true
+	return nil
+}
+
+// AddTag appends a new tag at a dotted tag path and returns it. The parent
+// path must already exist; the new tag becomes the parent's last child. The
+// final path segment must not have an index. See Find for the path syntax.
+func (r *Record) AddTag(path, value string) (*Tag, error) {
+	segments, ok := parseTagPath(path)
+	if !ok {
+		return nil, fmt.Errorf("invalid tag path %q", path)
+	}
+	last := segments[len(segments)-1]
+	if last.index >= 0 {
+		return nil, fmt.Errorf("tag path %q: final segment cannot have an index", path)
+	}
+
+	parent := -1
+	if len(segments) > 1 {
+		parents := r.findSegments(segments[:len(segments)-1])
+		if len(parents) == 0 {
+			return nil, fmt.Errorf("tag path %q: parent not found", path)
+		}
+		parent = parents[0]
+	}
+
+	return r.Tags[r.insertChild(parent, last.tag, value)], nil
+}
+
+// RemoveTag removes every tag matching a dotted tag path along with its
+// subordinate tags, and returns the number of matching tags removed.
+// See Find for the path syntax.
+func (r *Record) RemoveTag(path string) int {
+	indices := r.findIndices(path)
+	for i := len(indices) - 1; i >= 0; i-- {
+		pos := indices[i]
+		end := r.subtreeEnd(pos)
+		r.Tags = append(r.Tags[:pos], r.Tags[end:]...)
+		r.tagsModified = true
+	}
+	return len(indices)
+}
@@ -0,0 +1,247 @@
+package gedcom
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func pathTestRecord() *Record {
+	return &Record{
+		XRef: "@I1@",
+		Type: RecordTypeIndividual,
+		Tags: []*Tag{
+			{Level: 1, Tag: "NAME", Value: "John /Smith/"},
+			{Level: 2, Tag: "GIVN", Value: "John"},
+			{Level: 2, Tag: "SURN", Value: "Smith"},
+			{Level: 1, Tag: "NAME", Value: "Johnny /Smith/"},
+			{Level: 2, Tag: "SURN", Value: "Smyth"},
+			{Level: 1, Tag: "BIRT"},
+			{Level: 2, Tag: "DATE", Value: "1 JAN 1900"},
+			{Level: 1, Tag: "BIRT"},
+			{Level: 2, Tag: "DATE", Value: "ABT 1901"},
+			{Level: 2, Tag: "SOUR", Value: "@S1@"},
+			{Level: 3, Tag: "DATE", Value: "1950"},
+		},
+	}
+}
+
+func TestRecordFind(t *testing.T) {
+	r := pathTestRecord()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"NAME", "John /Smith/"},
+		{"NAME.SURN", "Smith"},
+		{"NAME[1].SURN", "Smyth"},
+		{"BIRT[1].DATE", "ABT 1901"},
+		{"BIRT.DATE", "1 JAN 1900"},
+		{"BIRT[1].SOUR.DATE", "1950"},
+		{"BIRT[0].SOUR", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			tag := r.Find(tt.path)
+			if tt.want == "" {
+				if tag != nil {
+					t.Errorf("Find(%q) = %+v, want nil", tt.path, tag)
+				}
+				return
+			}
+			if tag == nil || tag.Value != tt.want {
+				t.Errorf("Find(%q) = %+v, want value %q", tt.path, tag, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordFindAll(t *testing.T) {
+	r := pathTestRecord()
+
+	if got := r.FindAll("NAME.SURN"); len(got) != 2 || got[0].Value != "Smith" || got[1].Value != "Smyth" {
+		t.Errorf("FindAll(NAME.SURN) = %+v", got)
+	}
+	// DATE directly under BIRT only, not the nested SOUR.DATE
+	if got := r.FindAll("BIRT.DATE"); len(got) != 2 {
+		t.Errorf("FindAll(BIRT.DATE) = %d tags, want 2", len(got))
+	}
+	if got := r.FindAll("DEAT"); got != nil {
+		t.Errorf("FindAll(DEAT) = %+v, want nil", got)
+	}
+}
+
+func TestRecordFindInvalidPath(t *testing.T) {
+	r := pathTestRecord()
+	for _, path := range []string{"", ".", "NAME.", "NAME[", "NAME[x]", "NAME[-1]", "[0]"} {
+		if tag := r.Find(path); tag != nil {
+			t.Errorf("Find(%q) = %+v, want nil", path, tag)
+		}
+	}
+
+	var nilRecord *Record
+	if tag := nilRecord.Find("NAME"); tag != nil {
+		t.Error("Find on nil record should return nil")
+	}
+	if tag := (&Record{}).Find("NAME"); tag != nil {
+		t.Error("Find on empty record should return nil")
+	}
+}
+
+func tagLines(r *Record) []string {
+	lines := make([]string, len(r.Tags))
+	for i, tag := range r.Tags {
+		lines[i] = strconv.Itoa(tag.Level) + " " + tag.Tag
+		if tag.Value != "" {
+			lines[i] += " " + tag.Value
+		}
+	}
+	return lines
+}
+
+func assertTagLines(t *testing.T, r *Record, want []string) {
+	t.Helper()
+	got := tagLines(r)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("tags =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRecordSetTag(t *testing.T) {
+	r := &Record{Tags: []*Tag{
+		{Level: 1, Tag: "NAME", Value: "John /Smith/", LineNumber: 2},
+		{Level: 1, Tag: "BIRT", LineNumber: 3},
+		{Level: 2, Tag: "DATE", Value: "1900", LineNumber: 4},
+		{Level: 1, Tag: "SEX", Value: "M", LineNumber: 5},
+	}}
+
+	// Update existing
+	if err := r.SetTag("BIRT.DATE", "1 JAN 1900"); err != nil {
+		t.Fatalf("SetTag() error = %v", err)
+	}
+	// Create leaf under existing parent
+	if err := r.SetTag("BIRT.PLAC", "Boston"); err != nil {
+		t.Fatalf("SetTag() error = %v", err)
+	}
+	// Create missing ancestors
+	if err := r.SetTag("DEAT.PLAC.MAP.LATI", "N42.36"); err != nil {
+		t.Fatalf("SetTag() error = %v", err)
+	}
+	// Create the next indexed sibling
+	if err := r.SetTag("NAME[1]", "Johnny /Smith/"); err != nil {
+		t.Fatalf("SetTag() error = %v", err)
+	}
+
+	assertTagLines(t, r, []string{
+		"1 NAME John /Smith/",
+		"1 BIRT",
+		"2 DATE 1 JAN 1900",
+		"2 PLAC Boston",
+		"1 SEX M",
+		"1 DEAT",
+		"2 PLAC",
+		"3 MAP",
+		"4 LATI N42.36",
+		"1 NAME Johnny /Smith/",
+	})
+
+	if r.Tags[2].LineNumber != 4 || r.Tags[3].LineNumber != 0 || r.Tags[4].LineNumber != 5 {
+		t.Errorf("line numbers = %d, %d, %d; want source lines kept and 0 for new tags",
+			r.Tags[2].LineNumber, r.Tags[3].LineNumber, r.Tags[4].LineNumber)
+	}
+
+	if err := r.SetTag("NAME[5]", "x"); err == nil {
+		t.Error("SetTag() with out-of-range index should fail")
+	}
+	if err := r.SetTag("BAD[", "x"); err == nil {
+		t.Error("SetTag() with malformed path should fail")
+	}
+}
+
+func TestRecordSetTagEmptyRecord(t *testing.T) {
+	r := &Record{}
+	if err := r.SetTag("NAME.GIVN", "John"); err != nil {
+		t.Fatalf("SetTag() error = %v", err)
+	}
+	assertTagLines(t, r, []string{"1 NAME", "2 GIVN John"})
+}
+
+func TestRecordAddTag(t *testing.T) {
+	r := pathTestRecord()
+
+	tag, err := r.AddTag("BIRT[0].NOTE", "First birth record")
+	if err != nil {
+		t.Fatalf("AddTag() error = %v", err)
+	}
+	if tag.Level != 2 || r.Tags[7] != tag {
+		t.Errorf("added tag = %+v at wrong position", tag)
+	}
+
+	if _, err := r.AddTag("NOTE", "Top-level note"); err != nil {
+		t.Fatalf("AddTag() error = %v", err)
+	}
+	if last := r.Tags[len(r.Tags)-1]; last.Tag != "NOTE" || last.Level != 1 {
+		t.Errorf("last tag = %+v", last)
+	}
+
+	if _, err := r.AddTag("DEAT.DATE", "1950"); err == nil {
+		t.Error("AddTag() with missing parent should fail")
+	}
+	if _, err := r.AddTag("BIRT.NOTE[0]", "x"); err == nil {
+		t.Error("AddTag() with indexed final segment should fail")
+	}
+	if _, err := r.AddTag("", "x"); err == nil {
+		t.Error("AddTag() with empty path should fail")
+	}
+}
+
+func TestRecordRemoveTag(t *testing.T) {
+	r := pathTestRecord()
+
+	if n := r.RemoveTag("BIRT[1].SOUR"); n != 1 {
+		t.Errorf("RemoveTag(BIRT[1].SOUR) = %d, want 1", n)
+	}
+	if r.Find("BIRT[1].SOUR.DATE") != nil {
+		t.Error("subordinate tags should be removed with their parent")
+	}
+
+	if n := r.RemoveTag("NAME"); n != 2 {
+		t.Errorf("RemoveTag(NAME) = %d, want 2", n)
+	}
+	assertTagLines(t, r, []string{
+		"1 BIRT",
+		"2 DATE 1 JAN 1900",
+		"1 BIRT",
+		"2 DATE ABT 1901",
+	})
+
+	if n := r.RemoveTag("DEAT"); n != 0 {
+		t.Errorf("RemoveTag(DEAT) = %d, want 0", n)
+	}
+}
+
+func TestRecordModifiedState(t *testing.T) {
+	r := pathTestRecord()
+	if r.TagsModified() || r.EntityModified() {
+		t.Fatal("new record should not be modified")
+	}
+
+	if n := r.RemoveTag("DEAT"); n != 0 || r.TagsModified() {
+		t.Error("RemoveTag with no match should not mark tags modified")
+	}
+	if err := r.SetTag("SEX", "M"); err != nil || !r.TagsModified() {
+		t.Errorf("SetTag should mark tags modified (err = %v)", err)
+	}
+
+	r.MarkEntityModified()
+	if !r.EntityModified() {
+		t.Error("MarkEntityModified() not recorded")
+	}
+
+	r.ClearModified()
+	if r.TagsModified() || r.EntityModified() {
+		t.Error("ClearModified() should reset both flags")
+	}
+}
@@ -1,6 +1,7 @@
 package gedcom
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -117,6 +118,75 @@ func TestTag(t *testing.T) {
 	})
 }
 
+func TestTagTypedValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     Tag
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "text", tag: Tag{Tag: "OCCU", Value: "Farmer"}, want: "Farmer"},
+		{name: "name", tag: Tag{Tag: "NAME", Value: "John /Doe/"}, want: "John /Doe/"},
+		{name: "pointer", tag: Tag{Tag: "FAMC", Value: "@F1@"}, want: PointerValue("@F1@")},
+		{name: "bad pointer", tag: Tag{Tag: "FAMC", Value: "F1"}, wantErr: true},
+		{name: "note pointer", tag: Tag{Tag: "NOTE", Value: "@N1@"}, want: PointerValue("@N1@")},
+		{name: "note text", tag: Tag{Tag: "NOTE", Value: "Some text"}, want: "Some text"},
+		{name: "enum normalized", tag: Tag{Tag: "SEX", Value: " m "}, want: EnumValue("M")},
+		{name: "language", tag: Tag{Tag: "LANG", Value: "en-US"}, want: LanguageValue("en-US")},
+		{name: "media type", tag: Tag{Tag: "MIME", Value: "text/html"}, want: MediaTypeValue("text/html")},
+		{name: "integer", tag: Tag{Tag: "NCHI", Value: "4"}, want: 4},
+		{name: "bad integer", tag: Tag{Tag: "NCHI", Value: "four"}, wantErr: true},
+		{name: "event Y", tag: Tag{Tag: "BIRT", Value: "Y"}, want: true},
+		{name: "event empty", tag: Tag{Tag: "DEAT"}, want: false},
+		{name: "event bad", tag: Tag{Tag: "DEAT", Value: "yes"}, wantErr: true},
+		{name: "empty", tag: Tag{Tag: "DATE"}, want: nil},
+		{name: "bad date", tag: Tag{Tag: "DATE", Value: "32 FOO 1900 X"}, wantErr: true},
+		{name: "bad age", tag: Tag{Tag: "AGE", Value: "old"}, wantErr: true},
+		{name: "extension", tag: Tag{Tag: "_MILT", Value: "Army"}, want: "Army"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.tag.TypedValue()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TypedValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TypedValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("date", func(t *testing.T) {
+		tag := &Tag{Tag: "DATE", Value: "ABT 1850"}
+		got, err := tag.TypedValue()
+		if err != nil {
+			t.Fatalf("TypedValue() error = %v", err)
+		}
+		date, ok := got.(*Date)
+		if !ok {
+			t.Fatalf("TypedValue() = %T, want *Date", got)
+		}
+		if date.Year != 1850 || date.Modifier != ModifierAbout {
+			t.Errorf("date = %d %v, want ABT 1850", date.Year, date.Modifier)
+		}
+	})
+
+	t.Run("age", func(t *testing.T) {
+		tag := &Tag{Tag: "AGE", Value: "32y 5m"}
+		got, err := tag.TypedValue()
+		if err != nil {
+			t.Fatalf("TypedValue() error = %v", err)
+		}
+		age, ok := got.(*Age)
+		if !ok {
+			t.Fatalf("TypedValue() = %T, want *Age", got)
+		}
+		if age.Years != 32 || age.Months != 5 {
+			t.Errorf("age = %+v, want 32y 5m", age)
+		}
+	})
+}
+
 func TestRecord(t *testing.T) {
 	t.Run("IsIndividual", func(t *testing.T) {
 		record := &Record{Type: RecordTypeIndividual}
@@ -585,3 +655,101 @@ func TestNoteFullText(t *testing.T) {
 		}
 	})
 }
+
+func TestWarningString(t *testing.T) {
+	w := Warning{Code: "COMPAT_EMPTY_DATE", Line: 7, Message: "dropped empty DATE"}
+	if got, want := w.String(), "line 7: [COMPAT_EMPTY_DATE] dropped empty DATE"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	w.Line = 0
+	if got, want := w.String(), "[COMPAT_EMPTY_DATE] dropped empty DATE"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestDocumentHeaderSubmitter(t *testing.T) {
+	subm := &Submitter{XRef: "@U1@", Name: "Jane"}
+	doc := &Document{
+		Header:  &Header{Submitter: "@U1@"},
+		XRefMap: map[string]*Record{"@U1@": {XRef: "@U1@", Type: RecordTypeSubmitter, Entity: subm}},
+	}
+	if got := doc.HeaderSubmitter(); got != subm {
+		t.Errorf("HeaderSubmitter() = %v, want %v", got, subm)
+	}
+
+	doc.Header.Submitter = "@U2@"
+	if got := doc.HeaderSubmitter(); got != nil {
+		t.Errorf("HeaderSubmitter() with missing record = %v, want nil", got)
+	}
+	if got := (&Document{}).HeaderSubmitter(); got != nil {
+		t.Errorf("HeaderSubmitter() without header = %v, want nil", got)
+	}
+}
+
+func TestPlaceDetailEffectiveForm(t *testing.T) {
+	doc := &Document{Header: &Header{PlaceForm: "City, County, State, Country"}}
+
+	tests := []struct {
+		name  string
+		place *PlaceDetail
+		doc   *Document
+		want  string
+	}{
+		{"own form", &PlaceDetail{Form: "City, Country"}, doc, "City, Country"},
+		{"header form", &PlaceDetail{Name: "Springfield"}, doc, "City, County, State, Country"},
+		{"nil place", nil, doc, "City, County, State, Country"},
+		{"nil document", &PlaceDetail{Name: "Springfield"}, nil, ""},
+		{"no header", &PlaceDetail{Name: "Springfield"}, &Document{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.place.EffectiveForm(tt.doc); got != tt.want {
+				t.Errorf("EffectiveForm() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentDefaultLanguage(t *testing.T) {
+	doc := &Document{Header: &Header{Language: "English"}}
+	if got := doc.DefaultLanguage(); got != "English" {
+		t.Errorf("DefaultLanguage() = %q, want English", got)
+	}
+	if got := (&Document{}).DefaultLanguage(); got != "" {
+		t.Errorf("DefaultLanguage() without header = %q, want empty", got)
+	}
+	var nilDoc *Document
+	if got := nilDoc.DefaultLanguage(); got != "" {
+		t.Errorf("DefaultLanguage() on nil = %q, want empty", got)
+	}
+
+	if got := (&Note{Language: "fr"}).EffectiveLanguage(doc); got != "fr" {
+		t.Errorf("EffectiveLanguage() = %q, want fr", got)
+	}
+	if got := (&Note{}).EffectiveLanguage(doc); got != "English" {
+		t.Errorf("EffectiveLanguage() = %q, want English", got)
+	}
+	if got := (&Note{}).EffectiveLanguage(nil); got != "" {
+		t.Errorf("EffectiveLanguage(nil) = %q, want empty", got)
+	}
+}
+
+func TestRecordExtension(t *testing.T) {
+	record := &Record{Extensions: []Extension{
+		{Tag: "_MILT", Value: "Army"},
+		{Tag: "_APID", Value: 42},
+		{Tag: "_MILT", Value: "Navy"},
+	}}
+	if v, ok := record.Extension("_MILT"); !ok || v != "Army" {
+		t.Errorf("Extension(_MILT) = %v, %v, want Army, true", v, ok)
+	}
+	if _, ok := record.Extension("_UID"); ok {
+		t.Error("Extension(_UID) found, want none")
+	}
+	if got := record.ExtensionValues("_MILT"); !reflect.DeepEqual(got, []interface{}{"Army", "Navy"}) {
+		t.Errorf("ExtensionValues(_MILT) = %v", got)
+	}
+	if got := record.ExtensionValues("_UID"); got != nil {
+		t.Errorf("ExtensionValues(_UID) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,42 @@
+package gedcom
+
+import "strings"
+
+// FindByUID returns the individual or family record carrying uid in its UID
+// or UIDs, or nil if there is none. UIDs are compared ignoring case, hyphens,
+// and braces, so "{4A2B...}" written by one program matches "4a2b..."
+// written by another. Unlike XRefs, UIDs survive renumbering between
+// exports, which makes them the identity to sync on.
+func (d *Document) FindByUID(uid string) *Record {
+	want := normalizeUID(uid)
+	if want == "" {
+		return nil
+	}
+	for _, record := range d.Records {
+		var uid string
+		var uids []string
+		switch entity := record.LoadEntity().(type) {
+		case *Individual:
+			uid, uids = entity.UID, entity.UIDs
+		case *Family:
+			uid, uids = entity.UID, entity.UIDs
+		default:
+			continue
+		}
+		if normalizeUID(uid) == want {
+			return record
+		}
+		for _, value := range uids {
+			if normalizeUID(value) == want {
+				return record
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeUID returns uid uppercased without hyphens, braces, and
+// surrounding whitespace.
+func normalizeUID(uid string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", "{", "", "}", "").Replace(strings.TrimSpace(uid)))
+}
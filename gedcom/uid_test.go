@@ -0,0 +1,38 @@
+package gedcom
+
+import "testing"
+
+func TestDocumentFindByUID(t *testing.T) {
+	john := &Individual{XRef: "@I1@", UID: "7a6d2f1e-8c3b-4b5a-9f0e-1d2c3b4a5f60"}
+	mary := &Individual{XRef: "@I2@", UIDs: []string{"4A2B0C9D8E7F6A5B4C3D2E1F0A9B8C7D"}}
+	fam := &Family{XRef: "@F1@", UIDs: []string{"F-0001"}}
+	doc := createRelationshipTestDocument([]*Individual{john, mary}, []*Family{fam})
+
+	tests := []struct {
+		uid  string
+		want string
+	}{
+		{"7a6d2f1e-8c3b-4b5a-9f0e-1d2c3b4a5f60", "@I1@"},
+		{"{7A6D2F1E8C3B4B5A9F0E1D2C3B4A5F60}", "@I1@"},
+		{"4a2b0c9d-8e7f-6a5b-4c3d-2e1f0a9b8c7d", "@I2@"},
+		{" f0001 ", "@F1@"},
+		{"unknown", ""},
+		{"", ""},
+		{"--", ""},
+	}
+	for _, tt := range tests {
+		got := doc.FindByUID(tt.uid)
+		var xref string
+		if got != nil {
+			switch entity := got.Entity.(type) {
+			case *Individual:
+				xref = entity.XRef
+			case *Family:
+				xref = entity.XRef
+			}
+		}
+		if xref != tt.want {
+			t.Errorf("FindByUID(%q) = %q, want %q", tt.uid, xref, tt.want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package gedcom
+
+import "fmt"
+
+// Warning describes a non-fatal problem that was detected or corrected while
+// decoding, recording where the decoder deviated from the literal file
+// content. Some warnings mean content was dropped, such as
+// UNKNOWN_RECORD_SKIPPED for a skipped record or COMPAT_EMPTY_DATE for a
+// removed DATE line, so a decode with warnings is not necessarily lossless.
+type Warning struct {
+	// Code is a stable identifier for the kind of warning (e.g., "COMPAT_EMPTY_DATE")
+	Code string
+
+	// Line is the source line number the warning refers to (1-based), or 0 if unknown
+	Line int
+
+	// Message is a human-readable description
+	Message string
+}
+
+// String returns a formatted representation of the warning.
+func (w Warning) String() string {
+	if w.Line > 0 {
+		return fmt.Sprintf("line %d: [%s] %s", w.Line, w.Code, w.Message)
+	}
+	return fmt.Sprintf("[%s] %s", w.Code, w.Message)
+}
@@ -0,0 +1,135 @@
+// Package gedcomtest provides test helpers for applications that embed
+// gedcom-go, so they can hold the library to the same guarantees its own
+// test suite checks.
+package gedcomtest
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/cacack/gedcom-go/decoder"
+)
+
+// DecodeBudget is an allocation budget for decoding, per decoded record.
+// Per-record figures stay roughly constant as files grow, so one budget
+// covers small fixtures and large exports alike.
+type DecodeBudget struct {
+	// AllocsPerRecord is the maximum number of heap allocations per record
+	AllocsPerRecord float64
+
+	// BytesPerRecord is the maximum number of heap bytes allocated per record
+	BytesPerRecord float64
+}
+
+// DefaultDecodeBudget is the budget the library is held to for
+// decoder.DecodeWithOptions with default options on typical genealogy
+// files, such as the royal92 and pres2020 corpora in testdata (about 42 and
+// 68 allocations, 3.4KB and 5.2KB per record). It leaves about 25% headroom
+// over the costlier of the two, so exceeding it signals a real regression
+// rather than noise.
+//
+// Each decode also has a fixed cost of about 70KB for read buffers, which
+// dominates files with only a few records; check the budget against files
+// with at least several hundred records. Files dominated by long notes can
+// legitimately cost more; measure them with MeasureDecode and set a budget
+// of their own.
+var DefaultDecodeBudget = DecodeBudget{
+	AllocsPerRecord: 85,
+	BytesPerRecord:  6500,
+}
+
+// DecodeCost is the measured allocation cost of decoding a file.
+type DecodeCost struct {
+	// Records is the number of records in the decoded document
+	Records int
+
+	// AllocsPerRecord is the average number of heap allocations per record
+	AllocsPerRecord float64
+
+	// BytesPerRecord is the average number of heap bytes allocated per record
+	BytesPerRecord float64
+}
+
+// MeasureDecode decodes data runs times with opts (nil for defaults) and
+// returns the average allocation cost per record. A decode error, or data
+// without records, is returned as an error.
+func MeasureDecode(data []byte, opts *decoder.DecodeOptions, runs int) (DecodeCost, error) {
+	if runs < 1 {
+		runs = 1
+	}
+
+	// A first decode counts the records and warms up lazily initialized state
+	doc, err := decoder.DecodeWithOptions(bytes.NewReader(data), opts)
+	if err != nil {
+		return DecodeCost{}, err
+	}
+	records := len(doc.Records)
+	if records == 0 {
+		return DecodeCost{}, fmt.Errorf("gedcomtest: no records decoded")
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	for i := 0; i < runs; i++ {
+		if _, err := decoder.DecodeWithOptions(bytes.NewReader(data), opts); err != nil {
+			return DecodeCost{}, err
+		}
+	}
+	runtime.ReadMemStats(&after)
+
+	total := float64(runs * records)
+	return DecodeCost{
+		Records:         records,
+		AllocsPerRecord: float64(after.Mallocs-before.Mallocs) / total,
+		BytesPerRecord:  float64(after.TotalAlloc-before.TotalAlloc) / total,
+	}, nil
+}
+
+// CheckDecodeBudget decodes data with opts and fails tb if the cost per
+// record exceeds budget. Inside a benchmark it also reports the cost as
+// allocs/record and B/record metrics, so the numbers can be tracked with
+// benchstat between library upgrades; call it once per benchmark function,
+// not inside a b.N loop, as it decodes b.N times itself.
+//
+//	func TestDecodeBudget(t *testing.T) {
+//	    data, _ := os.ReadFile("testdata/family.ged")
+//	    gedcomtest.CheckDecodeBudget(t, data, nil, gedcomtest.DefaultDecodeBudget)
+//	}
+//
+// The race detector inflates allocation counts several times over, so the
+// check is skipped in binaries built with -race. It is also skipped in short
+// mode, so other instrumented runs can opt out with -short.
+func CheckDecodeBudget(tb testing.TB, data []byte, opts *decoder.DecodeOptions, budget DecodeBudget) {
+	tb.Helper()
+	if testing.Short() {
+		tb.Skip("gedcomtest: allocation budget not checked in short mode")
+	}
+	if raceEnabled {
+		tb.Skip("gedcomtest: allocation budget not checked with the race detector")
+	}
+
+	runs := 5
+	if b, ok := tb.(*testing.B); ok {
+		runs = max(b.N, 1)
+	}
+	cost, err := MeasureDecode(data, opts, runs)
+	if err != nil {
+		tb.Fatalf("gedcomtest: decode failed: %v", err)
+	}
+	if b, ok := tb.(*testing.B); ok {
+		b.ReportMetric(cost.AllocsPerRecord, "allocs/record")
+		b.ReportMetric(cost.BytesPerRecord, "B/record")
+	}
+
+	if budget.AllocsPerRecord > 0 && cost.AllocsPerRecord > budget.AllocsPerRecord {
+		tb.Errorf("gedcomtest: %.1f allocs/record over %d records exceeds budget of %.1f",
+			cost.AllocsPerRecord, cost.Records, budget.AllocsPerRecord)
+	}
+	if budget.BytesPerRecord > 0 && cost.BytesPerRecord > budget.BytesPerRecord {
+		tb.Errorf("gedcomtest: %.0f B/record over %d records exceeds budget of %.0f",
+			cost.BytesPerRecord, cost.Records, budget.BytesPerRecord)
+	}
+}
@@ -0,0 +1,107 @@
+package gedcomtest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+var budgetCorpora = []string{
+	"../testdata/gedcom-5.5/royal92.ged",
+	"../testdata/gedcom-5.5/pres2020.ged",
+}
+
+func TestDefaultDecodeBudget(t *testing.T) {
+	for _, path := range budgetCorpora {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		t.Run(path[strings.LastIndex(path, "/")+1:], func(t *testing.T) {
+			CheckDecodeBudget(t, data, nil, DefaultDecodeBudget)
+		})
+	}
+}
+
+func BenchmarkDecodeBudget(b *testing.B) {
+	for _, path := range budgetCorpora {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			b.Skip("Test file not found:", err)
+		}
+		b.Run(path[strings.LastIndex(path, "/")+1:], func(b *testing.B) {
+			CheckDecodeBudget(b, data, nil, DefaultDecodeBudget)
+		})
+	}
+}
+
+func TestMeasureDecode(t *testing.T) {
+	data := []byte("0 HEAD\n0 @I1@ INDI\n1 NAME John /Smith/\n0 @I2@ INDI\n0 TRLR\n")
+	cost, err := MeasureDecode(data, nil, 2)
+	if err != nil {
+		t.Fatalf("MeasureDecode() error = %v", err)
+	}
+	if cost.Records != 2 || cost.AllocsPerRecord <= 0 || cost.BytesPerRecord <= 0 {
+		t.Errorf("MeasureDecode() = %+v", cost)
+	}
+
+	if _, err := MeasureDecode([]byte("0 HEAD\n0 TRLR\n"), nil, 1); err == nil {
+		t.Error("MeasureDecode() without records should fail")
+	}
+	if _, err := MeasureDecode([]byte("not gedcom"), nil, 1); err == nil {
+		t.Error("MeasureDecode() on invalid input should fail")
+	}
+}
+
+// recordingTB captures failures and skips instead of failing the test.
+// Fatalf and Skipf return, so callers must tolerate running on afterwards.
+type recordingTB struct {
+	testing.TB
+	errors []string
+	fatals []string
+	skips  []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Fatalf(format string, args ...interface{}) {
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Skipf(format string, args ...interface{}) {
+	r.skips = append(r.skips, fmt.Sprintf(format, args...))
+}
+
+func TestCheckDecodeBudgetExceeded(t *testing.T) {
+	if testing.Short() || raceEnabled {
+		t.Skip("budget checks are skipped in short mode and with the race detector")
+	}
+	data := []byte("0 HEAD\n0 @I1@ INDI\n1 NAME John /Smith/\n0 TRLR\n")
+	tb := &recordingTB{TB: t}
+	CheckDecodeBudget(tb, data, nil, DecodeBudget{AllocsPerRecord: 1, BytesPerRecord: 1})
+	if len(tb.errors) != 2 {
+		t.Errorf("errors = %q, want allocation and byte failures", tb.errors)
+	}
+
+	tb = &recordingTB{TB: t}
+	CheckDecodeBudget(tb, data, nil, DecodeBudget{})
+	if len(tb.errors) != 0 {
+		t.Errorf("zero budget should not be checked, got %q", tb.errors)
+	}
+}
+
+func TestCheckDecodeBudgetDecodeError(t *testing.T) {
+	if testing.Short() || raceEnabled {
+		t.Skip("budget checks are skipped in short mode and with the race detector")
+	}
+	tb := &recordingTB{TB: t}
+	CheckDecodeBudget(tb, []byte("not gedcom"), nil, DefaultDecodeBudget)
+	if len(tb.fatals) != 1 {
+		t.Errorf("fatals = %q, want a decode failure", tb.fatals)
+	}
+}
@@ -0,0 +1,62 @@
+package gedcomtest
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// DefaultDeterminismRuns is the number of runs CheckDeterministic makes when
+// called with runs below 2. Go randomizes map iteration order on every range,
+// so a handful of runs catches output that depends on it with near
+// certainty.
+const DefaultDeterminismRuns = 5
+
+// CheckDeterministic runs export the given number of times and fails tb if
+// any run writes different bytes than the first, or returns an error.
+//
+// Every export of the library (encoder output, CSV and JSON writers, and
+// reports) is guaranteed to be byte-identical across runs for the same input.
+// Applications can hold their own exports to the same guarantee:
+//
+//	gedcomtest.CheckDeterministic(t, 0, func(w io.Writer) error {
+//	    return export.WriteEventsCSV(w, doc)
+//	})
+//
+// export should build anything derived from the input, such as a report,
+// afresh on each call, so that order decided while building is checked too.
+func CheckDeterministic(tb testing.TB, runs int, export func(w io.Writer) error) {
+	tb.Helper()
+	if runs < 2 {
+		runs = DefaultDeterminismRuns
+	}
+
+	var first []byte
+	for i := 0; i < runs; i++ {
+		var buf bytes.Buffer
+		if err := export(&buf); err != nil {
+			tb.Errorf("gedcomtest: export failed on run %d: %v", i+1, err)
+			return
+		}
+		if i == 0 {
+			first = buf.Bytes()
+			continue
+		}
+		if !bytes.Equal(first, buf.Bytes()) {
+			tb.Errorf("gedcomtest: run %d wrote different output than run 1, first difference at byte %d",
+				i+1, firstDifference(first, buf.Bytes()))
+			return
+		}
+	}
+}
+
+// firstDifference returns the offset of the first byte where a and b differ.
+func firstDifference(a, b []byte) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
@@ -0,0 +1,163 @@
+package gedcomtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/decoder"
+	"github.com/cacack/gedcom-go/encoder"
+	"github.com/cacack/gedcom-go/export"
+	"github.com/cacack/gedcom-go/gedcom"
+	"github.com/cacack/gedcom-go/validator"
+)
+
+// determinismRuns is the number of runs per export. The fixtures are small,
+// so more runs than the default keep map-order bugs from slipping through.
+const determinismRuns = 10
+
+func TestExportsDeterministic(t *testing.T) {
+	// Small fixtures with many places, families, and vendor tags exercise the
+	// same code paths as the large corpora at a fraction of the cost; the
+	// duplicate, kinship, and quality exports are quadratic in individuals.
+	for _, path := range []string{
+		"../testdata/edge-cases/relationships-complex.ged",
+		"../testdata/edge-cases/vendor-heredis.ged",
+		"../testdata/edge-cases/vendor-customtags-torture.ged",
+		"../testdata/gedcom-5.5.1/comprehensive.ged",
+		"../testdata/gedcom-7.0/maximal70.ged",
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc, err := decoder.Decode(strings.NewReader(string(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exports := map[string]func(w io.Writer) error{
+			"encoder": func(w io.Writer) error { return encoder.Encode(w, doc) },
+			"events":  func(w io.Writer) error { return export.WriteEventsCSV(w, doc) },
+			"event links": func(w io.Writer) error {
+				return export.WritePersonEventLinksCSV(w, doc)
+			},
+			"parent links": func(w io.Writer) error { return export.WriteParentLinksCSV(w, doc) },
+			"identifiers":  func(w io.Writer) error { return export.WriteIdentifiersCSV(w, doc) },
+			"dna matches":  func(w io.Writer) error { return export.WriteDNAMatchesCSV(w, doc) },
+			"repositories": func(w io.Writer) error { return export.WriteRepositoriesCSV(w, doc) },
+			"submitters":   func(w io.Writer) error { return export.WriteSubmittersCSV(w, doc) },
+			"sources":      func(w io.Writer) error { return export.WriteSourcesCSV(w, doc) },
+			"source coverage": func(w io.Writer) error {
+				return export.WriteSourceCoverageCSV(w, doc)
+			},
+			"source texts": func(w io.Writer) error { return export.WriteSourceTextsCSV(w, doc, -1) },
+			"citations":    func(w io.Writer) error { return export.WriteCitationsCSV(w, doc, 40) },
+			"citation texts": func(w io.Writer) error {
+				return export.WriteCitationTextsCSV(w, doc, 40)
+			},
+			"surnames":   func(w io.Writer) error { return export.WriteSurnameIndexCSV(w, doc) },
+			"phonetic":   func(w io.Writer) error { return export.WritePhoneticIndexCSV(w, doc) },
+			"migrations": func(w io.Writer) error { return export.WriteMigrationsCSV(w, doc) },
+			"geojson":    func(w io.Writer) error { return export.WriteMigrationsGeoJSON(w, doc) },
+			"burials geojson": func(w io.Writer) error {
+				return export.WriteBurialsGeoJSON(w, doc)
+			},
+			"kinship": func(w io.Writer) error {
+				return export.WriteKinshipCSV(w, doc, doc.Individuals()[0].XRef)
+			},
+			"burials kml":  func(w io.Writer) error { return export.WriteBurialsKML(w, doc) },
+			"outliers csv": func(w io.Writer) error { return export.WriteOutlierReportCSV(w, gedcom.BuildOutlierReport(doc, 0)) },
+			"outliers json": func(w io.Writer) error {
+				return export.WriteOutlierReportJSON(w, gedcom.BuildOutlierReport(doc, 0))
+			},
+			"heatmap csv":  func(w io.Writer) error { return export.WriteEventHeatmapCSV(w, gedcom.BuildEventHeatmap(doc, 2)) },
+			"heatmap json": func(w io.Writer) error { return export.WriteEventHeatmapJSON(w, gedcom.BuildEventHeatmap(doc, 2)) },
+			"fingerprint": func(w io.Writer) error {
+				_, err := io.WriteString(w, gedcom.Fingerprint(doc))
+				return err
+			},
+			"unhandled tags": func(w io.Writer) error {
+				return json.NewEncoder(w).Encode(doc.UnhandledTags())
+			},
+			"quality report": func(w io.Writer) error {
+				_, err := io.WriteString(w, validator.NewQualityAnalyzer().Analyze(doc).String())
+				return err
+			},
+			"quality json": func(w io.Writer) error {
+				data, err := validator.NewQualityAnalyzer().Analyze(doc).JSON()
+				if err != nil {
+					return err
+				}
+				_, err = w.Write(data)
+				return err
+			},
+			"duplicates": func(w io.Writer) error {
+				for _, pair := range validator.NewDuplicateDetector(nil).FindDuplicates(doc) {
+					fmt.Fprintf(w, "%s %s %.4f %v\n",
+						pair.Individual1.XRef, pair.Individual2.XRef, pair.Confidence, pair.MatchReasons)
+				}
+				return nil
+			},
+		}
+		for name, write := range exports {
+			t.Run(path[strings.LastIndex(path, "/")+1:]+"/"+name, func(t *testing.T) {
+				CheckDeterministic(t, determinismRuns, write)
+			})
+		}
+	}
+}
+
+func TestCheckDeterministicFailsOnMapOrder(t *testing.T) {
+	counts := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8}
+	tb := &recordingTB{TB: t}
+	CheckDeterministic(tb, 20, func(w io.Writer) error {
+		for k, v := range counts {
+			fmt.Fprintf(w, "%s=%d\n", k, v)
+		}
+		return nil
+	})
+	if len(tb.errors) == 0 {
+		t.Error("CheckDeterministic() passed output in map order, want failure")
+	}
+}
+
+func TestCheckDeterministicPasses(t *testing.T) {
+	tb := &recordingTB{TB: t}
+	CheckDeterministic(tb, 3, func(w io.Writer) error {
+		_, err := io.WriteString(w, "same")
+		return err
+	})
+	if len(tb.errors) > 0 {
+		t.Error("CheckDeterministic() failed for identical output")
+	}
+}
+
+func TestCheckDeterministicExportError(t *testing.T) {
+	tb := &recordingTB{TB: t}
+	CheckDeterministic(tb, 3, func(w io.Writer) error {
+		return fmt.Errorf("disk full")
+	})
+	if len(tb.errors) != 1 {
+		t.Errorf("errors = %q, want one export failure", tb.errors)
+	}
+}
+
+func TestFirstDifference(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"abc", "abd", 2},
+		{"abc", "abcd", 3},
+		{"abc", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := firstDifference([]byte(tt.a), []byte(tt.b)); got != tt.want {
+			t.Errorf("firstDifference(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !race
+
+package gedcomtest
+
+const raceEnabled = false
@@ -0,0 +1,7 @@
+//go:build race
+
+package gedcomtest
+
+// raceEnabled reports whether the race detector is on; it multiplies
+// allocation counts, so CheckDecodeBudget does not check budgets under it.
+const raceEnabled = true
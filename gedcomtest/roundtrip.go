@@ -0,0 +1,320 @@
+package gedcomtest
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/cacack/gedcom-go/decoder"
+	"github.com/cacack/gedcom-go/encoder"
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// RoundTripOptions configures RoundTrip and CheckRoundTrip. The zero value
+// decodes and encodes with default options.
+type RoundTripOptions struct {
+	// Decode is used for both decodes (nil for defaults)
+	Decode *decoder.DecodeOptions
+
+	// Encode is used to encode the first decoded document (nil for defaults)
+	Encode *encoder.EncodeOptions
+
+	// Transform, if set, is applied to the first decoded document before
+	// encoding, so an application can check its own edits survive a round
+	// trip. The re-decoded document is compared with the transformed one.
+	// Edits must be made to record tags (for example with Record.SetTag), or
+	// synced to them with encoder.SyncTags, since Compare compares tags.
+	Transform func(doc *gedcom.Document) error
+}
+
+// Divergence is the first difference Compare found between two documents.
+type Divergence struct {
+	// RecordXRef is the XRef of the differing record, or empty for the
+	// header, the record count, or a record without an XRef
+	RecordXRef string
+
+	// Path locates the difference: a tag path relative to the record in the
+	// form Record.Find accepts (such as "BIRT[1].DATE"), "HEAD.<field>" for
+	// a header field, or empty for the record itself
+	Path string
+
+	// Want and Got are the differing values in the expected and actual
+	// documents
+	Want string
+	Got  string
+
+	// Line is the line number of the expected tag or record, when known
+	Line int
+}
+
+// String describes the divergence, such as
+// `@I1@ BIRT.DATE: got "1900", want "1 JAN 1900" (line 12)`.
+func (d *Divergence) String() string {
+	where := d.RecordXRef
+	if d.Path != "" {
+		if where != "" {
+			where += " "
+		}
+		where += d.Path
+	}
+	if where == "" {
+		where = "document"
+	}
+	s := fmt.Sprintf("%s: got %q, want %q", where, d.Got, d.Want)
+	if d.Line > 0 {
+		s += fmt.Sprintf(" (line %d)", d.Line)
+	}
+	return s
+}
+
+// RoundTrip decodes data, applies opts.Transform, encodes the result,
+// decodes the output again, and compares the two decoded documents with
+// Compare. It returns the first divergence, or nil if the documents are
+// semantically equal. An error is returned if data does not decode or a
+// later step fails.
+func RoundTrip(data []byte, opts *RoundTripOptions) (*Divergence, error) {
+	if opts == nil {
+		opts = &RoundTripOptions{}
+	}
+
+	want, err := decoder.DecodeWithOptions(bytes.NewReader(data), opts.Decode)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Transform != nil {
+		if err := opts.Transform(want); err != nil {
+			return nil, fmt.Errorf("gedcomtest: transform failed: %w", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := encoder.EncodeWithOptions(&buf, want, opts.Encode); err != nil {
+		return nil, fmt.Errorf("gedcomtest: encode failed: %w", err)
+	}
+	got, err := decoder.DecodeWithOptions(&buf, opts.Decode)
+	if err != nil {
+		return nil, fmt.Errorf("gedcomtest: decode of encoded output failed: %w", err)
+	}
+
+	if opts.Encode != nil && opts.Encode.Encoding != "" && want.Header != nil && got.Header != nil {
+		// The requested output encoding is not a divergence
+		header := *want.Header
+		header.Encoding = got.Header.Encoding
+		want.Header = &header
+	}
+	return Compare(want, got), nil
+}
+
+// CheckRoundTrip runs RoundTrip on data and fails tb with the first
+// divergence. Data that does not decode in the first place is skipped, not
+// failed, so CheckRoundTrip can be called directly from a fuzz target:
+//
+//	func FuzzRoundTrip(f *testing.F) {
+//	    f.Add(seed)
+//	    f.Fuzz(func(t *testing.T, data []byte) {
+//	        gedcomtest.CheckRoundTrip(t, data, nil)
+//	    })
+//	}
+func CheckRoundTrip(tb testing.TB, data []byte, opts *RoundTripOptions) {
+	tb.Helper()
+	var decodeOpts *decoder.DecodeOptions
+	if opts != nil {
+		decodeOpts = opts.Decode
+	}
+	if _, err := decoder.DecodeWithOptions(bytes.NewReader(data), decodeOpts); err != nil {
+		tb.Skipf("gedcomtest: input does not decode: %v", err)
+	}
+
+	div, err := RoundTrip(data, opts)
+	if err != nil {
+		tb.Fatalf("gedcomtest: round trip failed: %v", err)
+	}
+	if div != nil {
+		tb.Errorf("gedcomtest: round trip diverged at %s", div)
+	}
+}
+
+// Compare reports the first semantic difference between two documents, or
+// nil if there is none. Records are compared in order by XRef, type, value,
+// and tag tree; header fields the encoder writes are compared by value.
+//
+// Differences that do not change meaning are ignored: line numbers, line
+// endings, and how text is split across CONT and CONC lines.
+func Compare(want, got *gedcom.Document) *Divergence {
+	if want == nil || got == nil {
+		if want != got {
+			return &Divergence{Want: present(want != nil), Got: present(got != nil)}
+		}
+		return nil
+	}
+
+	if div := compareHeaders(want.Header, got.Header); div != nil {
+		return div
+	}
+
+	n := min(len(want.Records), len(got.Records))
+	for i := 0; i < n; i++ {
+		if div := compareRecords(want.Records[i], got.Records[i]); div != nil {
+			return div
+		}
+	}
+	if len(want.Records) != len(got.Records) {
+		div := &Divergence{
+			Path: "records",
+			Want: strconv.Itoa(len(want.Records)) + " records",
+			Got:  strconv.Itoa(len(got.Records)) + " records",
+		}
+		if n < len(want.Records) {
+			div.RecordXRef = want.Records[n].XRef
+			div.Line = want.Records[n].LineNumber
+		}
+		return div
+	}
+	return nil
+}
+
+func present(ok bool) string {
+	if ok {
+		return "document"
+	}
+	return "nil"
+}
+
+// compareHeaders compares the header fields the encoder writes.
+func compareHeaders(want, got *gedcom.Header) *Divergence {
+	if want == nil || got == nil {
+		if want != got {
+			return &Divergence{Path: "HEAD", Want: present(want != nil), Got: present(got != nil)}
+		}
+		return nil
+	}
+	fields := []struct {
+		name      string
+		want, got string
+	}{
+		{"GEDC.VERS", string(want.Version), string(got.Version)},
+		{"CHAR", string(want.Encoding), string(got.Encoding)},
+		{"SOUR", want.SourceSystem, got.SourceSystem},
+		{"SOUR.VERS", want.SourceVersion, got.SourceVersion},
+		{"SOUR.NAME", want.SourceName, got.SourceName},
+		{"SOUR.CORP", want.SourceCorporation, got.SourceCorporation},
+		{"LANG", want.Language, got.Language},
+		{"COPR", want.Copyright, got.Copyright},
+		{"NOTE", want.Note, got.Note},
+		{"PLAC.FORM", want.PlaceForm, got.PlaceForm},
+		{"SUBM", want.Submitter, got.Submitter},
+	}
+	for _, f := range fields {
+		if f.want != f.got {
+			return &Divergence{Path: "HEAD." + f.name, Want: f.want, Got: f.got}
+		}
+	}
+	return nil
+}
+
+// node is a tag with its continuation lines folded into its value.
+type node struct {
+	tag      string
+	value    string
+	line     int
+	children []*node
+}
+
+// compareRecords compares two records and their tag trees.
+func compareRecords(want, got *gedcom.Record) *Divergence {
+	wantValue, wantTree := fold(want)
+	gotValue, gotTree := fold(got)
+
+	div := &Divergence{RecordXRef: want.XRef, Line: want.LineNumber}
+	switch {
+	case want.XRef != got.XRef:
+		div.Want, div.Got = want.XRef, got.XRef
+	case want.Type != got.Type:
+		div.Want, div.Got = string(want.Type), string(got.Type)
+	case wantValue != gotValue:
+		div.Want, div.Got = wantValue, gotValue
+	default:
+		return compareNodes(want.XRef, "", wantTree, gotTree)
+	}
+	return div
+}
+
+// compareNodes compares two lists of sibling tags under the path parent.
+func compareNodes(xref, parent string, want, got []*node) *Divergence {
+	counts := make(map[string]int)
+	for i, w := range want {
+		path := w.tag
+		if counts[w.tag] > 0 {
+			path += "[" + strconv.Itoa(counts[w.tag]) + "]"
+		}
+		counts[w.tag]++
+		if parent != "" {
+			path = parent + "." + path
+		}
+
+		if i >= len(got) {
+			return &Divergence{RecordXRef: xref, Path: path, Want: w.value, Got: "(missing)", Line: w.line}
+		}
+		g := got[i]
+		switch {
+		case w.tag != g.tag:
+			return &Divergence{RecordXRef: xref, Path: path, Want: w.tag, Got: g.tag, Line: w.line}
+		case w.value != g.value:
+			return &Divergence{RecordXRef: xref, Path: path, Want: w.value, Got: g.value, Line: w.line}
+		}
+		if div := compareNodes(xref, path, w.children, g.children); div != nil {
+			return div
+		}
+	}
+	if len(got) > len(want) {
+		extra := got[len(want)]
+		path := extra.tag
+		if parent != "" {
+			path = parent + "." + path
+		}
+		return &Divergence{RecordXRef: xref, Path: path, Want: "(missing)", Got: extra.tag + " " + extra.value}
+	}
+	return nil
+}
+
+// fold builds the record's tag tree, joining CONT and CONC lines to the value
+// they continue. It returns the record value with its own continuations.
+func fold(record *gedcom.Record) (string, []*node) {
+	value := record.Value
+	var roots []*node
+	// stack[i] is the open node at level i+1
+	var stack []*node
+	for _, tag := range record.Tags {
+		level := tag.Level
+		if level < 1 {
+			level = 1
+		}
+		if level-1 < len(stack) {
+			stack = stack[:level-1]
+		}
+
+		if tag.Tag == "CONT" || tag.Tag == "CONC" {
+			sep := ""
+			if tag.Tag == "CONT" {
+				sep = "\n"
+			}
+			if len(stack) == 0 {
+				value += sep + tag.Value
+			} else {
+				stack[len(stack)-1].value += sep + tag.Value
+			}
+			continue
+		}
+
+		n := &node{tag: tag.Tag, value: tag.Value, line: tag.LineNumber}
+		if len(stack) == 0 {
+			roots = append(roots, n)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, n)
+		}
+		stack = append(stack, n)
+	}
+	return value, roots
+}
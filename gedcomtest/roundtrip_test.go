@@ -0,0 +1,259 @@
+package gedcomtest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/decoder"
+	"github.com/cacack/gedcom-go/encoder"
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+const roundTripInput = "0 HEAD\n" +
+	"1 GEDC\n" +
+	"2 VERS 5.5.1\n" +
+	"1 CHAR UTF-8\n" +
+	"0 @I1@ INDI\n" +
+	"1 NAME John /Doe/\n" +
+	"1 BIRT\n" +
+	"2 DATE 1 JAN 1900\n" +
+	"1 BIRT\n" +
+	"2 DATE 1901\n" +
+	"1 _MILT Army\n" +
+	"0 @N1@ NOTE First line\n" +
+	"1 CONT second line\n" +
+	"0 TRLR\n"
+
+func TestRoundTripSampleFiles(t *testing.T) {
+	paths, err := filepath.Glob("../testdata/*/*.ged")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no sample files: %v", err)
+	}
+	for _, path := range paths {
+		if strings.Contains(path, "malformed") {
+			continue
+		}
+		path := path
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts := &RoundTripOptions{Encode: encoder.DefaultOptions()}
+			opts.Encode.Encoding = gedcom.EncodingUTF8
+			CheckRoundTrip(t, data, opts)
+		})
+	}
+}
+
+func TestRoundTripTransform(t *testing.T) {
+	opts := &RoundTripOptions{
+		Transform: func(doc *gedcom.Document) error {
+			return doc.GetRecord("@I1@").SetTag("BIRT[1].PLAC", "Boston")
+		},
+	}
+	div, err := RoundTrip([]byte(roundTripInput), opts)
+	if err != nil || div != nil {
+		t.Errorf("RoundTrip() = %v, %v, want no divergence", div, err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(doc *gedcom.Document)
+		want Divergence
+	}{
+		{
+			name: "tag value",
+			edit: func(doc *gedcom.Document) { doc.GetRecord("@I1@").Tags[4].Value = "1902" },
+			want: Divergence{RecordXRef: "@I1@", Path: "BIRT[1].DATE", Want: "1901", Got: "1902", Line: 10},
+		},
+		{
+			name: "missing tag",
+			edit: func(doc *gedcom.Document) { _ = doc.GetRecord("@I1@").RemoveTag("_MILT") },
+			want: Divergence{RecordXRef: "@I1@", Path: "_MILT", Want: "Army", Got: "(missing)", Line: 11},
+		},
+		{
+			name: "record value",
+			edit: func(doc *gedcom.Document) { doc.GetRecord("@N1@").Tags[0].Value = "other" },
+			want: Divergence{RecordXRef: "@N1@", Want: "First line\nsecond line", Got: "First line\nother", Line: 12},
+		},
+		{
+			name: "header",
+			edit: func(doc *gedcom.Document) { doc.Header.Language = "French" },
+			want: Divergence{Path: "HEAD.LANG", Got: "French"},
+		},
+		{
+			name: "record count",
+			edit: func(doc *gedcom.Document) { doc.Records = doc.Records[:1] },
+			want: Divergence{RecordXRef: "@N1@", Path: "records", Want: "2 records", Got: "1 records", Line: 12},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := decodeString(t, roundTripInput)
+			got := decodeString(t, roundTripInput)
+			if div := Compare(want, got); div != nil {
+				t.Fatalf("Compare() of equal documents = %v", div)
+			}
+			tt.edit(got)
+			div := Compare(want, got)
+			if div == nil || *div != tt.want {
+				t.Errorf("Compare() = %+v, want %+v", div, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareIgnoresLineSplitting(t *testing.T) {
+	want := decodeString(t, roundTripInput)
+	got := decodeString(t, strings.Replace(roundTripInput,
+		"1 _MILT Army\n", "1 _MILT Ar\n2 CONC my\n", 1))
+	if div := Compare(want, got); div != nil {
+		t.Errorf("Compare() = %v, want nil", div)
+	}
+}
+
+func TestDivergenceString(t *testing.T) {
+	div := &Divergence{RecordXRef: "@I1@", Path: "BIRT.DATE", Want: "1 JAN 1900", Got: "1900", Line: 12}
+	if got, want := div.String(), `@I1@ BIRT.DATE: got "1900", want "1 JAN 1900" (line 12)`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := (&Divergence{Want: "document", Got: "nil"}).String(); got != `document: got "nil", want "document"` {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestCheckRoundTripFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		transform func(doc *gedcom.Document) error
+		errors    int
+		fatals    int
+		skips     int
+	}{
+		{name: "undecodable input", data: "not gedcom", skips: 1, fatals: 1},
+		{
+			name: "divergence",
+			data: roundTripInput,
+			transform: func(doc *gedcom.Document) error {
+				doc.GetRecord("@I1@").Tags[0].Value = "  John /Doe/"
+				return nil
+			},
+			errors: 1,
+		},
+		{
+			name:      "transform error",
+			data:      roundTripInput,
+			transform: func(*gedcom.Document) error { return errors.New("boom") },
+			fatals:    1,
+		},
+		{
+			name: "re-decode error",
+			data: roundTripInput,
+			transform: func(doc *gedcom.Document) error {
+				doc.GetRecord("@I1@").Tags[0].Level = 7
+				return nil
+			},
+			fatals: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &recordingTB{TB: t}
+			CheckRoundTrip(tb, []byte(tt.data), &RoundTripOptions{Transform: tt.transform})
+			if len(tb.errors) != tt.errors || len(tb.fatals) != tt.fatals || len(tb.skips) != tt.skips {
+				t.Errorf("errors = %q, fatals = %q, skips = %q", tb.errors, tb.fatals, tb.skips)
+			}
+		})
+	}
+}
+
+func TestRoundTripEncodingIsNotADivergence(t *testing.T) {
+	opts := &RoundTripOptions{Encode: encoder.DefaultOptions()}
+	opts.Encode.Encoding = gedcom.EncodingUTF8
+	input := strings.Replace(roundTripInput, "1 CHAR UTF-8\n", "1 CHAR ASCII\n", 1)
+	div, err := RoundTrip([]byte(input), opts)
+	if err != nil || div != nil {
+		t.Errorf("RoundTrip() = %v, %v, want no divergence", div, err)
+	}
+}
+
+func TestCompareStructure(t *testing.T) {
+	doc := decodeString(t, roundTripInput)
+	if div := Compare(doc, nil); div == nil || div.Want != "document" || div.Got != "nil" {
+		t.Errorf("Compare(doc, nil) = %v", div)
+	}
+	if div := Compare(nil, nil); div != nil {
+		t.Errorf("Compare(nil, nil) = %v, want nil", div)
+	}
+
+	noHeader := decodeString(t, roundTripInput)
+	noHeader.Header = nil
+	if div := Compare(doc, noHeader); div == nil || div.Path != "HEAD" {
+		t.Errorf("Compare() without header = %v, want HEAD divergence", div)
+	}
+
+	tests := []struct {
+		name string
+		edit func(doc *gedcom.Document)
+		want Divergence
+	}{
+		{
+			name: "xref",
+			edit: func(doc *gedcom.Document) { doc.Records[0].XRef = "@I9@" },
+			want: Divergence{RecordXRef: "@I1@", Want: "@I1@", Got: "@I9@", Line: 5},
+		},
+		{
+			name: "type",
+			edit: func(doc *gedcom.Document) { doc.Records[0].Type = gedcom.RecordTypeFamily },
+			want: Divergence{RecordXRef: "@I1@", Want: "INDI", Got: "FAM", Line: 5},
+		},
+		{
+			name: "tag",
+			edit: func(doc *gedcom.Document) { doc.Records[0].Tags[0].Tag = "TITL" },
+			want: Divergence{RecordXRef: "@I1@", Path: "NAME", Want: "NAME", Got: "TITL", Line: 6},
+		},
+		{
+			name: "extra tag",
+			edit: func(doc *gedcom.Document) {
+				doc.Records[0].Tags = append(doc.Records[0].Tags, &gedcom.Tag{Level: 1, Tag: "SEX", Value: "M"})
+			},
+			want: Divergence{RecordXRef: "@I1@", Path: "SEX", Want: "(missing)", Got: "SEX M"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := decodeString(t, roundTripInput)
+			tt.edit(got)
+			if div := Compare(doc, got); div == nil || *div != tt.want {
+				t.Errorf("Compare() = %+v, want %+v", div, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte(roundTripInput))
+	f.Add([]byte("0 HEAD\n1 GEDC\n2 VERS 5.5\n0 TRLR"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) > 1<<16 {
+			t.Skip("input too large")
+		}
+		CheckRoundTrip(t, data, nil)
+	})
+}
+
+func decodeString(t *testing.T, input string) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
@@ -160,7 +160,7 @@ func TestParseErrorNoContext(t *testing.T) {
 
 func TestEnrichParseErrorNonParse(t *testing.T) {
 	baseErr := errors.New("boom")
-	if got := enrichParseError(baseErr, "prev", "line"); got != baseErr {
+	if got := enrichParseError(baseErr, "prev", "line", 0); got != baseErr {
 		t.Fatalf("enrichParseError() = %v, want %v", got, baseErr)
 	}
 }
@@ -128,3 +128,16 @@ func generateLargeGEDCOM(numIndividuals int) string {
 
 	return sb.String()
 }
+
+// BenchmarkParseLineInto benchmarks parsing into a reused Line from bytes
+func BenchmarkParseLineInto(b *testing.B) {
+	p := NewParser()
+	input := []byte("1 NAME John /Doe/")
+	var line Line
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		p.Reset()
+		_ = p.ParseLineInto(&line, input)
+	}
+}
@@ -14,6 +14,10 @@ type ParseError struct {
 	// Context provides the actual line content that caused the error
 	Context string
 
+	// Offset is the byte offset of the start of the line (0-based), set by
+	// Parse and ParseWithRecovery as for Line.Offset
+	Offset int64
+
 	// Err is the underlying error, if any
 	Err error
 }
@@ -88,7 +92,7 @@ func wrapParseError(line int, message, context string, err error) error {
 	}
 }
 
-func enrichParseError(err error, prevLine, currentLine string) error {
+func enrichParseError(err error, prevLine, currentLine string, offset int64) error {
 	parseErr, ok := err.(*ParseError)
 	if !ok {
 		return err
@@ -103,6 +107,7 @@ func enrichParseError(err error, prevLine, currentLine string) error {
 		Line:    parseErr.Line,
 		Message: parseErr.Message,
 		Context: context,
+		Offset:  offset,
 		Err:     parseErr.Err,
 	}
 }
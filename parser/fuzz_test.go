@@ -23,3 +23,30 @@ func FuzzParseDoesNotPanic(f *testing.F) {
 		_, _ = p.Parse(bytes.NewReader(data))
 	})
 }
+
+func FuzzLineStringRoundTrip(f *testing.F) {
+	seeds := []string{
+		"0 HEAD",
+		"0 @I1@ INDI",
+		"1 NAME John /Smith/",
+		"2 NOTE  indented  text  ",
+		"  1 DATE ABT 1900",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		line, err := NewParser().ParseLine(input)
+		if err != nil {
+			return
+		}
+		again, err := NewParser().ParseLine(line.String())
+		if err != nil {
+			t.Fatalf("ParseLine(%q) error = %v", line.String(), err)
+		}
+		if again.Level != line.Level || again.XRef != line.XRef || again.Tag != line.Tag || again.Value != line.Value {
+			t.Errorf("round trip of %q = %+v, want %+v", input, again, line)
+		}
+	})
+}
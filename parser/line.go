@@ -1,5 +1,7 @@
 package parser
 
+import "strconv"
+
 // Line represents a single parsed line from a GEDCOM file.
 // GEDCOM files use a line-based format with hierarchical levels.
 // Each line format: LEVEL [XREF] TAG [VALUE]
@@ -19,4 +21,96 @@ type Line struct {
 	// LineNumber is the line number in the source file (1-based)
 	// Used for error reporting
 	LineNumber int
+
+	// Offset is the byte offset of the start of the line in the input read
+	// by Parse or ParseWithRecovery (0-based). Input decoded from another
+	// encoding, or stripped of a byte order mark, is counted as the UTF-8
+	// text the parser reads. ParseLine and its variants do not know where
+	// the line sits and leave it 0.
+	Offset int64
+
+	// ValueColumn is the 1-based byte column where Value starts within the
+	// line, or 0 if the line has no value
+	ValueColumn int
+}
+
+// LevelRepair records an illegal level jump that was repaired by the parser.
+// See Parser.SetRepairLevelJumps.
+type LevelRepair struct {
+	// Line is the line number of the repaired line (1-based)
+	Line int
+
+	// Previous is the level of the preceding line
+	Previous int
+
+	// Original is the level as written in the file
+	Original int
+
+	// Repaired is the level assigned by the parser
+	Repaired int
+
+	// Context is the original line content
+	Context string
+}
+
+// XRefRepair records a malformed cross-reference identifier that was
+// normalized by the parser. See Parser.SetRepairXRefs.
+type XRefRepair struct {
+	// Line is the line number of the repaired xref (1-based)
+	Line int
+
+	// Original is the xref as written in the file
+	Original string
+
+	// Repaired is the normalized xref
+	Repaired string
+}
+
+// TagRepair records a tag uppercased by the parser. See TagRulesPermissive.
+type TagRepair struct {
+	// Line is the line number of the repaired tag (1-based)
+	Line int
+
+	// Original is the tag as written in the file
+	Original string
+
+	// Repaired is the uppercased tag
+	Repaired string
+}
+
+// ToleratedLine records malformed input accepted by the parser instead of
+// rejected. See Parser.SetSkipBlankLines and Parser.SetStripBOM.
+type ToleratedLine struct {
+	// Line is the line number of the tolerated line (1-based)
+	Line int
+
+	// Blank is true for a skipped blank line and false for a line that
+	// started with a stray byte order mark, which was removed
+	Blank bool
+}
+
+// String returns the line in GEDCOM form, "LEVEL [XREF] TAG [VALUE]",
+// without a line terminator. See AppendTo.
+func (l *Line) String() string {
+	return string(l.AppendTo(nil))
+}
+
+// AppendTo appends the line in GEDCOM form, "LEVEL [XREF] TAG [VALUE]", to
+// dst and returns the extended slice, without a line terminator. Lines
+// from the parser are written so that parsing the result gives the same
+// Level, XRef, Tag, and Value. Value is written as is: text containing
+// line breaks must be split into CONT lines first, as the encoder does.
+func (l *Line) AppendTo(dst []byte) []byte {
+	dst = strconv.AppendInt(dst, int64(l.Level), 10)
+	if l.XRef != "" {
+		dst = append(dst, ' ')
+		dst = append(dst, l.XRef...)
+	}
+	dst = append(dst, ' ')
+	dst = append(dst, l.Tag...)
+	if l.Value != "" {
+		dst = append(dst, ' ')
+		dst = append(dst, l.Value...)
+	}
+	return dst
 }
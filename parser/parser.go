@@ -24,10 +24,12 @@ package parser
 
 import (
 	"bufio"
+	"errors"
 	"io"
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // MaxNestingDepth is the maximum allowed nesting depth to prevent stack overflow.
@@ -45,6 +47,126 @@ type Parser struct {
 	lineNumber int
 	lastLevel  int
 	maxDepth   int
+
+	// Level jump repair state (see SetRepairLevelJumps)
+	repairLevelJumps bool
+	levelShifts      []levelShift
+	shiftTotal       int
+	levelRepairs     []LevelRepair
+
+	// XRef repair state (see SetRepairXRefs)
+	repairXRefs bool
+	xrefRepairs []XRefRepair
+
+	// Tolerant parsing state (see SetSkipBlankLines and SetStripBOM)
+	skipBlankLines bool
+	stripBOM       bool
+	tolerated      []ToleratedLine
+
+	// Called after each parsed line (see SetLineHook)
+	lineHook func(*Line)
+
+	// Tag syntax accepted (see SetTagRules), and for TagRulesAuto, the
+	// position within HEAD and whether HEAD.GEDC.VERS declared 7.x
+	tagRules   TagRules
+	headPath   []string
+	gedcom7    bool
+	tagRepairs []TagRepair
+}
+
+// TagRules selects the tag syntax a Parser accepts.
+type TagRules int
+
+const (
+	// TagRulesLegacy accepts the tags of GEDCOM 5.5 and 5.5.1: letters of
+	// either case, digits, and underscores, at most 31 characters. This is
+	// the default.
+	TagRulesLegacy TagRules = iota
+
+	// TagRulesGEDCOM7 accepts the tags of GEDCOM 7.0: standard tags start
+	// with an uppercase letter and extension tags with an underscore
+	// followed by at least one more character, and both continue with
+	// uppercase letters, digits, and underscores only. Lowercase letters
+	// are rejected; tag length is not limited.
+	TagRulesGEDCOM7
+
+	// TagRulesAuto applies TagRulesLegacy until HEAD.GEDC.VERS declares
+	// GEDCOM 7.x, and TagRulesGEDCOM7 to the lines after it.
+	TagRulesAuto
+
+	// TagRulesStrict accepts only the tags the GEDCOM 5.5 grammar allows:
+	// uppercase letters, digits, and underscores, at most 31 characters.
+	TagRulesStrict
+
+	// TagRulesPermissive accepts letters of either case, digits, and
+	// underscores without a length limit, and uppercases tags so "birt"
+	// from a careless exporter is read as BIRT. Each changed tag is
+	// recorded in TagRepairs.
+	TagRulesPermissive
+)
+
+// String returns the name of the tag rules.
+func (r TagRules) String() string {
+	switch r {
+	case TagRulesLegacy:
+		return "legacy"
+	case TagRulesGEDCOM7:
+		return "gedcom7"
+	case TagRulesAuto:
+		return "auto"
+	case TagRulesStrict:
+		return "strict"
+	case TagRulesPermissive:
+		return "permissive"
+	default:
+		return "TagRules(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+// SetTagRules sets the tag syntax accepted by the parser (default:
+// TagRulesLegacy). Choose TagRulesGEDCOM7 for files known to be GEDCOM 7.0,
+// TagRulesAuto to follow the version the file's header declares,
+// TagRulesStrict to reject anything the 5.5 grammar does not allow, or
+// TagRulesPermissive to read lowercase tags as uppercase.
+func (p *Parser) SetTagRules(rules TagRules) {
+	p.tagRules = rules
+}
+
+// activeTagRules returns the rules for the current line.
+func (p *Parser) activeTagRules() TagRules {
+	if p.tagRules != TagRulesAuto {
+		return p.tagRules
+	}
+	if p.gedcom7 {
+		return TagRulesGEDCOM7
+	}
+	return TagRulesLegacy
+}
+
+// trackVersion follows HEAD for TagRulesAuto, switching to GEDCOM 7 rules
+// once HEAD.GEDC.VERS declares 7.x.
+func (p *Parser) trackVersion(level int, tag, value string) {
+	if level == 0 {
+		p.headPath = p.headPath[:0]
+		if tag == "HEAD" {
+			p.headPath = append(p.headPath, tag)
+		}
+		return
+	}
+	if len(p.headPath) == 0 || level > len(p.headPath) {
+		return
+	}
+	p.headPath = append(p.headPath[:level], tag)
+	if level == 2 && p.headPath[1] == "GEDC" && tag == "VERS" {
+		p.gedcom7 = strings.HasPrefix(strings.TrimSpace(value), "7.")
+	}
+}
+
+// levelShift records a repaired level jump that applies to all following
+// lines at or below the original jump level.
+type levelShift struct {
+	from  int
+	delta int
 }
 
 // NewParser creates a new Parser instance.
@@ -60,6 +182,191 @@ func NewParser() *Parser {
 func (p *Parser) Reset() {
 	p.lineNumber = 0
 	p.lastLevel = -1
+	p.levelShifts = nil
+	p.shiftTotal = 0
+	p.levelRepairs = nil
+	p.xrefRepairs = nil
+	p.tolerated = nil
+	p.headPath = nil
+	p.gedcom7 = false
+	p.tagRepairs = nil
+}
+
+// SetRepairLevelJumps enables repair of illegal level jumps (e.g., 1 -> 3).
+// When enabled, a line that jumps more than one level deeper than its
+// predecessor is clamped to the previous level + 1, and its subordinate lines
+// are shifted by the same amount. Each repair is recorded and available from
+// LevelRepairs.
+func (p *Parser) SetRepairLevelJumps(enabled bool) {
+	p.repairLevelJumps = enabled
+}
+
+// SetSkipBlankLines makes Parse and ParseWithRecovery skip empty and
+// whitespace-only lines instead of reporting them as errors. Skipped lines
+// still count toward line numbers and are recorded in ToleratedLines.
+// ParseLine rejects blank lines regardless. Leading spaces and tabs before
+// the level are always accepted.
+func (p *Parser) SetSkipBlankLines(enabled bool) {
+	p.skipBlankLines = enabled
+}
+
+// SetStripBOM makes the parser remove byte order marks (U+FEFF) from the
+// start of a line instead of rejecting the line's level. Such stray marks
+// appear when files are concatenated or edited on phones. Each stripped
+// line is recorded in ToleratedLines.
+func (p *Parser) SetStripBOM(enabled bool) {
+	p.stripBOM = enabled
+}
+
+// ToleratedLines returns the blank lines skipped and the lines stripped of a
+// byte order mark since the last Reset.
+func (p *Parser) ToleratedLines() []ToleratedLine {
+	return p.tolerated
+}
+
+// skipBlankLine reports whether text is a blank line to be skipped under
+// SetSkipBlankLines, counting and recording it if so.
+func (p *Parser) skipBlankLine(text string) bool {
+	if !p.skipBlankLines {
+		return false
+	}
+	if p.stripBOM {
+		text = strings.TrimLeft(text, byteOrderMark)
+	}
+	if strings.TrimSpace(text) != "" {
+		return false
+	}
+	p.lineNumber++
+	p.tolerated = append(p.tolerated, ToleratedLine{Line: p.lineNumber, Blank: true})
+	return true
+}
+
+// byteOrderMark is U+FEFF encoded as UTF-8.
+const byteOrderMark = "\uFEFF"
+
+// SetLineHook registers fn to be called by Parse and ParseWithRecovery after
+// each line is parsed successfully, for example to report progress. Pass nil
+// to remove the hook.
+func (p *Parser) SetLineHook(fn func(*Line)) {
+	p.lineHook = fn
+}
+
+// LevelRepairs returns the level jumps repaired since the last Reset.
+func (p *Parser) LevelRepairs() []LevelRepair {
+	return p.levelRepairs
+}
+
+// SetRepairXRefs enables repair of malformed cross-reference identifiers.
+// When enabled, xrefs with embedded whitespace ("@ I1 @") or characters
+// outside A-Z, a-z, 0-9, and underscore ("@I-1@") are normalized by removing
+// whitespace and replacing other illegal characters with underscores. The
+// same normalization is applied to record definitions and pointer values, so
+// references stay consistent. Each repair is recorded and available from
+// XRefRepairs.
+func (p *Parser) SetRepairXRefs(enabled bool) {
+	p.repairXRefs = enabled
+}
+
+// TagRepairs returns the tags uppercased by TagRulesPermissive since the
+// last Reset.
+func (p *Parser) TagRepairs() []TagRepair {
+	return p.tagRepairs
+}
+
+// XRefRepairs returns the xrefs repaired since the last Reset.
+func (p *Parser) XRefRepairs() []XRefRepair {
+	return p.xrefRepairs
+}
+
+// repairXRef normalizes an xref, recording an XRefRepair if it changed.
+func (p *Parser) repairXRef(xref string) string {
+	repaired := NormalizeXRef(xref)
+	if repaired != xref {
+		p.xrefRepairs = append(p.xrefRepairs, XRefRepair{
+			Line:     p.lineNumber,
+			Original: xref,
+			Repaired: repaired,
+		})
+	}
+	return repaired
+}
+
+// NormalizeXRef returns xref with whitespace removed and characters other
+// than A-Z, a-z, 0-9, and underscore replaced by underscores. Returns xref
+// unchanged if nothing would remain between the @ delimiters.
+func NormalizeXRef(xref string) string {
+	inner := strings.TrimSuffix(strings.TrimPrefix(xref, "@"), "@")
+
+	var b strings.Builder
+	b.WriteByte('@')
+	for _, r := range inner {
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	if b.Len() == 1 {
+		return xref
+	}
+	b.WriteByte('@')
+	return b.String()
+}
+
+// xrefFieldEnd returns the index of the last field belonging to an xref that
+// starts at field 1. Without repair, or for well-formed xrefs, this is 1.
+// With repair, an xref split by whitespace ("@ I1 @") spans several fields.
+func (p *Parser) xrefFieldEnd(line string, fields []span) int {
+	first := fields[1].of(line)
+	if !p.repairXRefs || !strings.HasPrefix(first, "@") ||
+		(len(first) > 1 && strings.HasSuffix(first, "@")) {
+		return 1
+	}
+	for k := 2; k < len(fields)-1; k++ {
+		if strings.HasSuffix(fields[k].of(line), "@") {
+			return k
+		}
+	}
+	return 1
+}
+
+// isPointerValue reports whether a line value is a single pointer, possibly
+// padded with whitespace inside the delimiters ("@ F1 @"). Escapes such as
+// "@#DJULIAN@" are excluded.
+func isPointerValue(value string) bool {
+	if len(value) < 3 || value[0] != '@' || value[len(value)-1] != '@' || value[1] == '#' {
+		return false
+	}
+	inner := strings.TrimSpace(value[1 : len(value)-1])
+	return inner != "" && !strings.ContainsAny(inner, "@ \t")
+}
+
+// repairLevel maps an original line level to its repaired level, recording a
+// LevelRepair when the line itself is an illegal jump.
+func (p *Parser) repairLevel(level int, context string) int {
+	for len(p.levelShifts) > 0 && level < p.levelShifts[len(p.levelShifts)-1].from {
+		p.shiftTotal -= p.levelShifts[len(p.levelShifts)-1].delta
+		p.levelShifts = p.levelShifts[:len(p.levelShifts)-1]
+	}
+
+	repaired := level - p.shiftTotal
+	if p.lastLevel >= 0 && repaired > p.lastLevel+1 {
+		delta := repaired - (p.lastLevel + 1)
+		p.levelShifts = append(p.levelShifts, levelShift{from: level, delta: delta})
+		p.shiftTotal += delta
+		repaired -= delta
+		p.levelRepairs = append(p.levelRepairs, LevelRepair{
+			Line:     p.lineNumber,
+			Previous: p.lastLevel,
+			Original: level,
+			Repaired: repaired,
+			Context:  context,
+		})
+	}
+	return repaired
 }
 
 // SetMaxNestingDepth sets the maximum allowed nesting depth.
@@ -81,34 +388,124 @@ func (p *Parser) SetMaxNestingDepth(max int) {
 //	1 NAME John /Smith/
 //	2 GIVN John
 func (p *Parser) ParseLine(input string) (*Line, error) {
+	line := &Line{}
+	if err := p.parseLine(line, input); err != nil {
+		return nil, err
+	}
+	return line, nil
+}
+
+// ParseLineBytes parses a single GEDCOM line held in a byte slice, such as
+// the token of a bufio.Scanner, with ParseLineInto. It allocates only the
+// returned Line and one copy of input, so input may be reused once it
+// returns; use ParseLineInto to reuse the Line as well.
+func (p *Parser) ParseLineBytes(input []byte) (*Line, error) {
+	line := &Line{}
+	if err := p.ParseLineInto(line, input); err != nil {
+		return nil, err
+	}
+	return line, nil
+}
+
+// ParseLineInto parses a single GEDCOM line into dst, overwriting all of
+// its fields, so one Line can be reused across a scan. Fields are located
+// by index instead of being split out, and Tag, XRef, and Value share one
+// copy of input: the only allocation is that copy, which the returned
+// strings need to outlive input. On error, dst is left unchanged.
+func (p *Parser) ParseLineInto(dst *Line, input []byte) error {
+	return p.parseLine(dst, string(input))
+}
+
+// span is the [start, end) byte range of a field within a line.
+type span struct {
+	start, end int
+}
+
+func (s span) of(line string) string {
+	return line[s.start:s.end]
+}
+
+// appendFields appends the whitespace-separated fields of line to fields,
+// stopping after max fields when max > 0. Whitespace is as strings.Fields
+// defines it.
+func appendFields(fields []span, line string, max int) []span {
+	start := -1
+	for i := 0; i < len(line); {
+		c := line[i]
+		size := 1
+		space := asciiSpace[c] != 0
+		if c >= utf8.RuneSelf {
+			var r rune
+			r, size = utf8.DecodeRuneInString(line[i:])
+			space = unicode.IsSpace(r)
+		}
+		switch {
+		case space && start >= 0:
+			fields = append(fields, span{start, i})
+			start = -1
+			if max > 0 && len(fields) == max {
+				return fields
+			}
+		case !space && start < 0:
+			start = i
+		}
+		i += size
+	}
+	if start >= 0 {
+		fields = append(fields, span{start, len(line)})
+	}
+	return fields
+}
+
+// asciiSpace marks the ASCII characters unicode.IsSpace reports as space.
+var asciiSpace = [256]uint8{'\t': 1, '\n': 1, '\v': 1, '\f': 1, '\r': 1, ' ': 1}
+
+// parseLine parses input into dst. Only the fields up to the tag are
+// located; the value is the rest of the line from the next field on.
+func (p *Parser) parseLine(dst *Line, input string) error {
 	p.lineNumber++
 
+	// Remove stray byte order marks, keeping value columns relative to input
+	bomBytes := 0
+	if p.stripBOM && strings.HasPrefix(input, byteOrderMark) {
+		trimmed := strings.TrimLeft(input, byteOrderMark)
+		bomBytes = len(input) - len(trimmed)
+		input = trimmed
+	}
+
 	// Trim line endings (CRLF, LF, CR)
 	line := strings.TrimRight(input, "\r\n")
 
-	// Empty or whitespace-only lines are invalid
-	if strings.TrimSpace(line) == "" {
-		return nil, newParseError(p.lineNumber, "empty line", input)
+	// Level, xref, and tag fit in three fields unless a repaired xref
+	// spans several
+	var buf [3]span
+	maxFields := len(buf)
+	if p.repairXRefs {
+		maxFields = 0
 	}
+	fields := appendFields(buf[:0], line, maxFields)
 
-	// Split into parts
-	parts := strings.Fields(line)
-	if len(parts) < 2 {
-		return nil, newParseError(p.lineNumber, "line must have at least level and tag (expected a tag like HEAD, INDI, FAM, or SOUR)", line)
+	// Empty or whitespace-only lines are invalid
+	if len(fields) == 0 {
+		return newParseError(p.lineNumber, "empty line", input)
+	}
+	if len(fields) < 2 {
+		return newParseError(p.lineNumber, "line must have at least level and tag (expected a tag like HEAD, INDI, FAM, or SOUR)", line)
 	}
 
-	// Parse level (first part)
-	level, err := strconv.Atoi(parts[0])
+	// Parse level (first field)
+	rawLevel := fields[0].of(line)
+	level, err := strconv.Atoi(rawLevel)
 	if err != nil {
-		return nil, wrapParseError(p.lineNumber, "invalid level number", line, &InvalidLevelError{
-			Raw:    parts[0],
+		return wrapParseError(p.lineNumber, "invalid level number", line, &InvalidLevelError{
+			Raw:    rawLevel,
 			Reason: "not a number",
 		})
 	}
 
 	if level < 0 {
-		return nil, wrapParseError(p.lineNumber, "level cannot be negative", line, &InvalidLevelError{
-			Raw:    parts[0],
+		return wrapParseError(p.lineNumber, "level cannot be negative", line, &InvalidLevelError{
+			Raw:    rawLevel,
 			Reason: "negative",
 		})
 	}
@@ -119,14 +516,18 @@ func (p *Parser) ParseLine(input string) (*Line, error) {
 		limit = MaxNestingDepth
 	}
 	if level > limit {
-		return nil, wrapParseError(p.lineNumber, "maximum nesting depth exceeded", line, &InvalidLevelError{
-			Raw:    parts[0],
+		return wrapParseError(p.lineNumber, "maximum nesting depth exceeded", line, &InvalidLevelError{
+			Raw:    rawLevel,
 			Reason: "exceeds max depth",
 		})
 	}
 
+	if p.repairLevelJumps {
+		level = p.repairLevel(level, line)
+	}
+
 	if p.lastLevel >= 0 && level > p.lastLevel+1 {
-		return nil, wrapParseError(p.lineNumber, "level jump exceeds one", line, &LevelMismatchError{
+		return wrapParseError(p.lineNumber, "level jump exceeds one", line, &LevelMismatchError{
 			Previous: p.lastLevel,
 			Current:  level,
 		})
@@ -139,48 +540,71 @@ func (p *Parser) ParseLine(input string) (*Line, error) {
 	)
 	var valueStartIdx int
 
-	// Check if second part is an XRef (starts with @ and ends with @)
-	if strings.HasPrefix(parts[1], "@") && strings.HasSuffix(parts[1], "@") {
-		xref = parts[1]
+	// Check if second field is an XRef (starts with @ and ends with @)
+	xrefEnd := p.xrefFieldEnd(line, fields)
+	if strings.HasPrefix(fields[1].of(line), "@") && strings.HasSuffix(fields[xrefEnd].of(line), "@") {
+		xref = fields[1].of(line)
+		if xrefEnd > 1 {
+			xref = strings.Join(strings.Fields(line[fields[1].start:fields[xrefEnd].end]), " ")
+		}
+		if p.repairXRefs {
+			xref = p.repairXRef(xref)
+		}
 		if err := validateXRef(xref); err != nil {
-			return nil, wrapParseError(p.lineNumber, err.Error(), line, err)
+			return wrapParseError(p.lineNumber, err.Error(), line, err)
 		}
-		if len(parts) < 3 {
-			return nil, newParseError(p.lineNumber, "line with xref must have a tag (expected a tag like INDI, FAM, or SOUR)", line)
+		if len(fields) < xrefEnd+2 {
+			return newParseError(p.lineNumber, "line with xref must have a tag (expected a tag like INDI, FAM, or SOUR)", line)
 		}
-		tag = parts[2]
-		valueStartIdx = 3
+		tag = fields[xrefEnd+1].of(line)
+		valueStartIdx = xrefEnd + 2
 	} else {
-		tag = parts[1]
+		tag = fields[1].of(line)
 		valueStartIdx = 2
 	}
 
-	if err := validateTag(tag); err != nil {
-		message := err.Error()
-		if _, ok := err.(*InvalidTagError); ok {
-			message = message + " (expected A-Z, 0-9, underscore, max length 31)"
+	rules := p.activeTagRules()
+	if err := rules.validate(tag); err != nil {
+		message := err.Error() + " (" + rules.hint() + ")"
+		return wrapParseError(p.lineNumber, message, line, err)
+	}
+	if rules == TagRulesPermissive {
+		if upper := strings.ToUpper(tag); upper != tag {
+			p.tagRepairs = append(p.tagRepairs, TagRepair{Line: p.lineNumber, Original: tag, Repaired: upper})
+			tag = upper
 		}
-		return nil, wrapParseError(p.lineNumber, message, line, err)
 	}
 
 	// Parse value (everything after the tag)
-	var value string
-	if valueStartIdx < len(parts) {
-		valueStartPos := fieldStartIndex(line, valueStartIdx)
-		if valueStartPos >= 0 && valueStartPos < len(line) {
-			value = line[valueStartPos:]
-		}
+	var (
+		value       string
+		valueColumn int
+	)
+	if valueStartPos := fieldStartIndex(line, valueStartIdx); valueStartPos >= 0 && valueStartPos < len(line) {
+		value = line[valueStartPos:]
+		valueColumn = bomBytes + valueStartPos + 1
+	}
+	if p.repairXRefs && isPointerValue(value) {
+		value = p.repairXRef(value)
 	}
 
 	p.lastLevel = level
+	if bomBytes > 0 {
+		p.tolerated = append(p.tolerated, ToleratedLine{Line: p.lineNumber})
+	}
+	if p.tagRules == TagRulesAuto {
+		p.trackVersion(level, tag, value)
+	}
 
-	return &Line{
-		Level:      level,
-		Tag:        tag,
-		Value:      value,
-		XRef:       xref,
-		LineNumber: p.lineNumber,
-	}, nil
+	*dst = Line{
+		Level:       level,
+		Tag:         tag,
+		Value:       value,
+		XRef:        xref,
+		LineNumber:  p.lineNumber,
+		ValueColumn: valueColumn,
+	}
+	return nil
 }
 
 func fieldStartIndex(line string, fieldIndex int) int {
@@ -211,30 +635,63 @@ func fieldStartIndex(line string, fieldIndex int) int {
 // Parse reads a GEDCOM file from a reader and returns all parsed lines.
 // Supports all line ending styles: LF (Unix), CRLF (Windows), CR (old Macintosh).
 func (p *Parser) Parse(r io.Reader) ([]*Line, error) {
+	var lines []*Line
+	err := p.ParseFunc(r, func(line *Line) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// ErrStop can be returned by a ParseFunc handler to stop parsing early
+// without ParseFunc returning an error.
+var ErrStop = errors.New("stop parsing")
+
+// ParseFunc reads a GEDCOM file from a reader and calls fn with each parsed
+// line in order, without keeping the lines, so files of any size can be
+// processed in constant memory. Parsing stops at the first parse error,
+// which is returned, or when fn returns an error: ErrStop stops quietly,
+// and any other error is returned as is.
+func (p *Parser) ParseFunc(r io.Reader, fn func(*Line) error) error {
 	p.Reset()
 
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 64*1024), maxScannerTokenSize)
 	// Use custom split function that handles CR, LF, and CRLF line endings
-	scanner.Split(ScanGEDCOMLines)
-	var lines []*Line
+	var offsets lineOffsets
+	scanner.Split(offsets.split)
 	var prevLine string
 
 	for scanner.Scan() {
 		text := scanner.Text()
+		if p.skipBlankLine(text) {
+			continue
+		}
 		line, err := p.ParseLine(text)
 		if err != nil {
-			return nil, enrichParseError(err, prevLine, text)
+			return enrichParseError(err, prevLine, text, offsets.start)
 		}
-		lines = append(lines, line)
+		line.Offset = offsets.start
 		prevLine = text
+		if p.lineHook != nil {
+			p.lineHook(line)
+		}
+		if err := fn(line); err != nil {
+			if errors.Is(err, ErrStop) {
+				return nil
+			}
+			return err
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, wrapParseError(p.lineNumber, "error reading input", "", err)
+		return wrapParseError(p.lineNumber, "error reading input", "", err)
 	}
 
-	return lines, nil
+	return nil
 }
 
 // ParseWithRecovery parses lines and continues after errors, returning both lines and errors.
@@ -243,7 +700,8 @@ func (p *Parser) ParseWithRecovery(r io.Reader) ([]*Line, []error) {
 
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 64*1024), maxScannerTokenSize)
-	scanner.Split(ScanGEDCOMLines)
+	var offsets lineOffsets
+	scanner.Split(offsets.split)
 	var (
 		lines    []*Line
 		errs     []error
@@ -252,13 +710,20 @@ func (p *Parser) ParseWithRecovery(r io.Reader) ([]*Line, []error) {
 
 	for scanner.Scan() {
 		text := scanner.Text()
+		if p.skipBlankLine(text) {
+			continue
+		}
 		line, err := p.ParseLine(text)
 		if err != nil {
-			errs = append(errs, enrichParseError(err, prevLine, text))
+			errs = append(errs, enrichParseError(err, prevLine, text, offsets.start))
 			continue
 		}
+		line.Offset = offsets.start
 		lines = append(lines, line)
 		prevLine = text
+		if p.lineHook != nil {
+			p.lineHook(line)
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -268,6 +733,75 @@ func (p *Parser) ParseWithRecovery(r io.Reader) ([]*Line, []error) {
 	return lines, errs
 }
 
+// lineOffsets tracks the byte offset of each line split by ScanGEDCOMLines.
+type lineOffsets struct {
+	start int64 // offset of the last line returned
+	next  int64 // offset of the line after it
+}
+
+func (o *lineOffsets) split(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	advance, token, err = ScanGEDCOMLines(data, atEOF)
+	if token != nil {
+		o.start = o.next
+		o.next += int64(advance)
+	}
+	return advance, token, err
+}
+
+// validate checks tag against the rules.
+func (r TagRules) validate(tag string) error {
+	switch r {
+	case TagRulesGEDCOM7:
+	case TagRulesStrict:
+		if err := validateTag(tag); err != nil {
+			return err
+		}
+		if strings.ToUpper(tag) != tag {
+			return &InvalidTagError{Tag: tag, Reason: "contains lowercase letters"}
+		}
+		return nil
+	case TagRulesPermissive:
+		if tag == "" {
+			return &InvalidTagError{Tag: tag, Reason: "empty"}
+		}
+		return validateTagChars(tag)
+	default:
+		return validateTag(tag)
+	}
+	if tag == "" {
+		return &InvalidTagError{Tag: tag, Reason: "empty"}
+	}
+	if tag == "_" {
+		return &InvalidTagError{Tag: tag, Reason: "extension tag has no name after the underscore"}
+	}
+	if c := tag[0]; c != '_' && (c < 'A' || c > 'Z') {
+		return &InvalidTagError{Tag: tag, Reason: "must start with an uppercase letter or underscore"}
+	}
+	for _, r := range tag {
+		switch {
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_':
+			continue
+		case r >= 'a' && r <= 'z':
+			return &InvalidTagError{Tag: tag, Reason: "contains lowercase letters"}
+		default:
+			return &InvalidTagError{Tag: tag, Reason: "contains invalid characters"}
+		}
+	}
+	return nil
+}
+
+// hint describes the tags the rules accept, for error messages.
+func (r TagRules) hint() string {
+	switch r {
+	case TagRulesGEDCOM7:
+		return "expected A-Z, 0-9, underscore, starting with A-Z or an underscore for extensions"
+	case TagRulesPermissive:
+		return "expected letters, 0-9, underscore"
+	default:
+		return "expected A-Z, 0-9, underscore, max length 31"
+	}
+}
+
 func validateTag(tag string) error {
 	if tag == "" {
 		return &InvalidTagError{Tag: tag, Reason: "empty"}
@@ -275,6 +809,11 @@ func validateTag(tag string) error {
 	if len(tag) > maxTagLength {
 		return &InvalidTagError{Tag: tag, Reason: "too long"}
 	}
+	return validateTagChars(tag)
+}
+
+// validateTagChars checks that tag has only letters, digits, and underscores.
+func validateTagChars(tag string) error {
 	for _, r := range tag {
 		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
 			continue
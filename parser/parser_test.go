@@ -1,7 +1,9 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"testing/iotest"
@@ -586,3 +588,484 @@ func TestParseLineXRefWithoutTag(t *testing.T) {
 		})
 	}
 }
+
+func TestRepairLevelJumps(t *testing.T) {
+	input := `0 @I1@ INDI
+1 BIRT
+3 DATE 1 JAN 1900
+4 TIME 12:00
+3 PLAC Boston
+1 DEAT Y
+0 TRLR`
+
+	p := NewParser()
+	if _, err := p.Parse(strings.NewReader(input)); err == nil {
+		t.Fatal("Parse() without repair should fail on level jump")
+	}
+
+	p = NewParser()
+	p.SetRepairLevelJumps(true)
+	lines, err := p.Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	wantLevels := []int{0, 1, 2, 3, 2, 1, 0}
+	for i, line := range lines {
+		if line.Level != wantLevels[i] {
+			t.Errorf("line %d (%s) level = %d, want %d", i+1, line.Tag, line.Level, wantLevels[i])
+		}
+	}
+
+	repairs := p.LevelRepairs()
+	if len(repairs) != 1 {
+		t.Fatalf("LevelRepairs() = %d, want 1", len(repairs))
+	}
+	r := repairs[0]
+	if r.Line != 3 || r.Previous != 1 || r.Original != 3 || r.Repaired != 2 {
+		t.Errorf("LevelRepair = %+v", r)
+	}
+
+	p.Reset()
+	if len(p.LevelRepairs()) != 0 {
+		t.Error("Reset() should clear level repairs")
+	}
+}
+
+func TestNormalizeXRef(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"@I1@", "@I1@"},
+		{"@ I1 @", "@I1@"},
+		{"@I-1@", "@I_1@"},
+		{"@F 1.2@", "@F1_2@"},
+		{"@ @", "@ @"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeXRef(tt.in); got != tt.want {
+			t.Errorf("NormalizeXRef(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRepairXRefs(t *testing.T) {
+	input := `0 @ I1 @ INDI
+1 NAME John /Smith/
+1 FAMS @ F-1 @
+1 NOTE @#DJULIAN@ is not a pointer
+0 @F-1@ FAM
+1 HUSB @I1@
+0 TRLR`
+
+	p := NewParser()
+	if _, err := p.Parse(strings.NewReader(input)); err == nil {
+		t.Fatal("Parse() without repair should fail on split xref")
+	}
+
+	p = NewParser()
+	p.SetRepairXRefs(true)
+	lines, err := p.Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if lines[0].XRef != "@I1@" || lines[0].Tag != "INDI" {
+		t.Errorf("line 1 = %+v, want xref @I1@ tag INDI", lines[0])
+	}
+	if lines[2].Value != "@F_1@" {
+		t.Errorf("FAMS value = %q, want @F_1@", lines[2].Value)
+	}
+	if lines[3].Value != "@#DJULIAN@ is not a pointer" {
+		t.Errorf("NOTE value = %q, should be unchanged", lines[3].Value)
+	}
+	if lines[4].XRef != "@F_1@" {
+		t.Errorf("FAM xref = %q, want @F_1@", lines[4].XRef)
+	}
+
+	repairs := p.XRefRepairs()
+	if len(repairs) != 3 {
+		t.Fatalf("XRefRepairs() = %+v, want 3", repairs)
+	}
+	if repairs[0] != (XRefRepair{Line: 1, Original: "@ I1 @", Repaired: "@I1@"}) {
+		t.Errorf("first repair = %+v", repairs[0])
+	}
+}
+
+func TestSetLineHook(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\nbad line\n0 TRLR\n"
+
+	var tags []string
+	p := NewParser()
+	p.SetLineHook(func(line *Line) { tags = append(tags, line.Tag) })
+
+	_, errs := p.ParseWithRecovery(strings.NewReader(input))
+	if len(errs) != 1 {
+		t.Fatalf("ParseWithRecovery() errors = %v, want 1", errs)
+	}
+	if want := []string{"HEAD", "GEDC", "TRLR"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("hook saw %v, want %v", tags, want)
+	}
+
+	tags = nil
+	p.SetLineHook(nil)
+	if _, err := p.Parse(strings.NewReader("0 HEAD\n0 TRLR\n")); err != nil || tags != nil {
+		t.Errorf("Parse() = %v, hook saw %v after removal", err, tags)
+	}
+}
+
+func TestParseLineInto(t *testing.T) {
+	inputs := []string{
+		"0 HEAD",
+		"0 @I1@ INDI\r\n",
+		"1 NAME John  /Smith/  ",
+		"2 DATE\t1 JAN 1900",
+		"1 NOTE café au lait",
+		"1 NAME José",
+		"1 HUSB @ F1 @",
+		"0 @ I1 @ INDI",
+		"   ",
+		"x NAME",
+		"1 NA-ME John",
+		"0 @I1@",
+	}
+	for _, repair := range []bool{false, true} {
+		newParser := func() *Parser {
+			p := NewParser()
+			p.SetRepairXRefs(repair)
+			return p
+		}
+		for _, input := range inputs {
+			want, wantErr := newParser().ParseLine(input)
+
+			var got Line
+			err := newParser().ParseLineInto(&got, []byte(input))
+			if (err != nil) != (wantErr != nil) || (err != nil && err.Error() != wantErr.Error()) {
+				t.Errorf("ParseLineInto(%q) error = %v, want %v", input, err, wantErr)
+				continue
+			}
+			if err == nil && got != *want {
+				t.Errorf("ParseLineInto(%q) = %+v, want %+v", input, got, *want)
+			}
+
+			if line, err := newParser().ParseLineBytes([]byte(input)); err == nil && *line != *want {
+				t.Errorf("ParseLineBytes(%q) = %+v, want %+v", input, *line, *want)
+			}
+		}
+	}
+}
+
+func TestParseLineInto_Allocations(t *testing.T) {
+	p := NewParser()
+	input := []byte("2 DATE 1 JAN 1900")
+	var line Line
+	allocs := testing.AllocsPerRun(100, func() {
+		p.Reset()
+		if err := p.ParseLineInto(&line, input); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 1 {
+		t.Errorf("ParseLineInto() allocations = %v, want at most 1 (the copy of the line)", allocs)
+	}
+	if line.Tag != "DATE" || line.Value != "1 JAN 1900" {
+		t.Errorf("ParseLineInto() = %+v", line)
+	}
+}
+
+func TestParseLineBytes_Allocations(t *testing.T) {
+	p := NewParser()
+	input := []byte("2 DATE 1 JAN 1900")
+	var line *Line
+	allocs := testing.AllocsPerRun(100, func() {
+		p.Reset()
+		var err error
+		if line, err = p.ParseLineBytes(input); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 2 {
+		t.Errorf("ParseLineBytes() allocations = %v, want at most 2 (the Line and the copy of the line)", allocs)
+	}
+	if line.Tag != "DATE" || line.Value != "1 JAN 1900" {
+		t.Errorf("ParseLineBytes() = %+v", line)
+	}
+}
+
+func TestParseOffsets(t *testing.T) {
+	input := "0 HEAD\r\n1 NOTE  café\n0 @I1@ INDI\r1 NAME John /Doe/\nbad\n0 TRLR"
+
+	lines, errs := NewParser().ParseWithRecovery(strings.NewReader(input))
+	want := []struct {
+		offset      int64
+		valueColumn int
+	}{
+		{0, 0},  // 0 HEAD\r\n
+		{8, 9},  // 1 NOTE  café\n (é is two bytes)
+		{22, 0}, // 0 @I1@ INDI\r
+		{34, 8}, // 1 NAME John /Doe/\n
+		{56, 0}, // 0 TRLR, after "bad\n"
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("ParseWithRecovery() returned %d lines, want %d", len(lines), len(want))
+	}
+	for i, w := range want {
+		if lines[i].Offset != w.offset || lines[i].ValueColumn != w.valueColumn {
+			t.Errorf("line %d: Offset, ValueColumn = %d, %d, want %d, %d",
+				lines[i].LineNumber, lines[i].Offset, lines[i].ValueColumn, w.offset, w.valueColumn)
+		}
+		if w.valueColumn > 0 {
+			text := input[w.offset:]
+			if got := text[w.valueColumn-1 : w.valueColumn-1+len(lines[i].Value)]; got != lines[i].Value {
+				t.Errorf("line %d: value at offset = %q, want %q", lines[i].LineNumber, got, lines[i].Value)
+			}
+		}
+	}
+
+	var parseErr *ParseError
+	if len(errs) != 1 || !errors.As(errs[0], &parseErr) || parseErr.Offset != 52 {
+		t.Errorf("ParseWithRecovery() errors = %v, want one ParseError at offset 52", errs)
+	}
+
+	_, err := NewParser().Parse(strings.NewReader(input))
+	if !errors.As(err, &parseErr) || parseErr.Offset != 52 || parseErr.Line != 5 {
+		t.Errorf("Parse() error = %v, want ParseError at line 5, offset 52", err)
+	}
+}
+
+func TestTagRules(t *testing.T) {
+	tests := []struct {
+		tag        string
+		legacyOK   bool
+		gedcom7OK  bool
+		wantReason string // GEDCOM 7 reason
+	}{
+		{"NAME", true, true, ""},
+		{"_MILT", true, true, ""},
+		{"_M1_X", true, true, ""},
+		{"_milt", true, false, "contains lowercase letters"},
+		{"Name", true, false, "contains lowercase letters"},
+		{"_", true, false, "extension tag has no name after the underscore"},
+		{"1ABC", true, false, "must start with an uppercase letter or underscore"},
+		{"_THIS_IS_A_VERY_LONG_EXTENSION_TAG", false, true, ""},
+	}
+	for _, tt := range tests {
+		for _, rules := range []TagRules{TagRulesLegacy, TagRulesGEDCOM7} {
+			p := NewParser()
+			p.SetTagRules(rules)
+			_, err := p.ParseLine("1 " + tt.tag + " value")
+			wantOK := tt.legacyOK
+			if rules == TagRulesGEDCOM7 {
+				wantOK = tt.gedcom7OK
+			}
+			if (err == nil) != wantOK {
+				t.Errorf("%s: ParseLine(%q) error = %v, want ok %v", rules, tt.tag, err, wantOK)
+				continue
+			}
+			var tagErr *InvalidTagError
+			if rules == TagRulesGEDCOM7 && tt.wantReason != "" && (!errors.As(err, &tagErr) || tagErr.Reason != tt.wantReason) {
+				t.Errorf("%s: ParseLine(%q) error = %v, want reason %q", rules, tt.tag, err, tt.wantReason)
+			}
+		}
+	}
+}
+
+func TestTagRulesAuto(t *testing.T) {
+	header := func(version string) string {
+		return "0 HEAD\n1 SOUR App\n2 VERS 1.0\n1 GEDC\n2 VERS " + version + "\n0 @I1@ INDI\n1 _milt Army\n0 TRLR\n"
+	}
+
+	p := NewParser()
+	p.SetTagRules(TagRulesAuto)
+	if _, err := p.Parse(strings.NewReader(header("5.5.1"))); err != nil {
+		t.Errorf("Parse() of 5.5.1 file error = %v, want lowercase tag accepted", err)
+	}
+
+	_, err := p.Parse(strings.NewReader(header("7.0")))
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 7 || !strings.Contains(err.Error(), "lowercase") {
+		t.Errorf("Parse() of 7.0 file error = %v, want lowercase tag rejected at line 7", err)
+	}
+
+	if TagRulesAuto.String() != "auto" || TagRules(9).String() != "TagRules(9)" {
+		t.Error("TagRules.String() mismatch")
+	}
+}
+
+func TestParseTolerant(t *testing.T) {
+	input := "0 HEAD\n\n  1 GEDC\n\t2 VERS 5.5.1\n \t\n\uFEFF0 @I1@ INDI\n\uFEFF\n1 NAME John /Doe/\n0 TRLR\n"
+
+	if _, err := NewParser().Parse(strings.NewReader(input)); err == nil {
+		t.Fatal("Parse() without tolerance should fail on a blank line")
+	}
+
+	p := NewParser()
+	p.SetSkipBlankLines(true)
+	p.SetStripBOM(true)
+	lines, err := p.Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []struct {
+		lineNumber int
+		tag, xref  string
+	}{
+		{1, "HEAD", ""},
+		{3, "GEDC", ""},
+		{4, "VERS", ""},
+		{6, "INDI", "@I1@"},
+		{8, "NAME", ""},
+		{9, "TRLR", ""},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Parse() returned %d lines, want %d", len(lines), len(want))
+	}
+	for i, w := range want {
+		if lines[i].LineNumber != w.lineNumber || lines[i].Tag != w.tag || lines[i].XRef != w.xref {
+			t.Errorf("line %d = %+v, want line %d %s %s", i, lines[i], w.lineNumber, w.xref, w.tag)
+		}
+	}
+
+	wantTolerated := []ToleratedLine{{Line: 2, Blank: true}, {Line: 5, Blank: true}, {Line: 6}, {Line: 7, Blank: true}}
+	if got := p.ToleratedLines(); !reflect.DeepEqual(got, wantTolerated) {
+		t.Errorf("ToleratedLines() = %+v, want %+v", got, wantTolerated)
+	}
+
+	// Value columns count the stripped mark
+	p.Reset()
+	line, err := p.ParseLine("\uFEFF1 NOTE text")
+	if err != nil || line.Value != "text" || line.ValueColumn != 11 {
+		t.Errorf("ParseLine() = %+v, %v, want value %q at column 11", line, err, "text")
+	}
+	if _, err := p.ParseLine(""); err == nil {
+		t.Error("ParseLine() should reject a blank line")
+	}
+}
+
+func TestParseFunc(t *testing.T) {
+	input := "0 HEAD\n0 @I1@ INDI\n1 NAME John /Doe/\n0 @I2@ INDI\n0 TRLR\n"
+
+	var tags []string
+	err := NewParser().ParseFunc(strings.NewReader(input), func(line *Line) error {
+		tags = append(tags, line.Tag)
+		return nil
+	})
+	if err != nil || strings.Join(tags, " ") != "HEAD INDI NAME INDI TRLR" {
+		t.Errorf("ParseFunc() visited %v, error %v", tags, err)
+	}
+
+	// ErrStop ends the parse without an error
+	var visited int
+	err = NewParser().ParseFunc(strings.NewReader(input), func(line *Line) error {
+		visited++
+		if line.Tag == "NAME" {
+			return ErrStop
+		}
+		return nil
+	})
+	if err != nil || visited != 3 {
+		t.Errorf("ParseFunc() with ErrStop visited %d lines, error %v; want 3, nil", visited, err)
+	}
+
+	// Other handler errors are returned as is
+	errHandler := errors.New("handler failed")
+	err = NewParser().ParseFunc(strings.NewReader(input), func(*Line) error {
+		return errHandler
+	})
+	if err != errHandler {
+		t.Errorf("ParseFunc() error = %v, want %v", err, errHandler)
+	}
+
+	// Parse errors stop the parse after the lines before them
+	visited = 0
+	err = NewParser().ParseFunc(strings.NewReader("0 HEAD\nbad\n0 TRLR\n"), func(*Line) error {
+		visited++
+		return nil
+	})
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 || visited != 1 {
+		t.Errorf("ParseFunc() error = %v after %d lines, want ParseError at line 2 after 1", err, visited)
+	}
+}
+
+func TestLineString(t *testing.T) {
+	tests := []struct {
+		line Line
+		want string
+	}{
+		{Line{Level: 0, Tag: "HEAD"}, "0 HEAD"},
+		{Line{Level: 0, XRef: "@I1@", Tag: "INDI"}, "0 @I1@ INDI"},
+		{Line{Level: 1, Tag: "NAME", Value: "John /Smith/"}, "1 NAME John /Smith/"},
+		{Line{Level: 0, XRef: "@N1@", Tag: "NOTE", Value: "Shared text"}, "0 @N1@ NOTE Shared text"},
+		{Line{Level: 12, Tag: "_CUSTOM", Value: "@I1@"}, "12 _CUSTOM @I1@"},
+	}
+	for _, tt := range tests {
+		if got := tt.line.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+		if got := string(tt.line.AppendTo([]byte("> "))); got != "> "+tt.want {
+			t.Errorf("AppendTo() = %q, want %q", got, "> "+tt.want)
+		}
+	}
+
+	// Parsed lines survive a round trip
+	input := "  2 NOTE  spaced  value  "
+	line, err := NewParser().ParseLine(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := line.String(); got != "2 NOTE spaced  value  " {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestTagRulesStrictAndPermissive(t *testing.T) {
+	tests := []struct {
+		tag          string
+		strictOK     bool
+		permissiveOK bool
+		wantTag      string // tag read under TagRulesPermissive
+	}{
+		{"NAME", true, true, "NAME"},
+		{"_MILT", true, true, "_MILT"},
+		{"birt", false, true, "BIRT"},
+		{"_uid", false, true, "_UID"},
+		{"Name", false, true, "NAME"},
+		{"_THIS_IS_A_VERY_LONG_EXTENSION_TAG", false, true, "_THIS_IS_A_VERY_LONG_EXTENSION_TAG"},
+		{"BIR-T", false, false, ""},
+	}
+	for _, tt := range tests {
+		strict := NewParser()
+		strict.SetTagRules(TagRulesStrict)
+		if _, err := strict.ParseLine("1 " + tt.tag + " value"); (err == nil) != tt.strictOK {
+			t.Errorf("strict: ParseLine(%q) error = %v, want ok %v", tt.tag, err, tt.strictOK)
+		}
+
+		permissive := NewParser()
+		permissive.SetTagRules(TagRulesPermissive)
+		line, err := permissive.ParseLine("1 " + tt.tag + " value")
+		if (err == nil) != tt.permissiveOK {
+			t.Errorf("permissive: ParseLine(%q) error = %v, want ok %v", tt.tag, err, tt.permissiveOK)
+			continue
+		}
+		if err == nil && line.Tag != tt.wantTag {
+			t.Errorf("permissive: ParseLine(%q) tag = %q, want %q", tt.tag, line.Tag, tt.wantTag)
+		}
+	}
+
+	p := NewParser()
+	p.SetTagRules(TagRulesPermissive)
+	if _, err := p.Parse(strings.NewReader("0 @I1@ indi\n1 NAME John /Doe/\n1 birt\n")); err != nil {
+		t.Fatal(err)
+	}
+	want := []TagRepair{{Line: 1, Original: "indi", Repaired: "INDI"}, {Line: 3, Original: "birt", Repaired: "BIRT"}}
+	if got := p.TagRepairs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("TagRepairs() = %+v, want %+v", got, want)
+	}
+
+	if TagRulesStrict.String() != "strict" || TagRulesPermissive.String() != "permissive" {
+		t.Error("TagRules.String() mismatch")
+	}
+}
@@ -0,0 +1,193 @@
+// Package query provides document-wide searches over decoded GEDCOM data.
+//
+// Queries that are repeated against the same document build an index once
+// and reuse it:
+//
+//	idx := query.NewDateIndex(doc)
+//	from, _ := gedcom.ParseDate("1918")
+//	for _, e := range idx.Between(from, from) {
+//	    fmt.Println(e.RecordXRef, e.Event.Type, e.Event.Date)
+//	}
+package query
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// DatedEvent is an event owned by an individual or family record, with the
+// earliest and latest days its date can refer to.
+type DatedEvent struct {
+	// RecordXRef is the individual or family record that owns the event
+	RecordXRef string
+
+	// EventIndex is the event's position in the owning record's Events
+	EventIndex int
+
+	// Event is the event
+	Event *gedcom.Event
+
+	// Start is the earliest day the event's date can refer to
+	Start *gedcom.Date
+
+	// End is the latest day the event's date can refer to
+	End *gedcom.Date
+}
+
+// DateIndex is an index of a document's dated events ordered by start date.
+// It is not updated when the document changes.
+type DateIndex struct {
+	events []DatedEvent
+}
+
+// NewDateIndex indexes the events of every individual and family in doc that
+// have a parsed date. Date phrases and unparsable dates are skipped.
+//
+// A partial date covers its whole period ("1918" is 1 JAN to 31 DEC 1918), a
+// range or period (BET/AND, FROM/TO) covers its full span, and an open-ended
+// date (BEF, AFT, FROM, TO) covers only the date it names. Approximate dates
+// (ABT, CAL, EST) are treated as exact.
+func NewDateIndex(doc *gedcom.Document) *DateIndex {
+	idx := &DateIndex{}
+	if doc == nil {
+		return idx
+	}
+	add := func(xref string, events []*gedcom.Event) {
+		for i, event := range events {
+			start, end, ok := eventSpan(event)
+			if !ok {
+				continue
+			}
+			idx.events = append(idx.events, DatedEvent{
+				RecordXRef: xref, EventIndex: i, Event: event, Start: start, End: end,
+			})
+		}
+	}
+	for _, record := range doc.Records {
+		switch entity := record.LoadEntity().(type) {
+		case *gedcom.Individual:
+			add(entity.XRef, entity.Events)
+		case *gedcom.Family:
+			add(entity.XRef, entity.Events)
+		}
+	}
+	sort.SliceStable(idx.events, func(i, j int) bool {
+		return idx.events[i].Start.Compare(idx.events[j].Start) < 0
+	})
+	return idx
+}
+
+// Len returns the number of indexed events.
+func (idx *DateIndex) Len() int {
+	return len(idx.events)
+}
+
+// Between returns the events whose dates overlap the window from from to to,
+// ordered by start date and then document order. Partial window dates cover
+// their whole period, so Between(1918, 1918) returns everything that
+// happened in 1918. A nil bound leaves that side of the window open.
+func (idx *DateIndex) Between(from, to *gedcom.Date) []DatedEvent {
+	var windowStart, windowEnd *gedcom.Date
+	if from != nil {
+		windowStart = earliest(from)
+	}
+	if to != nil {
+		windowEnd = latest(to)
+		if to.EndDate != nil {
+			windowEnd = latest(to.EndDate)
+		}
+	}
+
+	// Events starting after the window cannot overlap it
+	n := len(idx.events)
+	if windowEnd != nil {
+		n = sort.Search(len(idx.events), func(i int) bool {
+			return idx.events[i].Start.Compare(windowEnd) > 0
+		})
+	}
+
+	var result []DatedEvent
+	for _, event := range idx.events[:n] {
+		if windowStart != nil && event.End.Compare(windowStart) < 0 {
+			continue
+		}
+		result = append(result, event)
+	}
+	return result
+}
+
+// EventsBetween returns the events in doc whose dates overlap the window from
+// from to to. It builds a DateIndex for a single query; build the index once
+// with NewDateIndex when running several.
+func EventsBetween(doc *gedcom.Document, from, to *gedcom.Date) []DatedEvent {
+	return NewDateIndex(doc).Between(from, to)
+}
+
+// eventSpan returns the earliest and latest days an event's date refers to.
+func eventSpan(event *gedcom.Event) (start, end *gedcom.Date, ok bool) {
+	date := event.ParsedDate
+	if date == nil || date.IsPhrase || date.Year == 0 {
+		return nil, nil, false
+	}
+	start, end = earliest(date), latest(date)
+	if date.EndDate != nil && date.EndDate.Year != 0 {
+		end = latest(date.EndDate)
+	}
+	return start, end, true
+}
+
+// earliest returns the first day a date can refer to.
+func earliest(d *gedcom.Date) *gedcom.Date {
+	return &gedcom.Date{
+		Year: d.Year, Month: max(d.Month, 1), Day: max(d.Day, 1),
+		Calendar: d.Calendar, IsBC: d.IsBC,
+	}
+}
+
+// latest returns the last day a date can refer to.
+func latest(d *gedcom.Date) *gedcom.Date {
+	end := &gedcom.Date{Year: d.Year, Month: d.Month, Day: d.Day, Calendar: d.Calendar, IsBC: d.IsBC}
+	if end.Month == 0 {
+		end.Month = monthsInYear(d.Calendar)
+	}
+	if end.Day == 0 {
+		end.Day = daysInMonth(d.Calendar, end.Year, end.Month)
+	}
+	return end
+}
+
+// monthsInYear returns the number of months in a calendar year.
+func monthsInYear(cal gedcom.Calendar) int {
+	switch cal {
+	case gedcom.CalendarHebrew, gedcom.CalendarFrenchRepublican:
+		return 13
+	default:
+		return 12
+	}
+}
+
+// daysInMonth returns the length of a month, using 30 days for calendars
+// other than Gregorian and Julian.
+func daysInMonth(cal gedcom.Calendar, year, month int) int {
+	switch cal {
+	case gedcom.CalendarGregorian:
+		return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	case gedcom.CalendarJulian:
+		if month == 2 {
+			if year%4 == 0 {
+				return 29
+			}
+			return 28
+		}
+		return time.Date(2001, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	case gedcom.CalendarFrenchRepublican:
+		if month == 13 {
+			return 6
+		}
+		return 30
+	default:
+		return 30
+	}
+}
@@ -0,0 +1,137 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/decoder"
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+const eventsSource = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 12 MAR 1890
+1 DEAT
+2 DATE 1918
+1 EVEN
+2 TYPE Military service
+2 DATE FROM 1914 TO 1917
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 BIRT
+2 DATE ABT 1895
+1 RESI
+2 DATE (during the war)
+1 DEAT
+2 DATE 2 JAN 1919
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 MARR
+2 DATE BET NOV 1917 AND FEB 1918
+0 TRLR
+`
+
+func mustParseDate(t *testing.T, s string) *gedcom.Date {
+	t.Helper()
+	d, err := gedcom.ParseDate(s)
+	if err != nil {
+		t.Fatalf("ParseDate(%q) error = %v", s, err)
+	}
+	return d
+}
+
+// eventKeys returns "XREF TYPE" for each event.
+func eventKeys(events []DatedEvent) []string {
+	var keys []string
+	for _, e := range events {
+		keys = append(keys, e.RecordXRef+" "+string(e.Event.Type))
+	}
+	return keys
+}
+
+func TestEventsBetween(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(eventsSource))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []string
+	}{
+		{"year", "1918", "1918", []string{"@F1@ MARR", "@I1@ DEAT"}},
+		{"day inside period", "1 JUN 1915", "1 JUN 1915", []string{"@I1@ EVEN"}},
+		{"month", "MAR 1890", "MAR 1890", []string{"@I1@ BIRT"}},
+		{"window", "1890", "1895", []string{"@I1@ BIRT", "@I2@ BIRT"}},
+		{"range window", "BET 1 JAN 1919 AND 31 DEC 1919", "BET 1 JAN 1919 AND 31 DEC 1919", []string{"@I2@ DEAT"}},
+		{"empty", "1950", "1960", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eventKeys(EventsBetween(doc, mustParseDate(t, tt.from), mustParseDate(t, tt.to)))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EventsBetween(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateIndex(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(eventsSource))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	idx := NewDateIndex(doc)
+	if idx.Len() != 6 {
+		t.Errorf("Len() = %d, want 6 (date phrases skipped)", idx.Len())
+	}
+
+	got := eventKeys(idx.Between(nil, nil))
+	want := []string{"@I1@ BIRT", "@I2@ BIRT", "@I1@ EVEN", "@F1@ MARR", "@I1@ DEAT", "@I2@ DEAT"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Between(nil, nil) = %v, want %v", got, want)
+	}
+
+	after := eventKeys(idx.Between(mustParseDate(t, "1918"), nil))
+	if !reflect.DeepEqual(after, []string{"@F1@ MARR", "@I1@ DEAT", "@I2@ DEAT"}) {
+		t.Errorf("Between(1918, nil) = %v", after)
+	}
+
+	events := idx.Between(mustParseDate(t, "1918"), mustParseDate(t, "1918"))
+	if e := events[1]; e.EventIndex != 1 || e.Start.Day != 1 || e.End.Month != 12 || e.End.Day != 31 {
+		t.Errorf("death event = %+v, want index 1 spanning 1 JAN to 31 DEC", e)
+	}
+
+	if NewDateIndex(nil).Len() != 0 {
+		t.Error("nil document should produce an empty index")
+	}
+}
+
+func TestDaysInMonth(t *testing.T) {
+	tests := []struct {
+		cal         gedcom.Calendar
+		year, month int
+		want        int
+	}{
+		{gedcom.CalendarGregorian, 1900, 2, 28},
+		{gedcom.CalendarGregorian, 2000, 2, 29},
+		{gedcom.CalendarJulian, 1700, 2, 29},
+		{gedcom.CalendarJulian, 1701, 4, 30},
+		{gedcom.CalendarFrenchRepublican, 2, 13, 6},
+		{gedcom.CalendarHebrew, 5780, 1, 30},
+	}
+	for _, tt := range tests {
+		if got := daysInMonth(tt.cal, tt.year, tt.month); got != tt.want {
+			t.Errorf("daysInMonth(%v, %d, %d) = %d, want %d", tt.cal, tt.year, tt.month, got, tt.want)
+		}
+	}
+}
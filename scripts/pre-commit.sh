@@ -43,7 +43,7 @@ echo ""
 
 # 4. Check coverage
 echo "4️⃣  Checking test coverage..."
-COVERAGE=$(go test -cover ./charset ./decoder ./encoder ./gedcom ./parser ./validator ./version 2>&1 | grep -oE '[0-9]+\.[0-9]+%' | tail -1 | sed 's/%//')
+COVERAGE=$(go test -cover ./charset ./csvimport ./decoder ./encoder ./export ./gedcom ./gedcomtest ./parser ./query ./tags ./validator ./version 2>&1 | grep -oE '[0-9]+\.[0-9]+%' | tail -1 | sed 's/%//')
 if [ -z "$COVERAGE" ]; then
   COVERAGE="0.0"
 fi
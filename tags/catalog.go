@@ -0,0 +1,358 @@
+package tags
+
+// Standard tags.
+const (
+	ABBR   Tag = "ABBR"   // Abbreviation
+	ADDR   Tag = "ADDR"   // Address
+	ADOP   Tag = "ADOP"   // Adoption
+	ADR1   Tag = "ADR1"   // Address line 1
+	ADR2   Tag = "ADR2"   // Address line 2
+	ADR3   Tag = "ADR3"   // Address line 3
+	AFN    Tag = "AFN"    // Ancestral File number
+	AGE    Tag = "AGE"    // Age at event
+	AGNC   Tag = "AGNC"   // Responsible agency
+	ALIA   Tag = "ALIA"   // Alias
+	ANCE   Tag = "ANCE"   // Generations of ancestors
+	ANCI   Tag = "ANCI"   // Ancestor interest
+	ANUL   Tag = "ANUL"   // Annulment
+	ASSO   Tag = "ASSO"   // Association
+	AUTH   Tag = "AUTH"   // Author
+	BAPL   Tag = "BAPL"   // LDS baptism
+	BAPM   Tag = "BAPM"   // Baptism
+	BARM   Tag = "BARM"   // Bar mitzvah
+	BASM   Tag = "BASM"   // Bas mitzvah
+	BIRT   Tag = "BIRT"   // Birth
+	BLES   Tag = "BLES"   // Blessing
+	BLOB   Tag = "BLOB"   // Binary object
+	BURI   Tag = "BURI"   // Burial
+	CALN   Tag = "CALN"   // Call number
+	CAST   Tag = "CAST"   // Caste
+	CAUS   Tag = "CAUS"   // Cause
+	CENS   Tag = "CENS"   // Census
+	CHAN   Tag = "CHAN"   // Change date
+	CHAR   Tag = "CHAR"   // Character set
+	CHIL   Tag = "CHIL"   // Child
+	CHR    Tag = "CHR"    // Christening
+	CHRA   Tag = "CHRA"   // Adult christening
+	CITY   Tag = "CITY"   // City
+	CONC   Tag = "CONC"   // Concatenation
+	CONF   Tag = "CONF"   // Confirmation
+	CONL   Tag = "CONL"   // LDS confirmation
+	CONT   Tag = "CONT"   // Continued
+	COPR   Tag = "COPR"   // Copyright
+	CORP   Tag = "CORP"   // Corporation
+	CREA   Tag = "CREA"   // Creation date
+	CREM   Tag = "CREM"   // Cremation
+	CROP   Tag = "CROP"   // Crop
+	CTRY   Tag = "CTRY"   // Country
+	DATA   Tag = "DATA"   // Data
+	DATE   Tag = "DATE"   // Date
+	DEAT   Tag = "DEAT"   // Death
+	DESC   Tag = "DESC"   // Generations of descendants
+	DESI   Tag = "DESI"   // Descendant interest
+	DEST   Tag = "DEST"   // Destination
+	DIV    Tag = "DIV"    // Divorce
+	DIVF   Tag = "DIVF"   // Divorce filed
+	DSCR   Tag = "DSCR"   // Physical description
+	EDUC   Tag = "EDUC"   // Education
+	EMAIL  Tag = "EMAIL"  // Email
+	EMIG   Tag = "EMIG"   // Emigration
+	ENDL   Tag = "ENDL"   // LDS endowment
+	ENGA   Tag = "ENGA"   // Engagement
+	EVEN   Tag = "EVEN"   // Event
+	EXID   Tag = "EXID"   // External identifier
+	FACT   Tag = "FACT"   // Fact
+	FAM    Tag = "FAM"    // Family
+	FAMC   Tag = "FAMC"   // Child to family link
+	FAMF   Tag = "FAMF"   // Family file
+	FAMS   Tag = "FAMS"   // Spouse to family link
+	FAX    Tag = "FAX"    // Fax
+	FCOM   Tag = "FCOM"   // First communion
+	FILE   Tag = "FILE"   // File
+	FONE   Tag = "FONE"   // Phonetic variation
+	FORM   Tag = "FORM"   // Format
+	GEDC   Tag = "GEDC"   // GEDCOM
+	GIVN   Tag = "GIVN"   // Given name
+	GRAD   Tag = "GRAD"   // Graduation
+	HEAD   Tag = "HEAD"   // Header
+	HEIGHT Tag = "HEIGHT" // Crop height
+	HUSB   Tag = "HUSB"   // Husband
+	IDNO   Tag = "IDNO"   // Identification number
+	IMMI   Tag = "IMMI"   // Immigration
+	INDI   Tag = "INDI"   // Individual
+	INIL   Tag = "INIL"   // LDS initiatory
+	LANG   Tag = "LANG"   // Language
+	LATI   Tag = "LATI"   // Latitude
+	LEFT   Tag = "LEFT"   // Crop left
+	LONG   Tag = "LONG"   // Longitude
+	MAP    Tag = "MAP"    // Map
+	MARB   Tag = "MARB"   // Marriage bann
+	MARC   Tag = "MARC"   // Marriage contract
+	MARL   Tag = "MARL"   // Marriage license
+	MARR   Tag = "MARR"   // Marriage
+	MARS   Tag = "MARS"   // Marriage settlement
+	MEDI   Tag = "MEDI"   // Medium
+	MIME   Tag = "MIME"   // Media type
+	NAME   Tag = "NAME"   // Name
+	NATI   Tag = "NATI"   // Nationality
+	NATU   Tag = "NATU"   // Naturalization
+	NCHI   Tag = "NCHI"   // Number of children
+	NICK   Tag = "NICK"   // Nickname
+	NMR    Tag = "NMR"    // Number of marriages
+	NO     Tag = "NO"     // Did not occur
+	NOTE   Tag = "NOTE"   // Note
+	NPFX   Tag = "NPFX"   // Name prefix
+	NSFX   Tag = "NSFX"   // Name suffix
+	OBJE   Tag = "OBJE"   // Object
+	OCCU   Tag = "OCCU"   // Occupation
+	ORDI   Tag = "ORDI"   // Ordinance process flag
+	ORDN   Tag = "ORDN"   // Ordination
+	PAGE   Tag = "PAGE"   // Page
+	PEDI   Tag = "PEDI"   // Pedigree
+	PHON   Tag = "PHON"   // Phone
+	PHRASE Tag = "PHRASE" // Phrase
+	PLAC   Tag = "PLAC"   // Place
+	POST   Tag = "POST"   // Postal code
+	PROB   Tag = "PROB"   // Probate
+	PROP   Tag = "PROP"   // Property
+	PUBL   Tag = "PUBL"   // Publication
+	QUAY   Tag = "QUAY"   // Quality of data
+	REFN   Tag = "REFN"   // Reference
+	RELA   Tag = "RELA"   // Relationship
+	RELI   Tag = "RELI"   // Religion
+	REPO   Tag = "REPO"   // Repository
+	RESI   Tag = "RESI"   // Residence
+	RESN   Tag = "RESN"   // Restriction
+	RETI   Tag = "RETI"   // Retirement
+	RFN    Tag = "RFN"    // Record file number
+	RIN    Tag = "RIN"    // Record ID number
+	ROLE   Tag = "ROLE"   // Role
+	ROMN   Tag = "ROMN"   // Romanized variation
+	SCHMA  Tag = "SCHMA"  // Extension schema
+	SDATE  Tag = "SDATE"  // Sort date
+	SEX    Tag = "SEX"    // Sex
+	SLGC   Tag = "SLGC"   // LDS child sealing
+	SLGS   Tag = "SLGS"   // LDS spouse sealing
+	SNOTE  Tag = "SNOTE"  // Shared note
+	SOUR   Tag = "SOUR"   // Source
+	SPFX   Tag = "SPFX"   // Surname prefix
+	SSN    Tag = "SSN"    // Social security number
+	STAE   Tag = "STAE"   // State
+	STAT   Tag = "STAT"   // Status
+	SUBM   Tag = "SUBM"   // Submitter
+	SUBN   Tag = "SUBN"   // Submission
+	SURN   Tag = "SURN"   // Surname
+	TAG    Tag = "TAG"    // Extension tag definition
+	TEMP   Tag = "TEMP"   // Temple
+	TEXT   Tag = "TEXT"   // Text from source
+	TIME   Tag = "TIME"   // Time
+	TITL   Tag = "TITL"   // Title
+	TOP    Tag = "TOP"    // Crop top
+	TRAN   Tag = "TRAN"   // Translation
+	TRLR   Tag = "TRLR"   // Trailer
+	TYPE   Tag = "TYPE"   // Type
+	UID    Tag = "UID"    // Unique identifier
+	VERS   Tag = "VERS"   // Version
+	WIDTH  Tag = "WIDTH"  // Crop width
+	WIFE   Tag = "WIFE"   // Wife
+	WILL   Tag = "WILL"   // Will
+	WWW    Tag = "WWW"    // Web page
+)
+
+// Groups of tags sharing parents.
+var (
+	individualEvents = []Tag{
+		ADOP, BAPM, BARM, BASM, BIRT, BLES, BURI, CENS, CHR, CHRA, CONF, CREM,
+		DEAT, EMIG, EVEN, FCOM, GRAD, IMMI, NATU, ORDN, PROB, RETI, WILL,
+	}
+	familyEvents = []Tag{ANUL, CENS, DIV, DIVF, ENGA, EVEN, MARB, MARC, MARL, MARR, MARS, RESI}
+	attributes   = []Tag{
+		CAST, DSCR, EDUC, FACT, IDNO, NATI, NCHI, NMR, OCCU, PROP, RELI, RESI, SSN, TITL,
+	}
+	events     = join(individualEvents, familyEvents, attributes)
+	ordinances = []Tag{BAPL, CONL, ENDL, INIL, SLGC, SLGS}
+	records    = []Tag{FAM, INDI, NOTE, OBJE, REPO, SNOTE, SOUR, SUBM}
+)
+
+// entries is the catalog, ordered by tag name.
+var entries = []Info{
+	{Tag: ABBR, Meaning: "Abbreviation", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{SOUR}},
+	{Tag: ADDR, Meaning: "Address", Versions: AllVersions, Payload: PayloadText, Parents: join([]Tag{CORP, REPO, SUBM}, events)},
+	{Tag: ADOP, Meaning: "Adoption", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI, FAMC}},
+	{Tag: ADR1, Meaning: "Address line 1", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{ADDR}},
+	{Tag: ADR2, Meaning: "Address line 2", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{ADDR}},
+	{Tag: ADR3, Meaning: "Address line 3", Versions: V551 | V70, Payload: PayloadText, Parents: []Tag{ADDR}},
+	{Tag: AFN, Meaning: "Ancestral File number", Versions: V5, Payload: PayloadText, Parents: []Tag{INDI}},
+	{Tag: AGE, Meaning: "Age at event", Versions: AllVersions, Payload: PayloadAge, Parents: join([]Tag{HUSB, WIFE}, events)},
+	{Tag: AGNC, Meaning: "Responsible agency", Versions: AllVersions, Payload: PayloadText, Parents: join([]Tag{DATA}, events)},
+	{Tag: ALIA, Meaning: "Alias", Versions: AllVersions, Payload: PayloadPointer, Parents: []Tag{INDI}},
+	{Tag: ANCE, Meaning: "Generations of ancestors", Versions: V5, Payload: PayloadInteger, Parents: []Tag{SUBN}},
+	{Tag: ANCI, Meaning: "Ancestor interest", Versions: V5, Payload: PayloadPointer, Parents: []Tag{INDI}},
+	{Tag: ANUL, Meaning: "Annulment", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{FAM}},
+	{Tag: ASSO, Meaning: "Association", Versions: AllVersions, Payload: PayloadPointer, Parents: join([]Tag{INDI, FAM}, events)},
+	{Tag: AUTH, Meaning: "Author", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{SOUR}},
+	{Tag: BAPL, Meaning: "LDS baptism", Versions: AllVersions, Payload: PayloadNone, Parents: []Tag{INDI}},
+	{Tag: BAPM, Meaning: "Baptism", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: BARM, Meaning: "Bar mitzvah", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: BASM, Meaning: "Bas mitzvah", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: BIRT, Meaning: "Birth", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: BLES, Meaning: "Blessing", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: BLOB, Meaning: "Binary object", Versions: V55, Payload: PayloadText, Parents: []Tag{OBJE}},
+	{Tag: BURI, Meaning: "Burial", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: CALN, Meaning: "Call number", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{REPO}},
+	{Tag: CAST, Meaning: "Caste", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{INDI}},
+	{Tag: CAUS, Meaning: "Cause", Versions: AllVersions, Payload: PayloadText, Parents: join(events)},
+	{Tag: CENS, Meaning: "Census", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI, FAM}},
+	{Tag: CHAN, Meaning: "Change date", Versions: AllVersions, Payload: PayloadNone, Parents: join(records)},
+	{Tag: CHAR, Meaning: "Character set", Versions: V5, Payload: PayloadEnum, Parents: []Tag{HEAD}},
+	{Tag: CHIL, Meaning: "Child", Versions: AllVersions, Payload: PayloadPointer, Parents: []Tag{FAM}},
+	{Tag: CHR, Meaning: "Christening", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: CHRA, Meaning: "Adult christening", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: CITY, Meaning: "City", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{ADDR}},
+	{Tag: CONC, Meaning: "Concatenation", Versions: V5, Payload: PayloadText, AnyParent: true},
+	{Tag: CONF, Meaning: "Confirmation", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: CONL, Meaning: "LDS confirmation", Versions: AllVersions, Payload: PayloadNone, Parents: []Tag{INDI}},
+	{Tag: CONT, Meaning: "Continued", Versions: AllVersions, Payload: PayloadText, AnyParent: true},
+	{Tag: COPR, Meaning: "Copyright", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{HEAD, DATA}},
+	{Tag: CORP, Meaning: "Corporation", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{SOUR}},
+	{Tag: CREA, Meaning: "Creation date", Versions: V70, Payload: PayloadNone, Parents: join(records)},
+	{Tag: CREM, Meaning: "Cremation", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: CROP, Meaning: "Crop", Versions: V70, Payload: PayloadNone, Parents: []Tag{OBJE}},
+	{Tag: CTRY, Meaning: "Country", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{ADDR}},
+	{Tag: DATA, Meaning: "Data", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{SOUR}},
+	{Tag: DATE, Meaning: "Date", Versions: AllVersions, Payload: PayloadDate, Parents: join([]Tag{CHAN, CREA, DATA, HEAD, NO}, events, ordinances)},
+	{Tag: DEAT, Meaning: "Death", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: DESC, Meaning: "Generations of descendants", Versions: V5, Payload: PayloadInteger, Parents: []Tag{SUBN}},
+	{Tag: DESI, Meaning: "Descendant interest", Versions: V5, Payload: PayloadPointer, Parents: []Tag{INDI}},
+	{Tag: DEST, Meaning: "Destination", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{HEAD}},
+	{Tag: DIV, Meaning: "Divorce", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{FAM}},
+	{Tag: DIVF, Meaning: "Divorce filed", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{FAM}},
+	{Tag: DSCR, Meaning: "Physical description", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{INDI}},
+	{Tag: EDUC, Meaning: "Education", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{INDI}},
+	{Tag: EMAIL, Meaning: "Email", Versions: V551 | V70, Payload: PayloadText, Parents: join([]Tag{CORP, REPO, SUBM}, events)},
+	{Tag: EMIG, Meaning: "Emigration", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: ENDL, Meaning: "LDS endowment", Versions: AllVersions, Payload: PayloadNone, Parents: []Tag{INDI}},
+	{Tag: ENGA, Meaning: "Engagement", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{FAM}},
+	{Tag: EVEN, Meaning: "Event", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{INDI, FAM, DATA, SOUR}},
+	{Tag: EXID, Meaning: "External identifier", Versions: V70, Payload: PayloadText, Parents: join(records)},
+	{Tag: FACT, Meaning: "Fact", Versions: V551 | V70, Payload: PayloadText, Parents: []Tag{INDI, FAM}},
+	{Tag: FAM, Meaning: "Family", Versions: AllVersions, Payload: PayloadNone, Record: true},
+	{Tag: FAMC, Meaning: "Child to family link", Versions: AllVersions, Payload: PayloadPointer, Parents: []Tag{INDI, ADOP, BIRT, CHR, SLGC}},
+	{Tag: FAMF, Meaning: "Family file", Versions: V5, Payload: PayloadText, Parents: []Tag{SUBN}},
+	{Tag: FAMS, Meaning: "Spouse to family link", Versions: AllVersions, Payload: PayloadPointer, Parents: []Tag{INDI}},
+	{Tag: FAX, Meaning: "Fax", Versions: V551 | V70, Payload: PayloadText, Parents: join([]Tag{CORP, REPO, SUBM}, events)},
+	{Tag: FCOM, Meaning: "First communion", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: FILE, Meaning: "File", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{HEAD, OBJE}},
+	{Tag: FONE, Meaning: "Phonetic variation", Versions: V551, Payload: PayloadText, Parents: []Tag{NAME, PLAC}},
+	{Tag: FORM, Meaning: "Format", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{FILE, GEDC, OBJE, PLAC}},
+	{Tag: GEDC, Meaning: "GEDCOM", Versions: AllVersions, Payload: PayloadNone, Parents: []Tag{HEAD}},
+	{Tag: GIVN, Meaning: "Given name", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{NAME, FONE, ROMN, TRAN}},
+	{Tag: GRAD, Meaning: "Graduation", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: HEAD, Meaning: "Header", Versions: AllVersions, Payload: PayloadNone, Record: true},
+	{Tag: HEIGHT, Meaning: "Crop height", Versions: V70, Payload: PayloadInteger, Parents: []Tag{CROP}},
+	{Tag: HUSB, Meaning: "Husband", Versions: AllVersions, Payload: PayloadPointer, Parents: join([]Tag{FAM}, familyEvents)},
+	{Tag: IDNO, Meaning: "Identification number", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{INDI}},
+	{Tag: IMMI, Meaning: "Immigration", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: INDI, Meaning: "Individual", Versions: AllVersions, Payload: PayloadNone, Record: true},
+	{Tag: INIL, Meaning: "LDS initiatory", Versions: V70, Payload: PayloadNone, Parents: []Tag{INDI}},
+	{Tag: LANG, Meaning: "Language", Versions: AllVersions, Payload: PayloadLanguage, Parents: []Tag{HEAD, SUBM, NOTE, SNOTE, PLAC, TRAN, TEXT}},
+	{Tag: LATI, Meaning: "Latitude", Versions: V551 | V70, Payload: PayloadText, Parents: []Tag{MAP}},
+	{Tag: LEFT, Meaning: "Crop left", Versions: V70, Payload: PayloadInteger, Parents: []Tag{CROP}},
+	{Tag: LONG, Meaning: "Longitude", Versions: V551 | V70, Payload: PayloadText, Parents: []Tag{MAP}},
+	{Tag: MAP, Meaning: "Map", Versions: V551 | V70, Payload: PayloadNone, Parents: []Tag{PLAC}},
+	{Tag: MARB, Meaning: "Marriage bann", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{FAM}},
+	{Tag: MARC, Meaning: "Marriage contract", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{FAM}},
+	{Tag: MARL, Meaning: "Marriage license", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{FAM}},
+	{Tag: MARR, Meaning: "Marriage", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{FAM}},
+	{Tag: MARS, Meaning: "Marriage settlement", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{FAM}},
+	{Tag: MEDI, Meaning: "Medium", Versions: AllVersions, Payload: PayloadEnum, Parents: []Tag{CALN, FORM}},
+	{Tag: MIME, Meaning: "Media type", Versions: V70, Payload: PayloadMediaType, Parents: []Tag{NOTE, SNOTE, TEXT}},
+	{Tag: NAME, Meaning: "Name", Versions: AllVersions, Payload: PayloadName, Parents: []Tag{INDI, REPO, SOUR, SUBM}},
+	{Tag: NATI, Meaning: "Nationality", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{INDI}},
+	{Tag: NATU, Meaning: "Naturalization", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: NCHI, Meaning: "Number of children", Versions: AllVersions, Payload: PayloadInteger, Parents: []Tag{INDI, FAM}},
+	{Tag: NICK, Meaning: "Nickname", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{NAME, FONE, ROMN, TRAN}},
+	{Tag: NMR, Meaning: "Number of marriages", Versions: AllVersions, Payload: PayloadInteger, Parents: []Tag{INDI}},
+	{Tag: NO, Meaning: "Did not occur", Versions: V70, Payload: PayloadEnum, Parents: []Tag{INDI, FAM}},
+	{Tag: NOTE, Meaning: "Note", Versions: AllVersions, Payload: PayloadPointerOrText, Record: true, AnyParent: true},
+	{Tag: NPFX, Meaning: "Name prefix", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{NAME, FONE, ROMN, TRAN}},
+	{Tag: NSFX, Meaning: "Name suffix", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{NAME, FONE, ROMN, TRAN}},
+	{Tag: OBJE, Meaning: "Object", Versions: AllVersions, Payload: PayloadPointer, Record: true, AnyParent: true},
+	{Tag: OCCU, Meaning: "Occupation", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{INDI}},
+	{Tag: ORDI, Meaning: "Ordinance process flag", Versions: V5, Payload: PayloadEnum, Parents: []Tag{SUBN}},
+	{Tag: ORDN, Meaning: "Ordination", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: PAGE, Meaning: "Page", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{SOUR}},
+	{Tag: PEDI, Meaning: "Pedigree", Versions: AllVersions, Payload: PayloadEnum, Parents: []Tag{FAMC}},
+	{Tag: PHON, Meaning: "Phone", Versions: AllVersions, Payload: PayloadText, Parents: join([]Tag{CORP, REPO, SUBM}, events)},
+	{Tag: PHRASE, Meaning: "Phrase", Versions: V70, Payload: PayloadText, AnyParent: true},
+	{Tag: PLAC, Meaning: "Place", Versions: AllVersions, Payload: PayloadText, Parents: join([]Tag{HEAD}, events, ordinances)},
+	{Tag: POST, Meaning: "Postal code", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{ADDR}},
+	{Tag: PROB, Meaning: "Probate", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: PROP, Meaning: "Property", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{INDI}},
+	{Tag: PUBL, Meaning: "Publication", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{SOUR}},
+	{Tag: QUAY, Meaning: "Quality of data", Versions: AllVersions, Payload: PayloadEnum, Parents: []Tag{SOUR}},
+	{Tag: REFN, Meaning: "Reference", Versions: AllVersions, Payload: PayloadText, Parents: join(records)},
+	{Tag: RELA, Meaning: "Relationship", Versions: V5, Payload: PayloadText, Parents: []Tag{ASSO}},
+	{Tag: RELI, Meaning: "Religion", Versions: AllVersions, Payload: PayloadText, Parents: join([]Tag{INDI}, events)},
+	{Tag: REPO, Meaning: "Repository", Versions: AllVersions, Payload: PayloadPointer, Record: true, Parents: []Tag{SOUR}},
+	{Tag: RESI, Meaning: "Residence", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{INDI, FAM}},
+	{Tag: RESN, Meaning: "Restriction", Versions: AllVersions, Payload: PayloadEnum, Parents: join([]Tag{INDI, FAM}, events)},
+	{Tag: RETI, Meaning: "Retirement", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: RFN, Meaning: "Record file number", Versions: V5, Payload: PayloadText, Parents: []Tag{INDI}},
+	{Tag: RIN, Meaning: "Record ID number", Versions: V5, Payload: PayloadText, Parents: join(records)},
+	{Tag: ROLE, Meaning: "Role", Versions: AllVersions, Payload: PayloadEnum, Parents: []Tag{ASSO, EVEN}},
+	{Tag: ROMN, Meaning: "Romanized variation", Versions: V551, Payload: PayloadText, Parents: []Tag{NAME, PLAC}},
+	{Tag: SCHMA, Meaning: "Extension schema", Versions: V70, Payload: PayloadNone, Parents: []Tag{HEAD}},
+	{Tag: SDATE, Meaning: "Sort date", Versions: V70, Payload: PayloadDate, Parents: join(events)},
+	{Tag: SEX, Meaning: "Sex", Versions: AllVersions, Payload: PayloadEnum, Parents: []Tag{INDI}},
+	{Tag: SLGC, Meaning: "LDS child sealing", Versions: AllVersions, Payload: PayloadNone, Parents: []Tag{INDI}},
+	{Tag: SLGS, Meaning: "LDS spouse sealing", Versions: AllVersions, Payload: PayloadNone, Parents: []Tag{FAM}},
+	{Tag: SNOTE, Meaning: "Shared note", Versions: V70, Payload: PayloadPointerOrText, Record: true, AnyParent: true},
+	{Tag: SOUR, Meaning: "Source", Versions: AllVersions, Payload: PayloadPointerOrText, Record: true, AnyParent: true},
+	{Tag: SPFX, Meaning: "Surname prefix", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{NAME, FONE, ROMN, TRAN}},
+	{Tag: SSN, Meaning: "Social security number", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{INDI}},
+	{Tag: STAE, Meaning: "State", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{ADDR}},
+	{Tag: STAT, Meaning: "Status", Versions: AllVersions, Payload: PayloadEnum, Parents: join([]Tag{FAMC}, ordinances)},
+	{Tag: SUBM, Meaning: "Submitter", Versions: AllVersions, Payload: PayloadPointer, Record: true, Parents: []Tag{HEAD, INDI, FAM, SUBN}},
+	{Tag: SUBN, Meaning: "Submission", Versions: V5, Payload: PayloadPointer, Record: true, Parents: []Tag{HEAD}},
+	{Tag: SURN, Meaning: "Surname", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{NAME, FONE, ROMN, TRAN}},
+	{Tag: TAG, Meaning: "Extension tag definition", Versions: V70, Payload: PayloadText, Parents: []Tag{SCHMA}},
+	{Tag: TEMP, Meaning: "Temple", Versions: AllVersions, Payload: PayloadText, Parents: join(ordinances)},
+	{Tag: TEXT, Meaning: "Text from source", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{SOUR, DATA}},
+	{Tag: TIME, Meaning: "Time", Versions: AllVersions, Payload: PayloadTime, Parents: []Tag{DATE}},
+	{Tag: TITL, Meaning: "Title", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{INDI, SOUR, OBJE, FILE}},
+	{Tag: TOP, Meaning: "Crop top", Versions: V70, Payload: PayloadInteger, Parents: []Tag{CROP}},
+	{Tag: TRAN, Meaning: "Translation", Versions: V70, Payload: PayloadText, Parents: []Tag{NAME, PLAC, FILE, NOTE, SNOTE}},
+	{Tag: TRLR, Meaning: "Trailer", Versions: AllVersions, Payload: PayloadNone, Record: true},
+	{Tag: TYPE, Meaning: "Type", Versions: AllVersions, Payload: PayloadText, Parents: join([]Tag{NAME, IDNO, FORM, EXID, REFN}, events)},
+	{Tag: UID, Meaning: "Unique identifier", Versions: V70, Payload: PayloadText, Parents: join(records)},
+	{Tag: VERS, Meaning: "Version", Versions: AllVersions, Payload: PayloadText, Parents: []Tag{GEDC, SOUR, CHAR}},
+	{Tag: WIDTH, Meaning: "Crop width", Versions: V70, Payload: PayloadInteger, Parents: []Tag{CROP}},
+	{Tag: WIFE, Meaning: "Wife", Versions: AllVersions, Payload: PayloadPointer, Parents: join([]Tag{FAM}, familyEvents)},
+	{Tag: WILL, Meaning: "Will", Versions: AllVersions, Payload: PayloadOptionalY, Parents: []Tag{INDI}},
+	{Tag: WWW, Meaning: "Web page", Versions: V551 | V70, Payload: PayloadText, Parents: join([]Tag{CORP, REPO, SUBM}, events)},
+}
+
+// catalog indexes entries by tag.
+var catalog = func() map[Tag]Info {
+	m := make(map[Tag]Info, len(entries))
+	for _, info := range entries {
+		m[info.Tag] = info
+	}
+	return m
+}()
+
+// join concatenates tag groups, dropping duplicates.
+func join(groups ...[]Tag) []Tag {
+	seen := make(map[Tag]bool)
+	var all []Tag
+	for _, group := range groups {
+		for _, t := range group {
+			if !seen[t] {
+				seen[t] = true
+				all = append(all, t)
+			}
+		}
+	}
+	return all
+}
@@ -0,0 +1,209 @@
+// Package tags catalogs the standard GEDCOM 5.5, 5.5.1, and 7.0 tags.
+//
+// Each tag is a typed constant carrying metadata: the versions that define
+// it, the kind of payload its line value holds, and the tags it may appear
+// under. Compare parsed tag names against the constants instead of string
+// literals:
+//
+//	switch tags.Tag(line.Tag) {
+//	case tags.INDI:
+//	    // ...
+//	}
+//
+//	info, ok := tags.Lookup(tags.Tag(line.Tag))
+//	if ok && !info.Versions.Has(tags.V70) {
+//	    fmt.Printf("%s is not part of GEDCOM 7.0\n", line.Tag)
+//	}
+package tags
+
+import "strings"
+
+// Tag is a GEDCOM tag name, such as INDI or BIRT.
+type Tag string
+
+// String returns the tag name.
+func (t Tag) String() string {
+	return string(t)
+}
+
+// IsExtension returns true for user-defined extension tags, which start
+// with an underscore (e.g., _UID, _MILT).
+func (t Tag) IsExtension() bool {
+	return strings.HasPrefix(string(t), "_")
+}
+
+// IsStandard returns true if the tag is defined by any supported GEDCOM
+// version.
+func (t Tag) IsStandard() bool {
+	_, ok := catalog[t]
+	return ok
+}
+
+// Versions is a set of GEDCOM versions.
+type Versions uint8
+
+// GEDCOM versions in a Versions set.
+const (
+	V55 Versions = 1 << iota
+	V551
+	V70
+
+	// V5 covers both 5.x versions
+	V5 = V55 | V551
+
+	// AllVersions covers every supported version
+	AllVersions = V55 | V551 | V70
+)
+
+// Has returns true if every version in other is in v.
+func (v Versions) Has(other Versions) bool {
+	return v&other == other
+}
+
+// String returns the versions as a comma-separated list (e.g., "5.5.1,7.0").
+func (v Versions) String() string {
+	var names []string
+	for _, version := range []struct {
+		bit  Versions
+		name string
+	}{{V55, "5.5"}, {V551, "5.5.1"}, {V70, "7.0"}} {
+		if v.Has(version.bit) {
+			names = append(names, version.name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// Payload describes what the value on a tag's line holds.
+type Payload int
+
+const (
+	// PayloadNone means the line has no value; the tag only groups
+	// subordinates
+	PayloadNone Payload = iota
+
+	// PayloadText is free text
+	PayloadText
+
+	// PayloadPointer is a cross-reference to a record (e.g., @I1@)
+	PayloadPointer
+
+	// PayloadPointerOrText is either a cross-reference or free text, as in
+	// GEDCOM 5.x NOTE and SOUR
+	PayloadPointerOrText
+
+	// PayloadDate is a date value
+	PayloadDate
+
+	// PayloadTime is a clock time
+	PayloadTime
+
+	// PayloadAge is an age value (e.g., "32y 5m")
+	PayloadAge
+
+	// PayloadInteger is a non-negative integer
+	PayloadInteger
+
+	// PayloadEnum is one of a fixed set of values (e.g., SEX M, F, U, X)
+	PayloadEnum
+
+	// PayloadName is a personal name with the surname between slashes
+	PayloadName
+
+	// PayloadLanguage is a language name or BCP 47 tag
+	PayloadLanguage
+
+	// PayloadMediaType is a media type or, in 5.x, a file format
+	PayloadMediaType
+
+	// PayloadOptionalY is empty or "Y", as on events that occurred without
+	// further details
+	PayloadOptionalY
+)
+
+// String returns the payload kind's name.
+func (p Payload) String() string {
+	switch p {
+	case PayloadNone:
+		return "none"
+	case PayloadText:
+		return "text"
+	case PayloadPointer:
+		return "pointer"
+	case PayloadPointerOrText:
+		return "pointer-or-text"
+	case PayloadDate:
+		return "date"
+	case PayloadTime:
+		return "time"
+	case PayloadAge:
+		return "age"
+	case PayloadInteger:
+		return "integer"
+	case PayloadEnum:
+		return "enum"
+	case PayloadName:
+		return "name"
+	case PayloadLanguage:
+		return "language"
+	case PayloadMediaType:
+		return "media-type"
+	case PayloadOptionalY:
+		return "optional-y"
+	default:
+		return "unknown"
+	}
+}
+
+// Info is the catalog entry for a standard tag.
+type Info struct {
+	// Tag is the tag name
+	Tag Tag
+
+	// Meaning is the tag's short description from the specification
+	Meaning string
+
+	// Versions are the GEDCOM versions that define the tag
+	Versions Versions
+
+	// Payload is the kind of value on the tag's line. Where versions
+	// differ, it is the GEDCOM 5.5.1 payload.
+	Payload Payload
+
+	// Record is true if the tag can start a level 0 record
+	Record bool
+
+	// Parents lists the tags the tag may appear under, across all versions
+	Parents []Tag
+
+	// AnyParent is true for tags allowed under most structures, such as
+	// CONT, NOTE, and source citations; Parents is then empty
+	AnyParent bool
+}
+
+// AllowedUnder returns true if the tag may appear as a subordinate of parent.
+func (i Info) AllowedUnder(parent Tag) bool {
+	if i.AnyParent {
+		return true
+	}
+	for _, p := range i.Parents {
+		if p == parent {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup returns the catalog entry for t. It returns false for extension
+// tags and unknown names.
+func Lookup(t Tag) (Info, bool) {
+	info, ok := catalog[t]
+	return info, ok
+}
+
+// All returns the catalog entries of every standard tag, ordered by name.
+func All() []Info {
+	all := make([]Info, 0, len(entries))
+	all = append(all, entries...)
+	return all
+}
@@ -0,0 +1,186 @@
+package tags
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      Tag
+		wantOK   bool
+		versions Versions
+		payload  Payload
+		record   bool
+	}{
+		{name: "record tag", tag: INDI, wantOK: true, versions: AllVersions, payload: PayloadNone, record: true},
+		{name: "event", tag: BIRT, wantOK: true, versions: AllVersions, payload: PayloadOptionalY},
+		{name: "removed in 7.0", tag: CONC, wantOK: true, versions: V5, payload: PayloadText},
+		{name: "added in 5.5.1", tag: EMAIL, wantOK: true, versions: V551 | V70, payload: PayloadText},
+		{name: "added in 7.0", tag: SNOTE, wantOK: true, versions: V70, payload: PayloadPointerOrText, record: true},
+		{name: "5.5 only", tag: BLOB, wantOK: true, versions: V55, payload: PayloadText},
+		{name: "extension", tag: "_UID", wantOK: false},
+		{name: "unknown", tag: "XYZZY", wantOK: false},
+		{name: "lowercase is not standard", tag: "indi", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, ok := Lookup(tt.tag)
+			if ok != tt.wantOK {
+				t.Fatalf("Lookup(%q) ok = %v, want %v", tt.tag, ok, tt.wantOK)
+			}
+			if tt.tag.IsStandard() != tt.wantOK {
+				t.Errorf("IsStandard() = %v, want %v", tt.tag.IsStandard(), tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if info.Tag != tt.tag {
+				t.Errorf("Tag = %q, want %q", info.Tag, tt.tag)
+			}
+			if info.Versions != tt.versions {
+				t.Errorf("Versions = %s, want %s", info.Versions, tt.versions)
+			}
+			if info.Payload != tt.payload {
+				t.Errorf("Payload = %s, want %s", info.Payload, tt.payload)
+			}
+			if info.Record != tt.record {
+				t.Errorf("Record = %v, want %v", info.Record, tt.record)
+			}
+		})
+	}
+}
+
+func TestTagIsExtension(t *testing.T) {
+	tests := []struct {
+		tag  Tag
+		want bool
+	}{
+		{"_UID", true},
+		{"_MILT", true},
+		{INDI, false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := tt.tag.IsExtension(); got != tt.want {
+			t.Errorf("Tag(%q).IsExtension() = %v, want %v", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestVersions(t *testing.T) {
+	tests := []struct {
+		v    Versions
+		want string
+	}{
+		{AllVersions, "5.5,5.5.1,7.0"},
+		{V5, "5.5,5.5.1"},
+		{V551 | V70, "5.5.1,7.0"},
+		{V70, "7.0"},
+		{0, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.want {
+			t.Errorf("Versions(%d).String() = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+
+	if !AllVersions.Has(V551) {
+		t.Error("AllVersions.Has(V551) = false, want true")
+	}
+	if V5.Has(V70) {
+		t.Error("V5.Has(V70) = true, want false")
+	}
+	if V5.Has(V551 | V70) {
+		t.Error("V5.Has(V551|V70) = true, want false")
+	}
+}
+
+func TestPayloadString(t *testing.T) {
+	for p := PayloadNone; p <= PayloadOptionalY; p++ {
+		if s := p.String(); s == "unknown" || s == "" {
+			t.Errorf("Payload(%d).String() = %q", p, s)
+		}
+	}
+	if got := Payload(-1).String(); got != "unknown" {
+		t.Errorf("Payload(-1).String() = %q, want unknown", got)
+	}
+}
+
+func TestAllowedUnder(t *testing.T) {
+	tests := []struct {
+		tag    Tag
+		parent Tag
+		want   bool
+	}{
+		{BIRT, INDI, true},
+		{BIRT, FAM, false},
+		{MARR, FAM, true},
+		{DATE, BIRT, true},
+		{DATE, MARR, true},
+		{DATE, OCCU, true},
+		{DATE, BAPL, true},
+		{DATE, CHAN, true},
+		{PLAC, DEAT, true},
+		{PLAC, NAME, false},
+		{TIME, DATE, true},
+		{GIVN, NAME, true},
+		{GIVN, INDI, false},
+		{AGE, HUSB, true},
+		{HUSB, MARR, true},
+		{CONT, NOTE, true},
+		{NOTE, BIRT, true},
+		{SOUR, NAME, true},
+		{CHAN, INDI, true},
+		{CHAN, NAME, false},
+	}
+	for _, tt := range tests {
+		info, ok := Lookup(tt.tag)
+		if !ok {
+			t.Fatalf("Lookup(%q) failed", tt.tag)
+		}
+		if got := info.AllowedUnder(tt.parent); got != tt.want {
+			t.Errorf("%s.AllowedUnder(%s) = %v, want %v", tt.tag, tt.parent, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogConsistency(t *testing.T) {
+	all := All()
+	if len(all) == 0 {
+		t.Fatal("All() returned no entries")
+	}
+	if !sort.SliceIsSorted(all, func(i, j int) bool { return all[i].Tag < all[j].Tag }) {
+		t.Error("All() is not sorted by tag")
+	}
+
+	for i, info := range all {
+		if i > 0 && all[i-1].Tag == info.Tag {
+			t.Errorf("duplicate entry for %s", info.Tag)
+		}
+		if info.Meaning == "" {
+			t.Errorf("%s has no meaning", info.Tag)
+		}
+		if info.Versions == 0 {
+			t.Errorf("%s has no versions", info.Tag)
+		}
+		if info.AnyParent && len(info.Parents) > 0 {
+			t.Errorf("%s has AnyParent and Parents", info.Tag)
+		}
+		if !info.Record && !info.AnyParent && len(info.Parents) == 0 {
+			t.Errorf("%s has no parents and is not a record", info.Tag)
+		}
+		for _, parent := range info.Parents {
+			if !parent.IsStandard() {
+				t.Errorf("%s lists unknown parent %s", info.Tag, parent)
+			}
+		}
+	}
+
+	// All returns a copy
+	all[0].Meaning = "changed"
+	if again := All(); again[0].Meaning == "changed" {
+		t.Error("All() exposes the catalog")
+	}
+}
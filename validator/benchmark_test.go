@@ -1,8 +1,10 @@
 package validator
 
 import (
+	"os"
 	"testing"
 
+	"github.com/cacack/gedcom-go/decoder"
 	"github.com/cacack/gedcom-go/gedcom"
 )
 
@@ -85,6 +87,38 @@ func BenchmarkValidateWithErrors(b *testing.B) {
 	}
 }
 
+// BenchmarkValidateRoyal92 benchmarks validating the royal92 corpus
+// (~3,000 individuals)
+func BenchmarkValidateRoyal92(b *testing.B) {
+	benchmarkValidateFile(b, "../testdata/gedcom-5.5/royal92.ged")
+}
+
+// BenchmarkValidatePres2020 benchmarks validating the US Presidents corpus
+// (~2,300 individuals)
+func BenchmarkValidatePres2020(b *testing.B) {
+	benchmarkValidateFile(b, "../testdata/gedcom-5.5/pres2020.ged")
+}
+
+func benchmarkValidateFile(b *testing.B, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		b.Skip("Test file not found:", err)
+	}
+	defer f.Close()
+	doc, err := decoder.Decode(f)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		v := New()
+		_ = v.Validate(doc)
+	}
+}
+
 // Helper to generate a valid document with N individuals
 func generateValidDocument(numIndividuals int) *gedcom.Document {
 	records := make([]*gedcom.Record, 0, numIndividuals)
@@ -6,46 +6,91 @@ import (
 	"github.com/cacack/gedcom-go/gedcom"
 )
 
-func (v *Validator) validateCircularRelationships(doc *gedcom.Document) {
-	for _, ind := range doc.Individuals() {
-		if ind == nil || ind.XRef == "" {
-			continue
-		}
-		if hasCircularAncestry(doc, ind, ind.XRef, make(map[string]bool), make(map[string]bool)) {
-			v.errors = append(v.errors, &ValidationError{
-				Code:    "CIRCULAR_REFERENCE",
-				Message: fmt.Sprintf("Circular family relationship detected for %s", ind.XRef),
-				XRef:    ind.XRef,
-			})
+// circularRelationshipRule reports every individual who is their own
+// ancestor. Individuals on a cycle of parent links form a strongly connected
+// component of the parent graph, so one traversal finds them all.
+func circularRelationshipRule() *rule {
+	r := &rule{}
+	r.finish = func(doc *gedcom.Document) {
+		individuals := doc.Individuals()
+		cyclic := findAncestryCycles(doc, individuals)
+		for _, ind := range individuals {
+			if ind != nil && cyclic[ind.XRef] {
+				r.add(&ValidationError{
+					Code:    "CIRCULAR_REFERENCE",
+					Message: fmt.Sprintf("Circular family relationship detected for %s", ind.XRef),
+					XRef:    ind.XRef,
+				})
+			}
 		}
 	}
+	return r
 }
 
-func hasCircularAncestry(doc *gedcom.Document, current *gedcom.Individual, target string, visiting, visited map[string]bool) bool {
-	if current == nil || current.XRef == "" {
-		return false
-	}
-	if visiting[current.XRef] {
-		return current.XRef == target
-	}
-	if visited[current.XRef] {
-		return false
+// findAncestryCycles returns the XRefs of individuals that are their own
+// ancestors, using Tarjan's strongly connected components algorithm over
+// child-to-parent links.
+func findAncestryCycles(doc *gedcom.Document, individuals []*gedcom.Individual) map[string]bool {
+	type node struct {
+		index, lowlink int
+		onStack        bool
 	}
+	nodes := make(map[string]*node)
 
-	visiting[current.XRef] = true
-	for _, parent := range current.Parents(doc) {
-		if parent == nil {
-			continue
+	cyclic := make(map[string]bool)
+	var stack []string
+	index := 0
+	var connect func(ind *gedcom.Individual)
+	connect = func(ind *gedcom.Individual) {
+		n := &node{index: index, lowlink: index, onStack: true}
+		nodes[ind.XRef] = n
+		index++
+		stack = append(stack, ind.XRef)
+
+		selfParent := false
+		for _, parent := range ind.Parents(doc) {
+			if parent == nil || parent.XRef == "" {
+				continue
+			}
+			if parent.XRef == ind.XRef {
+				selfParent = true
+			}
+			if p, seen := nodes[parent.XRef]; !seen {
+				connect(parent)
+				n.lowlink = min(n.lowlink, nodes[parent.XRef].lowlink)
+			} else if p.onStack {
+				n.lowlink = min(n.lowlink, p.index)
+			}
 		}
-		if parent.XRef == target {
-			return true
+
+		if n.lowlink != n.index {
+			return
+		}
+		// ind is the root of a component; pop it
+		var component []string
+		for {
+			xref := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			nodes[xref].onStack = false
+			component = append(component, xref)
+			if xref == ind.XRef {
+				break
+			}
 		}
-		if hasCircularAncestry(doc, parent, target, visiting, visited) {
-			return true
+		if len(component) > 1 || selfParent {
+			for _, xref := range component {
+				cyclic[xref] = true
+			}
 		}
 	}
-	visiting[current.XRef] = false
-	visited[current.XRef] = true
 
-	return false
+	for _, ind := range individuals {
+		if ind == nil || ind.XRef == "" {
+			continue
+		}
+		if _, seen := nodes[ind.XRef]; !seen {
+			connect(ind)
+		}
+	}
+	return cyclic
 }
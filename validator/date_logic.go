@@ -74,15 +74,24 @@ func NewDateLogicValidator(config *DateLogicConfig) *DateLogicValidator {
 
 // Validate runs all date logic validations on the document and returns any issues found.
 func (v *DateLogicValidator) Validate(doc *gedcom.Document) []Issue {
+	var issues []Issue
+	v.validateTo(doc, func(issue Issue) {
+		issues = append(issues, issue)
+	})
+	return issues
+}
+
+// validateTo passes the issues of each individual to report as soon as the
+// individual is checked.
+func (v *DateLogicValidator) validateTo(doc *gedcom.Document, report func(Issue)) {
 	if doc == nil {
-		return nil
+		return
 	}
-
-	var issues []Issue
 	for _, ind := range doc.Individuals() {
-		issues = append(issues, v.ValidateIndividual(doc, ind)...)
+		for _, issue := range v.ValidateIndividual(doc, ind) {
+			report(issue)
+		}
 	}
-	return issues
 }
 
 // ValidateIndividual runs all date logic validations on a single individual.
@@ -7,32 +7,31 @@ import (
 	"github.com/cacack/gedcom-go/gedcom"
 )
 
-func (v *Validator) validateDates(doc *gedcom.Document) {
-	for _, record := range doc.Records {
-		for _, tag := range record.Tags {
-			if tag.Tag != "DATE" {
-				continue
-			}
-			value := strings.TrimSpace(tag.Value)
-			if value == "" {
-				continue
-			}
-			parsed, err := gedcom.ParseDate(value)
-			if err != nil {
-				v.errors = append(v.errors, &ValidationError{
-					Code:    "INVALID_DATE",
-					Message: fmt.Sprintf("Invalid date %q", value),
-					Line:    tag.LineNumber,
-				})
-				continue
-			}
-			if err := parsed.Validate(); err != nil {
-				v.errors = append(v.errors, &ValidationError{
-					Code:    "INVALID_DATE",
-					Message: fmt.Sprintf("Invalid date %q: %v", value, err),
-					Line:    tag.LineNumber,
-				})
-			}
+// dateFormatRule checks that every DATE value parses and is a valid date.
+// Nonstandard forms enabled by opts pass.
+func dateFormatRule(opts gedcom.DateOptions) *rule {
+	r := &rule{tags: []string{"DATE"}}
+	r.tag = func(_ *gedcom.Record, tag *gedcom.Tag) {
+		value := strings.TrimSpace(tag.Value)
+		if value == "" {
+			return
+		}
+		parsed, err := gedcom.ParseDateWithOptions(value, opts)
+		if err != nil {
+			r.add(&ValidationError{
+				Code:    "INVALID_DATE",
+				Message: fmt.Sprintf("Invalid date %q", value),
+				Line:    tag.LineNumber,
+			})
+			return
+		}
+		if err := parsed.Validate(); err != nil {
+			r.add(&ValidationError{
+				Code:    "INVALID_DATE",
+				Message: fmt.Sprintf("Invalid date %q: %v", value, err),
+				Line:    tag.LineNumber,
+			})
 		}
 	}
+	return r
 }
@@ -8,7 +8,10 @@
 package validator
 
 import (
+	"encoding/csv"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -28,6 +31,11 @@ type DuplicateConfig struct {
 	// Default: true
 	NormalizeNames bool
 
+	// MatchSurnameVariants treats surname spelling variants (e.g., Smith/Smyth)
+	// as matching, using gedcom.ClusterSurnames over the document's surnames.
+	// Default: false
+	MatchSurnameVariants bool
+
 	// MinNameSimilarity is the minimum similarity threshold for given name comparison.
 	// Range: 0.0 to 1.0, where 1.0 is exact match.
 	// Default: 0.8
@@ -94,6 +102,36 @@ func (p DuplicatePair) ToIssue() Issue {
 	return issue
 }
 
+// WriteDuplicatesCSV writes candidate duplicate pairs as CSV with a header
+// row, one row per pair, so review queues can be built without running the
+// detector again. Pairs are written in the order given; FindDuplicates
+// returns only pairs at or above DuplicateConfig.MinConfidence. Scores have
+// four decimal places and reasons are joined with "; ".
+//
+// Columns: person_a, person_b, score, reasons.
+func WriteDuplicatesCSV(w io.Writer, pairs []DuplicatePair) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"person_a", "person_b", "score", "reasons"}); err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		row := []string{
+			pair.Individual1.XRef,
+			pair.Individual2.XRef,
+			strconv.FormatFloat(pair.Confidence, 'f', 4, 64),
+			strings.Join(pair.MatchReasons, "; "),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
 // getDisplayName returns a display name for an individual.
 func getDisplayName(ind *gedcom.Individual) string {
 	if ind == nil {
@@ -116,6 +154,10 @@ func getDisplayName(ind *gedcom.Individual) string {
 // DuplicateDetector detects potential duplicate individuals in a GEDCOM document.
 type DuplicateDetector struct {
 	config DuplicateConfig
+
+	// surnameVariants maps surnames to their variant group's canonical
+	// spelling; populated by FindDuplicates when MatchSurnameVariants is set.
+	surnameVariants map[string]string
 }
 
 // NewDuplicateDetector creates a new DuplicateDetector with the given configuration.
@@ -132,20 +174,33 @@ func NewDuplicateDetector(config *DuplicateConfig) *DuplicateDetector {
 // The algorithm groups individuals by normalized surname for efficiency, then compares
 // pairs within each surname group.
 func (d *DuplicateDetector) FindDuplicates(doc *gedcom.Document) []DuplicatePair {
+	var duplicates []DuplicatePair
+	d.findDuplicates(doc, func(pair DuplicatePair) {
+		duplicates = append(duplicates, pair)
+	})
+	return duplicates
+}
+
+// findDuplicates passes each candidate pair to found as soon as it is
+// scored, in the order FindDuplicates returns them.
+func (d *DuplicateDetector) findDuplicates(doc *gedcom.Document, found func(DuplicatePair)) {
 	if doc == nil {
-		return nil
+		return
 	}
 
 	individuals := doc.Individuals()
 	if len(individuals) < 2 {
-		return nil
+		return
+	}
+
+	d.surnameVariants = nil
+	if d.config.MatchSurnameVariants {
+		d.surnameVariants = d.buildSurnameVariants(individuals)
 	}
 
 	// Build surname groups for efficient comparison
 	surnameGroups := d.buildSurnameGroups(individuals)
 
-	var duplicates []DuplicatePair
-
 	// Compare pairs within each surname group
 	for _, group := range surnameGroups {
 		if len(group) < 2 {
@@ -156,31 +211,61 @@ func (d *DuplicateDetector) FindDuplicates(doc *gedcom.Document) []DuplicatePair
 		for i := 0; i < len(group); i++ {
 			for j := i + 1; j < len(group); j++ {
 				if pair, ok := d.comparePair(group[i], group[j]); ok {
-					duplicates = append(duplicates, pair)
+					found(pair)
 				}
 			}
 		}
 	}
-
-	return duplicates
 }
 
 // buildSurnameGroups groups individuals by their normalized surname.
-// Individuals without surnames are grouped under an empty string key.
-func (d *DuplicateDetector) buildSurnameGroups(individuals []*gedcom.Individual) map[string][]*gedcom.Individual {
-	groups := make(map[string][]*gedcom.Individual)
+// Individuals without surnames are grouped together. Groups are in order of
+// their first individual, so results do not depend on map iteration order.
+func (d *DuplicateDetector) buildSurnameGroups(individuals []*gedcom.Individual) [][]*gedcom.Individual {
+	var groups [][]*gedcom.Individual
+	index := make(map[string]int)
 
 	for _, ind := range individuals {
 		surname := d.extractSurname(ind)
 		if d.config.NormalizeNames {
 			surname = normalizeName(surname)
 		}
-		groups[surname] = append(groups[surname], ind)
+		if canonical, ok := d.surnameVariants[surname]; ok {
+			surname = canonical
+		}
+		i, ok := index[surname]
+		if !ok {
+			i = len(groups)
+			index[surname] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], ind)
 	}
 
 	return groups
 }
 
+// buildSurnameVariants clusters the individuals' surnames into spelling
+// variants and maps each surname to its group's canonical spelling.
+func (d *DuplicateDetector) buildSurnameVariants(individuals []*gedcom.Individual) map[string]string {
+	surnames := make([]string, 0, len(individuals))
+	for _, ind := range individuals {
+		surname := d.extractSurname(ind)
+		if d.config.NormalizeNames {
+			surname = normalizeName(surname)
+		}
+		surnames = append(surnames, surname)
+	}
+
+	variants := make(map[string]string)
+	for _, group := range gedcom.ClusterSurnames(surnames) {
+		for _, variant := range group.Variants {
+			variants[variant] = group.Canonical
+		}
+	}
+	return variants
+}
+
 // extractSurname extracts the surname from an individual's primary name.
 func (d *DuplicateDetector) extractSurname(ind *gedcom.Individual) string {
 	if ind == nil || len(ind.Names) == 0 {
@@ -253,15 +338,21 @@ func (d *DuplicateDetector) comparePair(ind1, ind2 *gedcom.Individual) (Duplicat
 		surname2 = normalizeName(surname2)
 	}
 
-	// Check surname match
+	// Check surname match, falling back to spelling variants
 	surnameMatch := compareSurnames(surname1, surname2, d.config.RequireExactSurname)
-	if !surnameMatch {
+	variantMatch := !surnameMatch && d.isSurnameVariant(surname1, surname2)
+	if !surnameMatch && !variantMatch {
 		return DuplicatePair{}, false
 	}
 
 	// Surname match contributes to confidence
-	confidence += 0.3
-	reasons = append(reasons, "exact surname match")
+	if variantMatch {
+		confidence += 0.25
+		reasons = append(reasons, fmt.Sprintf("surname variant match (%s/%s)", surname1, surname2))
+	} else {
+		confidence += 0.3
+		reasons = append(reasons, "exact surname match")
+	}
 
 	// Get and compare given names
 	given1 := extractGivenName(ind1)
@@ -362,6 +453,17 @@ func compareSurnames(s1, s2 string, exact bool) bool {
 	return s1 == s2
 }
 
+// isSurnameVariant reports whether two different surnames belong to the same
+// variant group found by FindDuplicates.
+func (d *DuplicateDetector) isSurnameVariant(s1, s2 string) bool {
+	if s1 == "" || s2 == "" {
+		return false
+	}
+	c1, ok1 := d.surnameVariants[s1]
+	c2, ok2 := d.surnameVariants[s2]
+	return ok1 && ok2 && c1 == c2
+}
+
 // compareGivenNames compares two given names and returns a similarity score.
 // Returns 0.0 if either name is empty.
 // Returns 1.0 for exact match, otherwise returns string similarity.
@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/cacack/gedcom-go/gedcom"
@@ -636,6 +638,49 @@ func TestFindDuplicates_DifferentSurnames(t *testing.T) {
 	}
 }
 
+func TestFindDuplicates_SurnameVariants(t *testing.T) {
+	birthDate, _ := gedcom.ParseDate("1 JAN 1900")
+	newInd := func(xref, full string) *gedcom.Individual {
+		return &gedcom.Individual{
+			XRef:   xref,
+			Names:  []*gedcom.PersonalName{{Full: full}},
+			Sex:    "M",
+			Events: []*gedcom.Event{{Type: gedcom.EventBirth, ParsedDate: birthDate}},
+		}
+	}
+	ind1 := newInd("@I1@", "John /Smith/")
+	ind2 := newInd("@I2@", "John /Smyth/")
+	ind3 := newInd("@I3@", "John /Snead/")
+
+	doc := &gedcom.Document{
+		Records: []*gedcom.Record{
+			{XRef: ind1.XRef, Type: gedcom.RecordTypeIndividual, Entity: ind1},
+			{XRef: ind2.XRef, Type: gedcom.RecordTypeIndividual, Entity: ind2},
+			{XRef: ind3.XRef, Type: gedcom.RecordTypeIndividual, Entity: ind3},
+		},
+	}
+
+	// Variants are not matched by default
+	if got := NewDuplicateDetector(nil).FindDuplicates(doc); len(got) != 0 {
+		t.Errorf("default config: got %d pairs, want 0", len(got))
+	}
+
+	config := DefaultDuplicateConfig()
+	config.MatchSurnameVariants = true
+	duplicates := NewDuplicateDetector(&config).FindDuplicates(doc)
+	if len(duplicates) != 1 {
+		t.Fatalf("got %d pairs, want 1", len(duplicates))
+	}
+	pair := duplicates[0]
+	xrefs := pair.Individual1.XRef + pair.Individual2.XRef
+	if xrefs != "@I1@@I2@" && xrefs != "@I2@@I1@" {
+		t.Errorf("pair = %s, want @I1@/@I2@", xrefs)
+	}
+	if !containsSubstring(pair.MatchReasons[0], "surname variant match") {
+		t.Errorf("MatchReasons = %v, want surname variant reason", pair.MatchReasons)
+	}
+}
+
 func TestFindDuplicates_BirthDateProximity(t *testing.T) {
 	birthDate1, _ := gedcom.ParseDate("1 JAN 1900")
 	birthDate2, _ := gedcom.ParseDate("1 JAN 1901")
@@ -1213,3 +1258,42 @@ func searchSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestWriteDuplicatesCSV(t *testing.T) {
+	pairs := []DuplicatePair{{
+		Individual1:  &gedcom.Individual{XRef: "@I1@"},
+		Individual2:  &gedcom.Individual{XRef: "@I7@"},
+		Confidence:   0.875,
+		MatchReasons: []string{"Exact name match", "Same birth year"},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteDuplicatesCSV(&buf, pairs); err != nil {
+		t.Fatalf("WriteDuplicatesCSV() error = %v", err)
+	}
+	want := "person_a,person_b,score,reasons\n" +
+		"@I1@,@I7@,0.8750,Exact name match; Same birth year\n"
+	if buf.String() != want {
+		t.Errorf("WriteDuplicatesCSV() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteDuplicatesCSV_FromDetector(t *testing.T) {
+	doc := &gedcom.Document{}
+	for _, xref := range []string{"@I1@", "@I2@", "@I3@"} {
+		ind := &gedcom.Individual{XRef: xref, Names: []*gedcom.PersonalName{{Full: "John /Doe/"}}, Sex: "M"}
+		doc.Records = append(doc.Records, &gedcom.Record{XRef: xref, Type: gedcom.RecordTypeIndividual, Entity: ind})
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDuplicatesCSV(&buf, NewDuplicateDetector(nil).FindDuplicates(doc)); err != nil {
+		t.Fatalf("WriteDuplicatesCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("WriteDuplicatesCSV() wrote %d lines, want header and 3 pairs:\n%s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[1], "@I1@,@I2@,") {
+		t.Errorf("first pair = %q, want @I1@,@I2@", lines[1])
+	}
+}
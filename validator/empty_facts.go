@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// emptyFacts returns an EMPTY_FACT issue for every individual and family
+// event that has neither a date nor a place, and the number of events
+// examined. Events whose value describes them (such as "1 EVEN Military
+// service") are not empty; the value "Y", which only asserts that the event
+// happened, is.
+func emptyFacts(doc *gedcom.Document) (issues []Issue, total int) {
+	for _, record := range doc.Records {
+		var events []*gedcom.Event
+		switch entity := record.LoadEntity().(type) {
+		case *gedcom.Individual:
+			events = entity.Events
+		case *gedcom.Family:
+			events = entity.Events
+		default:
+			continue
+		}
+		for _, event := range events {
+			if event == nil {
+				continue
+			}
+			total++
+			if !isEmptyFact(event) {
+				continue
+			}
+			issues = append(issues, NewIssue(SeverityInfo, CodeEmptyFact,
+				fmt.Sprintf("%s event has neither date nor place", event.Type),
+				record.XRef).
+				WithDetail("event_type", string(event.Type)))
+		}
+	}
+	return issues, total
+}
+
+// isEmptyFact reports whether event has no date, no place, and no
+// description other than "Y".
+func isEmptyFact(event *gedcom.Event) bool {
+	if event.Date != "" || event.ParsedDate != nil {
+		return false
+	}
+	if event.Place != "" || (event.PlaceDetail != nil && event.PlaceDetail.Name != "") {
+		return false
+	}
+	return event.Description == "" || event.Description == "Y"
+}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func makeEmptyFactsDocument() *gedcom.Document {
+	ind := &gedcom.Individual{
+		XRef: "@I1@",
+		Events: []*gedcom.Event{
+			{Type: gedcom.EventBirth, Date: "1850"},
+			{Type: gedcom.EventDeath, Description: "Y"},
+			{Type: gedcom.EventBurial, PlaceDetail: &gedcom.PlaceDetail{Name: "Salem"}},
+			{Type: gedcom.EventType("EVEN"), Description: "Military service"},
+		},
+	}
+	fam := &gedcom.Family{
+		XRef:   "@F1@",
+		Events: []*gedcom.Event{{Type: gedcom.EventMarriage}},
+	}
+	return makeDocument([]*gedcom.Individual{ind}, []*gedcom.Family{fam})
+}
+
+func TestFindEmptyFacts(t *testing.T) {
+	issues := New().FindEmptyFacts(makeEmptyFactsDocument())
+	if len(issues) != 2 {
+		t.Fatalf("FindEmptyFacts() = %v, want 2 issues", issues)
+	}
+	for i, want := range []struct{ xref, eventType string }{{"@I1@", "DEAT"}, {"@F1@", "MARR"}} {
+		issue := issues[i]
+		if issue.Code != CodeEmptyFact || issue.Severity != SeverityInfo ||
+			issue.RecordXRef != want.xref || issue.Details["event_type"] != want.eventType {
+			t.Errorf("issues[%d] = %v, want EMPTY_FACT for %s %s", i, issue, want.xref, want.eventType)
+		}
+	}
+	if New().FindEmptyFacts(nil) != nil {
+		t.Error("FindEmptyFacts(nil) should be nil")
+	}
+}
+
+func TestValidateAll_EmptyFactsStrictness(t *testing.T) {
+	doc := makeEmptyFactsDocument()
+	count := func(issues []Issue) int {
+		return len(FilterByCode(issues, CodeEmptyFact))
+	}
+	if n := count(New().ValidateAll(doc)); n != 0 {
+		t.Errorf("ValidateAll() at normal strictness reported %d EMPTY_FACT issues, want 0", n)
+	}
+	strict := NewWithConfig(&ValidatorConfig{Strictness: StrictnessStrict})
+	if n := count(strict.ValidateAll(doc)); n != 2 {
+		t.Errorf("ValidateAll() at strict strictness reported %d EMPTY_FACT issues, want 2", n)
+	}
+}
+
+func TestQualityReport_EmptyFactRate(t *testing.T) {
+	report := NewQualityAnalyzer().Analyze(makeEmptyFactsDocument())
+	if report.TotalEvents != 5 || report.EmptyEvents != 2 || report.EmptyFactRate != 0.4 {
+		t.Errorf("TotalEvents, EmptyEvents, EmptyFactRate = %d, %d, %f, want 5, 2, 0.4",
+			report.TotalEvents, report.EmptyEvents, report.EmptyFactRate)
+	}
+	if len(report.IssuesByCode(CodeEmptyFact)) != 2 {
+		t.Errorf("IssuesByCode(EMPTY_FACT) = %v, want 2 issues", report.IssuesByCode(CodeEmptyFact))
+	}
+	if !strings.Contains(report.String(), "- Empty facts: 40% (2/5 events)") {
+		t.Errorf("String() missing empty fact rate:\n%s", report.String())
+	}
+}
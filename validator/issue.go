@@ -7,41 +7,22 @@
 package validator
 
 import (
-	"fmt"
 	"strings"
+
+	"github.com/cacack/gedcom-go/gedcom"
 )
 
-// Severity represents the severity level of a validation issue.
-type Severity int
+// Severity represents the severity level of a validation issue. It is
+// shared with the decoder; see gedcom.Severity.
+type Severity = gedcom.Severity
 
+// Severity levels, as in the gedcom package.
 const (
-	// SeverityError indicates a data integrity issue that must be fixed.
-	// Examples: death before birth, orphaned cross-references.
-	SeverityError Severity = iota
-
-	// SeverityWarning indicates a potential problem that should be reviewed.
-	// Examples: unusual age at marriage, missing recommended fields.
-	SeverityWarning
-
-	// SeverityInfo indicates an informational data quality suggestion.
-	// Examples: missing sources, potential duplicates.
-	SeverityInfo
+	SeverityError   = gedcom.SeverityError
+	SeverityWarning = gedcom.SeverityWarning
+	SeverityInfo    = gedcom.SeverityInfo
 )
 
-// String returns the human-readable name of the severity level.
-func (s Severity) String() string {
-	switch s {
-	case SeverityError:
-		return "ERROR"
-	case SeverityWarning:
-		return "WARNING"
-	case SeverityInfo:
-		return "INFO"
-	default:
-		return fmt.Sprintf("UNKNOWN(%d)", s)
-	}
-}
-
 // Error codes for date logic validation.
 const (
 	// CodeDeathBeforeBirth indicates a person's death date is before their birth date.
@@ -101,6 +82,40 @@ const (
 
 	// CodeNoSources indicates a record has no source citations.
 	CodeNoSources = "NO_SOURCES"
+
+	// CodeEmptyFact indicates an event has neither a date nor a place.
+	CodeEmptyFact = "EMPTY_FACT"
+)
+
+// Error codes for text encoding validation.
+const (
+	// CodeReplacementCharacter indicates text contains U+FFFD replacement characters.
+	CodeReplacementCharacter = "REPLACEMENT_CHARACTER"
+
+	// CodeMojibake indicates text contains signatures of UTF-8 decoded as Latin-1 (e.g., "Ã©").
+	CodeMojibake = "MOJIBAKE"
+
+	// CodeHTMLEntity indicates text contains raw HTML entities (e.g., "&amp;").
+	CodeHTMLEntity = "HTML_ENTITY"
+
+	// CodeInvisibleCharacters indicates text contains control characters,
+	// zero-width spaces, or byte order marks (see gedcom.SanitizeText).
+	CodeInvisibleCharacters = "INVISIBLE_CHARACTERS"
+)
+
+// Error codes for source citation validation.
+const (
+	// CodeUnsourcedVitalEvent indicates a vital event (birth, death, marriage) has no source citation.
+	CodeUnsourcedVitalEvent = "UNSOURCED_VITAL_EVENT"
+
+	// CodeLowSourceCoverage indicates the fraction of sourced vital events is below the configured threshold.
+	CodeLowSourceCoverage = "LOW_SOURCE_COVERAGE"
+)
+
+// Error codes for version-specific event rules.
+const (
+	// CodeCauseOnNonDeathEvent indicates a CAUS on an event other than death in a GEDCOM 5.5.x file.
+	CodeCauseOnNonDeathEvent = "CAUSE_ON_NON_DEATH_EVENT"
 )
 
 // Issue represents a validation finding with severity, context, and actionable information.
@@ -199,6 +214,22 @@ func (i Issue) WithDetail(key, value string) Issue {
 	return i
 }
 
+// IssueSink receives issues as validation finds them, so callers can log
+// them or count them in metrics without waiting for the full result.
+type IssueSink interface {
+	Report(issue Issue)
+}
+
+// IssueSinkFunc adapts a function to an IssueSink.
+type IssueSinkFunc func(issue Issue)
+
+// Report calls f(issue).
+//
+//nolint:gocritic // Value parameter matches the Issue API
+func (f IssueSinkFunc) Report(issue Issue) {
+	f(issue)
+}
+
 // FilterBySeverity returns a slice containing only issues with the specified severity.
 func FilterBySeverity(issues []Issue, severity Severity) []Issue {
 	var result []Issue
@@ -28,11 +28,18 @@ type QualityReport struct {
 	IndividualsWithSources   int `json:"individuals_with_sources"`
 	IndividualsWithPlaces    int `json:"individuals_with_places"`
 
+	// Event counts for individual and family events
+	TotalEvents int `json:"total_events"`
+	EmptyEvents int `json:"empty_events"`
+
 	// Percentages (calculated) - 0.0 to 1.0
 	BirthDateCoverage float64 `json:"birth_date_coverage"`
 	DeathDateCoverage float64 `json:"death_date_coverage"`
 	SourceCoverage    float64 `json:"source_coverage"`
 
+	// EmptyFactRate is the fraction of events with neither date nor place
+	EmptyFactRate float64 `json:"empty_fact_rate"`
+
 	// Issues by severity
 	Errors   []Issue `json:"errors"`
 	Warnings []Issue `json:"warnings"`
@@ -65,6 +72,8 @@ func (r *QualityReport) String() string {
 		r.BirthDateCoverage*100, r.IndividualsWithBirthDate, r.TotalIndividuals)
 	fmt.Fprintf(&sb, "- Sources: %.0f%% (%d/%d)\n",
 		r.SourceCoverage*100, r.IndividualsWithSources, r.TotalIndividuals)
+	fmt.Fprintf(&sb, "- Empty facts: %.0f%% (%d/%d events)\n",
+		r.EmptyFactRate*100, r.EmptyEvents, r.TotalEvents)
 
 	fmt.Fprintf(&sb, "\nIssues Found: %d total\n", r.TotalIssues)
 	fmt.Fprintf(&sb, "- Errors: %d\n", r.ErrorCount)
@@ -85,7 +94,10 @@ func (r *QualityReport) String() string {
 			counts = append(counts, codeCount{code, count})
 		}
 		sort.Slice(counts, func(i, j int) bool {
-			return counts[i].count > counts[j].count
+			if counts[i].count != counts[j].count {
+				return counts[i].count > counts[j].count
+			}
+			return counts[i].code < counts[j].code
 		})
 		// Show top 5
 		for i, cc := range counts {
@@ -215,6 +227,7 @@ func (a *QualityAnalyzer) Analyze(doc *gedcom.Document) *QualityReport {
 
 	// Calculate completeness metrics and generate completeness issues
 	a.calculateCompleteness(individuals, report)
+	a.calculateEmptyFacts(doc, report)
 
 	// Aggregate issues by severity
 	a.aggregateIssues(report)
@@ -303,6 +316,18 @@ func (a *QualityAnalyzer) calculateCompleteness(individuals []*gedcom.Individual
 	}
 }
 
+// calculateEmptyFacts counts events with neither date nor place and adds
+// an EMPTY_FACT issue for each.
+func (a *QualityAnalyzer) calculateEmptyFacts(doc *gedcom.Document, report *QualityReport) {
+	issues, total := emptyFacts(doc)
+	report.CompletenessIssues = append(report.CompletenessIssues, issues...)
+	report.TotalEvents = total
+	report.EmptyEvents = len(issues)
+	if total > 0 {
+		report.EmptyFactRate = float64(len(issues)) / float64(total)
+	}
+}
+
 // hasPlace checks if an individual has any event with a place.
 func (a *QualityAnalyzer) hasPlace(ind *gedcom.Individual) bool {
 	for _, event := range ind.Events {
@@ -322,8 +347,9 @@ func (a *QualityAnalyzer) aggregateIssues(report *QualityReport) {
 	allIssues = append(allIssues, report.DuplicateIssues...)
 	allIssues = append(allIssues, report.CompletenessIssues...)
 
-	// Sort by severity (Errors first, then Warnings, then Info)
-	sort.Slice(allIssues, func(i, j int) bool {
+	// Sort by severity (Errors first, then Warnings, then Info), keeping
+	// the order issues were found in within each severity
+	sort.SliceStable(allIssues, func(i, j int) bool {
 		return allIssues[i].Severity < allIssues[j].Severity
 	})
 
@@ -50,23 +50,33 @@ func NewReferenceValidator() *ReferenceValidator {
 // any orphaned references found. Each issue includes the specific reference type
 // and detailed context about where the broken reference was found.
 func (v *ReferenceValidator) Validate(doc *gedcom.Document) []Issue {
+	var issues []Issue
+	v.validateTo(doc, func(issue Issue) {
+		issues = append(issues, issue)
+	})
+	return issues
+}
+
+// validateTo passes the issues of each record to report as soon as the
+// record is checked.
+func (v *ReferenceValidator) validateTo(doc *gedcom.Document, report func(Issue)) {
 	if doc == nil {
-		return nil
+		return
 	}
 
-	var issues []Issue
-
 	// Check individual references
 	for _, ind := range doc.Individuals() {
-		issues = append(issues, v.checkIndividualReferences(doc, ind)...)
+		for _, issue := range v.checkIndividualReferences(doc, ind) {
+			report(issue)
+		}
 	}
 
 	// Check family references
 	for _, fam := range doc.Families() {
-		issues = append(issues, v.checkFamilyReferences(doc, fam)...)
+		for _, issue := range v.checkFamilyReferences(doc, fam) {
+			report(issue)
+		}
 	}
-
-	return issues
 }
 
 // checkIndividualReferences validates all cross-references within an individual record.
@@ -0,0 +1,123 @@
+// sources.go provides source citation validation for users enforcing research standards.
+//
+// The SourceCoverageValidator detects:
+//   - Vital events (birth, death, marriage) that carry no source citation
+//   - Overall sourcing coverage of vital events below a configurable threshold
+
+package validator
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+// SourceCoverageConfig contains configurable settings for source citation validation.
+type SourceCoverageConfig struct {
+	// MinCoverage is the minimum acceptable fraction (0.0 to 1.0) of vital
+	// events that carry at least one source citation. Coverage below this
+	// generates a warning. Default: 0.8.
+	MinCoverage float64
+
+	// VitalEvents lists the event types that require a source citation.
+	// Default: BIRT, DEAT, MARR.
+	VitalEvents []gedcom.EventType
+}
+
+// DefaultSourceCoverageConfig returns a SourceCoverageConfig with reasonable defaults.
+func DefaultSourceCoverageConfig() *SourceCoverageConfig {
+	return &SourceCoverageConfig{
+		MinCoverage: 0.8,
+		VitalEvents: []gedcom.EventType{gedcom.EventBirth, gedcom.EventDeath, gedcom.EventMarriage},
+	}
+}
+
+// SourceCoverageValidator validates source citations on vital events.
+type SourceCoverageValidator struct {
+	config *SourceCoverageConfig
+}
+
+// NewSourceCoverageValidator creates a new SourceCoverageValidator with the given configuration.
+// If config is nil, default values are used.
+func NewSourceCoverageValidator(config *SourceCoverageConfig) *SourceCoverageValidator {
+	if config == nil {
+		config = DefaultSourceCoverageConfig()
+	}
+	// Apply defaults for any zero values
+	if config.MinCoverage == 0 {
+		config.MinCoverage = 0.8
+	}
+	if len(config.VitalEvents) == 0 {
+		config.VitalEvents = DefaultSourceCoverageConfig().VitalEvents
+	}
+	return &SourceCoverageValidator{config: config}
+}
+
+// Validate checks vital events for source citations and returns an issue for
+// each unsourced event, plus a coverage issue if overall coverage is below
+// the configured threshold.
+func (v *SourceCoverageValidator) Validate(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+
+	var issues []Issue
+	total, sourced := 0, 0
+
+	check := func(xref string, events []*gedcom.Event) {
+		for _, event := range events {
+			if event == nil || !v.isVital(event.Type) {
+				continue
+			}
+			total++
+			if len(event.SourceCitations) > 0 {
+				sourced++
+				continue
+			}
+			issue := NewIssue(
+				SeverityWarning,
+				CodeUnsourcedVitalEvent,
+				fmt.Sprintf("%s event has no source citation", event.Type),
+				xref,
+			).WithDetail("event_type", string(event.Type))
+			if event.Date != "" {
+				issue = issue.WithDetail("date", event.Date)
+			}
+			issues = append(issues, issue)
+		}
+	}
+
+	for _, ind := range doc.Individuals() {
+		check(ind.XRef, ind.Events)
+	}
+	for _, fam := range doc.Families() {
+		check(fam.XRef, fam.Events)
+	}
+
+	if total > 0 {
+		coverage := float64(sourced) / float64(total)
+		if coverage < v.config.MinCoverage {
+			issue := NewIssue(
+				SeverityWarning,
+				CodeLowSourceCoverage,
+				fmt.Sprintf("%.0f%% of vital events are sourced (%d/%d), below the %.0f%% threshold",
+					coverage*100, sourced, total, v.config.MinCoverage*100),
+				"",
+			).WithDetail("coverage", fmt.Sprintf("%.2f", coverage)).
+				WithDetail("threshold", fmt.Sprintf("%.2f", v.config.MinCoverage))
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+// isVital reports whether the event type is configured as a vital event.
+func (v *SourceCoverageValidator) isVital(t gedcom.EventType) bool {
+	for _, vital := range v.config.VitalEvents {
+		if t == vital {
+			return true
+		}
+	}
+	return false
+}
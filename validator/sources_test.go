@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func sourcedEvent(t gedcom.EventType) *gedcom.Event {
+	return &gedcom.Event{
+		Type:            t,
+		SourceCitations: []*gedcom.SourceCitation{{SourceXRef: "@S1@"}},
+	}
+}
+
+func TestSourceCoverageValidator(t *testing.T) {
+	i1 := &gedcom.Individual{XRef: "@I1@", Events: []*gedcom.Event{
+		sourcedEvent(gedcom.EventBirth),
+		{Type: gedcom.EventDeath, Date: "1950"},
+		{Type: gedcom.EventResidence}, // not vital
+	}}
+	i2 := &gedcom.Individual{XRef: "@I2@", Events: []*gedcom.Event{
+		sourcedEvent(gedcom.EventBirth),
+	}}
+	f1 := &gedcom.Family{XRef: "@F1@", Events: []*gedcom.Event{
+		{Type: gedcom.EventMarriage},
+	}}
+	doc := makeDocument([]*gedcom.Individual{i1, i2}, []*gedcom.Family{f1})
+
+	issues := NewSourceCoverageValidator(nil).Validate(doc)
+
+	unsourced := FilterByCode(issues, CodeUnsourcedVitalEvent)
+	if len(unsourced) != 2 {
+		t.Fatalf("unsourced issues = %d, want 2: %v", len(unsourced), unsourced)
+	}
+	if unsourced[0].RecordXRef != "@I1@" || unsourced[0].Details["event_type"] != "DEAT" ||
+		unsourced[0].Details["date"] != "1950" {
+		t.Errorf("first unsourced issue = %+v", unsourced[0])
+	}
+	if unsourced[1].RecordXRef != "@F1@" || unsourced[1].Details["event_type"] != "MARR" {
+		t.Errorf("second unsourced issue = %+v", unsourced[1])
+	}
+
+	// 2 of 4 vital events sourced: 50% < 80%
+	low := FilterByCode(issues, CodeLowSourceCoverage)
+	if len(low) != 1 {
+		t.Fatalf("coverage issues = %d, want 1", len(low))
+	}
+	if low[0].Details["coverage"] != "0.50" || low[0].Severity != SeverityWarning {
+		t.Errorf("coverage issue = %+v", low[0])
+	}
+
+	// Lower threshold suppresses the coverage issue
+	issues = NewSourceCoverageValidator(&SourceCoverageConfig{MinCoverage: 0.5}).Validate(doc)
+	if len(FilterByCode(issues, CodeLowSourceCoverage)) != 0 {
+		t.Error("coverage at threshold should not be reported")
+	}
+
+	// Custom vital events
+	issues = NewSourceCoverageValidator(&SourceCoverageConfig{
+		VitalEvents: []gedcom.EventType{gedcom.EventBirth},
+	}).Validate(doc)
+	if len(issues) != 0 {
+		t.Errorf("birth-only rule issues = %v, want none", issues)
+	}
+}
+
+func TestSourceCoverageValidatorEmpty(t *testing.T) {
+	v := NewSourceCoverageValidator(nil)
+	if issues := v.Validate(nil); issues != nil {
+		t.Errorf("Validate(nil) = %v, want nil", issues)
+	}
+	if issues := v.Validate(makeDocument(nil, nil)); len(issues) != 0 {
+		t.Errorf("Validate(empty) = %v, want none", issues)
+	}
+}
+
+func TestValidateAllSourceCoverage(t *testing.T) {
+	ind := &gedcom.Individual{XRef: "@I1@", Events: []*gedcom.Event{{Type: gedcom.EventBirth}}}
+	doc := makeDocument([]*gedcom.Individual{ind}, nil)
+
+	if issues := FilterByCode(New().ValidateAll(doc), CodeUnsourcedVitalEvent); len(issues) != 0 {
+		t.Errorf("ValidateAll without SourceCoverage config reported %d issues", len(issues))
+	}
+
+	v := NewWithConfig(&ValidatorConfig{
+		SourceCoverage: DefaultSourceCoverageConfig(),
+		Strictness:     StrictnessNormal,
+	})
+	issues := v.ValidateAll(doc)
+	if len(FilterByCode(issues, CodeUnsourcedVitalEvent)) != 1 || len(FilterByCode(issues, CodeLowSourceCoverage)) != 1 {
+		t.Errorf("ValidateAll issues = %v", issues)
+	}
+
+	if issues := New().ValidateSourceCoverage(doc); len(issues) != 2 {
+		t.Errorf("ValidateSourceCoverage() = %d issues, want 2", len(issues))
+	}
+	if issues := New().ValidateSourceCoverage(nil); issues != nil {
+		t.Errorf("ValidateSourceCoverage(nil) = %v", issues)
+	}
+}
@@ -0,0 +1,126 @@
+// text_encoding.go provides detection of upstream encoding damage in note text.
+//
+// The TextEncodingValidator detects:
+//   - Unicode replacement characters (U+FFFD) left by a lossy decode
+//   - Mojibake signatures such as "Ã©" or "â€™" (UTF-8 decoded as Latin-1/Windows-1252)
+//   - Raw HTML entities such as "&amp;" or "&#39;" copied from web pages
+//   - Control characters, zero-width spaces, and stray byte order marks
+
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+var (
+	mojibakePattern   = regexp.MustCompile(`Ã[\x{0080}-\x{00BF}]|Â[\x{00A0}-\x{00BF}]|â€`)
+	htmlEntityPattern = regexp.MustCompile(`&(?:[a-zA-Z][a-zA-Z0-9]{1,7}|#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6});`)
+)
+
+// TextEncodingValidator checks note text for signs of encoding damage.
+type TextEncodingValidator struct{}
+
+// NewTextEncodingValidator creates a new TextEncodingValidator.
+func NewTextEncodingValidator() *TextEncodingValidator {
+	return &TextEncodingValidator{}
+}
+
+// Validate checks note records and inline notes on individuals, families,
+// events, and sources, returning one issue per kind of damage per note.
+func (v *TextEncodingValidator) Validate(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+
+	var issues []Issue
+
+	for _, note := range doc.Notes() {
+		issues = append(issues, v.checkText(note.XRef, "Text", note.FullText())...)
+	}
+
+	for _, ind := range doc.Individuals() {
+		issues = append(issues, v.checkNotes(ind.XRef, "Notes", ind.Notes)...)
+		for i, event := range ind.Events {
+			issues = append(issues, v.checkNotes(ind.XRef, fmt.Sprintf("Events[%d].Notes", i), event.Notes)...)
+		}
+	}
+
+	for _, fam := range doc.Families() {
+		issues = append(issues, v.checkNotes(fam.XRef, "Notes", fam.Notes)...)
+		for i, event := range fam.Events {
+			issues = append(issues, v.checkNotes(fam.XRef, fmt.Sprintf("Events[%d].Notes", i), event.Notes)...)
+		}
+	}
+
+	for _, src := range doc.Sources() {
+		issues = append(issues, v.checkNotes(src.XRef, "Notes", src.Notes)...)
+	}
+
+	return issues
+}
+
+// checkNotes checks inline note values, skipping references to note records.
+func (v *TextEncodingValidator) checkNotes(xref, field string, notes []string) []Issue {
+	var issues []Issue
+	for i, text := range notes {
+		if strings.HasPrefix(text, "@") && strings.HasSuffix(text, "@") {
+			continue
+		}
+		issues = append(issues, v.checkText(xref, fmt.Sprintf("%s[%d]", field, i), text)...)
+	}
+	return issues
+}
+
+// checkText returns an issue for each kind of encoding damage found in text.
+func (v *TextEncodingValidator) checkText(xref, field, text string) []Issue {
+	var issues []Issue
+
+	if strings.ContainsRune(text, '\uFFFD') {
+		issues = append(issues, NewIssue(
+			SeverityWarning,
+			CodeReplacementCharacter,
+			"note contains U+FFFD replacement characters; the text was decoded with the wrong "+
+				"character set upstream. Re-export from the original program as UTF-8",
+			xref,
+		).WithDetail("field", field))
+	}
+
+	if sample := mojibakePattern.FindString(text); sample != "" {
+		issues = append(issues, NewIssue(
+			SeverityWarning,
+			CodeMojibake,
+			fmt.Sprintf("note contains mojibake %q, indicating UTF-8 text decoded as Latin-1 or "+
+				"Windows-1252. Re-export as UTF-8 or re-decode the affected text", sample),
+			xref,
+		).WithDetail("field", field).
+			WithDetail("sample", sample))
+	}
+
+	if sample := htmlEntityPattern.FindString(text); sample != "" {
+		issues = append(issues, NewIssue(
+			SeverityWarning,
+			CodeHTMLEntity,
+			fmt.Sprintf("note contains raw HTML entity %q, likely pasted from a web page. "+
+				"Replace entities with the characters they represent", sample),
+			xref,
+		).WithDetail("field", field).
+			WithDetail("sample", sample))
+	}
+
+	if _, removed := gedcom.SanitizeText(text); removed > 0 {
+		issues = append(issues, NewIssue(
+			SeverityInfo,
+			CodeInvisibleCharacters,
+			fmt.Sprintf("note contains %d control or invisible characters, such as zero-width spaces, "+
+				"that break CSV and JSON output. Decode with DecodeOptions.SanitizeText to remove them", removed),
+			xref,
+		).WithDetail("field", field).
+			WithDetail("count", fmt.Sprint(removed)))
+	}
+
+	return issues
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/cacack/gedcom-go/gedcom"
+)
+
+func TestTextEncodingValidator(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantCode string
+		sample   string
+	}{
+		{"replacement character", "Born in M�nchen", CodeReplacementCharacter, ""},
+		{"mojibake e-acute", "RenÃ© was baptized", CodeMojibake, "Ã©"},
+		{"mojibake apostrophe", "Johnâ€™s farm", CodeMojibake, "â€"},
+		{"named HTML entity", "Smith &amp; Sons", CodeHTMLEntity, "&amp;"},
+		{"numeric HTML entity", "O&#39;Brien", CodeHTMLEntity, "&#39;"},
+		{"hex HTML entity", "caf&#xE9;", CodeHTMLEntity, "&#xE9;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ind := &gedcom.Individual{XRef: "@I1@", Notes: []string{tt.text}}
+			issues := NewTextEncodingValidator().Validate(makeDocument([]*gedcom.Individual{ind}, nil))
+			if len(issues) != 1 {
+				t.Fatalf("issues = %v, want 1", issues)
+			}
+			issue := issues[0]
+			if issue.Code != tt.wantCode || issue.RecordXRef != "@I1@" || issue.Severity != SeverityWarning {
+				t.Errorf("issue = %+v", issue)
+			}
+			if issue.Details["field"] != "Notes[0]" {
+				t.Errorf("field = %q, want Notes[0]", issue.Details["field"])
+			}
+			if tt.sample != "" && issue.Details["sample"] != tt.sample {
+				t.Errorf("sample = %q, want %q", issue.Details["sample"], tt.sample)
+			}
+		})
+	}
+}
+
+func TestTextEncodingValidatorInvisibleCharacters(t *testing.T) {
+	ind := &gedcom.Individual{XRef: "@I1@", Notes: []string{"Born\u200B in\x07 Boston\uFEFF"}}
+	issues := NewTextEncodingValidator().Validate(makeDocument([]*gedcom.Individual{ind}, nil))
+	if len(issues) != 1 {
+		t.Fatalf("issues = %v, want 1", issues)
+	}
+	issue := issues[0]
+	if issue.Code != CodeInvisibleCharacters || issue.Severity != SeverityInfo || issue.Details["count"] != "3" {
+		t.Errorf("issue = %+v", issue)
+	}
+}
+
+func TestTextEncodingValidatorCleanText(t *testing.T) {
+	ind := &gedcom.Individual{XRef: "@I1@", Notes: []string{
+		"René moved to São Paulo & married",
+		"@N1@",
+		"Tom & Jerry; AT&T",
+		"Line one\nline\ttwo",
+	}}
+	issues := NewTextEncodingValidator().Validate(makeDocument([]*gedcom.Individual{ind}, nil))
+	if len(issues) != 0 {
+		t.Errorf("issues = %v, want none", issues)
+	}
+	if issues := NewTextEncodingValidator().Validate(nil); issues != nil {
+		t.Errorf("Validate(nil) = %v", issues)
+	}
+}
+
+func TestTextEncodingValidatorLocations(t *testing.T) {
+	ind := &gedcom.Individual{XRef: "@I1@", Events: []*gedcom.Event{
+		{Type: gedcom.EventBirth, Notes: []string{"ok", "Smith &amp; Co"}},
+	}}
+	fam := &gedcom.Family{XRef: "@F1@", Notes: []string{"M�ller"}}
+	doc := makeDocument([]*gedcom.Individual{ind}, []*gedcom.Family{fam})
+
+	note := &gedcom.Note{XRef: "@N1@", Text: "First", Continuation: []string{"Ã¼ber"}}
+	src := &gedcom.Source{XRef: "@S1@", Notes: []string{"&nbsp;"}}
+	for _, rec := range []*gedcom.Record{
+		{XRef: note.XRef, Type: gedcom.RecordTypeNote, Entity: note},
+		{XRef: src.XRef, Type: gedcom.RecordTypeSource, Entity: src},
+	} {
+		doc.Records = append(doc.Records, rec)
+		doc.XRefMap[rec.XRef] = rec
+	}
+
+	issues := New().ValidateTextEncoding(doc)
+	want := map[string]string{
+		"@N1@": CodeMojibake,
+		"@I1@": CodeHTMLEntity,
+		"@F1@": CodeReplacementCharacter,
+		"@S1@": CodeHTMLEntity,
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("issues = %v, want %d", issues, len(want))
+	}
+	for _, issue := range issues {
+		if want[issue.RecordXRef] != issue.Code {
+			t.Errorf("%s: code = %s, want %s", issue.RecordXRef, issue.Code, want[issue.RecordXRef])
+		}
+	}
+	if issues[1].Details["field"] != "Events[0].Notes[1]" {
+		t.Errorf("event note field = %q", issues[1].Details["field"])
+	}
+
+	if got := FilterByCode(New().ValidateAll(doc), CodeHTMLEntity); len(got) != 2 {
+		t.Errorf("ValidateAll HTML entity issues = %d, want 2", len(got))
+	}
+}
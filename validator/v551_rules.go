@@ -1,12 +1,8 @@
 package validator
 
-import "github.com/cacack/gedcom-go/gedcom"
-
-func validateV551Rules(doc *gedcom.Document) []error {
-	deprecated := map[string]string{
-		"UID":  "introduced in GEDCOM 7.0",
-		"CREA": "introduced in GEDCOM 7.0",
-		"MIME": "introduced in GEDCOM 7.0",
-	}
-	return validateDeprecatedTags(doc, gedcom.Version551, deprecated)
+// v551DeprecatedTags lists the tags that are not valid in GEDCOM 5.5.1.
+var v551DeprecatedTags = map[string]string{
+	"UID":  "introduced in GEDCOM 7.0",
+	"CREA": "introduced in GEDCOM 7.0",
+	"MIME": "introduced in GEDCOM 7.0",
 }
@@ -8,6 +8,7 @@
 //
 //   - Validate() - Original API returning []error for backward compatibility
 //   - ValidateAll() - Enhanced API returning []Issue with severity levels
+//   - ValidateAllTo() - ValidateAll passing each issue to an IssueSink
 //
 // # Basic Usage
 //
@@ -40,6 +41,8 @@
 //	dateIssues := v.ValidateDateLogic(doc)      // Check date logic
 //	refIssues := v.FindOrphanedReferences(doc)  // Find broken references
 //	duplicates := v.FindPotentialDuplicates(doc) // Find potential duplicates
+//	sourceIssues := v.ValidateSourceCoverage(doc) // Find unsourced vital events
+//	textIssues := v.ValidateTextEncoding(doc)     // Find encoding damage in notes
 //
 // # Quality Reports
 //
@@ -111,9 +114,24 @@ type ValidatorConfig struct {
 	// If nil, default values are used.
 	Duplicates *DuplicateConfig
 
+	// SourceCoverage enables source citation validation in ValidateAll.
+	// If nil, the rule is not run by ValidateAll; use ValidateSourceCoverage
+	// to run it with default thresholds.
+	SourceCoverage *SourceCoverageConfig
+
 	// Strictness controls which severity levels are included in results.
 	// Default: StrictnessNormal (errors and warnings).
 	Strictness Strictness
+
+	// LenientDates makes Validate accept the nonstandard date forms that
+	// gedcom.ParseDateLenient understands, such as "Jan 1 1900",
+	// "1900-01-01", and "circa 1850", instead of reporting INVALID_DATE.
+	LenientDates bool
+
+	// DatePeriods makes Validate accept quarter and season phrases such as
+	// "Q1 1880", "Mar Q 1880", and "Spring 1865" (see
+	// gedcom.DateOptions.Periods) instead of reporting INVALID_DATE.
+	DatePeriods bool
 }
 
 // ValidatorInterface defines the minimal validation API.
@@ -129,6 +147,8 @@ type Validator struct {
 	references *ReferenceValidator
 	duplicates *DuplicateDetector
 	quality    *QualityAnalyzer
+	sources    *SourceCoverageValidator
+	text       *TextEncodingValidator
 }
 
 // New creates a new Validator with default configuration.
@@ -182,6 +202,26 @@ func (v *Validator) getDuplicateDetector() *DuplicateDetector {
 	return v.duplicates
 }
 
+// getSourceCoverageValidator returns the source coverage validator, creating it lazily if needed.
+func (v *Validator) getSourceCoverageValidator() *SourceCoverageValidator {
+	if v.sources == nil {
+		var config *SourceCoverageConfig
+		if v.config != nil {
+			config = v.config.SourceCoverage
+		}
+		v.sources = NewSourceCoverageValidator(config)
+	}
+	return v.sources
+}
+
+// getTextEncodingValidator returns the text encoding validator, creating it lazily if needed.
+func (v *Validator) getTextEncodingValidator() *TextEncodingValidator {
+	if v.text == nil {
+		v.text = NewTextEncodingValidator()
+	}
+	return v.text
+}
+
 // getQualityAnalyzer returns the quality analyzer, creating it lazily if needed.
 func (v *Validator) getQualityAnalyzer() *QualityAnalyzer {
 	if v.quality == nil {
@@ -200,131 +240,151 @@ func (v *Validator) getQualityAnalyzer() *QualityAnalyzer {
 }
 
 // Validate validates a GEDCOM document and returns any validation errors.
+// The record rules run in a single pass over the document's records and
+// tags; errors are grouped by rule.
 func (v *Validator) Validate(doc *gedcom.Document) []error {
 	if doc == nil {
 		return nil
 	}
 
-	v.errors = make([]error, 0)
-
-	// Validate cross-references
-	v.validateXRefs(doc)
-
-	// Validate records
-	v.validateRecords(doc)
-
-	// Validate date formats
-	v.validateDates(doc)
-
-	// Validate XRef formats
-	v.validateXRefFormats(doc)
-
-	// Validate circular relationships
-	v.validateCircularRelationships(doc)
-
-	// Validate version-specific rules
-	v.validateVersionSpecific(doc)
+	rules := []*rule{
+		brokenXRefRule(doc),
+		requiredFieldsRule(),
+		dateFormatRule(v.dateOptions()),
+		xrefFormatRule(),
+		xrefIdentityRule(doc),
+		circularRelationshipRule(),
+	}
+	if r := versionRule(doc); r != nil {
+		rules = append(rules, r)
+	}
 
+	v.errors = append(make([]error, 0), walkRecords(doc, rules)...)
 	return v.errors
 }
 
-// validateXRefs checks that all cross-references are valid.
-func (v *Validator) validateXRefs(doc *gedcom.Document) {
-	// Scan all records for XRef usage
-	for _, record := range doc.Records {
-		for _, tag := range record.Tags {
-			// Check if value looks like an XRef
-			if len(tag.Value) > 2 && tag.Value[0] == '@' && tag.Value[len(tag.Value)-1] == '@' {
-				xref := tag.Value
-
-				// Verify the XRef exists
-				if doc.XRefMap[xref] == nil {
-					v.errors = append(v.errors, &ValidationError{
-						Code:    "BROKEN_XREF",
-						Message: fmt.Sprintf("Reference to non-existent record %s", xref),
-						Line:    tag.LineNumber,
-					})
-				}
+// dateOptions returns the nonstandard date forms Validate accepts.
+func (v *Validator) dateOptions() gedcom.DateOptions {
+	if v.config == nil {
+		return gedcom.DateOptions{}
+	}
+	return gedcom.DateOptions{Lenient: v.config.LenientDates, Periods: v.config.DatePeriods}
+}
+
+// brokenXRefRule checks that every tag value that looks like an XRef points
+// to a record.
+func brokenXRefRule(doc *gedcom.Document) *rule {
+	r := &rule{}
+	r.tag = func(_ *gedcom.Record, tag *gedcom.Tag) {
+		if len(tag.Value) > 2 && tag.Value[0] == '@' && tag.Value[len(tag.Value)-1] == '@' {
+			xref := tag.Value
+			if doc.XRefMap[xref] == nil {
+				r.add(&ValidationError{
+					Code:    "BROKEN_XREF",
+					Message: fmt.Sprintf("Reference to non-existent record %s", xref),
+					Line:    tag.LineNumber,
+				})
 			}
 		}
 	}
+	return r
 }
 
-// validateRecords validates individual records.
-func (v *Validator) validateRecords(doc *gedcom.Document) {
-	for _, record := range doc.Records {
+// requiredFieldsRule checks that individuals have a NAME and that families
+// have at least one spouse or child.
+func requiredFieldsRule() *rule {
+	var found bool
+	r := &rule{tags: []string{"NAME", "HUSB", "WIFE", "CHIL"}}
+	r.startRecord = func(*gedcom.Record) {
+		found = false
+	}
+	r.tag = func(record *gedcom.Record, tag *gedcom.Tag) {
 		switch record.Type {
 		case gedcom.RecordTypeIndividual:
-			v.validateIndividual(record)
+			found = found || tag.Tag == "NAME"
 		case gedcom.RecordTypeFamily:
-			v.validateFamily(record)
+			found = found || tag.Tag != "NAME"
 		}
 	}
-}
-
-// validateIndividual validates an individual record.
-func (v *Validator) validateIndividual(record *gedcom.Record) {
-	// Check for required NAME tag
-	hasName := false
-	for _, tag := range record.Tags {
-		if tag.Tag == "NAME" {
-			hasName = true
-			break
+	r.endRecord = func(record *gedcom.Record) {
+		if found {
+			return
 		}
-	}
-
-	if !hasName {
-		v.errors = append(v.errors, &ValidationError{
-			Code:    "MISSING_REQUIRED_FIELD",
-			Message: "Individual record missing required NAME tag",
-			XRef:    record.XRef,
-		})
-	}
-}
-
-// validateFamily validates a family record.
-func (v *Validator) validateFamily(record *gedcom.Record) {
-	// Family records should have at least one spouse or child
-	hasMembers := false
-	for _, tag := range record.Tags {
-		if tag.Tag == "HUSB" || tag.Tag == "WIFE" || tag.Tag == "CHIL" {
-			hasMembers = true
-			break
+		switch record.Type {
+		case gedcom.RecordTypeIndividual:
+			r.add(&ValidationError{
+				Code:    "MISSING_REQUIRED_FIELD",
+				Message: "Individual record missing required NAME tag",
+				XRef:    record.XRef,
+			})
+		case gedcom.RecordTypeFamily:
+			r.add(&ValidationError{
+				Code:    "EMPTY_FAMILY",
+				Message: "Family record has no members (no HUSB, WIFE, or CHIL tags)",
+				XRef:    record.XRef,
+			})
 		}
 	}
-
-	if !hasMembers {
-		v.errors = append(v.errors, &ValidationError{
-			Code:    "EMPTY_FAMILY",
-			Message: "Family record has no members (no HUSB, WIFE, or CHIL tags)",
-			XRef:    record.XRef,
-		})
-	}
+	return r
 }
 
 // ValidateAll returns comprehensive validation as Issues with severity levels.
 // This is the enhanced API that provides more detail than Validate().
 // Issues are filtered based on the configured Strictness level.
 func (v *Validator) ValidateAll(doc *gedcom.Document) []Issue {
+	var issues []Issue
+	v.ValidateAllTo(doc, IssueSinkFunc(func(issue Issue) {
+		issues = append(issues, issue)
+	}))
+	return issues
+}
+
+// ValidateAllTo runs the checks of ValidateAll and passes each issue to
+// sink instead of collecting them, so large trees can be validated with
+// live logging or metrics. Date logic and reference issues are reported as
+// each record is checked and duplicates as each pair is found; the
+// document-wide checks report when they finish. Issues arrive in the order
+// ValidateAll returns them and are filtered by the configured Strictness.
+func (v *Validator) ValidateAllTo(doc *gedcom.Document, sink IssueSink) {
 	if doc == nil {
-		return nil
+		return
+	}
+	report := func(issue Issue) {
+		if v.includes(issue.Severity) {
+			sink.Report(issue)
+		}
+	}
+	reportAll := func(issues []Issue) {
+		for _, issue := range issues {
+			report(issue)
+		}
 	}
-
-	var allIssues []Issue
 
 	// Run date logic validation
-	allIssues = append(allIssues, v.getDateLogicValidator().Validate(doc)...)
+	v.getDateLogicValidator().validateTo(doc, report)
 
 	// Run reference validation
-	allIssues = append(allIssues, v.getReferenceValidator().Validate(doc)...)
+	v.getReferenceValidator().validateTo(doc, report)
 
 	// Run duplicate detection and convert to issues
-	for _, pair := range v.getDuplicateDetector().FindDuplicates(doc) {
-		allIssues = append(allIssues, pair.ToIssue())
-	}
+	v.getDuplicateDetector().findDuplicates(doc, func(pair DuplicatePair) {
+		report(pair.ToIssue())
+	})
 
-	// Filter by strictness
-	return v.filterByStrictness(allIssues)
+	// Run text encoding validation
+	reportAll(v.getTextEncodingValidator().Validate(doc))
+
+	// Run version-specific event rules
+	reportAll(validateEventCauses(doc))
+
+	// Run empty fact detection (info level)
+	emptyFactIssues, _ := emptyFacts(doc)
+	reportAll(emptyFactIssues)
+
+	// Run source coverage validation when configured
+	if v.config != nil && v.config.SourceCoverage != nil {
+		reportAll(v.getSourceCoverageValidator().Validate(doc))
+	}
 }
 
 // ValidateDateLogic runs date logic validation and returns any issues found.
@@ -348,6 +408,38 @@ func (v *Validator) FindOrphanedReferences(doc *gedcom.Document) []Issue {
 	return v.filterByStrictness(issues)
 }
 
+// ValidateSourceCoverage checks that vital events (birth, death, marriage)
+// carry source citations and that overall sourcing coverage meets the
+// configured threshold. Uses default thresholds if SourceCoverage is not configured.
+func (v *Validator) ValidateSourceCoverage(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues := v.getSourceCoverageValidator().Validate(doc)
+	return v.filterByStrictness(issues)
+}
+
+// ValidateTextEncoding checks note text for signs of upstream encoding damage:
+// replacement characters, mojibake, and raw HTML entities.
+func (v *Validator) ValidateTextEncoding(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues := v.getTextEncodingValidator().Validate(doc)
+	return v.filterByStrictness(issues)
+}
+
+// FindEmptyFacts lists events that have neither a date nor a place, such as
+// bare "1 BIRT" lines left behind by imports, as info-level EMPTY_FACT
+// issues. Like FindPotentialDuplicates, it is not filtered by strictness.
+func (v *Validator) FindEmptyFacts(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues, _ := emptyFacts(doc)
+	return issues
+}
+
 // FindPotentialDuplicates detects potential duplicate individuals based on
 // name similarity and birth date proximity.
 func (v *Validator) FindPotentialDuplicates(doc *gedcom.Document) []DuplicatePair {
@@ -376,10 +468,22 @@ func (v *Validator) QualityReport(doc *gedcom.Document) *QualityReport {
 
 // filterByStrictness filters issues based on the configured strictness level.
 func (v *Validator) filterByStrictness(issues []Issue) []Issue {
-	if len(issues) == 0 {
+	if len(issues) == 0 || v.includes(SeverityInfo) {
 		return issues
 	}
 
+	var result []Issue
+	for _, issue := range issues {
+		if v.includes(issue.Severity) {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// includes reports whether the configured strictness level includes issues
+// of the given severity.
+func (v *Validator) includes(severity Severity) bool {
 	strictness := StrictnessNormal
 	if v.config != nil {
 		strictness = v.config.Strictness
@@ -388,26 +492,12 @@ func (v *Validator) filterByStrictness(issues []Issue) []Issue {
 	switch strictness {
 	case StrictnessRelaxed:
 		// Only errors
-		var result []Issue
-		for _, issue := range issues {
-			if issue.Severity == SeverityError {
-				result = append(result, issue)
-			}
-		}
-		return result
+		return severity == SeverityError
 	case StrictnessNormal:
 		// Errors and warnings
-		var result []Issue
-		for _, issue := range issues {
-			if issue.Severity == SeverityError || issue.Severity == SeverityWarning {
-				result = append(result, issue)
-			}
-		}
-		return result
-	case StrictnessStrict:
-		// All issues
-		return issues
+		return severity == SeverityError || severity == SeverityWarning
 	default:
-		return issues
+		// All issues
+		return true
 	}
 }
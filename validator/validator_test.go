@@ -2,9 +2,11 @@ package validator
 
 import (
 	"encoding/json"
+	"errors"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -140,6 +142,55 @@ func TestValidateInvalidDate(t *testing.T) {
 	}
 }
 
+func TestValidateLenientDates(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE Jan 1 1900
+1 DEAT
+2 DATE circa 1950
+1 BURI
+2 DATE 1950-02-30
+0 TRLR`
+
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	countInvalid := func(errs []error) int {
+		n := 0
+		for _, err := range errs {
+			if strings.Contains(err.Error(), "INVALID_DATE") {
+				n++
+			}
+		}
+		return n
+	}
+
+	if got := countInvalid(New().Validate(doc)); got != 3 {
+		t.Errorf("Validate() reported %d INVALID_DATE errors, want 3", got)
+	}
+
+	// Lenient dates pass, but a nonexistent day is still invalid
+	v := NewWithConfig(&ValidatorConfig{LenientDates: true})
+	if got := countInvalid(v.Validate(doc)); got != 1 {
+		t.Errorf("Validate() with LenientDates reported %d INVALID_DATE errors, want 1", got)
+	}
+
+	doc.Records[0].Tags[2].Value = "Mar Q 1900"
+	if got := countInvalid(v.Validate(doc)); got != 2 {
+		t.Errorf("Validate() with LenientDates reported %d INVALID_DATE errors for a GRO quarter, want 2", got)
+	}
+	v = NewWithConfig(&ValidatorConfig{LenientDates: true, DatePeriods: true})
+	if got := countInvalid(v.Validate(doc)); got != 1 {
+		t.Errorf("Validate() with DatePeriods reported %d INVALID_DATE errors, want 1", got)
+	}
+}
+
 func TestValidateCircularRelationship(t *testing.T) {
 	input := `0 HEAD
 1 GEDC
@@ -177,6 +228,86 @@ func TestValidateCircularRelationship(t *testing.T) {
 	}
 }
 
+func TestValidateCircularRelationshipCycle(t *testing.T) {
+	// @I1@ -> @I2@ -> @I3@ -> @I1@ is a cycle of parents; @I4@ descends from
+	// it without being part of it
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5
+0 @I1@ INDI
+1 NAME A /A/
+1 FAMC @F2@
+0 @I2@ INDI
+1 NAME B /B/
+1 FAMC @F3@
+0 @I3@ INDI
+1 NAME C /C/
+1 FAMC @F1@
+0 @I4@ INDI
+1 NAME D /D/
+1 FAMC @F4@
+0 @F1@ FAM
+1 HUSB @I1@
+1 CHIL @I3@
+0 @F2@ FAM
+1 HUSB @I2@
+1 CHIL @I1@
+0 @F3@ FAM
+1 WIFE @I3@
+1 CHIL @I2@
+0 @F4@ FAM
+1 HUSB @I1@
+1 CHIL @I4@
+0 TRLR`
+
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	var got []string
+	for _, err := range New().Validate(doc) {
+		var ve *ValidationError
+		if errors.As(err, &ve) && ve.Code == "CIRCULAR_REFERENCE" {
+			got = append(got, ve.XRef)
+		}
+	}
+	if want := []string{"@I1@", "@I2@", "@I3@"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CIRCULAR_REFERENCE for %v, want %v", got, want)
+	}
+}
+
+func TestValidateErrorOrder(t *testing.T) {
+	// Errors are grouped by rule, not by record
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5
+0 @I_1@ INDI
+1 BIRT
+2 DATE 32 JAN 1900
+1 FAMS @F9@
+0 @I2@ INDI
+1 UID 123
+0 TRLR`
+
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	var codes []string
+	for _, err := range New().Validate(doc) {
+		var ve *ValidationError
+		if errors.As(err, &ve) {
+			codes = append(codes, ve.Code)
+		}
+	}
+	want := []string{"BROKEN_XREF", "MISSING_REQUIRED_FIELD", "MISSING_REQUIRED_FIELD", "INVALID_DATE", "NON_STANDARD_XREF", "DEPRECATED_TAG"}
+	if !reflect.DeepEqual(codes, want) {
+		t.Errorf("codes = %v, want %v", codes, want)
+	}
+}
+
 func TestValidateNonStandardXRef(t *testing.T) {
 	input := `0 HEAD
 1 GEDC
@@ -210,6 +341,64 @@ func TestValidateNonStandardXRef(t *testing.T) {
 	}
 }
 
+func TestValidateXRefIdentity(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		records string
+		want    []string
+	}{
+		{
+			name:    "duplicate xref",
+			version: "5.5.1",
+			records: "0 @I1@ INDI\n1 NAME A /B/\n0 @I1@ INDI\n1 NAME C /D/\n",
+			want:    []string{"DUPLICATE_XREF"},
+		},
+		{
+			name:    "reserved VOID",
+			version: "7.0",
+			records: "0 @VOID@ INDI\n1 NAME A /B/\n",
+			want:    []string{"RESERVED_XREF"},
+		},
+		{
+			name:    "too long for 5.5.1",
+			version: "5.5.1",
+			records: "0 @I12345678901234567890@ INDI\n1 NAME A /B/\n",
+			want:    []string{"XREF_TOO_LONG"},
+		},
+		{
+			name:    "long xref allowed in 7.0",
+			version: "7.0",
+			records: "0 @I12345678901234567890@ INDI\n1 NAME A /B/\n",
+		},
+		{
+			name:    "22 characters allowed in 5.5",
+			version: "5.5",
+			records: "0 @I1234567890123456789@ INDI\n1 NAME A /B/\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := "0 HEAD\n1 GEDC\n2 VERS " + tt.version + "\n" + tt.records + "0 TRLR\n"
+			doc, err := decoder.Decode(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			var codes []string
+			for _, err := range New().Validate(doc) {
+				var ve *ValidationError
+				if errors.As(err, &ve) && ve.Code != "DEPRECATED_TAG" {
+					codes = append(codes, ve.Code)
+				}
+			}
+			if !reflect.DeepEqual(codes, tt.want) {
+				t.Errorf("codes = %v, want %v", codes, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidateVersionSpecificRules(t *testing.T) {
 	input := `0 HEAD
 1 GEDC
@@ -686,6 +875,117 @@ func TestValidateAllReturnsIssues(t *testing.T) {
 	}
 }
 
+func TestValidateAllTo(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 1950
+1 DEAT
+2 DATE 1940
+1 FAMC @F9@
+0 @I2@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 1950
+0 TRLR`
+
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	for _, strictness := range []Strictness{StrictnessRelaxed, StrictnessNormal, StrictnessStrict} {
+		v := NewWithConfig(&ValidatorConfig{Strictness: strictness})
+		var streamed []Issue
+		v.ValidateAllTo(doc, IssueSinkFunc(func(issue Issue) {
+			streamed = append(streamed, issue)
+		}))
+		if want := v.ValidateAll(doc); len(want) == 0 || !reflect.DeepEqual(streamed, want) {
+			t.Errorf("strictness %d: ValidateAllTo() = %v, want %v", strictness, streamed, want)
+		}
+	}
+
+	New().ValidateAllTo(nil, IssueSinkFunc(func(issue Issue) {
+		t.Errorf("ValidateAllTo(nil) reported %v", issue)
+	}))
+}
+
+func TestValidateAllToReportsAsFound(t *testing.T) {
+	// Both individuals die before birth. The sink corrects I2 when I1's issue
+	// arrives, which only takes effect if I1's issue is reported before I2
+	// is checked.
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 BIRT
+2 DATE 1950
+1 DEAT
+2 DATE 1940
+0 @I2@ INDI
+1 BIRT
+2 DATE 1950
+1 DEAT
+2 DATE 1940
+0 TRLR`
+
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	i2 := doc.GetIndividual("@I2@")
+	var xrefs []string
+	New().ValidateAllTo(doc, IssueSinkFunc(func(issue Issue) {
+		xrefs = append(xrefs, issue.RecordXRef)
+		i2.DeathEvent().ParsedDate = i2.BirthEvent().ParsedDate
+	}))
+	if len(xrefs) != 1 || xrefs[0] != "@I1@" {
+		t.Errorf("ValidateAllTo() reported %v, want only @I1@", xrefs)
+	}
+}
+
+func TestValidateAllCauseOnNonDeathEvent(t *testing.T) {
+	body := `0 @I1@ INDI
+1 NAME John /Smith/
+1 DEAT
+2 CAUS Pneumonia
+1 BURI
+2 CAUS Pneumonia
+0 TRLR`
+
+	tests := []struct {
+		version string
+		want    int
+	}{
+		{"5.5", 1},
+		{"5.5.1", 1},
+		{"7.0", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			input := "0 HEAD\n1 GEDC\n2 VERS " + tt.version + "\n" + body
+			doc, err := decoder.Decode(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			issues := FilterByCode(New().ValidateAll(doc), CodeCauseOnNonDeathEvent)
+			if len(issues) != tt.want {
+				t.Fatalf("got %d %s issues, want %d", len(issues), CodeCauseOnNonDeathEvent, tt.want)
+			}
+			if tt.want > 0 {
+				if issues[0].Severity != SeverityWarning || issues[0].Details["event_type"] != "BURI" {
+					t.Errorf("issue = %+v, want warning for BURI", issues[0])
+				}
+			}
+		})
+	}
+}
+
 // Test ValidateAll with nil document
 func TestValidateAllNilDocument(t *testing.T) {
 	v := New()
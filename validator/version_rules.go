@@ -6,48 +6,80 @@ import (
 	"github.com/cacack/gedcom-go/gedcom"
 )
 
-func (v *Validator) validateVersionSpecific(doc *gedcom.Document) {
+// versionRule returns the rule for the document's GEDCOM version, or nil if
+// the version is unknown.
+func versionRule(doc *gedcom.Document) *rule {
 	if doc.Header == nil {
-		return
+		return nil
 	}
-	var errs []error
 	switch doc.Header.Version {
 	case gedcom.Version55:
-		errs = validateV55Rules(doc)
+		return deprecatedTagsRule(gedcom.Version55, v55DeprecatedTags)
 	case gedcom.Version551:
-		errs = validateV551Rules(doc)
+		return deprecatedTagsRule(gedcom.Version551, v551DeprecatedTags)
 	case gedcom.Version70:
-		errs = validateV70Rules(doc)
+		return deprecatedTagsRule(gedcom.Version70, v70DeprecatedTags)
 	default:
-		return
+		return nil
 	}
-	v.errors = append(v.errors, errs...)
 }
 
-func validateDeprecatedTags(doc *gedcom.Document, version gedcom.Version, deprecated map[string]string) []error {
-	var errs []error
-	for _, record := range doc.Records {
-		if record == nil {
-			continue
-		}
+// deprecatedTagsRule flags records and tags whose names are not valid in
+// version, with the reason given by deprecated.
+func deprecatedTagsRule(version gedcom.Version, deprecated map[string]string) *rule {
+	r := &rule{}
+	for name := range deprecated {
+		r.tags = append(r.tags, name)
+	}
+	r.startRecord = func(record *gedcom.Record) {
 		if reason, ok := deprecated[string(record.Type)]; ok {
-			errs = append(errs, &ValidationError{
+			r.add(&ValidationError{
 				Code:    "DEPRECATED_TAG",
 				Message: fmt.Sprintf("Tag %s is not valid in GEDCOM %s: %s", record.Type, version, reason),
 				Line:    record.LineNumber,
 				XRef:    record.XRef,
 			})
 		}
-		for _, tag := range record.Tags {
-			if reason, ok := deprecated[tag.Tag]; ok {
-				errs = append(errs, &ValidationError{
-					Code:    "DEPRECATED_TAG",
-					Message: fmt.Sprintf("Tag %s is not valid in GEDCOM %s: %s", tag.Tag, version, reason),
-					Line:    tag.LineNumber,
-					XRef:    record.XRef,
-				})
+	}
+	r.tag = func(record *gedcom.Record, tag *gedcom.Tag) {
+		r.add(&ValidationError{
+			Code:    "DEPRECATED_TAG",
+			Message: fmt.Sprintf("Tag %s is not valid in GEDCOM %s: %s", tag.Tag, version, deprecated[tag.Tag]),
+			Line:    tag.LineNumber,
+			XRef:    record.XRef,
+		})
+	}
+	return r
+}
+
+// validateEventCauses flags CAUS on events other than death in GEDCOM 5.5.x
+// files. 5.5.x permits CAUS on any event, but outside death it usually
+// indicates a cause of death entered on the wrong event (e.g., burial).
+func validateEventCauses(doc *gedcom.Document) []Issue {
+	if doc.Header == nil || (doc.Header.Version != gedcom.Version55 && doc.Header.Version != gedcom.Version551) {
+		return nil
+	}
+
+	var issues []Issue
+	for _, record := range doc.Records {
+		var events []*gedcom.Event
+		switch entity := record.LoadEntity().(type) {
+		case *gedcom.Individual:
+			events = entity.Events
+		case *gedcom.Family:
+			events = entity.Events
+		default:
+			continue
+		}
+		for _, event := range events {
+			if event.Cause == "" || event.Type == gedcom.EventDeath {
+				continue
 			}
+			issues = append(issues, NewIssue(SeverityWarning, CodeCauseOnNonDeathEvent,
+				fmt.Sprintf("CAUS %q on %s event; cause is normally recorded on the death event", event.Cause, event.Type),
+				record.XRef).
+				WithDetail("event_type", string(event.Type)))
 		}
 	}
-	return errs
+	return issues
 }
@@ -0,0 +1,81 @@
+package validator
+
+import "github.com/cacack/gedcom-go/gedcom"
+
+// rule is a check run during Validate's single pass over the document's
+// records. Every callback is optional. Rules collect their own errors so the
+// combined result keeps a stable order by rule.
+type rule struct {
+	// tags lists the tag names passed to tag; nil passes every tag
+	tags []string
+
+	// startRecord is called for each record before its tags
+	startRecord func(record *gedcom.Record)
+
+	// tag is called for each matching tag of the record
+	tag func(record *gedcom.Record, tag *gedcom.Tag)
+
+	// endRecord is called for each record after its tags
+	endRecord func(record *gedcom.Record)
+
+	// finish is called once after all records, for document-wide checks
+	finish func(doc *gedcom.Document)
+
+	errs []error
+}
+
+func (r *rule) add(err error) {
+	r.errs = append(r.errs, err)
+}
+
+// walkRecords visits every record and tag of doc once, dispatching each tag
+// only to the rules interested in it, and returns the rules' errors in rule
+// order.
+func walkRecords(doc *gedcom.Document, rules []*rule) []error {
+	var allTags []*rule
+	byTag := make(map[string][]*rule)
+	for _, r := range rules {
+		switch {
+		case r.tag == nil:
+		case r.tags == nil:
+			allTags = append(allTags, r)
+		default:
+			for _, name := range r.tags {
+				byTag[name] = append(byTag[name], r)
+			}
+		}
+	}
+
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		for _, r := range rules {
+			if r.startRecord != nil {
+				r.startRecord(record)
+			}
+		}
+		for _, tag := range record.Tags {
+			for _, r := range allTags {
+				r.tag(record, tag)
+			}
+			for _, r := range byTag[tag.Tag] {
+				r.tag(record, tag)
+			}
+		}
+		for _, r := range rules {
+			if r.endRecord != nil {
+				r.endRecord(record)
+			}
+		}
+	}
+
+	var errs []error
+	for _, r := range rules {
+		if r.finish != nil {
+			r.finish(doc)
+		}
+		errs = append(errs, r.errs...)
+	}
+	return errs
+}
@@ -6,21 +6,74 @@ import (
 	"github.com/cacack/gedcom-go/gedcom"
 )
 
-func (v *Validator) validateXRefFormats(doc *gedcom.Document) {
-	for _, record := range doc.Records {
-		if record.XRef == "" {
-			continue
-		}
-		if isStandardXRef(record.XRef) {
-			continue
+// xrefFormatRule checks that record XRefs use only letters and digits.
+func xrefFormatRule() *rule {
+	r := &rule{}
+	r.startRecord = func(record *gedcom.Record) {
+		if record.XRef == "" || isStandardXRef(record.XRef) {
+			return
 		}
-		v.errors = append(v.errors, &ValidationError{
+		r.add(&ValidationError{
 			Code:    "NON_STANDARD_XREF",
 			Message: fmt.Sprintf("Non-standard XRef format %s", record.XRef),
 			Line:    record.LineNumber,
 			XRef:    record.XRef,
 		})
 	}
+	return r
+}
+
+// maxXRefLength55 is the longest XRef GEDCOM 5.5 and 5.5.1 allow,
+// including the @ delimiters. GEDCOM 7.0 sets no limit.
+const maxXRefLength55 = 22
+
+// xrefIdentityRule checks that no two records share an XRef, that no record
+// is named @VOID@, which GEDCOM 7.0 reserves for a null pointer and this
+// library resolves as one in every version, and that XRefs fit the length
+// limit of the document's version. Unlike xrefFormatRule it does not look at
+// the characters used.
+func xrefIdentityRule(doc *gedcom.Document) *rule {
+	maxLength := 0
+	if doc.Header != nil && (doc.Header.Version == gedcom.Version55 || doc.Header.Version == gedcom.Version551) {
+		maxLength = maxXRefLength55
+	}
+	firstLines := make(map[string]int)
+
+	r := &rule{}
+	r.startRecord = func(record *gedcom.Record) {
+		xref := record.XRef
+		if xref == "" {
+			return
+		}
+		if line, seen := firstLines[xref]; seen {
+			r.add(&ValidationError{
+				Code:    "DUPLICATE_XREF",
+				Message: fmt.Sprintf("XRef %s is already defined at line %d", xref, line),
+				Line:    record.LineNumber,
+				XRef:    xref,
+			})
+		} else {
+			firstLines[xref] = record.LineNumber
+		}
+		if xref == "@VOID@" {
+			r.add(&ValidationError{
+				Code:    "RESERVED_XREF",
+				Message: fmt.Sprintf("XRef %s is reserved for null pointers", xref),
+				Line:    record.LineNumber,
+				XRef:    xref,
+			})
+		}
+		if maxLength > 0 && len(xref) > maxLength {
+			r.add(&ValidationError{
+				Code: "XREF_TOO_LONG",
+				Message: fmt.Sprintf("XRef %s is %d characters long; GEDCOM %s allows %d",
+					xref, len(xref), doc.Header.Version, maxLength),
+				Line: record.LineNumber,
+				XRef: xref,
+			})
+		}
+	}
+	return r
 }
 
 func isStandardXRef(xref string) bool {